@@ -11,6 +11,8 @@ import (
 	"github.com/onsi/gomega"
 
 	"github.com/redhat-appstudio/e2e-tests/pkg/framework"
+	"github.com/redhat-appstudio/e2e-tests/pkg/logs"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
 	_ "github.com/redhat-appstudio/e2e-tests/tests/build"
 	_ "github.com/redhat-appstudio/e2e-tests/tests/byoc"
 	_ "github.com/redhat-appstudio/e2e-tests/tests/enterprise-contract"
@@ -29,10 +31,12 @@ import (
 
 var generateRPPreprocReport bool
 var rpPreprocDir string
+var htmlReportDir string
 
 func init() {
 	flag.BoolVar(&generateRPPreprocReport, "generate-rppreproc-report", false, "Generate report and folders for RP Preproc")
 	flag.StringVar(&rpPreprocDir, "rp-preproc-dir", ".", "Folder for RP Preproc")
+	flag.StringVar(&htmlReportDir, "html-report-dir", "", "Folder for the HTML report, $ARTIFACT_DIR/html-report by default")
 
 	klog.SetLogger(ginkgo.GinkgoLogr)
 
@@ -54,6 +58,16 @@ func TestE2E(t *testing.T) {
 	ginkgo.RunSpecs(t, "Red Hat App Studio E2E tests")
 }
 
+// the timing.json lets us see which steps of a failed or flaky spec took the time and how much of it was spent waiting
+var _ = ginkgo.ReportAfterEach(func(report types.SpecReport) {
+	if report.LeafNodeType != types.NodeTypeIt || !(report.Failed() || framework.PassedAfterRetry(report)) {
+		return
+	}
+	if err := logs.StoreTestTiming(); err != nil {
+		klog.Error(err)
+	}
+})
+
 var _ = ginkgo.ReportAfterSuite("RP Preproc reporter", func(report types.Report) {
 	if generateRPPreprocReport {
 		//Generate Logs in dirs
@@ -69,3 +83,16 @@ var _ = ginkgo.ReportAfterSuite("RP Preproc reporter", func(report types.Report)
 		}
 	}
 })
+
+// the HTML report links the artifacts, so it has to be generated after the RP Preproc reporter moves them
+var _ = ginkgo.ReportAfterSuite("HTML reporter", func(report types.Report) {
+	wd, _ := os.Getwd()
+	artifactDir := utils.GetEnv("ARTIFACT_DIR", fmt.Sprintf("%s/tmp", wd))
+	if htmlReportDir == "" {
+		htmlReportDir = artifactDir + "/html-report"
+	}
+
+	if err := framework.GenerateHTMLReport(report, htmlReportDir, artifactDir, rpPreprocDir+"/rp_preproc/attachments/xunit"); err != nil {
+		klog.Error(err)
+	}
+})
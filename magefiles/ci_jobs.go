@@ -0,0 +1,403 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// CIJob configures how the cluster and the tests are set up for the CI jobs it matches.
+// The jobs are matched against the entries of ciJobs in order, the first matching entry wins.
+type CIJob struct {
+	// Name identifies the entry in the logs and in the validation output
+	Name string
+	// JobNamePatterns are regular expressions which all have to match the name of the job (JOB_NAME)
+	JobNamePatterns []string
+	// Repo is the repository the job runs for (the repo of the refs in JOB_SPEC), any repository matches if empty
+	Repo string
+	// EnvVarPrefix is the prefix of the env vars overriding the image and the PR metadata of the tested service, e.g. "HAS"
+	// for HAS_IMAGE_REPO, HAS_IMAGE_TAG, HAS_PR_OWNER and HAS_PR_SHA
+	EnvVarPrefix string
+	// ImageTagSuffix is the suffix of the image tag the service image built by the job is pushed with
+	// ("redhat-appstudio-<suffix>"), the service image env vars are set only if it's set
+	ImageTagSuffix string
+	// LabelFilter is the Ginkgo label filter of the specs run for the job (E2E_TEST_SUITE_LABEL), all the specs run if empty
+	LabelFilter string
+	// SprayProxy is set if the tests require the PaC server to be registered to SprayProxy
+	SprayProxy bool
+	// MultiPlatform is set if the tests require the buildah-remote pipeline bundle of the multi-platform tests
+	MultiPlatform bool
+	// SetupHooks are the names of the ciJobSetupHooks run before the cluster is bootstrapped
+	SetupHooks []string
+}
+
+// ciJobs maps the CI jobs to the tests they run. To onboard the PR job of a new service, add an entry
+// before the catch-all one and check it with `./mage local:validateCIJobs`.
+var ciJobs = []CIJob{
+	{
+		// RHTAP Nightly E2E job
+		// The job name is taken from https://github.com/openshift/release/blob/f03153fa4ad36c0e10050d977e7f0f7619d2163a/ci-operator/config/redhat-appstudio/infra-deployments/redhat-appstudio-infra-deployments-main.yaml#L59C7-L59C35
+		Name:            "rhtap-nightly",
+		JobNamePatterns: []string{"appstudio-e2e-tests-periodic"},
+		SprayProxy:      true,
+		MultiPlatform:   true,
+	},
+	{
+		Name:          "e2e-tests",
+		Repo:          "e2e-tests",
+		SprayProxy:    true,
+		MultiPlatform: true,
+		SetupHooks:    []string{"infra-deployments-pairing"},
+	},
+	{
+		Name:            "application-service",
+		JobNamePatterns: []string{"application-service", "-service-e2e$"},
+		EnvVarPrefix:    "HAS",
+		ImageTagSuffix:  "has-image",
+		LabelFilter:     "e2e-demo,byoc",
+		SprayProxy:      true,
+	},
+	{
+		Name:            "release-service",
+		JobNamePatterns: []string{"release-service", "-service-e2e$"},
+		EnvVarPrefix:    "RELEASE_SERVICE",
+		ImageTagSuffix:  "release-service-image",
+		LabelFilter:     "release-service",
+	},
+	{
+		Name:            "integration-service",
+		JobNamePatterns: []string{"integration-service", "-service-e2e$"},
+		EnvVarPrefix:    "INTEGRATION_SERVICE",
+		ImageTagSuffix:  "integration-service-image",
+		LabelFilter:     "integration-service",
+		SprayProxy:      true,
+	},
+	{
+		Name:            "jvm-build-service",
+		JobNamePatterns: []string{"jvm-build-service", "-service-e2e$"},
+		EnvVarPrefix:    "JVM_BUILD_SERVICE",
+		ImageTagSuffix:  "jvm-build-service-image",
+		LabelFilter:     "jvm-build",
+		SetupHooks:      []string{"jvm-build-service-images", "java-builder-bundle"},
+	},
+	{
+		// has to go after jvm-build-service, which contains "build-service" too
+		Name:            "build-service",
+		JobNamePatterns: []string{"build-service", "-service-e2e$"},
+		EnvVarPrefix:    "BUILD_SERVICE",
+		ImageTagSuffix:  "build-service-image",
+		LabelFilter:     "build",
+		SprayProxy:      true,
+	},
+	{
+		Name:            "image-controller",
+		JobNamePatterns: []string{"image-controller"},
+		EnvVarPrefix:    "IMAGE_CONTROLLER",
+		ImageTagSuffix:  "image-controller-image",
+		LabelFilter:     "image-controller",
+		SprayProxy:      true,
+	},
+	{
+		Name:            "remote-secret-service",
+		JobNamePatterns: []string{"remote-secret-service", "-service-e2e$"},
+		EnvVarPrefix:    "REMOTE_SECRET",
+		ImageTagSuffix:  "remote-secret-image",
+		LabelFilter:     "remote-secret",
+	},
+	{
+		Name:            "spi-service",
+		JobNamePatterns: []string{"spi-service", "-service-e2e$"},
+		EnvVarPrefix:    "SPI_OPERATOR",
+		ImageTagSuffix:  "spi-image",
+		LabelFilter:     "spi-suite",
+		SetupHooks:      []string{"spi-oauth-image"},
+	},
+	{
+		Name:            "multi-platform-controller",
+		JobNamePatterns: []string{"multi-platform-controller", "-service-e2e$"},
+		EnvVarPrefix:    "MULTI_PLATFORM_CONTROLLER",
+		ImageTagSuffix:  "multi-platform-controller",
+		LabelFilter:     "multi-platform",
+		MultiPlatform:   true,
+	},
+	{
+		Name: "infra-deployments",
+		Repo: "infra-deployments",
+		/* Disabling "build tests" temporary due:
+		TODO: Enable when issues are done:
+		https://issues.redhat.com/browse/RHTAPBUGS-992, https://issues.redhat.com/browse/RHTAPBUGS-991, https://issues.redhat.com/browse/RHTAPBUGS-989,
+		https://issues.redhat.com/browse/RHTAPBUGS-978,https://issues.redhat.com/browse/RHTAPBUGS-956
+		*/
+		LabelFilter:   "e2e-demo,rhtap-demo,spi-suite,remote-secret,integration-service,ec,byoc,build-templates,multi-platform",
+		SprayProxy:    true,
+		MultiPlatform: true,
+		SetupHooks:    []string{"infra-deployments-pr"},
+	},
+	{
+		// release-service-catalog jobs (pull, rehearsal)
+		Name:            "release-service-catalog",
+		JobNamePatterns: []string{"release-service-catalog"},
+		EnvVarPrefix:    "RELEASE_SERVICE",
+		LabelFilter:     "release-pipelines",
+		SetupHooks:      []string{"release-service-catalog-pr"},
+	},
+	{
+		// openshift/release rehearse job for e2e-tests/infra-deployments repos
+		Name:          "default",
+		SprayProxy:    true,
+		MultiPlatform: true,
+	},
+}
+
+// ciJobSetupHook prepares what the tests of a job need besides the env vars set from its CIJob entry.
+type ciJobSetupHook struct {
+	// env returns the env vars set by the hook
+	env func(job CIJob) map[string]string
+	// run does the setup which can't be done by setting env vars, e.g. pushing a Tekton bundle, it runs after the env vars are set
+	run func(job CIJob) error
+}
+
+var ciJobSetupHooks = map[string]ciJobSetupHook{
+	// Since CI requires to have default values for dependency images
+	// (https://github.com/openshift/release/blob/master/ci-operator/step-registry/redhat-appstudio/e2e/redhat-appstudio-e2e-ref.yaml#L15)
+	// we cannot let these env vars to have identical names in CI as those env vars used in tests
+	// e.g. JVM_BUILD_SERVICE_REQPROCESSOR_IMAGE, otherwise those images they are referencing wouldn't
+	// be always relevant for tests and tests would be failing
+	"jvm-build-service-images": {env: func(job CIJob) map[string]string {
+		return map[string]string{
+			fmt.Sprintf("%s_REQPROCESSOR_IMAGE", job.EnvVarPrefix): os.Getenv("CI_JBS_REQPROCESSOR_IMAGE"),
+			fmt.Sprintf("%s_CACHE_IMAGE", job.EnvVarPrefix):        os.Getenv("CI_JBS_CACHE_IMAGE"),
+		}
+	}},
+	"java-builder-bundle": {run: func(CIJob) error {
+		return setupJavaBuilderBundle()
+	}},
+	// spi also requires service-provider-integration-oauth image
+	"spi-oauth-image": {env: func(CIJob) map[string]string {
+		im := strings.Split(os.Getenv("CI_SPI_OAUTH_IMAGE"), "@")
+		return map[string]string{
+			"SPI_OAUTH_IMAGE_REPO": im[0],
+			"SPI_OAUTH_IMAGE_TAG":  fmt.Sprintf("redhat-appstudio-%s", "spi-oauth-image"),
+		}
+	}},
+	"infra-deployments-pr": {env: func(CIJob) map[string]string {
+		return map[string]string{"INFRA_DEPLOYMENTS_ORG": pr.RemoteName, "INFRA_DEPLOYMENTS_BRANCH": pr.BranchName}
+	}},
+	"infra-deployments-pairing": {env: func(CIJob) map[string]string {
+		if !isPRPairingRequired("infra-deployments") {
+			return nil
+		}
+		return map[string]string{"INFRA_DEPLOYMENTS_ORG": pr.RemoteName, "INFRA_DEPLOYMENTS_BRANCH": pr.BranchName}
+	}},
+	"release-service-catalog-pr": {env: func(job CIJob) map[string]string {
+		env := map[string]string{}
+		// "rehearse" jobs metadata are not relevant for testing
+		if !strings.Contains(jobName, "rehearse") {
+			env[fmt.Sprintf("%s_CATALOG_URL", job.EnvVarPrefix)] = fmt.Sprintf("https://github.com/%s/%s", pr.RemoteName, pr.RepoName)
+			env[fmt.Sprintf("%s_CATALOG_REVISION", job.EnvVarPrefix)] = pr.CommitSHA
+		}
+		if os.Getenv("REL_IMAGE_CONTROLLER_QUAY_ORG") != "" {
+			env["IMAGE_CONTROLLER_QUAY_ORG"] = os.Getenv("REL_IMAGE_CONTROLLER_QUAY_ORG")
+		}
+		if os.Getenv("REL_IMAGE_CONTROLLER_QUAY_TOKEN") != "" {
+			env["IMAGE_CONTROLLER_QUAY_TOKEN"] = os.Getenv("REL_IMAGE_CONTROLLER_QUAY_TOKEN")
+		}
+		return env
+	}},
+}
+
+var envVarPrefixRegexp = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// Matches returns true if the job with the given name, running for the given repository, matches the entry.
+func (j CIJob) Matches(jobName, repo string) bool {
+	if j.Repo != "" && j.Repo != repo {
+		return false
+	}
+	for _, pattern := range j.JobNamePatterns {
+		if matches, err := regexp.MatchString(pattern, jobName); err != nil || !matches {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks the entry is complete and refers to existing setup hooks.
+func (j CIJob) Validate() error {
+	errs := []string{}
+	if j.Name == "" {
+		errs = append(errs, "the name is not set")
+	}
+	for _, pattern := range j.JobNamePatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			errs = append(errs, fmt.Sprintf("invalid job name pattern %q: %v", pattern, err))
+		}
+	}
+	if j.EnvVarPrefix != "" && !envVarPrefixRegexp.MatchString(j.EnvVarPrefix) {
+		errs = append(errs, fmt.Sprintf("invalid env var prefix %q", j.EnvVarPrefix))
+	}
+	if j.ImageTagSuffix != "" && j.EnvVarPrefix == "" {
+		errs = append(errs, "the env var prefix of the service image is not set")
+	}
+	if j.LabelFilter != "" {
+		if _, err := types.ParseLabelFilter(j.LabelFilter); err != nil {
+			errs = append(errs, fmt.Sprintf("invalid label filter %q: %v", j.LabelFilter, err))
+		}
+	}
+	for _, hook := range j.SetupHooks {
+		if _, ok := ciJobSetupHooks[hook]; !ok {
+			errs = append(errs, fmt.Sprintf("unknown setup hook %q", hook))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid CI job %q: %s", j.Name, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// validateCIJobs validates the entries and checks every entry can be matched, i.e. it's not preceded by a catch-all one,
+// and that the last entry is a catch-all one, so every job gets set up.
+func validateCIJobs(jobs []CIJob) error {
+	errs := []string{}
+	names := map[string]bool{}
+	for i, job := range jobs {
+		if err := job.Validate(); err != nil {
+			errs = append(errs, err.Error())
+		}
+		if names[job.Name] {
+			errs = append(errs, fmt.Sprintf("duplicate CI job %q", job.Name))
+		}
+		names[job.Name] = true
+		if job.Repo == "" && len(job.JobNamePatterns) == 0 && i < len(jobs)-1 {
+			errs = append(errs, fmt.Sprintf("the CI job %q matches all the jobs, so the entries after it are never used", job.Name))
+		}
+	}
+	if len(jobs) == 0 || jobs[len(jobs)-1].Repo != "" || len(jobs[len(jobs)-1].JobNamePatterns) > 0 {
+		errs = append(errs, "the last CI job has to match all the jobs")
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// findCIJob returns the first entry matching the job.
+func findCIJob(jobs []CIJob, jobName, repo string) (CIJob, error) {
+	for _, job := range jobs {
+		if job.Matches(jobName, repo) {
+			return job, nil
+		}
+	}
+	return CIJob{}, fmt.Errorf("no CI job matches the job %s of the repository %s", jobName, repo)
+}
+
+// EnvVars returns the env vars set for the tests and the cluster bootstrap of the job.
+func (j CIJob) EnvVars() map[string]string {
+	env := map[string]string{}
+	if j.ImageTagSuffix != "" {
+		sp := strings.Split(os.Getenv("COMPONENT_IMAGE"), "@")
+		env[fmt.Sprintf("%s_IMAGE_REPO", j.EnvVarPrefix)] = sp[0]
+		env[fmt.Sprintf("%s_IMAGE_TAG", j.EnvVarPrefix)] = fmt.Sprintf("redhat-appstudio-%s", j.ImageTagSuffix)
+		// "rehearse" jobs metadata are not relevant for testing
+		if !strings.Contains(jobName, "rehearse") {
+			env[fmt.Sprintf("%s_PR_OWNER", j.EnvVarPrefix)] = pr.RemoteName
+			env[fmt.Sprintf("%s_PR_SHA", j.EnvVarPrefix)] = pr.CommitSHA
+		}
+	}
+	if j.LabelFilter != "" {
+		env["E2E_TEST_SUITE_LABEL"] = j.LabelFilter
+	}
+	for _, name := range j.SetupHooks {
+		if hook := ciJobSetupHooks[name]; hook.env != nil {
+			for key, value := range hook.env(j) {
+				env[key] = value
+			}
+		}
+	}
+	return env
+}
+
+// apply sets the env vars of the tests and the cluster bootstrap according to the entry and runs its setup hooks.
+func (j CIJob) apply() error {
+	requiresSprayProxyRegistering = j.SprayProxy
+	requiresMultiPlatformTests = j.MultiPlatform
+
+	env := j.EnvVars()
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	// sorted to make the plan of the CI workflow stable
+	sort.Strings(keys)
+	for _, key := range keys {
+		setEnv(key, env[key])
+	}
+	for _, name := range j.SetupHooks {
+		if hook := ciJobSetupHooks[name]; hook.run != nil {
+			if err := hook.run(j); err != nil {
+				return fmt.Errorf("setup hook %s of the CI job %s failed: %v", name, j.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// jobReplayPlan describes how a CI job set up the cluster and which tests it ran.
+type jobReplayPlan struct {
+	JobName string `json:"jobName"`
+	JobType string `json:"jobType"`
+	// CIJob is the name of the ciJobs entry the job matches
+	CIJob       string               `json:"ciJob"`
+	PullRequest *PullRequestMetadata `json:"pullRequest,omitempty"`
+	// E2ETestsRef is the remote and the branch (or commit) of e2e-tests the job ran the tests from, empty for the main branch
+	E2ETestsRef   string            `json:"e2eTestsRef,omitempty"`
+	EnvVars       map[string]string `json:"envVars"`
+	LabelFilter   string            `json:"labelFilter"`
+	SprayProxy    bool              `json:"sprayProxy"`
+	MultiPlatform bool              `json:"multiPlatform"`
+	SetupHooks    []string          `json:"setupHooks,omitempty"`
+}
+
+// newJobReplayPlan sets up the job metadata from the job spec the same way as CI.init does and returns the plan
+// of the job together with its ciJobs entry.
+func newJobReplayPlan(spec OpenshiftJobSpec) (*jobReplayPlan, CIJob, error) {
+	jobName, jobType = spec.Job, spec.Type
+	if jobName == "" {
+		return nil, CIJob{}, fmt.Errorf("the job spec doesn't contain the name of the job")
+	}
+
+	plan := &jobReplayPlan{JobName: jobName, JobType: jobType}
+	// the job spec of the periodic and the rehearse jobs isn't parsed in CI
+	openshiftJobSpec = &OpenshiftJobSpec{Type: spec.Type, Job: spec.Job}
+	if jobType != "periodic" && !strings.Contains(jobName, "rehearse") {
+		openshiftJobSpec = &spec
+		if err := initPullRequestMetadata(); err != nil {
+			return nil, CIJob{}, err
+		}
+		plan.PullRequest = pr
+		// the same as CI.PrepareE2EBranch does
+		if spec.Refs.Repo == "e2e-tests" {
+			plan.E2ETestsRef = fmt.Sprintf("%s/%s", pr.RemoteName, pr.CommitSHA)
+		} else if isPRPairingRequired("e2e-tests") {
+			plan.E2ETestsRef = fmt.Sprintf("%s/%s", pr.RemoteName, pr.BranchName)
+		}
+	}
+
+	job, err := findCIJob(ciJobs, jobName, openshiftJobSpec.Refs.Repo)
+	if err != nil {
+		return nil, CIJob{}, err
+	}
+	plan.CIJob = job.Name
+	plan.EnvVars = job.EnvVars()
+	plan.LabelFilter = defaultE2ELabelFilter
+	if labelFilter, ok := plan.EnvVars["E2E_TEST_SUITE_LABEL"]; ok {
+		plan.LabelFilter = labelFilter
+	}
+	plan.SprayProxy = job.SprayProxy
+	plan.MultiPlatform = job.MultiPlatform
+	plan.SetupHooks = job.SetupHooks
+	return plan, job, nil
+}
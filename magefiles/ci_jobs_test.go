@@ -0,0 +1,153 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCIJobsAreValid(t *testing.T) {
+	if err := validateCIJobs(ciJobs); err != nil {
+		t.Errorf("invalid CI jobs: %v", err)
+	}
+}
+
+func TestFindCIJob(t *testing.T) {
+	for _, tc := range []struct {
+		jobName  string
+		repo     string
+		expected string
+	}{
+		{"periodic-ci-redhat-appstudio-infra-deployments-main-appstudio-e2e-tests-periodic", "infra-deployments", "rhtap-nightly"},
+		{"pull-ci-redhat-appstudio-e2e-tests-main-redhat-appstudio-e2e", "e2e-tests", "e2e-tests"},
+		{"pull-ci-redhat-appstudio-application-service-main-application-service-e2e", "application-service", "application-service"},
+		{"pull-ci-redhat-appstudio-release-service-main-release-service-e2e", "release-service", "release-service"},
+		{"pull-ci-redhat-appstudio-integration-service-main-integration-service-e2e", "integration-service", "integration-service"},
+		{"pull-ci-redhat-appstudio-jvm-build-service-main-jvm-build-service-e2e", "jvm-build-service", "jvm-build-service"},
+		{"pull-ci-redhat-appstudio-build-service-main-build-service-e2e", "build-service", "build-service"},
+		{"pull-ci-redhat-appstudio-image-controller-main-image-controller-e2e", "image-controller", "image-controller"},
+		{"pull-ci-redhat-appstudio-remote-secret-main-remote-secret-service-e2e", "remote-secret", "remote-secret-service"},
+		{"pull-ci-redhat-appstudio-service-provider-integration-operator-main-spi-service-e2e", "service-provider-integration-operator", "spi-service"},
+		{"pull-ci-redhat-appstudio-multi-platform-controller-main-multi-platform-controller-service-e2e", "multi-platform-controller", "multi-platform-controller"},
+		{"pull-ci-redhat-appstudio-infra-deployments-main-appstudio-e2e-tests", "infra-deployments", "infra-deployments"},
+		{"pull-ci-redhat-appstudio-release-service-catalog-main-release-pipelines-e2e", "release-service-catalog", "release-service-catalog"},
+		{"rehearse-12345-pull-ci-redhat-appstudio-e2e-tests-main-redhat-appstudio-e2e", "release", "default"},
+	} {
+		job, err := findCIJob(ciJobs, tc.jobName, tc.repo)
+		if err != nil {
+			t.Errorf("no CI job found for %s: %v", tc.jobName, err)
+			continue
+		}
+		if job.Name != tc.expected {
+			t.Errorf("expected the job %s to match the CI job %q, got %q", tc.jobName, tc.expected, job.Name)
+		}
+	}
+}
+
+func TestValidateCIJobs(t *testing.T) {
+	catchAll := CIJob{Name: "default"}
+	for _, tc := range []struct {
+		name     string
+		jobs     []CIJob
+		expected string
+	}{
+		{"invalid pattern", []CIJob{{Name: "svc", JobNamePatterns: []string{"svc("}}, catchAll}, "invalid job name pattern"},
+		{"invalid env var prefix", []CIJob{{Name: "svc", Repo: "svc", EnvVarPrefix: "my-svc"}, catchAll}, "invalid env var prefix"},
+		{"image without prefix", []CIJob{{Name: "svc", Repo: "svc", ImageTagSuffix: "svc-image"}, catchAll}, "env var prefix of the service image is not set"},
+		{"invalid label filter", []CIJob{{Name: "svc", Repo: "svc", LabelFilter: "build && ("}, catchAll}, "invalid label filter"},
+		{"unknown hook", []CIJob{{Name: "svc", Repo: "svc", SetupHooks: []string{"unknown"}}, catchAll}, "unknown setup hook"},
+		{"duplicate", []CIJob{{Name: "default", Repo: "svc"}, catchAll}, "duplicate CI job"},
+		{"unreachable", []CIJob{catchAll, {Name: "svc", Repo: "svc"}}, "entries after it are never used"},
+		{"no catch-all", []CIJob{{Name: "svc", Repo: "svc"}}, "the last CI job has to match all the jobs"},
+	} {
+		err := validateCIJobs(tc.jobs)
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("%s: expected an error containing %q, got %v", tc.name, tc.expected, err)
+		}
+	}
+}
+
+func TestApplyCIJob(t *testing.T) {
+	t.Setenv("COMPONENT_IMAGE", "quay.io/redhat-appstudio/integration-service@sha256:1234")
+	for _, env := range []string{"INTEGRATION_SERVICE_IMAGE_REPO", "INTEGRATION_SERVICE_IMAGE_TAG", "INTEGRATION_SERVICE_PR_OWNER", "INTEGRATION_SERVICE_PR_SHA", "E2E_TEST_SUITE_LABEL"} {
+		t.Setenv(env, "")
+	}
+	pr.RemoteName, pr.CommitSHA = "contributor", "abcdef"
+	defer func() {
+		pr.RemoteName, pr.CommitSHA = "", ""
+		requiresSprayProxyRegistering, requiresMultiPlatformTests = false, false
+	}()
+
+	job, err := findCIJob(ciJobs, "pull-ci-redhat-appstudio-integration-service-main-integration-service-e2e", "integration-service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := job.apply(); err != nil {
+		t.Fatalf("failed to apply the CI job: %v", err)
+	}
+
+	for env, expected := range map[string]string{
+		"INTEGRATION_SERVICE_IMAGE_REPO": "quay.io/redhat-appstudio/integration-service",
+		"INTEGRATION_SERVICE_IMAGE_TAG":  "redhat-appstudio-integration-service-image",
+		"INTEGRATION_SERVICE_PR_OWNER":   "contributor",
+		"INTEGRATION_SERVICE_PR_SHA":     "abcdef",
+		"E2E_TEST_SUITE_LABEL":           "integration-service",
+	} {
+		if value := os.Getenv(env); value != expected {
+			t.Errorf("expected %s to be %q, got %q", env, expected, value)
+		}
+	}
+	if !requiresSprayProxyRegistering || requiresMultiPlatformTests {
+		t.Errorf("the integration-service job requires only SprayProxy, got SprayProxy %t and multi-platform %t", requiresSprayProxyRegistering, requiresMultiPlatformTests)
+	}
+}
+
+func TestNewJobReplayPlan(t *testing.T) {
+	t.Setenv("COMPONENT_IMAGE", "quay.io/redhat-appstudio/application-service@sha256:1234")
+	defer func(name, typ string, spec *OpenshiftJobSpec) {
+		jobName, jobType, openshiftJobSpec = name, typ, spec
+	}(jobName, jobType, openshiftJobSpec)
+
+	// the rehearse jobs don't need the pull request metadata from GitHub
+	spec := OpenshiftJobSpec{
+		Type: "presubmit",
+		Job:  "rehearse-43210-pull-ci-redhat-appstudio-application-service-main-application-service-e2e",
+		Refs: Refs{Organization: "openshift", Repo: "release", Pulls: []Pull{{Number: 43210, SHA: "abcdef"}}},
+	}
+	plan, job, err := newJobReplayPlan(spec)
+	if err != nil {
+		t.Fatalf("failed to plan the replay: %v", err)
+	}
+	if job.Name != "application-service" || plan.CIJob != "application-service" {
+		t.Errorf("expected the job to match the application-service CI job, got %q", plan.CIJob)
+	}
+	if plan.LabelFilter != "e2e-demo,byoc" || !plan.SprayProxy || plan.MultiPlatform {
+		t.Errorf("unexpected plan %+v", plan)
+	}
+	if plan.PullRequest != nil || plan.E2ETestsRef != "" {
+		t.Errorf("the pull request of a rehearse job isn't relevant for the tests, got %+v and %q", plan.PullRequest, plan.E2ETestsRef)
+	}
+	expectedEnv := map[string]string{
+		"HAS_IMAGE_REPO":       "quay.io/redhat-appstudio/application-service",
+		"HAS_IMAGE_TAG":        "redhat-appstudio-has-image",
+		"E2E_TEST_SUITE_LABEL": "e2e-demo,byoc",
+	}
+	if len(plan.EnvVars) != len(expectedEnv) {
+		t.Errorf("expected the env vars %v, got %v", expectedEnv, plan.EnvVars)
+	}
+	for env, expected := range expectedEnv {
+		if plan.EnvVars[env] != expected {
+			t.Errorf("expected %s to be %q, got %q", env, expected, plan.EnvVars[env])
+		}
+	}
+
+	spec.Job = "periodic-ci-redhat-appstudio-infra-deployments-main-appstudio-e2e-tests-periodic"
+	spec.Type = "periodic"
+	plan, _, err = newJobReplayPlan(spec)
+	if err != nil {
+		t.Fatalf("failed to plan the replay: %v", err)
+	}
+	if plan.CIJob != "rhtap-nightly" || plan.LabelFilter != defaultE2ELabelFilter || len(plan.EnvVars) != 0 {
+		t.Errorf("unexpected plan of the periodic job %+v", plan)
+	}
+}
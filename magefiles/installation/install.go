@@ -3,13 +3,10 @@ package installation
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"os"
 	"time"
 
-	"strings"
-
 	appsv1 "k8s.io/api/apps/v1"
 
 	"github.com/devfile/library/v2/pkg/util"
@@ -17,7 +14,6 @@ import (
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 
-	appclientset "github.com/argoproj/argo-cd/v2/pkg/client/clientset/versioned"
 	kubeCl "github.com/redhat-appstudio/e2e-tests/pkg/clients/kubernetes"
 	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
 	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
@@ -26,7 +22,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
-	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog/v2"
 )
 
@@ -86,6 +81,13 @@ type InstallAppStudio struct {
 
 	// Default expiration for image tags
 	DefaultImageTagExpiration string
+
+	// CRDs checked by CheckOperatorsReady, the preview mode installs all of them. The manifest bundle installer
+	// checks only the CRDs in the bundle.
+	ExpectedCRDs []string
+
+	// How long CheckOperatorsReady waits for the cluster to become ready
+	ReadinessTimeout time.Duration
 }
 
 func NewAppStudioInstallController() (*InstallAppStudio, error) {
@@ -108,6 +110,8 @@ func NewAppStudioInstallController() (*InstallAppStudio, error) {
 		DefaultImageQuayOrg:              utils.GetEnv("DEFAULT_QUAY_ORG", DEFAULT_E2E_QUAY_ORG),
 		DefaultImageQuayOrgOAuth2Token:   utils.GetEnv("DEFAULT_QUAY_ORG_TOKEN", ""),
 		DefaultImageTagExpiration:        utils.GetEnv(constants.IMAGE_TAG_EXPIRATION_ENV, constants.DefaultImageTagExpiration),
+		ExpectedCRDs:                     DefaultExpectedCRDs,
+		ReadinessTimeout:                 readinessTimeout(),
 	}, nil
 }
 
@@ -131,6 +135,8 @@ func NewAppStudioInstallControllerUpgrade(infraFork string, infraBranch string)
 		DefaultImageQuayOrg:              utils.GetEnv("DEFAULT_QUAY_ORG", ""),
 		DefaultImageQuayOrgOAuth2Token:   utils.GetEnv("DEFAULT_QUAY_ORG_TOKEN", ""),
 		DefaultImageTagExpiration:        utils.GetEnv(constants.IMAGE_TAG_EXPIRATION_ENV, constants.DefaultImageTagExpiration),
+		ExpectedCRDs:                     DefaultExpectedCRDs,
+		ReadinessTimeout:                 readinessTimeout(),
 	}, nil
 }
 
@@ -187,77 +193,6 @@ func (i *InstallAppStudio) cloneInfraDeployments() (*git.Remote, error) {
 	return repo.CreateRemote(&config.RemoteConfig{Name: i.LocalForkName, URLs: []string{fmt.Sprintf("https://github.com/%s/infra-deployments.git", i.LocalGithubForkOrganization)}})
 }
 
-func (i *InstallAppStudio) CheckOperatorsReady() (err error) {
-	apiConfig, err := clientcmd.NewDefaultClientConfigLoadingRules().Load()
-	if err != nil {
-		klog.Fatal(err)
-	}
-	config, err := clientcmd.NewDefaultClientConfig(*apiConfig, &clientcmd.ConfigOverrides{}).ClientConfig()
-	if err != nil {
-		klog.Fatal(err)
-	}
-	appClientset := appclientset.NewForConfigOrDie(config)
-
-	patchPayload := []patchStringValue{{
-		Op:    "replace",
-		Path:  "/metadata/annotations/argocd.argoproj.io~1refresh",
-		Value: "hard",
-	}}
-	patchPayloadBytes, err := json.Marshal(patchPayload)
-	if err != nil {
-		klog.Fatal(err)
-	}
-	_, err = appClientset.ArgoprojV1alpha1().Applications("openshift-gitops").Patch(context.Background(), "all-application-sets", types.JSONPatchType, patchPayloadBytes, metav1.PatchOptions{})
-	if err != nil {
-		klog.Fatal(err)
-	}
-
-	for {
-		var count = 0
-		appsListFor, err := appClientset.ArgoprojV1alpha1().Applications("openshift-gitops").List(context.Background(), metav1.ListOptions{})
-		for _, app := range appsListFor.Items {
-			fmt.Printf("Check application: %s\n", app.Name)
-			application, err := appClientset.ArgoprojV1alpha1().Applications("openshift-gitops").Get(context.Background(), app.Name, metav1.GetOptions{})
-			if err != nil {
-				klog.Fatal(err)
-			}
-
-			if !(application.Status.Sync.Status == "Synced" && application.Status.Health.Status == "Healthy") {
-				klog.Infof("Application %s not ready", app.Name)
-				count++
-			} else if strings.Contains(application.String(), ("context deadline exceeded")) {
-				fmt.Printf("Refreshing Application %s\n", app.Name)
-				patchPayload := []patchStringValue{{
-					Op:    "replace",
-					Path:  "/metadata/annotations/argocd.argoproj.io~1refresh",
-					Value: "soft",
-				}}
-
-				patchPayloadBytes, err := json.Marshal(patchPayload)
-				if err != nil {
-					klog.Fatal(err)
-				}
-				for _, app := range appsListFor.Items {
-					_, err = i.KubernetesClient.KubeInterface().AppsV1().Deployments("openshift-gitops").Patch(context.Background(), app.Name, types.JSONPatchType, patchPayloadBytes, metav1.PatchOptions{})
-					if err != nil {
-						klog.Fatal(err)
-					}
-				}
-			}
-		}
-		if err != nil {
-			klog.Fatal(err)
-		}
-
-		if count == 0 {
-			klog.Info("All Application are ready\n")
-			break
-		}
-		time.Sleep(10 * time.Second)
-	}
-	return err
-}
-
 // Create secret in e2e-secrets which can be copied to testing namespaces
 func (i *InstallAppStudio) createE2EQuaySecret() error {
 	quayToken := os.Getenv("QUAY_TOKEN")
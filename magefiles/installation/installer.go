@@ -0,0 +1,298 @@
+package installation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/klog/v2"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The installer backends selected by the INSTALLER_BACKEND env var
+const (
+	// PreviewModeBackend clones infra-deployments and bootstraps the cluster in the preview mode
+	PreviewModeBackend = "preview"
+	// ManifestBundleBackend applies the pre-rendered kustomize output from the MANIFEST_BUNDLE_DIR directory
+	ManifestBundleBackend = "manifest-bundle"
+
+	manifestFieldOwner         = "e2e-tests"
+	manifestApplyRetryInterval = 5 * time.Second
+)
+
+// Installer installs RHTAP into the cluster and checks it's ready for the tests.
+type Installer interface {
+	// Name is the name of the backend
+	Name() string
+	Install() error
+	CheckReady() (*ClusterReadinessReport, error)
+}
+
+// NewInstaller returns the installer of the given backend, the preview mode if the backend is empty.
+func NewInstaller(ic *InstallAppStudio, backend string) (Installer, error) {
+	switch backend {
+	case "", PreviewModeBackend:
+		return &PreviewModeInstaller{InstallAppStudio: ic}, nil
+	case ManifestBundleBackend:
+		dir := utils.GetEnv(constants.MANIFEST_BUNDLE_DIR_ENV, "")
+		if dir == "" {
+			return nil, fmt.Errorf("%s has to be set for the %s installer", constants.MANIFEST_BUNDLE_DIR_ENV, ManifestBundleBackend)
+		}
+		return &ManifestBundleInstaller{InstallAppStudio: ic, Dir: dir}, nil
+	default:
+		return nil, fmt.Errorf("unknown installer backend %q, expected %q or %q", backend, PreviewModeBackend, ManifestBundleBackend)
+	}
+}
+
+// NewInstallerFromEnv returns the installer of the backend from the INSTALLER_BACKEND env var.
+func NewInstallerFromEnv(ic *InstallAppStudio) (Installer, error) {
+	return NewInstaller(ic, utils.GetEnv(constants.INSTALLER_BACKEND_ENV, PreviewModeBackend))
+}
+
+// PreviewModeInstaller installs RHTAP from infra-deployments by hack/bootstrap-cluster.sh in the preview mode.
+type PreviewModeInstaller struct {
+	*InstallAppStudio
+}
+
+func (p *PreviewModeInstaller) Name() string {
+	return PreviewModeBackend
+}
+
+func (p *PreviewModeInstaller) Install() error {
+	return p.InstallAppStudioPreviewMode()
+}
+
+func (p *PreviewModeInstaller) CheckReady() (*ClusterReadinessReport, error) {
+	return p.CheckOperatorsReady()
+}
+
+// ManifestBundleInstaller applies the pre-rendered kustomize output (e.g. `kustomize build` of the infra-deployments
+// overlays) from a local directory, so clusters without access to GitHub or without ArgoCD (e.g. kind) can be bootstrapped.
+type ManifestBundleInstaller struct {
+	*InstallAppStudio
+
+	// Dir is the directory with the YAML or JSON manifests, all the files with the .yaml, .yml and .json extensions are applied
+	Dir string
+}
+
+func (m *ManifestBundleInstaller) Name() string {
+	return ManifestBundleBackend
+}
+
+// Install applies the manifests by the server-side apply. The Namespaces and CRDs are applied first and the objects
+// whose kind isn't known yet are retried until their CRDs are established.
+func (m *ManifestBundleInstaller) Install() error {
+	objects, err := readManifests(m.Dir)
+	if err != nil {
+		return err
+	}
+	if len(objects) == 0 {
+		return fmt.Errorf("no manifests found in %s", m.Dir)
+	}
+	sortManifests(objects)
+	klog.Infof("applying %d objects from the manifest bundle %s", len(objects), m.Dir)
+
+	pending := objects
+	err = utils.WaitUntilWithInterval(func() (done bool, err error) {
+		remaining := []*unstructured.Unstructured{}
+		for _, obj := range pending {
+			if err := m.apply(obj); err != nil {
+				if meta.IsNoMatchError(err) {
+					remaining = append(remaining, obj)
+					continue
+				}
+				return false, fmt.Errorf("failed to apply %s: %v", describeManifest(obj), err)
+			}
+		}
+		pending = remaining
+		if len(pending) > 0 {
+			klog.Infof("waiting for the CRDs of %d objects, e.g. %s", len(pending), describeManifest(pending[0]))
+		}
+		return len(pending) == 0, nil
+	}, manifestApplyRetryInterval, m.ReadinessTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to apply the manifest bundle %s: %v", m.Dir, err)
+	}
+
+	if m.QuayToken == "" {
+		klog.Warning("QUAY_TOKEN not set: not creating the quay secret for the tests")
+		return nil
+	}
+	return m.createE2EQuaySecret()
+}
+
+func (m *ManifestBundleInstaller) apply(obj *unstructured.Unstructured) error {
+	obj = obj.DeepCopy()
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+	return m.KubernetesClient.KubeRest().Patch(context.Background(), obj, crclient.Apply, crclient.FieldOwner(manifestFieldOwner), crclient.ForceOwnership)
+}
+
+// CheckReady waits until the Deployments from the bundle are available and its CRDs are installed. Only the content
+// of the bundle is checked, so a partial bundle (e.g. of a single service) doesn't have to contain the ExpectedCRDs.
+// The bundle is read again, so the cluster can be checked without installing it first (e.g. before an upgrade).
+func (m *ManifestBundleInstaller) CheckReady() (*ClusterReadinessReport, error) {
+	objects, err := readManifests(m.Dir)
+	if err != nil {
+		return nil, err
+	}
+	deployments, crds, err := bundleReadinessTargets(objects)
+	if err != nil {
+		return nil, err
+	}
+	return waitUntilReady(func() (*ClusterReadinessReport, error) {
+		report := &ClusterReadinessReport{Time: time.Now(), Applications: []ApplicationReadiness{}, Deployments: []DeploymentReadiness{}}
+		for _, obj := range deployments {
+			deployment, err := m.KubernetesClient.KubeInterface().AppsV1().Deployments(obj.GetNamespace()).Get(context.Background(), obj.GetName(), metav1.GetOptions{})
+			if err != nil {
+				if !k8sErrors.IsNotFound(err) {
+					return nil, fmt.Errorf("failed to get the Deployment %s/%s: %v", obj.GetNamespace(), obj.GetName(), err)
+				}
+				report.Deployments = append(report.Deployments, DeploymentReadiness{Application: ManifestBundleBackend, Namespace: obj.GetNamespace(), Name: obj.GetName(), Message: "not found"})
+				continue
+			}
+			report.Deployments = append(report.Deployments, newDeploymentReadiness(ManifestBundleBackend, deployment))
+		}
+
+		var err error
+		if report.CRDs, err = m.crdReadiness(crds); err != nil {
+			return nil, err
+		}
+		report.Ready = len(report.Unhealthy()) == 0
+		return report, nil
+	}, m.ReadinessTimeout)
+}
+
+// bundleReadinessTargets returns the Deployments and the names of the CRDs in the bundle, which are checked by CheckReady.
+// The bundle doesn't have a target namespace, so a Deployment without the namespace is an error, it couldn't be found.
+func bundleReadinessTargets(objects []*unstructured.Unstructured) ([]*unstructured.Unstructured, []string, error) {
+	deployments := []*unstructured.Unstructured{}
+	crds := []string{}
+	for _, obj := range objects {
+		switch obj.GetKind() {
+		case "Deployment":
+			if obj.GetNamespace() == "" {
+				return nil, nil, fmt.Errorf("the %s in the manifest bundle has no namespace, set metadata.namespace in the bundle (e.g. by the kustomize namespace field)", describeManifest(obj))
+			}
+			deployments = append(deployments, obj)
+		case "CustomResourceDefinition":
+			crds = append(crds, obj.GetName())
+		}
+	}
+	return deployments, uniqueStrings(crds), nil
+}
+
+// readManifests reads the objects from all the manifest files in the directory and its subdirectories, in the lexical order of the files.
+func readManifests(dir string) ([]*unstructured.Unstructured, error) {
+	objects := []*unstructured.Unstructured{}
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), 4096)
+		for {
+			obj := &unstructured.Unstructured{}
+			if err := decoder.Decode(&obj.Object); err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return fmt.Errorf("failed to parse the manifest %s: %v", path, err)
+			}
+			// empty documents, e.g. between two "---"
+			if len(obj.Object) == 0 {
+				continue
+			}
+			items := []*unstructured.Unstructured{obj}
+			if obj.IsList() {
+				items = []*unstructured.Unstructured{}
+				if err := obj.EachListItem(func(item runtime.Object) error {
+					items = append(items, item.(*unstructured.Unstructured))
+					return nil
+				}); err != nil {
+					return fmt.Errorf("failed to parse the list in the manifest %s: %v", path, err)
+				}
+			}
+			for _, item := range items {
+				if item.GetKind() == "" || item.GetAPIVersion() == "" || item.GetName() == "" {
+					return fmt.Errorf("the manifest %s contains an object without the apiVersion, kind or name", path)
+				}
+				objects = append(objects, item)
+			}
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the manifest bundle %s: %v", dir, err)
+	}
+	return objects, nil
+}
+
+// manifestKindOrder are the kinds applied before the other objects
+var manifestKindOrder = map[string]int{
+	"Namespace":                0,
+	"CustomResourceDefinition": 1,
+	"ServiceAccount":           2,
+	"ClusterRole":              2,
+	"Role":                     2,
+	"ClusterRoleBinding":       3,
+	"RoleBinding":              3,
+}
+
+// sortManifests moves the Namespaces, CRDs and RBAC before the other objects, keeping the order of the files otherwise.
+func sortManifests(objects []*unstructured.Unstructured) {
+	order := func(obj *unstructured.Unstructured) int {
+		if o, ok := manifestKindOrder[obj.GetKind()]; ok {
+			return o
+		}
+		return len(manifestKindOrder)
+	}
+	sort.SliceStable(objects, func(i, j int) bool {
+		return order(objects[i]) < order(objects[j])
+	})
+}
+
+func describeManifest(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s %s", obj.GetKind(), obj.GetName())
+	}
+	return fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+}
+
+func uniqueStrings(values []string) []string {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
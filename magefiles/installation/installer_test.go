@@ -0,0 +1,166 @@
+package installation
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
+)
+
+func writeManifest(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadManifests(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, dir, "build-service/deployment.yaml", `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: build-service-controller-manager
+  namespace: build-service
+---
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: build-service
+`)
+	writeManifest(t, dir, "crds.json", `{"apiVersion": "v1", "kind": "List", "items": [
+  {"apiVersion": "apiextensions.k8s.io/v1", "kind": "CustomResourceDefinition", "metadata": {"name": "components.appstudio.redhat.com"}}
+]}`)
+	writeManifest(t, dir, "README.md", "not a manifest")
+
+	objects, err := readManifests(dir)
+	if err != nil {
+		t.Fatalf("failed to read the manifests: %v", err)
+	}
+	sortManifests(objects)
+
+	described := []string{}
+	for _, obj := range objects {
+		described = append(described, describeManifest(obj))
+	}
+	expected := []string{
+		"Namespace build-service",
+		"CustomResourceDefinition components.appstudio.redhat.com",
+		"Deployment build-service/build-service-controller-manager",
+	}
+	if !reflect.DeepEqual(expected, described) {
+		t.Errorf("expected the objects %v in this order, got %v", expected, described)
+	}
+}
+
+func TestBundleReadinessTargets(t *testing.T) {
+	// a partial bundle of a single service, without the other RHTAP CRDs
+	dir := t.TempDir()
+	writeManifest(t, dir, "build-service.yaml", `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: components.appstudio.redhat.com
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: build-service-controller-manager
+  namespace: build-service
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: build-pipeline-selector
+  namespace: build-service
+`)
+	writeManifest(t, dir, "crds/components.yaml", `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: components.appstudio.redhat.com
+`)
+	objects, err := readManifests(dir)
+	if err != nil {
+		t.Fatalf("failed to read the manifests: %v", err)
+	}
+
+	deployments, crds, err := bundleReadinessTargets(objects)
+	if err != nil {
+		t.Fatalf("failed to get the readiness targets: %v", err)
+	}
+	if len(deployments) != 1 || deployments[0].GetName() != "build-service-controller-manager" {
+		t.Errorf("expected only the build-service Deployment to be checked, got %v", deployments)
+	}
+	if expected := []string{"components.appstudio.redhat.com"}; !reflect.DeepEqual(expected, crds) {
+		t.Errorf("expected only the CRDs of the bundle %v to be checked, got %v", expected, crds)
+	}
+}
+
+func TestBundleReadinessTargetsWithoutNamespace(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, dir, "build-service.yaml", `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: build-service-controller-manager
+`)
+	objects, err := readManifests(dir)
+	if err != nil {
+		t.Fatalf("failed to read the manifests: %v", err)
+	}
+
+	if _, _, err := bundleReadinessTargets(objects); err == nil || !strings.Contains(err.Error(), "Deployment build-service-controller-manager") {
+		t.Errorf("expected an error for the Deployment without the namespace, got %v", err)
+	}
+}
+
+func TestReadInvalidManifests(t *testing.T) {
+	for name, content := range map[string]string{
+		"no kind":     "apiVersion: v1\nmetadata:\n  name: test\n",
+		"no name":     "apiVersion: v1\nkind: ConfigMap\n",
+		"invalid doc": "apiVersion: v1\nkind: [ConfigMap\n",
+	} {
+		dir := t.TempDir()
+		writeManifest(t, dir, "manifest.yaml", content)
+		if _, err := readManifests(dir); err == nil || !strings.Contains(err.Error(), "manifest.yaml") {
+			t.Errorf("%s: expected an error pointing to the manifest, got %v", name, err)
+		}
+	}
+}
+
+func TestNewInstaller(t *testing.T) {
+	ic := &InstallAppStudio{}
+	t.Setenv(constants.MANIFEST_BUNDLE_DIR_ENV, "")
+
+	for backend, expected := range map[string]string{"": PreviewModeBackend, PreviewModeBackend: PreviewModeBackend} {
+		installer, err := NewInstaller(ic, backend)
+		if err != nil || installer.Name() != expected {
+			t.Errorf("expected the %q backend to be the %s installer, got %v, %v", backend, expected, installer, err)
+		}
+	}
+	if _, err := NewInstaller(ic, ManifestBundleBackend); err == nil {
+		t.Errorf("the manifest bundle installer requires %s", constants.MANIFEST_BUNDLE_DIR_ENV)
+	}
+	if _, err := NewInstaller(ic, "helm"); err == nil {
+		t.Errorf("expected an error for an unknown backend")
+	}
+
+	t.Setenv(constants.MANIFEST_BUNDLE_DIR_ENV, "/tmp/rhtap-manifests")
+	t.Setenv(constants.INSTALLER_BACKEND_ENV, ManifestBundleBackend)
+	installer, err := NewInstallerFromEnv(ic)
+	if err != nil {
+		t.Fatalf("failed to create the installer: %v", err)
+	}
+	if bundle, ok := installer.(*ManifestBundleInstaller); !ok || bundle.Dir != "/tmp/rhtap-manifests" {
+		t.Errorf("expected the manifest bundle installer of /tmp/rhtap-manifests, got %+v", installer)
+	}
+}
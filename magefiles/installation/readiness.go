@@ -0,0 +1,444 @@
+package installation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	argov1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	appclientset "github.com/argoproj/argo-cd/v2/pkg/client/clientset/versioned"
+	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+)
+
+const (
+	DEFAULT_READINESS_TIMEOUT = 45 * time.Minute
+	ARGOCD_NAMESPACE          = "openshift-gitops"
+
+	readinessPollInterval = 10 * time.Second
+)
+
+var (
+	// CRDs which have to be installed before running the tests
+	DefaultExpectedCRDs = []string{
+		"applications.appstudio.redhat.com",
+		"components.appstudio.redhat.com",
+		"environments.appstudio.redhat.com",
+		"snapshots.appstudio.redhat.com",
+		"integrationtestscenarios.appstudio.redhat.com",
+		"releaseplans.appstudio.redhat.com",
+		"releaseplanadmissions.appstudio.redhat.com",
+		"releases.appstudio.redhat.com",
+		"spiaccesstokenbindings.appstudio.redhat.com",
+		"pipelineruns.tekton.dev",
+	}
+
+	crdResource = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+)
+
+// ClusterReadinessReport is the state of the ArgoCD Applications, the operator Deployments they manage and the expected CRDs.
+type ClusterReadinessReport struct {
+	Time         time.Time              `json:"time"`
+	Ready        bool                   `json:"ready"`
+	TimedOut     bool                   `json:"timedOut,omitempty"`
+	Applications []ApplicationReadiness `json:"applications"`
+	Deployments  []DeploymentReadiness  `json:"deployments"`
+	CRDs         []CRDReadiness         `json:"crds"`
+	// Diff compares what was unhealthy at the first check with the last check, it's set only if the check timed out
+	Diff *ReadinessDiff `json:"diff,omitempty"`
+}
+
+// ApplicationReadiness is the sync and health status of an ArgoCD Application.
+type ApplicationReadiness struct {
+	Name         string `json:"name"`
+	SyncStatus   string `json:"syncStatus"`
+	HealthStatus string `json:"healthStatus"`
+	Message      string `json:"message,omitempty"`
+	// DegradedResources are the resources of the Application which are out of sync or unhealthy
+	DegradedResources []ResourceReadiness `json:"degradedResources,omitempty"`
+}
+
+// ResourceReadiness is the status of a resource managed by an ArgoCD Application.
+type ResourceReadiness struct {
+	Kind         string `json:"kind"`
+	Namespace    string `json:"namespace,omitempty"`
+	Name         string `json:"name"`
+	SyncStatus   string `json:"syncStatus"`
+	HealthStatus string `json:"healthStatus,omitempty"`
+	Message      string `json:"message,omitempty"`
+}
+
+// DeploymentReadiness is the state of an operator Deployment managed by an ArgoCD Application.
+type DeploymentReadiness struct {
+	Application   string `json:"application"`
+	Namespace     string `json:"namespace"`
+	Name          string `json:"name"`
+	Replicas      int32  `json:"replicas"`
+	ReadyReplicas int32  `json:"readyReplicas"`
+	Ready         bool   `json:"ready"`
+	Message       string `json:"message,omitempty"`
+}
+
+// CRDReadiness tells whether an expected CRD is installed.
+type CRDReadiness struct {
+	Name    string `json:"name"`
+	Present bool   `json:"present"`
+}
+
+// ReadinessDiff lists what changed between two readiness reports, the items are described as "<kind> <name>: <status>".
+type ReadinessDiff struct {
+	// StillUnhealthy were unhealthy in both reports
+	StillUnhealthy []string `json:"stillUnhealthy"`
+	// Recovered were unhealthy only in the first report
+	Recovered []string `json:"recovered"`
+	// NewlyUnhealthy were unhealthy only in the second report
+	NewlyUnhealthy []string `json:"newlyUnhealthy"`
+}
+
+func (a ApplicationReadiness) healthy() bool {
+	return a.SyncStatus == string(argov1alpha1.SyncStatusCodeSynced) && a.HealthStatus == "Healthy"
+}
+
+// Unhealthy returns the description of the Applications, Deployments and CRDs which aren't ready, by their key.
+func (r *ClusterReadinessReport) Unhealthy() map[string]string {
+	unhealthy := map[string]string{}
+	for _, app := range r.Applications {
+		if !app.healthy() {
+			unhealthy["Application "+app.Name] = fmt.Sprintf("%s/%s", app.SyncStatus, app.HealthStatus)
+		}
+	}
+	for _, d := range r.Deployments {
+		if !d.Ready {
+			unhealthy[fmt.Sprintf("Deployment %s/%s", d.Namespace, d.Name)] = fmt.Sprintf("%d/%d ready", d.ReadyReplicas, d.Replicas)
+		}
+	}
+	for _, crd := range r.CRDs {
+		if !crd.Present {
+			unhealthy["CRD "+crd.Name] = "missing"
+		}
+	}
+	return unhealthy
+}
+
+// DiffReadinessReports compares what was unhealthy in the before and after reports.
+func DiffReadinessReports(before, after *ClusterReadinessReport) ReadinessDiff {
+	diff := ReadinessDiff{StillUnhealthy: []string{}, Recovered: []string{}, NewlyUnhealthy: []string{}}
+	unhealthyBefore, unhealthyAfter := before.Unhealthy(), after.Unhealthy()
+	for key, status := range unhealthyAfter {
+		if _, ok := unhealthyBefore[key]; ok {
+			diff.StillUnhealthy = append(diff.StillUnhealthy, key+": "+status)
+		} else {
+			diff.NewlyUnhealthy = append(diff.NewlyUnhealthy, key+": "+status)
+		}
+	}
+	for key, status := range unhealthyBefore {
+		if _, ok := unhealthyAfter[key]; !ok {
+			diff.Recovered = append(diff.Recovered, key+": "+status)
+		}
+	}
+	sort.Strings(diff.StillUnhealthy)
+	sort.Strings(diff.Recovered)
+	sort.Strings(diff.NewlyUnhealthy)
+	return diff
+}
+
+// WriteText writes a table of the Applications, Deployments and CRDs which aren't ready.
+func (r *ClusterReadinessReport) WriteText(w io.Writer) error {
+	if r.Ready {
+		fmt.Fprintf(w, "The cluster is ready: %d ArgoCD Applications, %d Deployments and %d CRDs checked\n", len(r.Applications), len(r.Deployments), len(r.CRDs))
+		return nil
+	}
+	fmt.Fprintf(w, "The cluster is not ready (timed out: %t)\n\n", r.TimedOut)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tNAME\tSYNC\tHEALTH\tMESSAGE")
+	for _, app := range r.Applications {
+		if app.healthy() {
+			continue
+		}
+		fmt.Fprintf(tw, "Application\t%s\t%s\t%s\t%s\n", app.Name, app.SyncStatus, app.HealthStatus, oneLine(app.Message))
+		for _, res := range app.DegradedResources {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n", res.Kind, path.Join(res.Namespace, res.Name), res.SyncStatus, res.HealthStatus, oneLine(res.Message))
+		}
+	}
+	for _, d := range r.Deployments {
+		if !d.Ready {
+			fmt.Fprintf(tw, "Deployment\t%s/%s\t\t%d/%d ready\t%s\n", d.Namespace, d.Name, d.ReadyReplicas, d.Replicas, oneLine(d.Message))
+		}
+	}
+	for _, crd := range r.CRDs {
+		if !crd.Present {
+			fmt.Fprintf(tw, "CRD\t%s\t\tmissing\t\n", crd.Name)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if r.Diff != nil {
+		for _, section := range []struct {
+			title string
+			items []string
+		}{
+			{"Unhealthy since the first check", r.Diff.StillUnhealthy},
+			{"Recovered since the first check", r.Diff.Recovered},
+			{"Unhealthy only in the last check", r.Diff.NewlyUnhealthy},
+		} {
+			if len(section.items) > 0 {
+				fmt.Fprintf(w, "\n%s:\n  %s\n", section.title, strings.Join(section.items, "\n  "))
+			}
+		}
+	}
+	return nil
+}
+
+// WriteFiles writes the report into <name>.json and <name>.txt in the directory.
+func (r *ClusterReadinessReport) WriteFiles(dir, name string) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".json"), content, 0644); err != nil {
+		return err
+	}
+
+	file, err := os.Create(filepath.Join(dir, name+".txt"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return r.WriteText(file)
+}
+
+func oneLine(message string) string {
+	return strings.Join(strings.Fields(message), " ")
+}
+
+// CheckOperatorsReady hard refreshes the ArgoCD Applications and waits until all of them are synced and healthy,
+// the operator Deployments they manage are available and the expected CRDs are installed.
+// The last report is returned along with an error if the cluster isn't ready within the ReadinessTimeout.
+func (i *InstallAppStudio) CheckOperatorsReady() (*ClusterReadinessReport, error) {
+	apiConfig, err := clientcmd.NewDefaultClientConfigLoadingRules().Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the kubeconfig: %v", err)
+	}
+	config, err := clientcmd.NewDefaultClientConfig(*apiConfig, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the client config: %v", err)
+	}
+	appClientset, err := appclientset.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the ArgoCD client: %v", err)
+	}
+
+	if err := refreshApplication(appClientset, "all-application-sets", "hard"); err != nil {
+		return nil, err
+	}
+
+	return waitUntilReady(func() (*ClusterReadinessReport, error) {
+		report, err := i.collectReadinessReport(appClientset)
+		if err != nil {
+			return nil, err
+		}
+		for _, app := range report.Applications {
+			if app.healthy() && strings.Contains(app.Message, "context deadline exceeded") {
+				klog.Infof("Refreshing Application %s", app.Name)
+				if err := refreshApplication(appClientset, app.Name, "soft"); err != nil {
+					klog.Warning(err)
+				}
+			}
+		}
+		return report, nil
+	}, i.ReadinessTimeout)
+}
+
+// waitUntilReady polls the readiness reports until the cluster is ready or the timeout expires. The last report is returned,
+// along with the diff against the first one and an error if the cluster isn't ready in time.
+func waitUntilReady(collect func() (*ClusterReadinessReport, error), timeout time.Duration) (*ClusterReadinessReport, error) {
+	var first, last *ClusterReadinessReport
+	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
+		report, err := collect()
+		if err != nil {
+			klog.Warningf("failed to check the readiness of the cluster: %v", err)
+			return false, nil
+		}
+		if first == nil {
+			first = report
+		}
+		last = report
+
+		if !report.Ready {
+			klog.Infof("%d of the ArgoCD Applications, Deployments and CRDs aren't ready", len(report.Unhealthy()))
+		}
+		return report.Ready, nil
+	}, readinessPollInterval, timeout)
+
+	if last == nil {
+		return nil, fmt.Errorf("the readiness of the cluster couldn't be checked within %s: %v", timeout, err)
+	}
+	if err != nil {
+		last.TimedOut = true
+		diff := DiffReadinessReports(first, last)
+		last.Diff = &diff
+		return last, fmt.Errorf("the cluster isn't ready after %s: %d of the ArgoCD Applications, Deployments and CRDs are unhealthy", timeout, len(last.Unhealthy()))
+	}
+	klog.Info("The cluster is ready")
+	return last, nil
+}
+
+func refreshApplication(appClientset appclientset.Interface, name, refreshType string) error {
+	patchPayloadBytes, err := json.Marshal([]patchStringValue{{
+		Op:    "replace",
+		Path:  "/metadata/annotations/argocd.argoproj.io~1refresh",
+		Value: refreshType,
+	}})
+	if err != nil {
+		return err
+	}
+	_, err = appClientset.ArgoprojV1alpha1().Applications(ARGOCD_NAMESPACE).Patch(context.Background(), name, types.JSONPatchType, patchPayloadBytes, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to refresh the Application %s: %v", name, err)
+	}
+	return nil
+}
+
+func (i *InstallAppStudio) collectReadinessReport(appClientset appclientset.Interface) (*ClusterReadinessReport, error) {
+	ctx := context.Background()
+	report := &ClusterReadinessReport{Time: time.Now(), Applications: []ApplicationReadiness{}, Deployments: []DeploymentReadiness{}, CRDs: []CRDReadiness{}}
+
+	apps, err := appClientset.ArgoprojV1alpha1().Applications(ARGOCD_NAMESPACE).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the ArgoCD Applications: %v", err)
+	}
+	for _, app := range apps.Items {
+		report.Applications = append(report.Applications, newApplicationReadiness(app))
+
+		for _, res := range app.Status.Resources {
+			if res.Group != appsv1.GroupName || res.Kind != "Deployment" {
+				continue
+			}
+			deployment, err := i.KubernetesClient.KubeInterface().AppsV1().Deployments(res.Namespace).Get(ctx, res.Name, metav1.GetOptions{})
+			if err != nil {
+				if !k8sErrors.IsNotFound(err) {
+					return nil, fmt.Errorf("failed to get the Deployment %s/%s: %v", res.Namespace, res.Name, err)
+				}
+				report.Deployments = append(report.Deployments, DeploymentReadiness{Application: app.Name, Namespace: res.Namespace, Name: res.Name, Message: "not found"})
+				continue
+			}
+			report.Deployments = append(report.Deployments, newDeploymentReadiness(app.Name, deployment))
+		}
+	}
+
+	crds, err := i.crdReadiness(i.ExpectedCRDs)
+	if err != nil {
+		return nil, err
+	}
+	report.CRDs = crds
+
+	report.Ready = len(report.Unhealthy()) == 0
+	return report, nil
+}
+
+func (i *InstallAppStudio) crdReadiness(names []string) ([]CRDReadiness, error) {
+	crds := []CRDReadiness{}
+	for _, name := range names {
+		_, err := i.KubernetesClient.DynamicClient().Resource(crdResource).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get the CRD %s: %v", name, err)
+		}
+		crds = append(crds, CRDReadiness{Name: name, Present: err == nil})
+	}
+	return crds, nil
+}
+
+func newApplicationReadiness(app argov1alpha1.Application) ApplicationReadiness {
+	readiness := ApplicationReadiness{
+		Name:         app.Name,
+		SyncStatus:   string(app.Status.Sync.Status),
+		HealthStatus: string(app.Status.Health.Status),
+		Message:      app.Status.Health.Message,
+	}
+	if readiness.Message == "" && app.Status.OperationState != nil {
+		readiness.Message = app.Status.OperationState.Message
+	}
+	for _, condition := range app.Status.Conditions {
+		if readiness.Message == "" || condition.IsError() {
+			readiness.Message = condition.Message
+		}
+	}
+
+	for _, res := range app.Status.Resources {
+		healthStatus, message := "", ""
+		if res.Health != nil {
+			healthStatus, message = string(res.Health.Status), res.Health.Message
+		}
+		if res.Status == argov1alpha1.SyncStatusCodeSynced && (healthStatus == "" || healthStatus == "Healthy") {
+			continue
+		}
+		readiness.DegradedResources = append(readiness.DegradedResources, ResourceReadiness{
+			Kind:         res.Kind,
+			Namespace:    res.Namespace,
+			Name:         res.Name,
+			SyncStatus:   string(res.Status),
+			HealthStatus: healthStatus,
+			Message:      message,
+		})
+	}
+	return readiness
+}
+
+func newDeploymentReadiness(application string, deployment *appsv1.Deployment) DeploymentReadiness {
+	readiness := DeploymentReadiness{
+		Application:   application,
+		Namespace:     deployment.Namespace,
+		Name:          deployment.Name,
+		Replicas:      1,
+		ReadyReplicas: deployment.Status.ReadyReplicas,
+	}
+	if deployment.Spec.Replicas != nil {
+		readiness.Replicas = *deployment.Spec.Replicas
+	}
+	readiness.Ready = readiness.ReadyReplicas >= readiness.Replicas
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentAvailable && condition.Status != corev1.ConditionTrue {
+			readiness.Ready = false
+			readiness.Message = condition.Message
+		} else if condition.Type == appsv1.DeploymentProgressing && condition.Status == corev1.ConditionFalse && readiness.Message == "" {
+			readiness.Message = condition.Message
+		}
+	}
+	return readiness
+}
+
+// readinessTimeout returns the timeout of the readiness check from the CLUSTER_READINESS_TIMEOUT env var, e.g. "30m".
+func readinessTimeout() time.Duration {
+	value := utils.GetEnv(constants.CLUSTER_READINESS_TIMEOUT_ENV, "")
+	if value == "" {
+		return DEFAULT_READINESS_TIMEOUT
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		klog.Warningf("invalid %s %q, using the default timeout %s", constants.CLUSTER_READINESS_TIMEOUT_ENV, value, DEFAULT_READINESS_TIMEOUT)
+		return DEFAULT_READINESS_TIMEOUT
+	}
+	return timeout
+}
@@ -0,0 +1,134 @@
+package installation
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	argov1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewApplicationReadiness(t *testing.T) {
+	app := argov1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "build-service-in-cluster-local"},
+		Status: argov1alpha1.ApplicationStatus{
+			Sync:   argov1alpha1.SyncStatus{Status: argov1alpha1.SyncStatusCodeOutOfSync},
+			Health: argov1alpha1.HealthStatus{Status: "Degraded"},
+			Conditions: []argov1alpha1.ApplicationCondition{
+				{Type: argov1alpha1.ApplicationConditionSyncError, Message: "one or more objects failed to apply"},
+			},
+			Resources: []argov1alpha1.ResourceStatus{
+				{Group: "apps", Kind: "Deployment", Namespace: "build-service", Name: "build-service-controller-manager", Status: argov1alpha1.SyncStatusCodeSynced,
+					Health: &argov1alpha1.HealthStatus{Status: "Degraded", Message: "Deployment exceeded its progress deadline"}},
+				{Kind: "ConfigMap", Namespace: "build-service", Name: "build-pipeline-selector", Status: argov1alpha1.SyncStatusCodeOutOfSync},
+				{Kind: "Service", Namespace: "build-service", Name: "build-service-metrics", Status: argov1alpha1.SyncStatusCodeSynced,
+					Health: &argov1alpha1.HealthStatus{Status: "Healthy"}},
+			},
+		},
+	}
+
+	readiness := newApplicationReadiness(app)
+	if readiness.healthy() {
+		t.Errorf("the application %+v shouldn't be healthy", readiness)
+	}
+	if readiness.SyncStatus != "OutOfSync" || readiness.HealthStatus != "Degraded" || readiness.Message != "one or more objects failed to apply" {
+		t.Errorf("unexpected readiness of the application %+v", readiness)
+	}
+	expected := []ResourceReadiness{
+		{Kind: "Deployment", Namespace: "build-service", Name: "build-service-controller-manager", SyncStatus: "Synced", HealthStatus: "Degraded", Message: "Deployment exceeded its progress deadline"},
+		{Kind: "ConfigMap", Namespace: "build-service", Name: "build-pipeline-selector", SyncStatus: "OutOfSync"},
+	}
+	if !reflect.DeepEqual(expected, readiness.DegradedResources) {
+		t.Errorf("expected the degraded resources %+v, got %+v", expected, readiness.DegradedResources)
+	}
+}
+
+func TestNewDeploymentReadiness(t *testing.T) {
+	replicas := int32(2)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "integration-service", Name: "integration-service-controller-manager"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: 2},
+	}
+	if readiness := newDeploymentReadiness("integration", deployment); !readiness.Ready {
+		t.Errorf("the deployment with all the replicas ready should be ready, got %+v", readiness)
+	}
+
+	deployment.Status = appsv1.DeploymentStatus{
+		ReadyReplicas: 1,
+		Conditions: []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse, Message: "Deployment does not have minimum availability."},
+		},
+	}
+	readiness := newDeploymentReadiness("integration", deployment)
+	if readiness.Ready || readiness.ReadyReplicas != 1 || readiness.Replicas != 2 || readiness.Message != "Deployment does not have minimum availability." {
+		t.Errorf("unexpected readiness of the unavailable deployment %+v", readiness)
+	}
+}
+
+func TestDiffReadinessReports(t *testing.T) {
+	first := &ClusterReadinessReport{
+		Applications: []ApplicationReadiness{
+			{Name: "build-service", SyncStatus: "OutOfSync", HealthStatus: "Progressing"},
+			{Name: "release", SyncStatus: "Synced", HealthStatus: "Progressing"},
+		},
+		CRDs: []CRDReadiness{{Name: "releases.appstudio.redhat.com", Present: false}},
+	}
+	last := &ClusterReadinessReport{
+		Applications: []ApplicationReadiness{
+			{Name: "build-service", SyncStatus: "OutOfSync", HealthStatus: "Degraded"},
+			{Name: "release", SyncStatus: "Synced", HealthStatus: "Healthy"},
+		},
+		Deployments: []DeploymentReadiness{{Namespace: "spi-system", Name: "spi-oauth-service", Replicas: 1}},
+		CRDs:        []CRDReadiness{{Name: "releases.appstudio.redhat.com", Present: true}},
+	}
+
+	expected := ReadinessDiff{
+		StillUnhealthy: []string{"Application build-service: OutOfSync/Degraded"},
+		Recovered:      []string{"Application release: Synced/Progressing", "CRD releases.appstudio.redhat.com: missing"},
+		NewlyUnhealthy: []string{"Deployment spi-system/spi-oauth-service: 0/1 ready"},
+	}
+	if diff := DiffReadinessReports(first, last); !reflect.DeepEqual(expected, diff) {
+		t.Errorf("expected the diff %+v, got %+v", expected, diff)
+	}
+}
+
+func TestReadinessReportWriteFiles(t *testing.T) {
+	report := &ClusterReadinessReport{
+		TimedOut: true,
+		Applications: []ApplicationReadiness{
+			{Name: "has", SyncStatus: "Synced", HealthStatus: "Healthy"},
+			{Name: "spi", SyncStatus: "Synced", HealthStatus: "Degraded", DegradedResources: []ResourceReadiness{
+				{Kind: "Deployment", Namespace: "spi-system", Name: "spi-oauth-service", SyncStatus: "Synced", HealthStatus: "Degraded", Message: "Deployment\nexceeded its progress deadline"},
+			}},
+		},
+		CRDs: []CRDReadiness{{Name: "spiaccesstokenbindings.appstudio.redhat.com", Present: false}},
+	}
+	report.Diff = &ReadinessDiff{StillUnhealthy: []string{"Application spi: Synced/Degraded"}}
+	dir := t.TempDir()
+	if err := report.WriteFiles(dir, "cluster-readiness-bootstrap"); err != nil {
+		t.Fatalf("failed to write the report: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "cluster-readiness-bootstrap.json")); err != nil {
+		t.Errorf("the JSON report wasn't written: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "cluster-readiness-bootstrap.txt"))
+	if err != nil {
+		t.Fatalf("the text report wasn't written: %v", err)
+	}
+	text := string(content)
+	for _, expected := range []string{"timed out: true", "spi-system/spi-oauth-service", "Deployment exceeded its progress deadline", "spiaccesstokenbindings.appstudio.redhat.com", "Unhealthy since the first check"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("expected the report to contain %q, got\n%s", expected, text)
+		}
+	}
+	if strings.Contains(text, "has ") {
+		t.Errorf("the healthy applications shouldn't be listed, got\n%s", text)
+	}
+}
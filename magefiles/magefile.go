@@ -6,38 +6,39 @@ import (
 	"net/http"
 	"os"
 	"reflect"
-	"regexp"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/devfile/library/v2/pkg/util"
-	"k8s.io/apimachinery/pkg/runtime"
 
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/yaml"
 
 	"github.com/google/go-containerregistry/pkg/authn"
-	"github.com/google/go-containerregistry/pkg/name"
 	remoteimg "github.com/google/go-containerregistry/pkg/v1/remote"
 	gh "github.com/google/go-github/v44/github"
 	"github.com/magefile/mage/sh"
 	"github.com/redhat-appstudio/e2e-tests/magefiles/installation"
+	"github.com/redhat-appstudio/e2e-tests/magefiles/reaper"
 	"github.com/redhat-appstudio/e2e-tests/magefiles/testspecs"
 	"github.com/redhat-appstudio/e2e-tests/pkg/clients/github"
+	kubeCl "github.com/redhat-appstudio/e2e-tests/pkg/clients/kubernetes"
 	"github.com/redhat-appstudio/e2e-tests/pkg/clients/slack"
 	"github.com/redhat-appstudio/e2e-tests/pkg/clients/sprayproxy"
 	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
 	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils/flakiness"
 	"github.com/redhat-appstudio/e2e-tests/pkg/utils/tekton"
 	"github.com/redhat-appstudio/image-controller/pkg/quay"
-	tektonapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 )
 
 const (
-	quayApiUrl       = "https://quay.io/api/v1"
-	gitopsRepository = "GitOps Repository"
+	quayApiUrl = "https://quay.io/api/v1"
+	// the specs run by RunE2ETests if E2E_TEST_SUITE_LABEL isn't set
+	defaultE2ELabelFilter = "!upgrade-create && !upgrade-verify && !upgrade-cleanup && !release-pipelines && !verify-stage"
 )
 
 var (
@@ -47,9 +48,7 @@ var (
 	pr               = &PullRequestMetadata{}
 	jobName          = utils.GetEnv("JOB_NAME", "")
 	// can be periodic, presubmit or postsubmit
-	jobType                    = utils.GetEnv("JOB_TYPE", "")
-	reposToDeleteDefaultRegexp = "jvm-build|e2e-dotnet|build-suite|e2e|pet-clinic-e2e|test-app|e2e-quayio|petclinic|test-app|integ-app|^dockerfile-|new-|^python|my-app|^test-|^multi-component"
-	repositoriesWithWebhooks   = []string{"devfile-sample-hello-world", "hacbs-test-project"}
+	jobType = utils.GetEnv("JOB_TYPE", "")
 	// determine whether CI will run tests that require to register SprayProxy
 	// in order to run tests that require PaC application
 	requiresSprayProxyRegistering bool
@@ -80,6 +79,17 @@ func (ci CI) init() error {
 		return err
 	}
 
+	return initPullRequestMetadata()
+}
+
+// initPullRequestMetadata fills the metadata of the pull request the job runs for from the parsed job spec and GitHub.
+func initPullRequestMetadata() error {
+	var err error
+
+	if len(openshiftJobSpec.Refs.Pulls) == 0 {
+		return fmt.Errorf("the job spec doesn't reference any pull request")
+	}
+
 	pr.Organization = openshiftJobSpec.Refs.Organization
 	pr.RepoName = openshiftJobSpec.Refs.Repo
 	pr.CommitSHA = openshiftJobSpec.Refs.Pulls[0].SHA
@@ -132,106 +142,304 @@ func (Local) TestE2E() error {
 	return RunE2ETests()
 }
 
-// Deletes autogenerated repositories from redhat-appstudio-qe Github org.
-// Env vars to configure this target: REPO_REGEX (optional), DRY_RUN (optional) - defaults to false
-// Remove all repos which with 1 day lifetime. By default will delete gitops repositories from redhat-appstudio-qe
+// Deletes the GitHub repositories leaked by the e2e tests, selected by the "github-repositories" rule of the reaper policy (see CleanupLeakedResources).
+// Env vars to configure this target: REPO_REGEX (optional) - replaces the patterns of the rule, DRY_RUN (optional) - defaults to true
 func (Local) CleanupGithubOrg() error {
-	githubToken := os.Getenv("GITHUB_TOKEN")
-	if githubToken == "" {
-		return fmt.Errorf("env var GITHUB_TOKEN is not set")
+	policy, err := reaperPolicyRules("github-repositories")
+	if err != nil {
+		return err
+	}
+	if repoRegex := os.Getenv("REPO_REGEX"); repoRegex != "" {
+		if err := policy.SetPatterns("github-repositories", repoRegex); err != nil {
+			return err
+		}
 	}
-	dryRun, err := strconv.ParseBool(utils.GetEnv("DRY_RUN", "true"))
+	return reapLeakedResources(true, policy)
+}
+
+// Deletes the Quay repositories and robot accounts leaked by the e2e tests, selected by the "quay-repositories" and
+// "quay-robot-accounts" rules of the reaper policy (see CleanupLeakedResources). DRY_RUN (optional) - defaults to false
+func (Local) CleanupQuayReposAndRobots() error {
+	policy, err := reaperPolicyRules("quay-repositories", "quay-robot-accounts")
 	if err != nil {
-		return fmt.Errorf("unable to parse DRY_RUN env var\n\t%s", err)
+		return err
+	}
+	return reapLeakedResources(false, policy)
+}
+
+// Deletes the Quay tags leaked by the e2e tests, selected by the "quay-tags" rule of the reaper policy (see CleanupLeakedResources).
+// DRY_RUN (optional) - defaults to false
+func (Local) CleanupQuayTags() error {
+	policy, err := reaperPolicyRules("quay-tags")
+	if err != nil {
+		return err
 	}
+	return reapLeakedResources(false, policy)
+}
 
-	// Get all repos
-	githubOrgName := utils.GetEnv(constants.GITHUB_E2E_ORGANIZATION_ENV, "redhat-appstudio-qe")
-	ghClient, err := github.NewGithubClient(githubToken, githubOrgName)
+// Deletes the private Quay repositories leaked by the e2e tests, selected by the "quay-private-repositories" rule of the reaper policy
+// (see CleanupLeakedResources). DRY_RUN (optional) - defaults to false
+func (Local) CleanupPrivateRepos() error {
+	policy, err := reaperPolicyRules("quay-private-repositories")
 	if err != nil {
 		return err
 	}
-	repos, err := ghClient.GetAllRepositories()
+	return reapLeakedResources(false, policy)
+}
+
+// Deletes the resources leaked by the e2e tests in GitHub, Quay, SprayProxy and the cluster, selected by the reaper policy.
+// Env vars to configure this target: REAPER_POLICY (optional) - path to the YAML policy, defaults to magefiles/reaper/default-policy.yaml,
+// DRY_RUN (optional) - defaults to true, only the leaked resources are reported.
+// The resources are skipped if their credentials aren't set: GITHUB_TOKEN (GitHub), DEFAULT_QUAY_ORG_TOKEN (Quay),
+// QE_SPRAYPROXY_HOST and QE_SPRAYPROXY_TOKEN (SprayProxy) and the kubeconfig (cluster).
+// Writes reaper-report.json and reaper-report.txt into ARTIFACT_DIR.
+func (Local) CleanupLeakedResources() error {
+	policy, err := reaper.PolicyFromEnv()
 	if err != nil {
 		return err
 	}
-	var reposToDelete []*gh.Repository
+	return reapLeakedResources(true, policy)
+}
+
+// reaperPolicyRules returns the rules of the given names of the reaper policy from REAPER_POLICY or of the default one
+func reaperPolicyRules(names ...string) (*reaper.Policy, error) {
+	policy, err := reaper.PolicyFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return policy.Only(names...)
+}
 
-	// Filter repos by regex & time check
-	r, err := regexp.Compile(utils.GetEnv("REPO_REGEX", reposToDeleteDefaultRegexp))
+// reapLeakedResources runs the reaper with the policy on the resources whose credentials are set.
+// The DRY_RUN env var defaults to defaultDryRun.
+func reapLeakedResources(defaultDryRun bool, policy *reaper.Policy) error {
+	dryRun, err := strconv.ParseBool(utils.GetEnv("DRY_RUN", strconv.FormatBool(defaultDryRun)))
 	if err != nil {
-		return fmt.Errorf("unable to compile regex: %s", err)
-	}
-	for _, repo := range repos {
-		// Add only repos older than 24 hours
-		dayDuration, _ := time.ParseDuration("24h")
-		if time.Since(repo.GetCreatedAt().Time) > dayDuration {
-			// Add only repos matching the regex
-			if r.MatchString(*repo.Name) || *repo.Description == gitopsRepository {
-				reposToDelete = append(reposToDelete, repo)
+		return fmt.Errorf("unable to parse DRY_RUN env var\n\t%s", err)
+	}
+	providers, err := newReaperProviders()
+	if err != nil {
+		return err
+	}
+	selected := []reaper.Provider{}
+	for _, provider := range providers {
+		for _, rule := range policy.Rules {
+			if rule.Provider == provider.Name() {
+				selected = append(selected, provider)
+				break
 			}
 		}
 	}
+	if len(selected) == 0 {
+		return fmt.Errorf("the credentials of the resources of the reaper policy are not set")
+	}
 
+	report := (&reaper.Reaper{Policy: policy, Providers: selected, DryRun: dryRun}).Run()
+	if err := report.WriteText(os.Stdout); err != nil {
+		return err
+	}
+	if err := report.WriteFiles(artifactDir, "reaper-report"); err != nil {
+		return fmt.Errorf("failed to write the reaper report: %v", err)
+	}
 	if dryRun {
-		klog.Info("Dry run enabled. Listing repositories that would be deleted:")
+		klog.Info("If you really want to delete these resources, run `DRY_RUN=false [REAPER_POLICY=<path>] mage local:cleanupLeakedResources`")
 	}
+	return report.Err()
+}
 
-	// Delete repos
-	for _, repo := range reposToDelete {
-		if dryRun {
-			klog.Infof("\t%s", repo.GetName())
-		} else {
-			err := ghClient.DeleteRepository(repo)
-			if err != nil {
-				klog.Warningf("error deleting repository: %s\n", err)
-			}
+// newReaperProviders creates the reaper providers of the resources whose credentials are set
+func newReaperProviders() ([]reaper.Provider, error) {
+	providers := []reaper.Provider{}
+
+	if token := utils.GetEnv(constants.GITHUB_TOKEN_ENV, ""); token != "" {
+		ghClient, err := github.NewGithubClient(token, utils.GetEnv(constants.GITHUB_E2E_ORGANIZATION_ENV, "redhat-appstudio-qe"))
+		if err != nil {
+			return nil, err
 		}
+		providers = append(providers,
+			&reaper.GitHubRepositoryProvider{Client: ghClient},
+			&reaper.GitHubBranchProvider{Client: ghClient},
+			&reaper.GitHubWebhookProvider{Client: ghClient},
+		)
+	} else {
+		klog.Warningf("env var %s is not set, skipping the GitHub resources", constants.GITHUB_TOKEN_ENV)
 	}
-	if dryRun {
-		klog.Info("If you really want to delete these repositories, run `DRY_RUN=false [REGEXP=<regexp>] mage local:cleanupGithubOrg`")
+
+	if quayOrgToken := os.Getenv("DEFAULT_QUAY_ORG_TOKEN"); quayOrgToken != "" {
+		quayOrg := utils.GetEnv("DEFAULT_QUAY_ORG", "redhat-appstudio-qe")
+		quayClient := quay.NewQuayClient(&http.Client{Transport: &http.Transport{}}, quayOrgToken, quayApiUrl)
+		providers = append(providers,
+			&reaper.QuayRepositoryProvider{Client: quayClient, Organization: quayOrg},
+			&reaper.QuayRobotAccountProvider{Client: quayClient, Organization: quayOrg},
+			&reaper.QuayTagProvider{Client: quayClient, Organization: quayOrg},
+		)
+	} else {
+		klog.Warningf("%s, skipping the Quay resources", quayTokenNotFoundError)
 	}
-	return nil
+
+	if os.Getenv("QE_SPRAYPROXY_HOST") != "" {
+		config, err := newSprayProxy()
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize SprayProxy config: %+v", err)
+		}
+		providers = append(providers, &reaper.SprayProxyServerProvider{Client: config})
+	} else {
+		klog.Warning("env var QE_SPRAYPROXY_HOST is not set, skipping the SprayProxy servers")
+	}
+
+	if k8sClient, err := kubeCl.NewAdminKubernetesClient(); err == nil {
+		providers = append(providers,
+			&reaper.NamespaceProvider{Client: k8sClient.KubeInterface()},
+			&reaper.UserSignupProvider{Client: k8sClient.DynamicClient()},
+		)
+	} else {
+		klog.Warningf("failed to connect to the cluster, skipping the cluster resources: %v", err)
+	}
+	return providers, nil
 }
 
-// Deletes Quay repos and robot accounts older than 24 hours with prefixes `has-e2e` and `e2e-demos`, uses env vars DEFAULT_QUAY_ORG and DEFAULT_QUAY_ORG_TOKEN
-func (Local) CleanupQuayReposAndRobots() error {
-	quayOrgToken := os.Getenv("DEFAULT_QUAY_ORG_TOKEN")
-	if quayOrgToken == "" {
-		return fmt.Errorf(quayTokenNotFoundError)
+// Analyzes the JUnit reports of the previous test runs to find the flaky specs. Env vars to configure this target:
+// JUNIT_HISTORY_DIR (required) - directory with the JUnit files (e.g. e2e-report.xml or xunit.xml) of the previous runs, searched recursively,
+// FLAKINESS_THRESHOLD (optional) - the score from which a spec is considered flaky, defaults to 0.3,
+// FLAKINESS_MIN_RUNS (optional) - the number of runs needed before a spec is considered flaky, defaults to 5.
+// Writes flakiness-report.json, flakiness-report.txt and quarantine.json into ARTIFACT_DIR. Run the tests with QUARANTINE_FILE
+// set to the path of the quarantine.json to exclude the flaky specs.
+func (Local) FlakinessReport() error {
+	historyDir := os.Getenv("JUNIT_HISTORY_DIR")
+	if historyDir == "" {
+		return fmt.Errorf("JUNIT_HISTORY_DIR env var was not found")
+	}
+	config := flakiness.Config{Threshold: flakiness.DefaultThreshold, MinRuns: flakiness.DefaultMinRuns}
+	if threshold := os.Getenv("FLAKINESS_THRESHOLD"); threshold != "" {
+		value, err := strconv.ParseFloat(threshold, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse FLAKINESS_THRESHOLD: %v", err)
+		}
+		config.Threshold = value
+	}
+	if minRuns := os.Getenv("FLAKINESS_MIN_RUNS"); minRuns != "" {
+		value, err := strconv.Atoi(minRuns)
+		if err != nil {
+			return fmt.Errorf("failed to parse FLAKINESS_MIN_RUNS: %v", err)
+		}
+		config.MinRuns = value
 	}
-	quayOrg := utils.GetEnv("DEFAULT_QUAY_ORG", "redhat-appstudio-qe")
 
-	quayClient := quay.NewQuayClient(&http.Client{Transport: &http.Transport{}}, quayOrgToken, quayApiUrl)
-	return cleanupQuayReposAndRobots(quayClient, quayOrg)
+	runs, err := flakiness.ReadRuns(historyDir)
+	if err != nil {
+		return fmt.Errorf("failed to read the JUnit reports from %s: %v", historyDir, err)
+	}
+	report := flakiness.Analyze(runs, config)
+	if err := report.WriteFiles(artifactDir); err != nil {
+		return fmt.Errorf("failed to write the flakiness report: %v", err)
+	}
+	if err := report.WriteText(os.Stdout); err != nil {
+		return err
+	}
+
+	for _, spec := range report.Quarantine().Refused {
+		klog.Warningf("flaky spec %q of %s can't be told apart from other specs by its text, so it isn't quarantined", strings.TrimSpace(spec.Name), spec.Classname)
+	}
+	return nil
 }
 
-// Deletes Quay Tags older than 7 days in `test-images` repository
-func (Local) CleanupQuayTags() error {
-	quayOrgToken := os.Getenv("DEFAULT_QUAY_ORG_TOKEN")
-	if quayOrgToken == "" {
-		return fmt.Errorf(quayTokenNotFoundError)
+// Validates the mapping of the CI jobs to the tests (ciJobs in magefiles/ci_jobs.go) and prints it.
+// If JOB_NAME is set, prints the entry the job matches, the repository of the job can be given by REPO_NAME.
+func (Local) ValidateCIJobs() error {
+	if err := validateCIJobs(ciJobs); err != nil {
+		return err
 	}
-	quayOrg := utils.GetEnv("DEFAULT_QUAY_ORG", "redhat-appstudio-qe")
 
-	quayClient := quay.NewQuayClient(&http.Client{Transport: &http.Transport{}}, quayOrgToken, quayApiUrl)
-	return cleanupQuayTags(quayClient, quayOrg, "test-images")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tREPO\tJOB NAME PATTERNS\tENV VAR PREFIX\tLABEL FILTER\tSPRAYPROXY\tMULTI-PLATFORM\tSETUP HOOKS")
+	for _, job := range ciJobs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\t%t\t%s\n", job.Name, job.Repo, strings.Join(job.JobNamePatterns, " "), job.EnvVarPrefix,
+			job.LabelFilter, job.SprayProxy, job.MultiPlatform, strings.Join(job.SetupHooks, ","))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if jobName != "" {
+		job, err := findCIJob(ciJobs, jobName, os.Getenv("REPO_NAME"))
+		if err != nil {
+			return err
+		}
+		klog.Infof("the job %s matches the CI job %q", jobName, job.Name)
+	}
+	return nil
 }
 
-// Deletes the private repos with prefix "build-e2e" or "rhtap-demo"
-func (Local) CleanupPrivateRepos() error {
-	repoNamePrefixes := []string{"build-e2e", "rhtap-demo", "multi-platform", "jvm-build"}
-	quayOrgToken := os.Getenv("DEFAULT_QUAY_ORG_TOKEN")
-	if quayOrgToken == "" {
-		return fmt.Errorf(quayTokenNotFoundError)
+// Replays the CI job described by the given job spec file (the JSON from the JOB_SPEC env var of the job) on the current cluster.
+// Prints the plan of the job: the ciJobs entry it matches, the pull request and the paired e2e-tests branch, the env vars set
+// for the cluster bootstrap and the tests and the label filter of the tests run. Env vars to configure this target:
+// REPLAY_EXECUTE (optional) - if "true", runs the setup hooks of the job, bootstraps the cluster and runs the tests of the job, defaults to false,
+// COMPONENT_IMAGE (optional) - the image of the service built by the PR job, used for the service image env vars.
+func (Local) ReplayJob(jobSpecFile string) error {
+	content, err := os.ReadFile(jobSpecFile)
+	if err != nil {
+		return err
+	}
+	spec := OpenshiftJobSpec{}
+	if err := json.Unmarshal(content, &spec); err != nil {
+		return fmt.Errorf("error when parsing openshift job spec data: %v", err)
+	}
+
+	plan, job, err := newJobReplayPlan(spec)
+	if err != nil {
+		return fmt.Errorf("failed to plan the replay of the job: %v", err)
+	}
+	planYaml, err := yaml.Marshal(plan)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s", planYaml)
+	if plan.E2ETestsRef != "" {
+		klog.Warningf("the job ran the tests from %s of e2e-tests, check it out to run the same tests", plan.E2ETestsRef)
+	}
+	if job.ImageTagSuffix != "" && os.Getenv("COMPONENT_IMAGE") == "" {
+		klog.Warningf("COMPONENT_IMAGE env var is not set, set it to the image built by the job to test the same image")
+	}
+
+	if os.Getenv("REPLAY_EXECUTE") != "true" {
+		return nil
 	}
-	quayOrg := utils.GetEnv("DEFAULT_QUAY_ORG", "redhat-appstudio-qe")
 
-	quayClient := quay.NewQuayClient(&http.Client{Transport: &http.Transport{}}, quayOrgToken, quayApiUrl)
-	return cleanupPrivateRepos(quayClient, quayOrg, repoNamePrefixes)
+	if err := PreflightChecks(); err != nil {
+		return fmt.Errorf("error when running preflight checks: %v", err)
+	}
+	if err := setupJobEnvVars(job); err != nil {
+		return fmt.Errorf("error when setting up required env vars: %v", err)
+	}
+	if err := BootstrapCluster(); err != nil {
+		return fmt.Errorf("error when bootstrapping cluster: %v", err)
+	}
+	if requiresMultiPlatformTests {
+		if err := setupMultiPlatformTests(); err != nil {
+			return err
+		}
+	}
+	if requiresSprayProxyRegistering {
+		if err := registerPacServer(); err != nil {
+			klog.Warningf("failed to register SprayProxy, the PaC tests will be skipped: %v", err)
+			setEnv(constants.SKIP_PAC_TESTS_ENV, "true")
+		} else {
+			defer func() {
+				if err := unregisterPacServer(); err != nil {
+					klog.Warningf("failed to unregister SprayProxy: %v", err)
+				}
+			}()
+		}
+	}
+	return RunE2ETests()
 }
 
+// Bootstraps the cluster for the CI job. If CI_PLAN_FILE is set, the plan of the bootstrap is written into the file instead.
 func (ci CI) Bootstrap() error {
+	return runOrPlan(ci.bootstrap)
+}
+
+func (ci CI) bootstrap() error {
 	if err := ci.init(); err != nil {
 		return fmt.Errorf("error when running ci init: %v", err)
 	}
@@ -242,7 +450,13 @@ func (ci CI) Bootstrap() error {
 	return nil
 }
 
+// Bootstraps the cluster and runs the e2e tests of the CI job. If CI_PLAN_FILE is set, the env vars set by the workflow,
+// the Tekton bundles pushed, the SprayProxy calls and the ginkgo command line are written into the file instead.
 func (ci CI) TestE2E() error {
+	return runOrPlan(ci.testE2E)
+}
+
+func (ci CI) testE2E() error {
 	var testFailure bool
 
 	if err := ci.init(); err != nil {
@@ -266,7 +480,7 @@ func (ci CI) TestE2E() error {
 	if requiresSprayProxyRegistering {
 		err := registerPacServer()
 		if err != nil {
-			os.Setenv(constants.SKIP_PAC_TESTS_ENV, "true")
+			setEnv(constants.SKIP_PAC_TESTS_ENV, "true")
 			if alertErr := HandleErrorWithAlert(fmt.Errorf("failed to register SprayProxy: %+v", err), slack.ErrorSeverityLevelError); alertErr != nil {
 				return alertErr
 			}
@@ -277,7 +491,11 @@ func (ci CI) TestE2E() error {
 		testFailure = true
 	}
 
-	if requiresSprayProxyRegistering && sprayProxyConfig != nil {
+	if err := postRunSummary(); err != nil {
+		klog.Warningf("failed to post the run summary: %v", err)
+	}
+
+	if requiresSprayProxyRegistering && (sprayProxyConfig != nil || planning != nil) {
 		err := unregisterPacServer()
 		if err != nil {
 			if alertErr := HandleErrorWithAlert(fmt.Errorf("failed to unregister SprayProxy: %+v", err), slack.ErrorSeverityLevelInfo); alertErr != nil {
@@ -294,11 +512,34 @@ func (ci CI) TestE2E() error {
 }
 
 func RunE2ETests() error {
-	labelFilter := utils.GetEnv("E2E_TEST_SUITE_LABEL", "!upgrade-create && !upgrade-verify && !upgrade-cleanup && !release-pipelines && !verify-stage")
+	labelFilter := utils.GetEnv("E2E_TEST_SUITE_LABEL", defaultE2ELabelFilter)
 	return runTests(labelFilter, "e2e-report.xml")
 }
 
 func PreflightChecks() error {
+	if planning != nil {
+		step := ciPlanStep{Action: ciPlanActionPreflightChecks}
+		// the checks don't change anything, so they run in the plan mode too, but their failure doesn't stop the planning
+		if err := checkRequiredEnvAndBinaries(); err != nil {
+			step.Error = err.Error()
+		}
+		planning.record(step)
+		planning.record(ciPlanStep{Action: ciPlanActionInstallBinary, Target: "ginkgo", Command: []string{"go", "install", "-mod=mod", "github.com/onsi/ginkgo/v2/ginkgo"}})
+		return nil
+	}
+
+	if err := checkRequiredEnvAndBinaries(); err != nil {
+		return err
+	}
+
+	if err := sh.RunV("go", "install", "-mod=mod", "github.com/onsi/ginkgo/v2/ginkgo"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func checkRequiredEnvAndBinaries() error {
 	requiredEnv := []string{
 		"GITHUB_TOKEN",
 		"QUAY_TOKEN",
@@ -321,276 +562,117 @@ func PreflightChecks() error {
 		}
 	}
 
-	if err := sh.RunV("go", "install", "-mod=mod", "github.com/onsi/ginkgo/v2/ginkgo"); err != nil {
-		return err
-	}
-
 	return nil
 }
 
+// setRequiredEnvVars sets up the tests and the cluster bootstrap of the current CI job according to its entry in ciJobs.
 func setRequiredEnvVars() error {
-
-	// RHTAP Nightly E2E job
-	// The job name is taken from https://github.com/openshift/release/blob/f03153fa4ad36c0e10050d977e7f0f7619d2163a/ci-operator/config/redhat-appstudio/infra-deployments/redhat-appstudio-infra-deployments-main.yaml#L59C7-L59C35
-	if strings.Contains(jobName, "appstudio-e2e-tests-periodic") {
-		requiresMultiPlatformTests = true
-		requiresSprayProxyRegistering = true
-		return nil
+	job, err := findCIJob(ciJobs, jobName, openshiftJobSpec.Refs.Repo)
+	if err != nil {
+		return err
 	}
+	klog.Infof("setting up the job %s according to the CI job %q", jobName, job.Name)
+	return setupJobEnvVars(job)
+}
 
-	if openshiftJobSpec.Refs.Repo != "e2e-tests" {
-
-		if strings.HasSuffix(jobName, "-service-e2e") || strings.Contains(jobName, "image-controller") {
-			var envVarPrefix, imageTagSuffix, testSuiteLabel string
-			sp := strings.Split(os.Getenv("COMPONENT_IMAGE"), "@")
-
-			switch {
-			case strings.Contains(jobName, "application-service"):
-				requiresSprayProxyRegistering = true
-				envVarPrefix = "HAS"
-				imageTagSuffix = "has-image"
-				testSuiteLabel = "e2e-demo,byoc"
-			case strings.Contains(jobName, "release-service"):
-				envVarPrefix = "RELEASE_SERVICE"
-				imageTagSuffix = "release-service-image"
-				testSuiteLabel = "release-service"
-			case strings.Contains(jobName, "integration-service"):
-				requiresSprayProxyRegistering = true
-				envVarPrefix = "INTEGRATION_SERVICE"
-				imageTagSuffix = "integration-service-image"
-				testSuiteLabel = "integration-service"
-			case strings.Contains(jobName, "jvm-build-service"):
-				envVarPrefix = "JVM_BUILD_SERVICE"
-				imageTagSuffix = "jvm-build-service-image"
-				testSuiteLabel = "jvm-build"
-				// Since CI requires to have default values for dependency images
-				// (https://github.com/openshift/release/blob/master/ci-operator/step-registry/redhat-appstudio/e2e/redhat-appstudio-e2e-ref.yaml#L15)
-				// we cannot let these env vars to have identical names in CI as those env vars used in tests
-				// e.g. JVM_BUILD_SERVICE_REQPROCESSOR_IMAGE, otherwise those images they are referencing wouldn't
-				// be always relevant for tests and tests would be failing
-				os.Setenv(fmt.Sprintf("%s_REQPROCESSOR_IMAGE", envVarPrefix), os.Getenv("CI_JBS_REQPROCESSOR_IMAGE"))
-				os.Setenv(fmt.Sprintf("%s_CACHE_IMAGE", envVarPrefix), os.Getenv("CI_JBS_CACHE_IMAGE"))
-
-				klog.Infof("going to override default Tekton bundle s2i-java task for the purpose of testing jvm-build-service PR")
-				var err error
-				var defaultBundleRef string
-				var tektonObj runtime.Object
-
-				tag := fmt.Sprintf("%d-%s", time.Now().Unix(), util.GenerateRandomString(4))
-				quayOrg := utils.GetEnv(constants.DEFAULT_QUAY_ORG_ENV, constants.DefaultQuayOrg)
-				newS2iJavaTaskImg := strings.ReplaceAll(constants.DefaultImagePushRepo, constants.DefaultQuayOrg, quayOrg)
-				var newS2iJavaTaskRef, _ = name.ParseReference(fmt.Sprintf("%s:task-bundle-%s", newS2iJavaTaskImg, tag))
-				newJavaBuilderPipelineImg := strings.ReplaceAll(constants.DefaultImagePushRepo, constants.DefaultQuayOrg, quayOrg)
-				var newJavaBuilderPipelineRef, _ = name.ParseReference(fmt.Sprintf("%s:pipeline-bundle-%s", newJavaBuilderPipelineImg, tag))
-				var newReqprocessorImage = os.Getenv("JVM_BUILD_SERVICE_REQPROCESSOR_IMAGE")
-				var newTaskYaml, newPipelineYaml []byte
-
-				if err = utils.CreateDockerConfigFile(os.Getenv("QUAY_TOKEN")); err != nil {
-					return fmt.Errorf("failed to create docker config file: %+v", err)
-				}
-				if defaultBundleRef, err = tekton.GetDefaultPipelineBundleRef(constants.BuildPipelineSelectorYamlURL, "Java"); err != nil {
-					return fmt.Errorf("failed to get the pipeline bundle ref: %+v", err)
-				}
-				if tektonObj, err = tekton.ExtractTektonObjectFromBundle(defaultBundleRef, "pipeline", "java-builder"); err != nil {
-					return fmt.Errorf("failed to extract the Tekton Pipeline from bundle: %+v", err)
-				}
-				javaPipelineObj := tektonObj.(*tektonapi.Pipeline)
-
-				var currentS2iJavaTaskRef string
-				for _, t := range javaPipelineObj.PipelineSpec().Tasks {
-					params := t.TaskRef.Params
-					var lastBundle *tektonapi.Param
-					s2iTask := false
-					for i, param := range params {
-						if param.Name == "bundle" {
-							lastBundle = &t.TaskRef.Params[i]
-						} else if param.Name == "name" && param.Value.StringVal == "s2i-java" {
-							s2iTask = true
-						}
-					}
-					if s2iTask {
-						currentS2iJavaTaskRef = lastBundle.Value.StringVal
-						klog.Infof("Found current task ref %s", currentS2iJavaTaskRef)
-						lastBundle.Value = *tektonapi.NewStructuredValues(newS2iJavaTaskRef.String())
-						break
-					}
-				}
-				if tektonObj, err = tekton.ExtractTektonObjectFromBundle(currentS2iJavaTaskRef, "task", "s2i-java"); err != nil {
-					return fmt.Errorf("failed to extract the Tekton Task from bundle: %+v", err)
-				}
-				taskObj := tektonObj.(*tektonapi.Task)
-
-				for i, s := range taskObj.Spec.Steps {
-					if s.Name == "analyse-dependencies-java-sbom" {
-						taskObj.Spec.Steps[i].Image = newReqprocessorImage
-					}
-				}
-
-				if newTaskYaml, err = yaml.Marshal(taskObj); err != nil {
-					return fmt.Errorf("error when marshalling a new task to YAML: %v", err)
-				}
-				if newPipelineYaml, err = yaml.Marshal(javaPipelineObj); err != nil {
-					return fmt.Errorf("error when marshalling a new pipeline to YAML: %v", err)
-				}
-
-				keychain := authn.NewMultiKeychain(authn.DefaultKeychain)
-				authOption := remoteimg.WithAuthFromKeychain(keychain)
-
-				if err = tekton.BuildAndPushTektonBundle(newTaskYaml, newS2iJavaTaskRef, authOption); err != nil {
-					return fmt.Errorf("error when building/pushing a tekton task bundle: %v", err)
-				}
-				if err = tekton.BuildAndPushTektonBundle(newPipelineYaml, newJavaBuilderPipelineRef, authOption); err != nil {
-					return fmt.Errorf("error when building/pushing a tekton pipeline bundle: %v", err)
-				}
-				os.Setenv(constants.CUSTOM_JAVA_PIPELINE_BUILD_BUNDLE_ENV, newJavaBuilderPipelineRef.String())
-			case strings.Contains(jobName, "build-service"):
-				requiresSprayProxyRegistering = true
-				envVarPrefix = "BUILD_SERVICE"
-				imageTagSuffix = "build-service-image"
-				testSuiteLabel = "build"
-			case strings.Contains(jobName, "image-controller"):
-				requiresSprayProxyRegistering = true
-				envVarPrefix = "IMAGE_CONTROLLER"
-				imageTagSuffix = "image-controller-image"
-				testSuiteLabel = "image-controller"
-			case strings.Contains(jobName, "remote-secret-service"):
-				envVarPrefix = "REMOTE_SECRET"
-				imageTagSuffix = "remote-secret-image"
-				testSuiteLabel = "remote-secret"
-			case strings.Contains(jobName, "spi-service"):
-				envVarPrefix = "SPI_OPERATOR"
-				imageTagSuffix = "spi-image"
-				testSuiteLabel = "spi-suite"
-				// spi also requires service-provider-integration-oauth image
-				im := strings.Split(os.Getenv("CI_SPI_OAUTH_IMAGE"), "@")
-				os.Setenv("SPI_OAUTH_IMAGE_REPO", im[0])
-				os.Setenv("SPI_OAUTH_IMAGE_TAG", fmt.Sprintf("redhat-appstudio-%s", "spi-oauth-image"))
-			case strings.Contains(jobName, "multi-platform-controller"):
-				envVarPrefix = "MULTI_PLATFORM_CONTROLLER"
-				imageTagSuffix = "multi-platform-controller"
-				testSuiteLabel = "multi-platform"
-				requiresMultiPlatformTests = true
-			}
-
-			os.Setenv(fmt.Sprintf("%s_IMAGE_REPO", envVarPrefix), sp[0])
-			os.Setenv(fmt.Sprintf("%s_IMAGE_TAG", envVarPrefix), fmt.Sprintf("redhat-appstudio-%s", imageTagSuffix))
-			// "rehearse" jobs metadata are not relevant for testing
-			if !strings.Contains(jobName, "rehearse") {
-				os.Setenv(fmt.Sprintf("%s_PR_OWNER", envVarPrefix), pr.RemoteName)
-				os.Setenv(fmt.Sprintf("%s_PR_SHA", envVarPrefix), pr.CommitSHA)
-			}
-
-			os.Setenv("E2E_TEST_SUITE_LABEL", testSuiteLabel)
-
-		} else if openshiftJobSpec.Refs.Repo == "infra-deployments" {
-			requiresMultiPlatformTests = true
-			requiresSprayProxyRegistering = true
-			os.Setenv("INFRA_DEPLOYMENTS_ORG", pr.RemoteName)
-			os.Setenv("INFRA_DEPLOYMENTS_BRANCH", pr.BranchName)
-			/* Disabling "build tests" temporary due:
-			TODO: Enable when issues are done:
-			https://issues.redhat.com/browse/RHTAPBUGS-992, https://issues.redhat.com/browse/RHTAPBUGS-991, https://issues.redhat.com/browse/RHTAPBUGS-989,
-			https://issues.redhat.com/browse/RHTAPBUGS-978,https://issues.redhat.com/browse/RHTAPBUGS-956
-			*/
-			os.Setenv("E2E_TEST_SUITE_LABEL", "e2e-demo,rhtap-demo,spi-suite,remote-secret,integration-service,ec,byoc,build-templates,multi-platform")
-		} else if strings.Contains(jobName, "release-service-catalog") { // release-service-catalog jobs (pull, rehearsal)
-			envVarPrefix := "RELEASE_SERVICE"
-			// "rehearse" jobs metadata are not relevant for testing
-			if !strings.Contains(jobName, "rehearse") {
-				os.Setenv(fmt.Sprintf("%s_CATALOG_URL", envVarPrefix), fmt.Sprintf("https://github.com/%s/%s", pr.RemoteName, pr.RepoName))
-				os.Setenv(fmt.Sprintf("%s_CATALOG_REVISION", envVarPrefix), pr.CommitSHA)
-			}
-			if os.Getenv("REL_IMAGE_CONTROLLER_QUAY_ORG") != "" {
-				os.Setenv("IMAGE_CONTROLLER_QUAY_ORG", os.Getenv("REL_IMAGE_CONTROLLER_QUAY_ORG"))
-			}
-			if os.Getenv("REL_IMAGE_CONTROLLER_QUAY_TOKEN") != "" {
-				os.Setenv("IMAGE_CONTROLLER_QUAY_TOKEN", os.Getenv("REL_IMAGE_CONTROLLER_QUAY_TOKEN"))
-			}
-			os.Setenv("E2E_TEST_SUITE_LABEL", "release-pipelines")
-		} else { // openshift/release rehearse job for e2e-tests/infra-deployments repos
-			requiresMultiPlatformTests = true
-			requiresSprayProxyRegistering = true
-		}
-	} else { // e2e-tests repository PR
-		requiresMultiPlatformTests = true
-		requiresSprayProxyRegistering = true
-		if isPRPairingRequired("infra-deployments") {
-			os.Setenv("INFRA_DEPLOYMENTS_ORG", pr.RemoteName)
-			os.Setenv("INFRA_DEPLOYMENTS_BRANCH", pr.BranchName)
-		}
+// setupJobEnvVars exports the env vars of the CI job and the references of the pipeline bundles it overrides,
+// so the replayed job runs the tests the same way as the CI job.
+func setupJobEnvVars(job CIJob) error {
+	if err := job.apply(); err != nil {
+		return err
 	}
+	return setupPipelineBundleOverrides()
+}
 
-	return nil
+// setupJavaBuilderBundle builds the java-builder pipeline bundle with the s2i-java task using the jvm-build-service
+// request processor image built by the PR job.
+func setupJavaBuilderBundle() error {
+	klog.Infof("going to override default Tekton bundle s2i-java task for the purpose of testing jvm-build-service PR")
+	return overridePipelineBundles(tekton.PipelineBundleOverride{
+		Selector: "Java",
+		Pipeline: "java-builder",
+		Patches: []tekton.BundlePatch{
+			{Type: tekton.ChangeStepImage, Task: "s2i-java", Step: "analyse-dependencies-java-sbom", Image: os.Getenv("JVM_BUILD_SERVICE_REQPROCESSOR_IMAGE")},
+		},
+		Env: constants.CUSTOM_JAVA_PIPELINE_BUILD_BUNDLE_ENV,
+	})
 }
 
 func setupMultiPlatformTests() error {
 	klog.Infof("going to create new Tekton bundle remote-build for the purpose of testing multi-platform-controller PR")
-	var err error
-	var defaultBundleRef string
-	var tektonObj runtime.Object
-
-	tag := fmt.Sprintf("%d-%s", time.Now().Unix(), util.GenerateRandomString(4))
-	quayOrg := utils.GetEnv(constants.DEFAULT_QUAY_ORG_ENV, constants.DefaultQuayOrg)
-	newMultiPlatformBuilderPipelineImg := strings.ReplaceAll(constants.DefaultImagePushRepo, constants.DefaultQuayOrg, quayOrg)
-	var newRemotePipeline, _ = name.ParseReference(fmt.Sprintf("%s:pipeline-bundle-%s", newMultiPlatformBuilderPipelineImg, tag))
-	var newPipelineYaml []byte
-
-	if err = utils.CreateDockerConfigFile(os.Getenv("QUAY_TOKEN")); err != nil {
-		return fmt.Errorf("failed to create docker config file: %+v", err)
-	}
-	if defaultBundleRef, err = tekton.GetDefaultPipelineBundleRef(constants.BuildPipelineSelectorYamlURL, "Docker build"); err != nil {
-		return fmt.Errorf("failed to get the pipeline bundle ref: %+v", err)
-	}
-	if tektonObj, err = tekton.ExtractTektonObjectFromBundle(defaultBundleRef, "pipeline", "docker-build"); err != nil {
-		return fmt.Errorf("failed to extract the Tekton Pipeline from bundle: %+v", err)
-	}
-	dockerPipelineObject := tektonObj.(*tektonapi.Pipeline)
-
-	var currentBuildahTaskRef string
-	for i := range dockerPipelineObject.PipelineSpec().Tasks {
-		t := &dockerPipelineObject.PipelineSpec().Tasks[i]
-		params := t.TaskRef.Params
-		var lastBundle *tektonapi.Param
-		var lastName *tektonapi.Param
-		buildahTask := false
-		for i, param := range params {
-			if param.Name == "bundle" {
-				lastBundle = &t.TaskRef.Params[i]
-			} else if param.Name == "name" && param.Value.StringVal == "buildah" {
-				lastName = &t.TaskRef.Params[i]
-				buildahTask = true
-			}
-		}
-		if buildahTask {
-			currentBuildahTaskRef = lastBundle.Value.StringVal
-			klog.Infof("Found current task ref %s", currentBuildahTaskRef)
+	return overridePipelineBundles(tekton.PipelineBundleOverride{
+		Selector: "Docker build",
+		Pipeline: "docker-build",
+		Patches: []tekton.BundlePatch{
 			//TODO: current use pinned sha?
-			lastBundle.Value = *tektonapi.NewStructuredValues("quay.io/redhat-appstudio-tekton-catalog/task-buildah-remote:0.1")
-			lastName.Value = *tektonapi.NewStructuredValues("buildah-remote")
-			t.Params = append(t.Params, tektonapi.Param{Name: "PLATFORM", Value: *tektonapi.NewStructuredValues("$(params.PLATFORM)")})
-			dockerPipelineObject.Spec.Params = append(dockerPipelineObject.PipelineSpec().Params, tektonapi.ParamSpec{Name: "PLATFORM", Default: tektonapi.NewStructuredValues("linux/arm64")})
-			dockerPipelineObject.Name = "buildah-remote-pipeline"
-			break
-		}
-	}
-	if currentBuildahTaskRef == "" {
-		return fmt.Errorf("failed to extract the Tekton Task from bundle: %+v", err)
+			{Type: tekton.ReplaceTaskBundle, Task: "buildah", Bundle: "quay.io/redhat-appstudio-tekton-catalog/task-buildah-remote:0.1", TaskName: "buildah-remote"},
+			{Type: tekton.AddParam, Task: "buildah-remote", Param: "PLATFORM", Value: "$(params.PLATFORM)"},
+			{Type: tekton.AddParam, Param: "PLATFORM", Value: "linux/arm64"},
+			{Type: tekton.RenamePipeline, Name: "buildah-remote-pipeline"},
+		},
+		Env: constants.CUSTOM_BUILDAH_REMOTE_PIPELINE_BUILD_BUNDLE_ENV,
+	})
+}
+
+// setupPipelineBundleOverrides pushes the pipeline bundles patched according to the file given by TEKTON_BUNDLE_OVERRIDES,
+// so the PR jobs of the task catalogs and the services can run the tests with their changes of the tasks.
+func setupPipelineBundleOverrides() error {
+	path := os.Getenv(constants.TEKTON_BUNDLE_OVERRIDES_ENV)
+	if path == "" {
+		return nil
 	}
-	if newPipelineYaml, err = yaml.Marshal(dockerPipelineObject); err != nil {
-		return fmt.Errorf("error when marshalling a new pipeline to YAML: %v", err)
+	overrides, err := tekton.LoadPipelineBundleOverrides(path)
+	if err != nil {
+		return err
 	}
+	return overridePipelineBundles(overrides...)
+}
 
+// overridePipelineBundles patches the default build pipelines, pushes them into the test images repository of the
+// DEFAULT_QUAY_ORG and exports the references of the pushed bundles to the env vars of the overrides.
+func overridePipelineBundles(overrides ...tekton.PipelineBundleOverride) error {
+	quayOrg := utils.GetEnv(constants.DEFAULT_QUAY_ORG_ENV, constants.DefaultQuayOrg)
+	repository := strings.ReplaceAll(constants.DefaultImagePushRepo, constants.DefaultQuayOrg, quayOrg)
 	keychain := authn.NewMultiKeychain(authn.DefaultKeychain)
 	authOption := remoteimg.WithAuthFromKeychain(keychain)
 
-	if err = tekton.BuildAndPushTektonBundle(newPipelineYaml, newRemotePipeline, authOption); err != nil {
-		return fmt.Errorf("error when building/pushing a tekton pipeline bundle: %v", err)
+	if planning == nil {
+		if err := utils.CreateDockerConfigFile(os.Getenv("QUAY_TOKEN")); err != nil {
+			return fmt.Errorf("failed to create docker config file: %+v", err)
+		}
+	}
+
+	for _, override := range overrides {
+		if override.Env == "" {
+			return fmt.Errorf("the env var of the override of the %s pipeline is not set", override.Pipeline)
+		}
+		tag := fmt.Sprintf("%d-%s", time.Now().Unix(), util.GenerateRandomString(4))
+		patcher := tekton.NewBundlePatcher(repository, tag, authOption)
+
+		if planning != nil {
+			ref, err := patcher.PipelineRef()
+			if err != nil {
+				return err
+			}
+			planning.record(ciPlanStep{Action: ciPlanActionPushBundle, Target: ref.String(), Value: fmt.Sprintf("%s pipeline of the %q build pipeline selector with %d patches", override.Pipeline, override.Selector, len(override.Patches))})
+			setEnv(override.Env, ref.String())
+			continue
+		}
+
+		ref, err := patcher.Override(override)
+		if err != nil {
+			return fmt.Errorf("failed to override the %s pipeline bundle: %v", override.Pipeline, err)
+		}
+		klog.Infof("exported the %s pipeline bundle %s to %s", override.Pipeline, ref, override.Env)
 	}
-	os.Setenv(constants.CUSTOM_BUILDAH_REMOTE_PIPELINE_BUILD_BUNDLE_ENV, newRemotePipeline.String())
 	return nil
 }
 
 func BootstrapCluster() error {
+	return runOrPlan(bootstrapCluster)
+}
+
+func bootstrapCluster() error {
 	envVars := map[string]string{}
 
 	if os.Getenv("CI") == "true" {
@@ -604,12 +686,25 @@ func BootstrapCluster() error {
 		}
 	}
 
+	if planning != nil {
+		planning.record(ciPlanStep{Action: ciPlanActionBootstrapCluster, Target: utils.GetEnv(constants.INSTALLER_BACKEND_ENV, installation.PreviewModeBackend)})
+		return nil
+	}
+
 	ic, err := installation.NewAppStudioInstallController()
 	if err != nil {
 		return fmt.Errorf("failed to initialize installation controller: %+v", err)
 	}
+	installer, err := installation.NewInstallerFromEnv(ic)
+	if err != nil {
+		return err
+	}
 
-	return ic.InstallAppStudioPreviewMode()
+	klog.Infof("installing RHTAP by the %s installer", installer.Name())
+	if err := installer.Install(); err != nil {
+		return err
+	}
+	return CheckClusterReadiness(installer, "bootstrap")
 }
 
 func isPRPairingRequired(repoForPairing string) bool {
@@ -658,34 +753,14 @@ func GenerateTestSuiteFile(packageName string) error {
 	return nil
 }
 
-// Remove all webhooks which with 1 day lifetime. By default will delete webooks from redhat-appstudio-qe
+// Deletes the GitHub webhooks leaked by the e2e tests, selected by the "github-webhooks" rule of the reaper policy (see Local.CleanupLeakedResources).
+// DRY_RUN (optional) - defaults to false
 func CleanWebHooks() error {
-	token := utils.GetEnv(constants.GITHUB_TOKEN_ENV, "")
-	if token == "" {
-		return fmt.Errorf("empty GITHUB_TOKEN env. Please provide a valid github token")
-	}
-
-	githubOrg := utils.GetEnv(constants.GITHUB_E2E_ORGANIZATION_ENV, "redhat-appstudio-qe")
-	gh, err := github.NewGithubClient(token, githubOrg)
+	policy, err := reaperPolicyRules("github-webhooks")
 	if err != nil {
 		return err
 	}
-	for _, repo := range repositoriesWithWebhooks {
-		webhookList, err := gh.ListRepoWebhooks(repo)
-		if err != nil {
-			return err
-		}
-		for _, wh := range webhookList {
-			dayDuration, _ := time.ParseDuration("24h")
-			if time.Since(wh.GetCreatedAt()) > dayDuration {
-				klog.Infof("removing webhook: %s, git_organization: %s, git_repository: %s", wh.GetName(), githubOrg, repo)
-				if err := gh.DeleteWebhook(repo, wh.GetID()); err != nil {
-					return fmt.Errorf("failed to delete webhook: %v, repo: %s", wh.Name, repo)
-				}
-			}
-		}
-	}
-	return nil
+	return reapLeakedResources(false, policy)
 }
 
 // Generate a Text Outline file from a Ginkgo Spec
@@ -837,6 +912,10 @@ func newSprayProxy() (*sprayproxy.SprayProxyConfig, error) {
 func registerPacServer() error {
 	var err error
 	var pacHost string
+	if planning != nil {
+		planning.record(ciPlanStep{Action: ciPlanActionRegisterPacServer, Target: os.Getenv("QE_SPRAYPROXY_HOST")})
+		return nil
+	}
 	sprayProxyConfig, err = newSprayProxy()
 	if err != nil {
 		return fmt.Errorf("failed to set up SprayProxy credentials: %+v", err)
@@ -860,6 +939,10 @@ func registerPacServer() error {
 }
 
 func unregisterPacServer() error {
+	if planning != nil {
+		planning.record(ciPlanStep{Action: ciPlanActionUnregisterPacServer, Target: os.Getenv("QE_SPRAYPROXY_HOST")})
+		return nil
+	}
 	if sprayProxyConfig == nil {
 		return fmt.Errorf("SprayProxy config is empty")
 	}
@@ -945,61 +1028,103 @@ func (Local) TestUpgrade() error {
 	return nil
 }
 
+// UpgradeTestsWorkflow bootstraps the cluster from the source ref of the first upgrade hop, creates the workload and
+// upgrades the cluster hop by hop, see upgradeHopsFromEnv. After each hop the readiness of the cluster, the state of
+// the RHTAP CRs and the workload are verified.
 func UpgradeTestsWorkflow() error {
-	ic, err := BootstrapClusterForUpgrade()
+	hops, err := upgradeHopsFromEnv()
 	if err != nil {
 		klog.Errorf("%s", err)
 		return err
 	}
 
-	err = CheckClusterAfterUpgrade(ic)
+	ic, err := BootstrapClusterForUpgrade(hops[0].From)
 	if err != nil {
 		klog.Errorf("%s", err)
 		return err
 	}
+	installer := &installation.PreviewModeInstaller{InstallAppStudio: ic}
 
-	err = CreateWorkload()
+	err = CheckClusterReadiness(installer, "pre-upgrade")
 	if err != nil {
 		klog.Errorf("%s", err)
 		return err
 	}
 
-	err = VerifyWorkload()
+	err = CreateWorkload()
 	if err != nil {
 		klog.Errorf("%s", err)
 		return err
 	}
 
-	err = UpgradeCluster()
+	for i, hop := range hops {
+		err = runUpgradeHop(installer, i+1, hop)
+		if err != nil {
+			klog.Errorf("%s", err)
+			return err
+		}
+	}
+
+	err = CleanWorkload()
 	if err != nil {
 		klog.Errorf("%s", err)
 		return err
 	}
 
-	err = CheckClusterAfterUpgrade(ic)
+	return nil
+}
+
+// runUpgradeHop upgrades the cluster to the target ref of the hop and verifies it. The state of the RHTAP CRs before and after
+// the upgrade, their diff and the JUnit report of the workload verification are written into ARTIFACT_DIR with the upgrade-hop-<n> prefix.
+func runUpgradeHop(installer *installation.PreviewModeInstaller, n int, hop upgradeHop) error {
+	prefix := fmt.Sprintf("upgrade-hop-%d", n)
+	klog.Infof("upgrade hop %d: %s -> %s", n, hop.From, hop.To)
+
+	client := installer.KubernetesClient.DynamicClient()
+	before, err := snapshotClusterState(client)
 	if err != nil {
-		klog.Errorf("%s", err)
+		return fmt.Errorf("failed to snapshot the cluster state before the upgrade hop %d: %v", n, err)
+	}
+	if err := writeUpgradeArtifact(prefix+"-state-before.json", before); err != nil {
 		return err
 	}
 
-	err = VerifyWorkload()
-	if err != nil {
-		klog.Errorf("%s", err)
+	if err := MergePRInRemote(hop.To.Branch, hop.To.Organization, "./tmp/infra-deployments"); err != nil {
+		return fmt.Errorf("failed to upgrade the cluster to %s: %v", hop.To, err)
+	}
+	if err := CheckClusterReadiness(installer, prefix+"-post-upgrade"); err != nil {
 		return err
 	}
 
-	err = CleanWorkload()
+	after, err := snapshotClusterState(client)
 	if err != nil {
-		klog.Errorf("%s", err)
+		return fmt.Errorf("failed to snapshot the cluster state after the upgrade hop %d: %v", n, err)
+	}
+	if err := writeUpgradeArtifact(prefix+"-state-after.json", after); err != nil {
+		return err
+	}
+	strictStatus, err := strictStatusFromEnv()
+	if err != nil {
+		return err
+	}
+	diff := stateDiff{Hop: hop, Changes: diffClusterState(before, after, ignoredStateFieldsFromEnv(), strictStatus)}
+	if err := writeUpgradeArtifact(prefix+"-state-diff.json", diff); err != nil {
 		return err
 	}
 
-	return nil
+	// the workload is verified even if the state changed unexpectedly, so the report shows the impact of the changes
+	verifyErr := runTests("upgrade-verify", prefix+"-verify-report.xml")
+	if unexpected := diff.unexpected(); len(unexpected) > 0 {
+		for _, change := range unexpected {
+			klog.Errorf("unexpected change after the upgrade to %s: %s %s %s (%v -> %v)", hop.To, change.Object, change.Type, change.Field, change.Before, change.After)
+		}
+		return fmt.Errorf("%d unexpected changes of the RHTAP CRs after the upgrade to %s, see %s-state-diff.json", len(unexpected), hop.To, prefix)
+	}
+	return verifyErr
 }
 
-func BootstrapClusterForUpgrade() (*installation.InstallAppStudio, error) {
-	//Use main branch of infra-deployments in redhat-appstudio org as default version for upgrade
-	ic, err := installation.NewAppStudioInstallControllerUpgrade("redhat-appstudio", "main")
+func BootstrapClusterForUpgrade(from upgradeRef) (*installation.InstallAppStudio, error) {
+	ic, err := installation.NewAppStudioInstallControllerUpgrade(from.Organization, from.Branch)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize installation controller: %+v", err)
 	}
@@ -1011,8 +1136,24 @@ func UpgradeCluster() error {
 	return MergePRInRemote(utils.GetEnv("UPGRADE_BRANCH", ""), utils.GetEnv("UPGRADE_FORK_ORGANIZATION", "redhat-appstudio"), "./tmp/infra-deployments")
 }
 
-func CheckClusterAfterUpgrade(ic *installation.InstallAppStudio) error {
-	return ic.CheckOperatorsReady()
+// CheckClusterReadiness waits until the cluster is ready and writes the readiness report of the given stage into
+// cluster-readiness-<stage>.json and cluster-readiness-<stage>.txt in ARTIFACT_DIR.
+func CheckClusterReadiness(installer installation.Installer, stage string) error {
+	report, err := installer.CheckReady()
+	if report != nil {
+		if writeErr := report.WriteFiles(artifactDir, "cluster-readiness-"+stage); writeErr != nil {
+			klog.Errorf("failed to write the cluster readiness report: %v", writeErr)
+		}
+		if err != nil {
+			if textErr := report.WriteText(os.Stdout); textErr != nil {
+				klog.Error(textErr)
+			}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("the cluster isn't ready (%s): %v", stage, err)
+	}
+	return nil
 }
 
 func CreateWorkload() error {
@@ -1024,46 +1165,47 @@ func VerifyWorkload() error {
 }
 
 func CleanWorkload() error {
-	return runTests("upgrade-cleanup", "upgrade-verify-report.xml")
+	return runTests("upgrade-cleanup", "upgrade-cleanup-report.xml")
 }
 
 func runTests(labelsToRun string, junitReportFile string) error {
-	// added --output-interceptor-mode=none to mitigate RHTAPBUGS-34
-	return sh.RunV("ginkgo", "-p", "--output-interceptor-mode=none", "--timeout=90m", fmt.Sprintf("--output-dir=%s", artifactDir), "--junit-report="+junitReportFile, "--label-filter="+labelsToRun, "./cmd", "--", "--generate-rppreproc-report=true", fmt.Sprintf("--rp-preproc-dir=%s", artifactDir))
-}
-
-func CleanupRegisteredPacServers() error {
-	var err error
-	sprayProxyConfig, err = newSprayProxy()
+	skipFlags, err := quarantinedSpecsSkipFlags()
 	if err != nil {
-		return fmt.Errorf("failed to initialize SprayProxy config: %+v", err)
+		return err
 	}
-
-	servers, err := sprayProxyConfig.GetServers()
-	if err != nil {
-		return fmt.Errorf("failed to get registered PaC servers from SprayProxy: %+v", err)
+	// added --output-interceptor-mode=none to mitigate RHTAPBUGS-34
+	args := []string{"-p", "--output-interceptor-mode=none", "--timeout=90m", fmt.Sprintf("--output-dir=%s", artifactDir), "--junit-report=" + junitReportFile, "--label-filter=" + labelsToRun}
+	args = append(args, skipFlags...)
+	args = append(args, "./cmd", "--", "--generate-rppreproc-report=true", fmt.Sprintf("--rp-preproc-dir=%s", artifactDir))
+	if planning != nil {
+		planning.record(ciPlanStep{Action: ciPlanActionRunTests, Value: labelsToRun, Command: append([]string{"ginkgo"}, args...)})
+		return nil
 	}
-	klog.Infof("Before cleaningup Pac servers, the registered PaC servers: %v", servers)
+	return sh.RunV("ginkgo", args...)
+}
 
-	for _, server := range strings.Split(servers, ",") {
-		// Verify if the server is a valid host, if not, unregister it
-		if !isValidPacHost(server) {
-			_, err := sprayProxyConfig.UnregisterServer(strings.TrimSpace(server))
-			if err != nil {
-				return fmt.Errorf("error when unregistering PaC server %s from SprayProxy server %s: %+v", server, sprayProxyConfig.BaseURL, err)
-			}
-			klog.Infof("Cleanup invalid PaC server: %s", server)
-		}
+// quarantinedSpecsSkipFlags returns the ginkgo flags skipping the specs in the QUARANTINE_FILE, written by the FlakinessReport target.
+func quarantinedSpecsSkipFlags() ([]string, error) {
+	quarantineFile := os.Getenv("QUARANTINE_FILE")
+	if quarantineFile == "" {
+		return nil, nil
 	}
-	klog.Infof("After cleaningup Pac servers...")
-	err = printRegisteredPacServers()
+	quarantine, err := flakiness.ReadQuarantine(quarantineFile)
 	if err != nil {
-		klog.Error(err)
+		return nil, fmt.Errorf("failed to read the quarantined specs: %v", err)
 	}
-	return nil
+	for _, spec := range quarantine.Specs {
+		klog.Infof("excluding the quarantined spec %q of %s (flakiness score %.2f)", strings.TrimSpace(spec.Name), spec.Classname, spec.Score)
+	}
+	return quarantine.SkipFlags(), nil
 }
 
-func isValidPacHost(server string) bool {
-	_, err := http.Get(strings.TrimSpace(server))
-	return err == nil
+// Unregisters the PaC servers of the deleted clusters from SprayProxy, selected by the "sprayproxy-servers" rule of the reaper policy
+// (see Local.CleanupLeakedResources). DRY_RUN (optional) - defaults to false
+func CleanupRegisteredPacServers() error {
+	policy, err := reaperPolicyRules("sprayproxy-servers")
+	if err != nil {
+		return err
+	}
+	return reapLeakedResources(false, policy)
 }
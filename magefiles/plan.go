@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// the effects of the CI workflow recorded in its plan
+const (
+	ciPlanActionPreflightChecks     = "preflight-checks"
+	ciPlanActionInstallBinary       = "install-binary"
+	ciPlanActionSetEnv              = "set-env"
+	ciPlanActionPushBundle          = "push-bundle"
+	ciPlanActionBootstrapCluster    = "bootstrap-cluster"
+	ciPlanActionRegisterPacServer   = "register-pac-server"
+	ciPlanActionUnregisterPacServer = "unregister-pac-server"
+	ciPlanActionRunTests            = "run-tests"
+	ciPlanActionPostRunSummary      = "post-run-summary"
+)
+
+// ciPlan is what the CI workflow would do. It's recorded instead of executing the workflow if the CI_PLAN_FILE env var is set.
+type ciPlan struct {
+	JobName string       `json:"jobName"`
+	JobType string       `json:"jobType"`
+	Steps   []ciPlanStep `json:"steps"`
+	// Error is the error the workflow ended with
+	Error string `json:"error,omitempty"`
+}
+
+// ciPlanStep is a single effect of the CI workflow.
+type ciPlanStep struct {
+	Action string `json:"action"`
+	// Target is what the action is applied to, e.g. the name of the env var, the reference of the bundle or the SprayProxy server
+	Target string `json:"target,omitempty"`
+	Value  string `json:"value,omitempty"`
+	// Command is the command line which would be executed
+	Command []string `json:"command,omitempty"`
+	// Error is the result of the checks done in the plan mode too, e.g. the preflight checks
+	Error string `json:"error,omitempty"`
+}
+
+// planning is the plan being recorded, nil unless the CI workflow runs in the plan mode
+var planning *ciPlan
+
+// runOrPlan runs the workflow or, if the CI_PLAN_FILE env var is set, records its plan into the file instead.
+// The steps of the workflow check the planning var and record their effects into the plan instead of executing them.
+func runOrPlan(workflow func() error) error {
+	planFile := os.Getenv("CI_PLAN_FILE")
+	// the workflow can be nested in another one which is already being planned
+	if planFile == "" || planning != nil {
+		return workflow()
+	}
+
+	planning = &ciPlan{JobName: jobName, JobType: jobType, Steps: []ciPlanStep{}}
+	defer func() { planning = nil }()
+
+	err := workflow()
+	if err != nil {
+		planning.Error = err.Error()
+	}
+	if writeErr := planning.write(planFile); writeErr != nil {
+		return writeErr
+	}
+	return err
+}
+
+func (p *ciPlan) record(step ciPlanStep) {
+	p.Steps = append(p.Steps, step)
+}
+
+func (p *ciPlan) write(path string) error {
+	content, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// setEnv sets the env var of the mage process, recording it in the plan in the plan mode. The env vars are set in the plan mode too,
+// so the following steps of the workflow see them.
+func setEnv(key, value string) {
+	if planning != nil {
+		planning.record(ciPlanStep{Action: ciPlanActionSetEnv, Target: key, Value: value})
+	}
+	os.Setenv(key, value)
+}
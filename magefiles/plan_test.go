@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
+)
+
+// planCIJob records the plan of CI.TestE2E for the job described by the job spec.
+func planCIJob(t *testing.T, spec OpenshiftJobSpec) *ciPlan {
+	t.Helper()
+	jobSpec, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	planFile := filepath.Join(t.TempDir(), "plan.json")
+	t.Setenv("CI", "true")
+	t.Setenv("CI_PLAN_FILE", planFile)
+	t.Setenv("JOB_SPEC", string(jobSpec))
+	t.Setenv("QE_SPRAYPROXY_HOST", "https://sprayproxy.example.com")
+	// restore the env vars set by the workflow after the test
+	for _, env := range []string{"COMPONENT_IMAGE", "E2E_TEST_SUITE_LABEL", "QUARANTINE_FILE", "SLACK_RUN_SUMMARY_CONFIG", "SLACK_RUN_SUMMARY_DRY_RUN", "HAS_IMAGE_REPO", "HAS_IMAGE_TAG",
+		"INFRA_DEPLOYMENTS_ORG", "INFRA_DEPLOYMENTS_BRANCH", constants.CUSTOM_BUILDAH_REMOTE_PIPELINE_BUILD_BUNDLE_ENV, constants.SKIP_PAC_TESTS_ENV} {
+		t.Setenv(env, os.Getenv(env))
+	}
+	defer func(name, typ string) {
+		jobName, jobType = name, typ
+		openshiftJobSpec = &OpenshiftJobSpec{}
+		*pr = PullRequestMetadata{}
+		requiresSprayProxyRegistering, requiresMultiPlatformTests = false, false
+	}(jobName, jobType)
+	jobName, jobType = spec.Job, spec.Type
+	openshiftJobSpec = &OpenshiftJobSpec{}
+
+	if err := (CI{}).TestE2E(); err != nil {
+		t.Fatalf("failed to plan the CI workflow: %v", err)
+	}
+	content, err := os.ReadFile(planFile)
+	if err != nil {
+		t.Fatalf("the plan wasn't written: %v", err)
+	}
+	plan := &ciPlan{}
+	if err := json.Unmarshal(content, plan); err != nil {
+		t.Fatalf("invalid plan: %v", err)
+	}
+	return plan
+}
+
+// planSummary lists the steps of the plan as "action target"
+func planSummary(plan *ciPlan) []string {
+	steps := []string{}
+	for _, step := range plan.Steps {
+		summary := step.Action
+		switch step.Action {
+		case ciPlanActionSetEnv:
+			summary += " " + step.Target + "=" + step.Value
+		case ciPlanActionRunTests:
+			summary += " " + step.Value
+		}
+		steps = append(steps, summary)
+	}
+	return steps
+}
+
+func TestPlanPeriodicJob(t *testing.T) {
+	plan := planCIJob(t, OpenshiftJobSpec{Type: "periodic", Job: "periodic-ci-redhat-appstudio-infra-deployments-main-appstudio-e2e-tests-periodic"})
+
+	steps := planSummary(plan)
+	// the reference of the bundle contains a timestamp
+	if len(steps) > 4 && strings.HasPrefix(steps[4], ciPlanActionSetEnv+" "+constants.CUSTOM_BUILDAH_REMOTE_PIPELINE_BUILD_BUNDLE_ENV+"=") {
+		steps[4] = ciPlanActionSetEnv + " " + constants.CUSTOM_BUILDAH_REMOTE_PIPELINE_BUILD_BUNDLE_ENV
+	}
+	expected := []string{
+		ciPlanActionPreflightChecks,
+		ciPlanActionInstallBinary,
+		ciPlanActionBootstrapCluster,
+		ciPlanActionPushBundle,
+		ciPlanActionSetEnv + " " + constants.CUSTOM_BUILDAH_REMOTE_PIPELINE_BUILD_BUNDLE_ENV,
+		ciPlanActionRegisterPacServer,
+		ciPlanActionRunTests + " " + defaultE2ELabelFilter,
+		ciPlanActionUnregisterPacServer,
+	}
+	if !reflect.DeepEqual(expected, steps) {
+		t.Fatalf("expected the plan\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(steps, "\n"))
+	}
+	if plan.Steps[5].Target != "https://sprayproxy.example.com" {
+		t.Errorf("expected the PaC server to be registered to the SprayProxy server from QE_SPRAYPROXY_HOST, got %q", plan.Steps[5].Target)
+	}
+	runTests := plan.Steps[6]
+	if len(runTests.Command) == 0 || runTests.Command[0] != "ginkgo" || !strings.Contains(strings.Join(runTests.Command, " "), "--label-filter="+defaultE2ELabelFilter+" ./cmd") {
+		t.Errorf("unexpected ginkgo command line %v", runTests.Command)
+	}
+}
+
+func TestPlanRehearseServiceJob(t *testing.T) {
+	t.Setenv("SLACK_RUN_SUMMARY_DRY_RUN", "true")
+	t.Setenv("COMPONENT_IMAGE", "registry.build01.ci.openshift.org/ci-op-1234/pipeline@sha256:abcd")
+	plan := planCIJob(t, OpenshiftJobSpec{
+		Type: "presubmit",
+		Job:  "rehearse-43210-pull-ci-redhat-appstudio-application-service-main-application-service-e2e",
+		Refs: Refs{Organization: "openshift", Repo: "release", Pulls: []Pull{{Number: 43210, SHA: "abcdef"}}},
+	})
+
+	expected := []string{
+		ciPlanActionPreflightChecks,
+		ciPlanActionInstallBinary,
+		ciPlanActionSetEnv + " E2E_TEST_SUITE_LABEL=e2e-demo,byoc",
+		ciPlanActionSetEnv + " HAS_IMAGE_REPO=registry.build01.ci.openshift.org/ci-op-1234/pipeline",
+		ciPlanActionSetEnv + " HAS_IMAGE_TAG=redhat-appstudio-has-image",
+		ciPlanActionBootstrapCluster,
+		ciPlanActionRegisterPacServer,
+		ciPlanActionRunTests + " e2e-demo,byoc",
+		ciPlanActionPostRunSummary,
+		ciPlanActionUnregisterPacServer,
+	}
+	if steps := planSummary(plan); !reflect.DeepEqual(expected, steps) {
+		t.Errorf("expected the plan\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(steps, "\n"))
+	}
+}
+
+func TestPlanE2ETestsPullRequestJob(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.github.com").
+		Get("/repos/redhat-appstudio/e2e-tests/pulls/123").
+		Reply(200).
+		JSON(map[string]interface{}{"head": map[string]string{"label": "contributor:new-feature"}})
+	// the PR has a pair in infra-deployments
+	gock.New("https://api.github.com").
+		Get("/repos/redhat-appstudio/infra-deployments/pulls").
+		MatchParam("per_page", "100").
+		Reply(200).
+		JSON([]map[string]interface{}{{"head": map[string]string{"ref": "new-feature"}, "user": map[string]string{"login": "contributor"}}})
+
+	plan := planCIJob(t, OpenshiftJobSpec{
+		Type: "presubmit",
+		Job:  "pull-ci-redhat-appstudio-e2e-tests-main-redhat-appstudio-e2e",
+		Refs: Refs{Organization: "redhat-appstudio", Repo: "e2e-tests", Pulls: []Pull{{Number: 123, SHA: "abcdef"}}},
+	})
+
+	steps := planSummary(plan)
+	if len(steps) > 6 && strings.HasPrefix(steps[6], ciPlanActionSetEnv+" "+constants.CUSTOM_BUILDAH_REMOTE_PIPELINE_BUILD_BUNDLE_ENV+"=") {
+		steps[6] = ciPlanActionSetEnv + " " + constants.CUSTOM_BUILDAH_REMOTE_PIPELINE_BUILD_BUNDLE_ENV
+	}
+	expected := []string{
+		ciPlanActionPreflightChecks,
+		ciPlanActionInstallBinary,
+		ciPlanActionSetEnv + " INFRA_DEPLOYMENTS_BRANCH=new-feature",
+		ciPlanActionSetEnv + " INFRA_DEPLOYMENTS_ORG=contributor",
+		ciPlanActionBootstrapCluster,
+		ciPlanActionPushBundle,
+		ciPlanActionSetEnv + " " + constants.CUSTOM_BUILDAH_REMOTE_PIPELINE_BUILD_BUNDLE_ENV,
+		ciPlanActionRegisterPacServer,
+		ciPlanActionRunTests + " " + defaultE2ELabelFilter,
+		ciPlanActionUnregisterPacServer,
+	}
+	if !reflect.DeepEqual(expected, steps) {
+		t.Errorf("expected the plan\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(steps, "\n"))
+	}
+	if !gock.IsDone() {
+		t.Errorf("the pull request metadata wasn't fetched from GitHub")
+	}
+}
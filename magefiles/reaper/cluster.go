@@ -0,0 +1,68 @@
+package reaper
+
+import (
+	"context"
+
+	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+var userSignupResource = schema.GroupVersionResource{Group: "toolchain.dev.openshift.com", Version: "v1alpha1", Resource: "usersignups"}
+
+// NamespaceProvider lists the namespaces of the cluster. The namespaces being deleted are left out.
+type NamespaceProvider struct {
+	Client kubernetes.Interface
+}
+
+func (p *NamespaceProvider) Name() string {
+	return Namespaces
+}
+
+func (p *NamespaceProvider) List([]string) ([]Resource, error) {
+	namespaces, err := p.Client.CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	resources := []Resource{}
+	for _, ns := range namespaces.Items {
+		if ns.DeletionTimestamp == nil {
+			resources = append(resources, Resource{Name: ns.Name, CreatedAt: ns.CreationTimestamp.Time})
+		}
+	}
+	return resources, nil
+}
+
+func (p *NamespaceProvider) Delete(resource Resource) error {
+	return p.Client.CoreV1().Namespaces().Delete(context.Background(), resource.Name, metav1.DeleteOptions{})
+}
+
+// UserSignupProvider lists the UserSignups in the toolchain host operator namespace. Deleting a UserSignup
+// deprovisions the user together with its tenant namespace. The UserSignups being deleted are left out.
+type UserSignupProvider struct {
+	Client dynamic.Interface
+}
+
+func (p *UserSignupProvider) Name() string {
+	return UserSignups
+}
+
+func (p *UserSignupProvider) List([]string) ([]Resource, error) {
+	userSignups, err := p.Client.Resource(userSignupResource).Namespace(constants.HostOperatorNamespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	resources := []Resource{}
+	for _, userSignup := range userSignups.Items {
+		if userSignup.GetDeletionTimestamp() == nil {
+			resources = append(resources, Resource{Name: userSignup.GetName(), CreatedAt: userSignup.GetCreationTimestamp().Time})
+		}
+	}
+	return resources, nil
+}
+
+func (p *UserSignupProvider) Delete(resource Resource) error {
+	return p.Client.Resource(userSignupResource).Namespace(constants.HostOperatorNamespace).Delete(context.Background(), resource.Name, metav1.DeleteOptions{})
+}
@@ -0,0 +1,66 @@
+package reaper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestClusterProviders(t *testing.T) {
+	old := metav1.NewTime(time.Now().Add(-48 * time.Hour))
+	deleting := metav1.Now()
+	kubeClient := kubefake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "build-e2e-abcd", CreationTimestamp: old}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "build-e2e-efgh", CreationTimestamp: old, DeletionTimestamp: &deleting}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "build-service", CreationTimestamp: old}},
+	)
+	userSignup := &unstructured.Unstructured{}
+	userSignup.SetAPIVersion("toolchain.dev.openshift.com/v1alpha1")
+	userSignup.SetKind("UserSignup")
+	userSignup.SetName("build-e2e-abcd")
+	userSignup.SetNamespace(constants.HostOperatorNamespace)
+	userSignup.SetCreationTimestamp(old)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{userSignupResource: "UserSignupList"}, userSignup)
+
+	policy, err := DefaultPolicy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := (&Reaper{Policy: policy, Providers: []Provider{&NamespaceProvider{Client: kubeClient}, &UserSignupProvider{Client: dynamicClient}}}).Run()
+	if err := report.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	leaked := map[string][]string{}
+	for _, p := range report.Providers {
+		for _, l := range p.Leaked {
+			if !l.Deleted {
+				t.Errorf("expected %s %s to be deleted", p.Provider, l.Name)
+			}
+			leaked[p.Provider] = append(leaked[p.Provider], l.Name)
+		}
+	}
+	// the namespace being deleted is left out
+	expected := map[string][]string{Namespaces: {"build-e2e-abcd"}, UserSignups: {"build-e2e-abcd"}}
+	if !reflect.DeepEqual(expected, leaked) {
+		t.Errorf("expected the leaked resources %v, got %v", expected, leaked)
+	}
+
+	resources, err := (&UserSignupProvider{Client: dynamicClient}).List(nil)
+	if err != nil || len(resources) != 0 {
+		t.Errorf("expected the UserSignup to be deleted, got %v, %v", resources, err)
+	}
+	resources, err = (&NamespaceProvider{Client: kubeClient}).List(nil)
+	if err != nil || len(resources) != 1 || resources[0].Name != "build-service" {
+		t.Errorf("expected only the build-service namespace to be left, got %v, %v", resources, err)
+	}
+}
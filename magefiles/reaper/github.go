@@ -0,0 +1,116 @@
+package reaper
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	gh "github.com/google/go-github/v44/github"
+	"github.com/redhat-appstudio/e2e-tests/pkg/clients/github"
+)
+
+// GitHubRepositoryProvider lists the repositories of the GitHub organization.
+type GitHubRepositoryProvider struct {
+	Client *github.Github
+}
+
+func (p *GitHubRepositoryProvider) Name() string {
+	return GitHubRepositories
+}
+
+func (p *GitHubRepositoryProvider) List([]string) ([]Resource, error) {
+	repos, err := p.Client.GetAllRepositories()
+	if err != nil {
+		return nil, err
+	}
+	resources := []Resource{}
+	for _, repo := range repos {
+		resources = append(resources, Resource{Name: repo.GetName(), Description: repo.GetDescription(), CreatedAt: repo.GetCreatedAt().Time})
+	}
+	return resources, nil
+}
+
+func (p *GitHubRepositoryProvider) Delete(resource Resource) error {
+	return p.Client.DeleteRepository(&gh.Repository{Name: gh.String(resource.Name)})
+}
+
+// GitHubBranchProvider lists the branches of the repositories. GitHub doesn't record when a branch was created and
+// the tests create the branches for the PaC pull requests from old revisions, so the age of a branch is the age of
+// the oldest open pull request whose head or base it is. The branches without an open pull request have no age,
+// so the rules with a maxAge never match them.
+type GitHubBranchProvider struct {
+	Client *github.Github
+}
+
+func (p *GitHubBranchProvider) Name() string {
+	return GitHubBranches
+}
+
+func (p *GitHubBranchProvider) List(repositories []string) ([]Resource, error) {
+	resources := []Resource{}
+	for _, repository := range repositories {
+		branches, err := p.Client.ListBranches(repository)
+		if err != nil {
+			return nil, err
+		}
+		prs, err := p.Client.ListAllPullRequests(repository)
+		if err != nil {
+			return nil, err
+		}
+		for _, branch := range branches {
+			resources = append(resources, Resource{Repository: repository, Name: branch.GetName(), CreatedAt: branchCreatedAt(branch.GetName(), prs)})
+		}
+	}
+	return resources, nil
+}
+
+// branchCreatedAt returns when the oldest of the pull requests with the branch as their head or base was created,
+// or the zero time if there is none
+func branchCreatedAt(branch string, prs []*gh.PullRequest) time.Time {
+	createdAt := time.Time{}
+	for _, pr := range prs {
+		if pr.GetHead().GetRef() != branch && pr.GetBase().GetRef() != branch {
+			continue
+		}
+		if createdAt.IsZero() || pr.GetCreatedAt().Before(createdAt) {
+			createdAt = pr.GetCreatedAt()
+		}
+	}
+	return createdAt
+}
+
+func (p *GitHubBranchProvider) Delete(resource Resource) error {
+	return p.Client.DeleteRef(resource.Repository, resource.Name)
+}
+
+// GitHubWebhookProvider lists the webhooks of the repositories by their URLs.
+type GitHubWebhookProvider struct {
+	Client *github.Github
+}
+
+func (p *GitHubWebhookProvider) Name() string {
+	return GitHubWebhooks
+}
+
+func (p *GitHubWebhookProvider) List(repositories []string) ([]Resource, error) {
+	resources := []Resource{}
+	for _, repository := range repositories {
+		webhooks, err := p.Client.ListRepoWebhooks(repository)
+		if err != nil {
+			return nil, err
+		}
+		for _, webhook := range webhooks {
+			url, _ := webhook.Config["url"].(string)
+			resources = append(resources, Resource{Repository: repository, Name: url, ID: strconv.FormatInt(webhook.GetID(), 10), CreatedAt: webhook.GetCreatedAt()})
+		}
+	}
+	return resources, nil
+}
+
+func (p *GitHubWebhookProvider) Delete(resource Resource) error {
+	id, err := strconv.ParseInt(resource.ID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid ID %q of the webhook %s: %v", resource.ID, resource, err)
+	}
+	return p.Client.DeleteWebhook(resource.Repository, id)
+}
@@ -0,0 +1,211 @@
+package reaper
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// the number of resources of a provider deleted in parallel if the policy doesn't set it
+const defaultConcurrency = 10
+
+//go:embed default-policy.yaml
+var defaultPolicy []byte
+
+// Rule selects the leaked resources of a provider.
+type Rule struct {
+	// Name identifies the rule for the targets running only some of the rules, e.g. "quay-private-repositories"
+	Name string `json:"name,omitempty"`
+	// Provider is the name of the provider the rule applies to, e.g. "github-repositories"
+	Provider string `json:"provider"`
+	// Repositories limits the rule to the resources of these repositories, they are the repositories listed
+	// by the providers of the GitHub branches and webhooks and of the Quay tags
+	Repositories []string `json:"repositories,omitempty"`
+	// Patterns are the regular expressions matching the names of the leaked resources, e.g. "^build-e2e-" for a prefix
+	Patterns []string `json:"patterns,omitempty"`
+	// Descriptions are the exact descriptions of the leaked resources, e.g. of the GitOps repositories in GitHub
+	Descriptions []string `json:"descriptions,omitempty"`
+	// MaxAge is the age from which the matching resources are leaked, e.g. "24h". The resources of unknown age
+	// (like the SprayProxy servers) match only the rules without MaxAge
+	MaxAge metav1.Duration `json:"maxAge,omitempty"`
+	// Private limits the rule to the private resources, e.g. the private Quay repositories
+	Private bool `json:"private,omitempty"`
+
+	patterns []*regexp.Regexp
+}
+
+// Policy lists the rules selecting the leaked resources. A resource is leaked if any rule of its provider matches it.
+type Policy struct {
+	// Concurrency is the number of resources of a provider deleted in parallel, 10 if not set
+	Concurrency int    `json:"concurrency,omitempty"`
+	Rules       []Rule `json:"rules"`
+}
+
+// DefaultPolicy returns the policy from default-policy.yaml.
+func DefaultPolicy() (*Policy, error) {
+	return parsePolicy(defaultPolicy, "default-policy.yaml")
+}
+
+// LoadPolicy reads the policy from a YAML file.
+func LoadPolicy(path string) (*Policy, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parsePolicy(content, path)
+}
+
+// PolicyFromEnv reads the policy from the file given by the REAPER_POLICY environment variable,
+// falling back to the DefaultPolicy if it is not set.
+func PolicyFromEnv() (*Policy, error) {
+	path := os.Getenv(constants.REAPER_POLICY_ENV)
+	if path == "" {
+		return DefaultPolicy()
+	}
+	return LoadPolicy(path)
+}
+
+func parsePolicy(content []byte, name string) (*Policy, error) {
+	policy := &Policy{}
+	if err := yaml.Unmarshal(content, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse the reaper policy %s: %v", name, err)
+	}
+	if err := policy.compile(); err != nil {
+		return nil, fmt.Errorf("invalid reaper policy %s: %v", name, err)
+	}
+	return policy, nil
+}
+
+// Only returns the policy with only the rules of the given names.
+func (p *Policy) Only(names ...string) (*Policy, error) {
+	only := &Policy{Concurrency: p.Concurrency}
+	for _, name := range names {
+		found := false
+		for _, rule := range p.Rules {
+			if rule.Name == name {
+				only.Rules = append(only.Rules, rule)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no rule %q in the reaper policy", name)
+		}
+	}
+	return only, nil
+}
+
+// SetPatterns replaces the patterns of the rules of the given name, e.g. by a pattern from an environment variable.
+func (p *Policy) SetPatterns(name string, patterns ...string) error {
+	found := false
+	for i := range p.Rules {
+		if p.Rules[i].Name == name {
+			p.Rules[i].Patterns = patterns
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("no rule %q in the reaper policy", name)
+	}
+	return p.compile()
+}
+
+// compile validates the rules and compiles their patterns
+func (p *Policy) compile() error {
+	if p.Concurrency < 0 {
+		return fmt.Errorf("negative concurrency %d", p.Concurrency)
+	}
+	if p.Concurrency == 0 {
+		p.Concurrency = defaultConcurrency
+	}
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if !isKnownProvider(rule.Provider) {
+			return fmt.Errorf("rule #%d: unknown provider %q, expected one of %v", i+1, rule.Provider, Providers)
+		}
+		if len(rule.Patterns) == 0 && len(rule.Descriptions) == 0 {
+			return fmt.Errorf("rule #%d of %s: no patterns or descriptions, use the pattern \".*\" to match all the resources", i+1, rule.Provider)
+		}
+		if rule.MaxAge.Duration < 0 {
+			return fmt.Errorf("rule #%d of %s: negative max age %s", i+1, rule.Provider, rule.MaxAge.Duration)
+		}
+		rule.patterns = nil
+		for _, pattern := range rule.Patterns {
+			r, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("rule #%d of %s: invalid pattern %q: %v", i+1, rule.Provider, pattern, err)
+			}
+			rule.patterns = append(rule.patterns, r)
+		}
+	}
+	return nil
+}
+
+// rulesOf returns the rules of the provider
+func (p *Policy) rulesOf(provider string) []Rule {
+	rules := []Rule{}
+	for _, rule := range p.Rules {
+		if rule.Provider == provider {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// repositoriesOf returns the repositories the rules of the provider are limited to
+func (p *Policy) repositoriesOf(provider string) []string {
+	repositories := []string{}
+	seen := map[string]bool{}
+	for _, rule := range p.rulesOf(provider) {
+		for _, repository := range rule.Repositories {
+			if !seen[repository] {
+				seen[repository] = true
+				repositories = append(repositories, repository)
+			}
+		}
+	}
+	return repositories
+}
+
+// matches returns whether the resource is leaked by the rule and why
+func (r Rule) matches(resource Resource, now time.Time) (bool, string) {
+	if len(r.Repositories) > 0 && !contains(r.Repositories, resource.Repository) {
+		return false, ""
+	}
+	if r.Private && !resource.Private {
+		return false, ""
+	}
+	reason := ""
+	if resource.Description != "" && contains(r.Descriptions, resource.Description) {
+		reason = fmt.Sprintf("has the description %q", resource.Description)
+	}
+	for _, pattern := range r.patterns {
+		if reason == "" && pattern.MatchString(resource.Name) {
+			reason = fmt.Sprintf("matches %q", pattern.String())
+		}
+	}
+	if reason == "" {
+		return false, ""
+	}
+	if r.MaxAge.Duration > 0 {
+		if resource.CreatedAt.IsZero() || now.Sub(resource.CreatedAt) < r.MaxAge.Duration {
+			return false, ""
+		}
+		reason += fmt.Sprintf(" and is older than %s", r.MaxAge.Duration)
+	}
+	return true, reason
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package reaper
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redhat-appstudio/image-controller/pkg/quay"
+	"k8s.io/klog/v2"
+)
+
+// the format of the creation time of the Quay robot accounts
+const quayTimeFormat = "Mon, 02 Jan 2006 15:04:05 -0700"
+
+// QuayRepositoryProvider lists the repositories of the Quay organization. Quay doesn't return when a repository
+// was created, its age is the time since it was last modified. A repository which has never been pushed is as old
+// as the robot account with its name without the slashes, e.g. "e2e-demos/comp" and "e2e-demoscomp", or of unknown
+// age if there's none.
+type QuayRepositoryProvider struct {
+	Client       quay.QuayService
+	Organization string
+}
+
+func (p *QuayRepositoryProvider) Name() string {
+	return QuayRepositories
+}
+
+func (p *QuayRepositoryProvider) List([]string) ([]Resource, error) {
+	repos, err := p.Client.GetAllRepositories(p.Organization)
+	if err != nil {
+		return nil, err
+	}
+	var robotsCreatedAt map[string]time.Time
+	resources := []Resource{}
+	for _, repo := range repos {
+		createdAt := time.Time{}
+		if repo.LastModified != 0 {
+			createdAt = time.Unix(int64(repo.LastModified), 0)
+		} else {
+			if robotsCreatedAt == nil {
+				if robotsCreatedAt, err = p.robotsCreatedAt(); err != nil {
+					return nil, err
+				}
+			}
+			createdAt = robotsCreatedAt[strings.ReplaceAll(repo.Name, "/", "")]
+		}
+		resources = append(resources, Resource{Name: repo.Name, CreatedAt: createdAt, Private: !repo.IsPublic})
+	}
+	return resources, nil
+}
+
+// robotsCreatedAt returns when the robot accounts were created by their short names
+func (p *QuayRepositoryProvider) robotsCreatedAt() (map[string]time.Time, error) {
+	robots, err := (&QuayRobotAccountProvider{Client: p.Client, Organization: p.Organization}).List(nil)
+	if err != nil {
+		return nil, err
+	}
+	createdAt := map[string]time.Time{}
+	for _, robot := range robots {
+		createdAt[robot.Name] = robot.CreatedAt
+	}
+	return createdAt, nil
+}
+
+func (p *QuayRepositoryProvider) Delete(resource Resource) error {
+	deleted, err := p.Client.DeleteRepository(p.Organization, resource.Name)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		klog.Infof("repository %s has already been deleted, skipping", resource.Name)
+	}
+	return nil
+}
+
+// QuayRobotAccountProvider lists the robot accounts of the Quay organization by their short names,
+// e.g. "e2e-demos" for "redhat-appstudio-qe+e2e-demos".
+type QuayRobotAccountProvider struct {
+	Client       quay.QuayService
+	Organization string
+}
+
+func (p *QuayRobotAccountProvider) Name() string {
+	return QuayRobotAccounts
+}
+
+func (p *QuayRobotAccountProvider) List([]string) ([]Resource, error) {
+	robots, err := p.Client.GetAllRobotAccounts(p.Organization)
+	if err != nil {
+		return nil, err
+	}
+	resources := []Resource{}
+	for _, robot := range robots {
+		shortName := strings.TrimPrefix(robot.Name, p.Organization+"+")
+		createdAt, err := time.Parse(quayTimeFormat, robot.Created)
+		if err != nil {
+			// the robot account is only leaked by the rules without max age
+			klog.Warningf("failed to parse the creation time %q of the robot account %s: %v", robot.Created, robot.Name, err)
+		}
+		resources = append(resources, Resource{Name: shortName, CreatedAt: createdAt})
+	}
+	return resources, nil
+}
+
+func (p *QuayRobotAccountProvider) Delete(resource Resource) error {
+	deleted, err := p.Client.DeleteRobotAccount(p.Organization, resource.Name)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		klog.Infof("robot account %s has already been deleted, skipping", resource.Name)
+	}
+	return nil
+}
+
+// QuayTagProvider lists the tags of the repositories of the Quay organization.
+type QuayTagProvider struct {
+	Client       quay.QuayService
+	Organization string
+}
+
+func (p *QuayTagProvider) Name() string {
+	return QuayTags
+}
+
+func (p *QuayTagProvider) List(repositories []string) ([]Resource, error) {
+	resources := []Resource{}
+	for _, repository := range repositories {
+		for page := 1; ; page++ {
+			tags, hasAdditional, err := p.Client.GetTagsFromPage(p.Organization, repository, page)
+			if err != nil {
+				return nil, fmt.Errorf("error getting tags of `%s` repository of `%s` organization on page `%d`, error: %s", repository, p.Organization, page, err)
+			}
+			for _, tag := range tags {
+				resources = append(resources, Resource{Repository: repository, Name: tag.Name, CreatedAt: time.Unix(tag.StartTS, 0)})
+			}
+			if !hasAdditional {
+				break
+			}
+		}
+	}
+	return resources, nil
+}
+
+func (p *QuayTagProvider) Delete(resource Resource) error {
+	deleted, err := p.Client.DeleteTag(p.Organization, resource.Repository, resource.Name)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		klog.Infof("tag %s was not deleted", resource)
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package reaper
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redhat-appstudio/image-controller/pkg/quay"
+)
+
+type quayClientMock struct {
+	quay.QuayService
+
+	AllRepositories  []quay.Repository
+	AllRobotAccounts []quay.RobotAccount
+	AllTags          []quay.Tag
+	TagsOnPage       int
+	TagPages         int
+
+	mu                      sync.Mutex
+	DeleteRepositoryCalls   map[string]bool
+	DeleteRobotAccountCalls map[string]bool
+	DeleteTagCalls          map[string]bool
+}
+
+func (m *quayClientMock) GetAllRepositories(string) ([]quay.Repository, error) {
+	return m.AllRepositories, nil
+}
+
+func (m *quayClientMock) GetAllRobotAccounts(string) ([]quay.RobotAccount, error) {
+	return m.AllRobotAccounts, nil
+}
+
+func (m *quayClientMock) DeleteRepository(_, repoName string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.DeleteRepositoryCalls[repoName] = true
+	return true, nil
+}
+
+func (m *quayClientMock) DeleteRobotAccount(_, robotName string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.DeleteRobotAccountCalls[robotName] = true
+	return true, nil
+}
+
+func (m *quayClientMock) GetTagsFromPage(_, _ string, page int) ([]quay.Tag, bool, error) {
+	return m.AllTags[(page-1)*m.TagsOnPage : (page * m.TagsOnPage)], page != m.TagPages, nil
+}
+
+func (m *quayClientMock) DeleteTag(_, _, tag string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.DeleteTagCalls[tag] = true
+	return true, nil
+}
+
+func TestQuayRepositoryProviderList(t *testing.T) {
+	old, recent := time.Now().Add(-25*time.Hour), time.Now()
+	client := &quayClientMock{
+		AllRepositories: []quay.Repository{
+			{Name: "e2e-demos/pushed", LastModified: int(old.Unix()), IsPublic: true},
+			{Name: "e2e-demos/not-pushed"},
+			{Name: "e2e-demos/not-pushed-without-robot"},
+		},
+		AllRobotAccounts: []quay.RobotAccount{
+			{Name: "test-org+e2e-demosnot-pushed", Created: recent.Format(quayTimeFormat)},
+		},
+	}
+	resources, err := (&QuayRepositoryProvider{Client: client, Organization: "test-org"}).List(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Resource{
+		{Name: "e2e-demos/pushed", CreatedAt: time.Unix(old.Unix(), 0)},
+		// the repository which has never been pushed is as old as its robot account
+		{Name: "e2e-demos/not-pushed", CreatedAt: time.Unix(recent.Unix(), 0), Private: true},
+		{Name: "e2e-demos/not-pushed-without-robot", Private: true},
+	}
+	if len(resources) != len(expected) {
+		t.Fatalf("expected the repositories %v, got %v", expected, resources)
+	}
+	for i := range expected {
+		if resources[i].Name != expected[i].Name || !resources[i].CreatedAt.Equal(expected[i].CreatedAt) || resources[i].Private != expected[i].Private {
+			t.Errorf("expected the repository %+v, got %+v", expected[i], resources[i])
+		}
+	}
+}
+
+func TestDefaultPolicyQuayReposAndRobots(t *testing.T) {
+	policy, err := DefaultPolicy()
+	if err != nil {
+		t.Fatalf("failed to load the default policy: %v", err)
+	}
+	if policy, err = policy.Only("quay-repositories", "quay-robot-accounts"); err != nil {
+		t.Fatal(err)
+	}
+	// the repositories have never been pushed, they are deleted by the age of their robot accounts
+	deletedRepos := []quay.Repository{
+		{Name: "e2e-demos/test-old"},
+		{Name: "has-e2e/test-old"},
+	}
+	preservedRepos := []quay.Repository{
+		{Name: "e2e-demos/test-new"},
+		{Name: "has-e2e/test-new"},
+		{Name: "other/test-new"},
+		{Name: "other/test-old"},
+		// left to the rule of the private repositories
+		{Name: "rhtap-demo/test-old", LastModified: int(time.Now().AddDate(0, 0, -8).Unix())},
+	}
+	deletedRobots := []quay.RobotAccount{
+		{Name: "test-org+e2e-demostest-old", Created: time.Now().Add(-25 * time.Hour).Format(quayTimeFormat)},
+		{Name: "test-org+has-e2etest-old", Created: time.Now().Add(-25 * time.Hour).Format(quayTimeFormat)},
+	}
+	preservedRobots := []quay.RobotAccount{
+		{Name: "test-org+e2e-demostest-new", Created: time.Now().Format(quayTimeFormat)},
+		{Name: "test-org+has-e2etest-new", Created: time.Now().Format(quayTimeFormat)},
+		{Name: "test-org+othertest-old", Created: time.Now().Add(-25 * time.Hour).Format(quayTimeFormat)},
+		{Name: "test-org+othertest-new", Created: time.Now().Format(quayTimeFormat)},
+	}
+	client := &quayClientMock{
+		AllRepositories:         append(deletedRepos, preservedRepos...),
+		AllRobotAccounts:        append(deletedRobots, preservedRobots...),
+		DeleteRepositoryCalls:   make(map[string]bool),
+		DeleteRobotAccountCalls: make(map[string]bool),
+	}
+	providers := []Provider{
+		&QuayRepositoryProvider{Client: client, Organization: "test-org"},
+		&QuayRobotAccountProvider{Client: client, Organization: "test-org"},
+	}
+	if err := (&Reaper{Policy: policy, Providers: providers}).Run().Err(); err != nil {
+		t.Fatalf("error during quay cleanup, error: %s", err)
+	}
+
+	for _, repo := range deletedRepos {
+		if !client.DeleteRepositoryCalls[repo.Name] {
+			t.Errorf("DeleteRepository() should have been called for '%s'", repo.Name)
+		}
+	}
+	for _, repo := range preservedRepos {
+		if client.DeleteRepositoryCalls[repo.Name] {
+			t.Errorf("DeleteRepository() should not have been called for '%s'", repo.Name)
+		}
+	}
+	for _, robot := range deletedRobots {
+		if shortName := robot.Name[len("test-org+"):]; !client.DeleteRobotAccountCalls[shortName] {
+			t.Errorf("DeleteRobotAccount() should have been called for '%s'", shortName)
+		}
+	}
+	for _, robot := range preservedRobots {
+		if shortName := robot.Name[len("test-org+"):]; client.DeleteRobotAccountCalls[shortName] {
+			t.Errorf("DeleteRobotAccount() should not have been called for '%s'", shortName)
+		}
+	}
+}
+
+func TestDefaultPolicyQuayPrivateRepos(t *testing.T) {
+	policy, err := DefaultPolicy()
+	if err != nil {
+		t.Fatalf("failed to load the default policy: %v", err)
+	}
+	if policy, err = policy.Only("quay-private-repositories"); err != nil {
+		t.Fatal(err)
+	}
+	old, recent := int(time.Now().AddDate(0, 0, -8).Unix()), int(time.Now().Unix())
+	deletedRepos := []quay.Repository{
+		{Name: "rhtap-demo/test-old", LastModified: old},
+		{Name: "jvm-build/test-old", LastModified: old},
+	}
+	preservedRepos := []quay.Repository{
+		{Name: "rhtap-demo/test-public", LastModified: old, IsPublic: true},
+		{Name: "rhtap-demo/test-new", LastModified: recent},
+		// left to the rule of the repositories with the robot accounts
+		{Name: "e2e-demos/test-old", LastModified: old},
+		{Name: "other/test-old", LastModified: old},
+	}
+	client := &quayClientMock{
+		AllRepositories:       append(deletedRepos, preservedRepos...),
+		DeleteRepositoryCalls: make(map[string]bool),
+	}
+	providers := []Provider{&QuayRepositoryProvider{Client: client, Organization: "test-org"}}
+	if err := (&Reaper{Policy: policy, Providers: providers}).Run().Err(); err != nil {
+		t.Fatalf("error during quay cleanup, error: %s", err)
+	}
+
+	for _, repo := range deletedRepos {
+		if !client.DeleteRepositoryCalls[repo.Name] {
+			t.Errorf("DeleteRepository() should have been called for '%s'", repo.Name)
+		}
+	}
+	for _, repo := range preservedRepos {
+		if client.DeleteRepositoryCalls[repo.Name] {
+			t.Errorf("DeleteRepository() should not have been called for '%s'", repo.Name)
+		}
+	}
+}
+
+func TestDefaultPolicyQuayTags(t *testing.T) {
+	policy, err := DefaultPolicy()
+	if err != nil {
+		t.Fatalf("failed to load the default policy: %v", err)
+	}
+	tagsOnPage := 20
+	tagPages := 20
+
+	var deletedTags []quay.Tag
+	var preservedTags []quay.Tag
+	var allTags []quay.Tag
+
+	// Randomly generate slices of deleted and preserved tags
+	for i := 0; i < tagsOnPage*tagPages; i++ {
+		tagName := fmt.Sprintf("tag%d", i)
+		var tag quay.Tag
+		if rand.Intn(2) == 0 {
+			tag = quay.Tag{Name: tagName, StartTS: time.Now().AddDate(0, 0, -8).Unix()}
+			deletedTags = append(deletedTags, tag)
+		} else {
+			tag = quay.Tag{Name: tagName, StartTS: time.Now().Unix()}
+			preservedTags = append(preservedTags, tag)
+		}
+		allTags = append(allTags, tag)
+	}
+
+	if policy, err = policy.Only("quay-tags"); err != nil {
+		t.Fatal(err)
+	}
+	client := &quayClientMock{
+		AllTags:        allTags,
+		DeleteTagCalls: make(map[string]bool),
+		TagsOnPage:     tagsOnPage,
+		TagPages:       tagPages,
+	}
+	providers := []Provider{&QuayTagProvider{Client: client, Organization: "test-org"}}
+	if err := (&Reaper{Policy: policy, Providers: providers}).Run().Err(); err != nil {
+		t.Fatalf("error during quay tag cleanup, error: %s", err)
+	}
+
+	for _, tag := range deletedTags {
+		if !client.DeleteTagCalls[tag.Name] {
+			t.Errorf("DeleteTag() should have been called for '%s'", tag.Name)
+		}
+	}
+	for _, tag := range preservedTags {
+		if client.DeleteTagCalls[tag.Name] {
+			t.Errorf("DeleteTag() should not have been called for '%s'", tag.Name)
+		}
+	}
+}
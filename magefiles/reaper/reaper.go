@@ -0,0 +1,226 @@
+package reaper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// The names of the providers the policy rules refer to
+const (
+	GitHubRepositories = "github-repositories"
+	GitHubBranches     = "github-branches"
+	GitHubWebhooks     = "github-webhooks"
+	QuayRepositories   = "quay-repositories"
+	QuayRobotAccounts  = "quay-robot-accounts"
+	QuayTags           = "quay-tags"
+	SprayProxyServers  = "sprayproxy-servers"
+	Namespaces         = "namespaces"
+	UserSignups        = "usersignups"
+)
+
+// Providers lists the names of all the providers
+var Providers = []string{
+	GitHubRepositories, GitHubBranches, GitHubWebhooks,
+	QuayRepositories, QuayRobotAccounts, QuayTags,
+	SprayProxyServers,
+	Namespaces, UserSignups,
+}
+
+func isKnownProvider(name string) bool {
+	return contains(Providers, name)
+}
+
+// Resource is a resource created by the e2e tests.
+type Resource struct {
+	// Repository of the GitHub branches and webhooks and of the Quay tags
+	Repository string `json:"repository,omitempty"`
+	Name       string `json:"name"`
+	// ID identifies the resource for the provider if the name doesn't, e.g. the ID of a GitHub webhook
+	ID          string    `json:"id,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	// Private is set for the private resources, e.g. the private Quay repositories
+	Private bool `json:"private,omitempty"`
+}
+
+func (r Resource) String() string {
+	if r.Repository != "" {
+		return r.Repository + "/" + r.Name
+	}
+	return r.Name
+}
+
+// Provider lists and deletes the resources of a kind.
+type Provider interface {
+	// Name of the provider in the policy rules, one of Providers
+	Name() string
+	// List returns the resources of the provider. The providers of the resources in repositories list only
+	// those of the given repositories.
+	List(repositories []string) ([]Resource, error)
+	Delete(resource Resource) error
+}
+
+// Reaper deletes the resources of the providers matching the rules of the policy.
+type Reaper struct {
+	Policy    *Policy
+	Providers []Provider
+	// DryRun only reports the leaked resources
+	DryRun bool
+}
+
+// Report lists the leaked resources of every provider.
+type Report struct {
+	Time      time.Time        `json:"time"`
+	DryRun    bool             `json:"dryRun"`
+	Providers []ProviderReport `json:"providers"`
+}
+
+type ProviderReport struct {
+	Provider string `json:"provider"`
+	// Listed is the number of resources listed by the provider
+	Listed int              `json:"listed"`
+	Leaked []LeakedResource `json:"leaked"`
+	// Error of listing the resources
+	Error string `json:"error,omitempty"`
+}
+
+type LeakedResource struct {
+	Resource
+	// Reason is why the resource is leaked, e.g. `matches "^e2e-" and is older than 24h0m0s`
+	Reason  string `json:"reason"`
+	Deleted bool   `json:"deleted"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Run lists the resources of the providers and deletes the leaked ones. The providers without rules are skipped.
+// A failing provider or deletion doesn't stop the others, their errors are in the report.
+func (r *Reaper) Run() *Report {
+	report := &Report{Time: time.Now(), DryRun: r.DryRun, Providers: []ProviderReport{}}
+	for _, provider := range r.Providers {
+		rules := r.Policy.rulesOf(provider.Name())
+		if len(rules) == 0 {
+			klog.Infof("no reaper rules for %s, skipping", provider.Name())
+			continue
+		}
+		report.Providers = append(report.Providers, r.reap(provider, rules, report.Time))
+	}
+	return report
+}
+
+func (r *Reaper) reap(provider Provider, rules []Rule, now time.Time) ProviderReport {
+	providerReport := ProviderReport{Provider: provider.Name(), Leaked: []LeakedResource{}}
+	resources, err := provider.List(r.Policy.repositoriesOf(provider.Name()))
+	if err != nil {
+		providerReport.Error = err.Error()
+		return providerReport
+	}
+	providerReport.Listed = len(resources)
+
+	for _, resource := range resources {
+		for _, rule := range rules {
+			if matched, reason := rule.matches(resource, now); matched {
+				providerReport.Leaked = append(providerReport.Leaked, LeakedResource{Resource: resource, Reason: reason})
+				break
+			}
+		}
+	}
+	if r.DryRun {
+		return providerReport
+	}
+
+	// every worker updates its own items of the slice
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, r.Policy.Concurrency)
+	for i := range providerReport.Leaked {
+		leaked := &providerReport.Leaked[i]
+		wg.Add(1)
+		limit <- struct{}{}
+		go func() {
+			defer func() { <-limit; wg.Done() }()
+			if err := provider.Delete(leaked.Resource); err != nil {
+				leaked.Error = err.Error()
+				return
+			}
+			leaked.Deleted = true
+			klog.Infof("deleted %s %s (%s)", provider.Name(), leaked.Resource, leaked.Reason)
+		}()
+	}
+	wg.Wait()
+	return providerReport
+}
+
+// Err joins the errors of listing and deleting the resources.
+func (r *Report) Err() error {
+	errs := []error{}
+	for _, p := range r.Providers {
+		if p.Error != "" {
+			errs = append(errs, fmt.Errorf("failed to list %s: %s", p.Provider, p.Error))
+		}
+		for _, leaked := range p.Leaked {
+			if leaked.Error != "" {
+				errs = append(errs, fmt.Errorf("failed to delete %s %s: %s", p.Provider, leaked.Resource, leaked.Error))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WriteText writes the leaked resources as a table.
+func (r *Report) WriteText(w io.Writer) error {
+	action := "Deleted"
+	if r.DryRun {
+		action = "Dry run, would delete"
+	}
+	fmt.Fprintf(w, "Leaked resources at %s\n\n", r.Time.Format(time.RFC3339))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROVIDER\tLISTED\tLEAKED\tERROR")
+	for _, p := range r.Providers {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\n", p.Provider, p.Listed, len(p.Leaked), p.Error)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, p := range r.Providers {
+		if len(p.Leaked) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s %s:\n", action, p.Provider)
+		for _, leaked := range p.Leaked {
+			line := fmt.Sprintf("  %s: %s", leaked.Resource, leaked.Reason)
+			if leaked.Error != "" {
+				line += fmt.Sprintf(" (failed: %s)", leaked.Error)
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+	return nil
+}
+
+// WriteFiles writes the report into <name>.json and <name>.txt in the directory.
+func (r *Report) WriteFiles(dir, name string) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".json"), content, 0644); err != nil {
+		return err
+	}
+	f, err := os.Create(filepath.Join(dir, name+".txt"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return r.WriteText(f)
+}
@@ -0,0 +1,244 @@
+package reaper
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gh "github.com/google/go-github/v44/github"
+)
+
+type providerMock struct {
+	name      string
+	resources []Resource
+	listErr   error
+	deleteErr map[string]error
+
+	mu          sync.Mutex
+	deleted     []string
+	running     int
+	maxParallel int
+}
+
+func (p *providerMock) Name() string {
+	return p.name
+}
+
+func (p *providerMock) List([]string) ([]Resource, error) {
+	return p.resources, p.listErr
+}
+
+func (p *providerMock) Delete(resource Resource) error {
+	p.mu.Lock()
+	p.running++
+	if p.running > p.maxParallel {
+		p.maxParallel = p.running
+	}
+	p.mu.Unlock()
+	time.Sleep(10 * time.Millisecond)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.running--
+	if err := p.deleteErr[resource.Name]; err != nil {
+		return err
+	}
+	p.deleted = append(p.deleted, resource.Name)
+	return nil
+}
+
+func TestDefaultPolicy(t *testing.T) {
+	policy, err := DefaultPolicy()
+	if err != nil {
+		t.Fatalf("failed to load the default policy: %v", err)
+	}
+	if policy.Concurrency != 10 {
+		t.Errorf("expected the concurrency 10, got %d", policy.Concurrency)
+	}
+
+	now := time.Now()
+	old, recent := now.Add(-48*time.Hour), now.Add(-time.Hour)
+	for _, tc := range []struct {
+		provider string
+		resource Resource
+		leaked   bool
+	}{
+		{Namespaces, Resource{Name: "build-e2e-abcd", CreatedAt: old}, true},
+		{Namespaces, Resource{Name: "happy-path-managed-abcd", CreatedAt: old}, true},
+		{Namespaces, Resource{Name: "build-e2e-abcd", CreatedAt: recent}, false},
+		{Namespaces, Resource{Name: "build-e2e-abcd-tenant", CreatedAt: old}, false},
+		{Namespaces, Resource{Name: "build-service", CreatedAt: old}, false},
+		{UserSignups, Resource{Name: "spi-user-a-abcd", CreatedAt: old}, true},
+		{GitHubRepositories, Resource{Name: "my-gitops", Description: "GitOps Repository", CreatedAt: old}, true},
+		{GitHubRepositories, Resource{Name: "infra-deployments", CreatedAt: old}, false},
+		{GitHubBranches, Resource{Repository: "hacbs-test-project", Name: "base-abcdef", CreatedAt: old}, true},
+		// the branch without an open pull request
+		{GitHubBranches, Resource{Repository: "hacbs-test-project", Name: "base-abcdef"}, false},
+		{GitHubBranches, Resource{Repository: "hacbs-test-project", Name: "main", CreatedAt: old}, false},
+		{GitHubBranches, Resource{Repository: "other-repo", Name: "base-abcdef", CreatedAt: old}, false},
+		{QuayRepositories, Resource{Name: "rhtap-demo/component", CreatedAt: old}, false},
+		{QuayRepositories, Resource{Name: "rhtap-demo/component", CreatedAt: now.Add(-8 * 24 * time.Hour), Private: true}, true},
+		{QuayRepositories, Resource{Name: "rhtap-demo/component", CreatedAt: now.Add(-8 * 24 * time.Hour)}, false},
+		// the repository which has never been pushed and has no robot account
+		{QuayRepositories, Resource{Name: "e2e-demos/component", Private: true}, false},
+		{SprayProxyServers, Resource{Name: "https://pac.deleted-cluster.example.com"}, true},
+	} {
+		leaked := false
+		for _, rule := range policy.rulesOf(tc.provider) {
+			if matched, _ := rule.matches(tc.resource, now); matched {
+				leaked = true
+			}
+		}
+		if leaked != tc.leaked {
+			t.Errorf("expected %s %s to be leaked: %t, got %t", tc.provider, tc.resource, tc.leaked, leaked)
+		}
+	}
+}
+
+func TestBranchCreatedAt(t *testing.T) {
+	now := time.Now()
+	old, recent := now.Add(-48*time.Hour), now.Add(-time.Hour)
+	pr := func(head, base string, createdAt time.Time) *gh.PullRequest {
+		return &gh.PullRequest{Head: &gh.PullRequestBranch{Ref: gh.String(head)}, Base: &gh.PullRequestBranch{Ref: gh.String(base)}, CreatedAt: &createdAt}
+	}
+	prs := []*gh.PullRequest{
+		pr("appstudio-comp", "base-abcdef", recent),
+		pr("appstudio-comp-2", "base-abcdef", old),
+		pr("appstudio-new", "base-ghijkl", recent),
+	}
+	for branch, expected := range map[string]time.Time{
+		"base-abcdef":    old,
+		"appstudio-comp": recent,
+		"base-ghijkl":    recent,
+		// the branch created from an old revision for a pull request which isn't open yet
+		"base-mnopqr": {},
+	} {
+		if createdAt := branchCreatedAt(branch, prs); !createdAt.Equal(expected) {
+			t.Errorf("expected the branch %s to be created at %v, got %v", branch, expected, createdAt)
+		}
+	}
+}
+
+func TestPolicyOnly(t *testing.T) {
+	policy, err := DefaultPolicy()
+	if err != nil {
+		t.Fatalf("failed to load the default policy: %v", err)
+	}
+	only, err := policy.Only("github-repositories")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(only.Rules) != 1 || only.Rules[0].Provider != GitHubRepositories || only.Concurrency != policy.Concurrency {
+		t.Fatalf("expected only the rule of the GitHub repositories, got %+v", only)
+	}
+	if _, err := policy.Only("gitlab-repositories"); err == nil {
+		t.Error("expected an error for an unknown rule")
+	}
+
+	// e.g. the REPO_REGEX of the CleanupGithubOrg target, the descriptions are kept
+	old := time.Now().Add(-48 * time.Hour)
+	if err := only.SetPatterns("github-repositories", "^my-"); err != nil {
+		t.Fatal(err)
+	}
+	for name, leaked := range map[string]bool{"my-app": true, "e2e-app": false} {
+		if matched, _ := only.Rules[0].matches(Resource{Name: name, CreatedAt: old}, time.Now()); matched != leaked {
+			t.Errorf("expected the repository %s to be leaked: %t, got %t", name, leaked, matched)
+		}
+	}
+	if matched, _ := only.Rules[0].matches(Resource{Name: "gitops", Description: "GitOps Repository", CreatedAt: old}, time.Now()); !matched {
+		t.Error("expected the GitOps repository to be leaked")
+	}
+	if err := only.SetPatterns("github-repositories", "^(my-"); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+	// the rules of the whole policy aren't changed
+	if matched, _ := policy.Rules[0].matches(Resource{Name: "e2e-app", CreatedAt: old}, time.Now()); !matched {
+		t.Error("expected the repository e2e-app to be leaked by the default policy")
+	}
+}
+
+func TestLoadInvalidPolicy(t *testing.T) {
+	for content, expectedErr := range map[string]string{
+		"rules: [{provider: gitlab-repositories, patterns: [.*]}]":       "unknown provider",
+		"rules: [{provider: namespaces, maxAge: 24h}]":                   "no patterns or descriptions",
+		"rules: [{provider: namespaces, patterns: ['^(e2e']}]":           "invalid pattern",
+		"rules: [{provider: namespaces, patterns: [.*], maxAge: 1 day}]": "failed to parse",
+		"concurrency: -1": "negative concurrency",
+	} {
+		path := filepath.Join(t.TempDir(), "policy.yaml")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadPolicy(path); err == nil || !strings.Contains(err.Error(), expectedErr) {
+			t.Errorf("%q: expected an error containing %q, got %v", content, expectedErr, err)
+		}
+	}
+}
+
+func TestReaperRun(t *testing.T) {
+	policy, err := parsePolicy([]byte(`
+concurrency: 2
+rules:
+  - provider: namespaces
+    patterns: ["^e2e-"]
+    maxAge: 24h
+  - provider: usersignups
+    patterns: [".*"]
+`), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	newNamespaces := func() *providerMock {
+		return &providerMock{
+			name: Namespaces,
+			resources: []Resource{
+				{Name: "e2e-1", CreatedAt: old}, {Name: "e2e-2", CreatedAt: old}, {Name: "e2e-3", CreatedAt: old},
+				{Name: "e2e-4", CreatedAt: old}, {Name: "e2e-new", CreatedAt: time.Now()}, {Name: "default", CreatedAt: old},
+			},
+			deleteErr: map[string]error{"e2e-4": fmt.Errorf("forbidden")},
+		}
+	}
+	userSignups := &providerMock{name: UserSignups, listErr: fmt.Errorf("the server could not find the requested resource")}
+	// there are no rules for the tags
+	tags := &providerMock{name: QuayTags, resources: []Resource{{Repository: "test-images", Name: "latest"}}}
+
+	// the dry run only reports the leaked resources
+	namespaces := newNamespaces()
+	report := (&Reaper{Policy: policy, Providers: []Provider{namespaces, userSignups, tags}, DryRun: true}).Run()
+	if len(namespaces.deleted) != 0 {
+		t.Errorf("expected no deleted namespaces in the dry run, got %v", namespaces.deleted)
+	}
+	if len(report.Providers) != 2 || report.Providers[0].Listed != 6 || len(report.Providers[0].Leaked) != 4 {
+		t.Fatalf("expected 4 of the 6 namespaces to be leaked and the usersignups to be reported, got %+v", report.Providers)
+	}
+
+	namespaces = newNamespaces()
+	report = (&Reaper{Policy: policy, Providers: []Provider{namespaces, userSignups, tags}}).Run()
+	sort.Strings(namespaces.deleted)
+	if expected := []string{"e2e-1", "e2e-2", "e2e-3"}; !reflect.DeepEqual(expected, namespaces.deleted) {
+		t.Errorf("expected the deleted namespaces %v, got %v", expected, namespaces.deleted)
+	}
+	if namespaces.maxParallel > 2 {
+		t.Errorf("expected at most 2 namespaces deleted in parallel, got %d", namespaces.maxParallel)
+	}
+	err = report.Err()
+	if err == nil || !strings.Contains(err.Error(), "failed to delete namespaces e2e-4: forbidden") || !strings.Contains(err.Error(), "failed to list usersignups") {
+		t.Errorf("expected the errors of the failed deletion and listing, got %v", err)
+	}
+
+	text := &bytes.Buffer{}
+	if err := report.WriteText(text); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text.String(), `e2e-1: matches "^e2e-" and is older than 24h0m0s`) || !strings.Contains(text.String(), "e2e-4: matches \"^e2e-\" and is older than 24h0m0s (failed: forbidden)") {
+		t.Errorf("unexpected text report:\n%s", text)
+	}
+}
@@ -0,0 +1,55 @@
+package reaper
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/redhat-appstudio/e2e-tests/pkg/clients/sprayproxy"
+)
+
+// SprayProxyServerProvider lists the PaC servers registered in SprayProxy which aren't reachable anymore,
+// i.e. those of the clusters which were deleted without unregistering them. SprayProxy doesn't record when
+// a server was registered, so only the rules without max age match them.
+type SprayProxyServerProvider struct {
+	Client *sprayproxy.SprayProxyConfig
+	// Reachable checks whether the server is up, the servers answering an HTTP GET are if not set
+	Reachable func(server string) bool
+}
+
+func (p *SprayProxyServerProvider) Name() string {
+	return SprayProxyServers
+}
+
+func (p *SprayProxyServerProvider) List([]string) ([]Resource, error) {
+	servers, err := p.Client.GetServers()
+	if err != nil {
+		return nil, err
+	}
+	reachable := p.Reachable
+	if reachable == nil {
+		reachable = isReachable
+	}
+	resources := []Resource{}
+	for _, server := range strings.Split(servers, ",") {
+		if server = strings.TrimSpace(server); server != "" && !reachable(server) {
+			resources = append(resources, Resource{Name: server})
+		}
+	}
+	return resources, nil
+}
+
+func (p *SprayProxyServerProvider) Delete(resource Resource) error {
+	_, err := p.Client.UnregisterServer(resource.Name)
+	return err
+}
+
+func isReachable(server string) bool {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(server)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return true
+}
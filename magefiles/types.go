@@ -6,7 +6,10 @@ type Local mg.Namespace
 type CI mg.Namespace
 
 type OpenshiftJobSpec struct {
-	Refs Refs `json:"refs"`
+	// Type can be periodic, presubmit or postsubmit
+	Type string `json:"type"`
+	Job  string `json:"job"`
+	Refs Refs   `json:"refs"`
 }
 type Refs struct {
 	RepoLink     string `json:"repo_link"`
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+)
+
+const defaultUpgradeOrganization = "redhat-appstudio"
+
+// the RHTAP CRs compared before and after each upgrade hop
+var upgradeStateResources = []schema.GroupVersionResource{
+	{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "applications"},
+	{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "components"},
+	{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "environments"},
+	{Group: "toolchain.dev.openshift.com", Version: "v1alpha1", Resource: "usersignups"},
+	{Group: "toolchain.dev.openshift.com", Version: "v1alpha1", Resource: "spaces"},
+}
+
+// the fields whose changes are expected in any upgrade, in addition to those from UPGRADE_STATE_DIFF_IGNORE
+var defaultIgnoredStateFields = []string{
+	"status.observedGeneration",
+	"status.conditions.*.lastTransitionTime",
+	"status.conditions.*.lastUpdateTime",
+	"status.conditions.*.lastHeartbeatTime",
+}
+
+// upgradeRef is a branch of infra-deployments in a GitHub organization
+type upgradeRef struct {
+	Organization string `json:"organization"`
+	Branch       string `json:"branch"`
+}
+
+func (r upgradeRef) String() string {
+	return r.Organization + ":" + r.Branch
+}
+
+// upgradeHop upgrades the cluster installed from the From ref by merging the To ref
+type upgradeHop struct {
+	From upgradeRef `json:"from"`
+	To   upgradeRef `json:"to"`
+}
+
+// parseUpgradeRef parses "<organization>:<branch>" or "<branch>" of the redhat-appstudio organization.
+func parseUpgradeRef(ref string) (upgradeRef, error) {
+	org, branch, found := strings.Cut(strings.TrimSpace(ref), ":")
+	if !found {
+		org, branch = defaultUpgradeOrganization, org
+	}
+	if org == "" || branch == "" {
+		return upgradeRef{}, fmt.Errorf("invalid upgrade ref %q, expected <organization>:<branch> or <branch>", ref)
+	}
+	return upgradeRef{Organization: org, Branch: branch}, nil
+}
+
+// parseUpgradeMatrix parses the comma separated hops "<from>-><to>". Every hop has to start from the ref the previous one upgraded to,
+// since all the hops upgrade the same cluster.
+func parseUpgradeMatrix(matrix string) ([]upgradeHop, error) {
+	hops := []upgradeHop{}
+	for _, hopSpec := range strings.Split(matrix, ",") {
+		if strings.TrimSpace(hopSpec) == "" {
+			continue
+		}
+		from, to, found := strings.Cut(hopSpec, "->")
+		if !found {
+			return nil, fmt.Errorf("invalid upgrade hop %q, expected <from>-><to>", hopSpec)
+		}
+		fromRef, err := parseUpgradeRef(from)
+		if err != nil {
+			return nil, err
+		}
+		toRef, err := parseUpgradeRef(to)
+		if err != nil {
+			return nil, err
+		}
+		if len(hops) > 0 && hops[len(hops)-1].To != fromRef {
+			return nil, fmt.Errorf("the upgrade hop %s->%s doesn't start from %s the previous hop upgraded to", fromRef, toRef, hops[len(hops)-1].To)
+		}
+		hops = append(hops, upgradeHop{From: fromRef, To: toRef})
+	}
+	if len(hops) == 0 {
+		return nil, fmt.Errorf("no upgrade hops in %q", matrix)
+	}
+	return hops, nil
+}
+
+// upgradeHopsFromEnv returns the hops from UPGRADE_MATRIX or, if it's not set, the single hop from the main branch
+// of redhat-appstudio to UPGRADE_BRANCH of UPGRADE_FORK_ORGANIZATION.
+func upgradeHopsFromEnv() ([]upgradeHop, error) {
+	if matrix := utils.GetEnv(constants.UPGRADE_MATRIX_ENV, ""); matrix != "" {
+		return parseUpgradeMatrix(matrix)
+	}
+	branch := utils.GetEnv("UPGRADE_BRANCH", "")
+	if branch == "" {
+		return nil, fmt.Errorf("either %s or UPGRADE_BRANCH has to be set", constants.UPGRADE_MATRIX_ENV)
+	}
+	return []upgradeHop{{
+		From: upgradeRef{Organization: defaultUpgradeOrganization, Branch: "main"},
+		To:   upgradeRef{Organization: utils.GetEnv("UPGRADE_FORK_ORGANIZATION", defaultUpgradeOrganization), Branch: branch},
+	}}, nil
+}
+
+// clusterState is a snapshot of the spec and status of the RHTAP CRs, by "<resource>.<group> <namespace>/<name>".
+type clusterState struct {
+	Time    time.Time                     `json:"time"`
+	Objects map[string]clusterObjectState `json:"objects"`
+}
+
+type clusterObjectState struct {
+	Spec   interface{} `json:"spec,omitempty"`
+	Status interface{} `json:"status,omitempty"`
+}
+
+// snapshotClusterState lists the RHTAP CRs in all the namespaces. The resources whose CRDs aren't installed are skipped.
+func snapshotClusterState(client dynamic.Interface) (*clusterState, error) {
+	state := &clusterState{Time: time.Now(), Objects: map[string]clusterObjectState{}}
+	for _, gvr := range upgradeStateResources {
+		list, err := client.Resource(gvr).Namespace(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			if k8sErrors.IsNotFound(err) {
+				klog.Warningf("%s not found in the cluster, not included in the state", gvr.GroupResource())
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s: %v", gvr.GroupResource(), err)
+		}
+		for _, item := range list.Items {
+			key := fmt.Sprintf("%s %s", gvr.GroupResource(), path.Join(item.GetNamespace(), item.GetName()))
+			state.Objects[key] = clusterObjectState{Spec: item.Object["spec"], Status: item.Object["status"]}
+		}
+	}
+	return state, nil
+}
+
+// The types of the stateChange
+const (
+	stateChangeObjectAdded   = "object-added"
+	stateChangeObjectRemoved = "object-removed"
+	stateChangeFieldAdded    = "field-added"
+	stateChangeFieldRemoved  = "field-removed"
+	stateChangeFieldChanged  = "field-changed"
+)
+
+// stateChange is a difference of a CR between two cluster states
+type stateChange struct {
+	Object string      `json:"object"`
+	Type   string      `json:"type"`
+	Field  string      `json:"field,omitempty"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+	// Unexpected changes fail the upgrade hop: removed objects and the changes of spec fields. The changes of status fields
+	// are informational unless UPGRADE_STATE_DIFF_STRICT_STATUS is set, then only the new status fields are expected
+	Unexpected bool `json:"unexpected"`
+}
+
+type stateDiff struct {
+	Hop     upgradeHop    `json:"hop"`
+	Changes []stateChange `json:"changes"`
+}
+
+func (d stateDiff) unexpected() []stateChange {
+	unexpected := []stateChange{}
+	for _, change := range d.Changes {
+		if change.Unexpected {
+			unexpected = append(unexpected, change)
+		}
+	}
+	return unexpected
+}
+
+// diffClusterState compares the CRs in the two states field by field. The changes of the fields matching the ignored patterns
+// (e.g. "status.conditions.*.lastTransitionTime") are left out. The list items with a "type" field, like the conditions, are
+// identified by their type instead of their index. The changes of the status fields are unexpected only if strictStatus is set.
+func diffClusterState(before, after *clusterState, ignoredFields []string, strictStatus bool) []stateChange {
+	changes := []stateChange{}
+	keys := map[string]bool{}
+	for key := range before.Objects {
+		keys[key] = true
+	}
+	for key := range after.Objects {
+		keys[key] = true
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	for _, key := range sortedKeys {
+		beforeObj, inBefore := before.Objects[key]
+		afterObj, inAfter := after.Objects[key]
+		if !inAfter {
+			changes = append(changes, stateChange{Object: key, Type: stateChangeObjectRemoved, Unexpected: true})
+			continue
+		}
+		if !inBefore {
+			changes = append(changes, stateChange{Object: key, Type: stateChangeObjectAdded})
+			continue
+		}
+
+		beforeFields, afterFields := map[string]interface{}{}, map[string]interface{}{}
+		flattenFields("spec", beforeObj.Spec, beforeFields)
+		flattenFields("status", beforeObj.Status, beforeFields)
+		flattenFields("spec", afterObj.Spec, afterFields)
+		flattenFields("status", afterObj.Status, afterFields)
+
+		fields := map[string]bool{}
+		for field := range beforeFields {
+			fields[field] = true
+		}
+		for field := range afterFields {
+			fields[field] = true
+		}
+		sortedFields := make([]string, 0, len(fields))
+		for field := range fields {
+			if !isIgnoredField(field, ignoredFields) {
+				sortedFields = append(sortedFields, field)
+			}
+		}
+		sort.Strings(sortedFields)
+
+		for _, field := range sortedFields {
+			beforeValue, inBefore := beforeFields[field]
+			afterValue, inAfter := afterFields[field]
+			// the controllers reconcile the status after the upgrade, so its changes are expected unless they are checked strictly
+			change := stateChange{Object: key, Field: field, Before: beforeValue, After: afterValue, Unexpected: strictStatus || strings.HasPrefix(field, "spec.")}
+			switch {
+			case !inBefore:
+				change.Type = stateChangeFieldAdded
+				// the new versions of the controllers can report more in the status
+				change.Unexpected = strings.HasPrefix(field, "spec.")
+			case !inAfter:
+				change.Type = stateChangeFieldRemoved
+			case !reflect.DeepEqual(beforeValue, afterValue):
+				change.Type = stateChangeFieldChanged
+			default:
+				continue
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// flattenFields puts the leaf values under the value into the fields by their dot separated paths
+func flattenFields(prefix string, value interface{}, fields map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			flattenFields(prefix+"."+key, item, fields)
+		}
+	case []interface{}:
+		for i, item := range v {
+			id := strconv.Itoa(i)
+			if m, ok := item.(map[string]interface{}); ok {
+				if t, ok := m["type"].(string); ok && t != "" {
+					id = t
+				}
+			}
+			flattenFields(prefix+"."+id, item, fields)
+		}
+	case nil:
+	default:
+		fields[prefix] = v
+	}
+}
+
+// isIgnoredField matches the field against the patterns, where "*" matches a single segment of the path
+func isIgnoredField(field string, patterns []string) bool {
+	fieldPath := strings.ReplaceAll(field, ".", "/")
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(strings.ReplaceAll(pattern, ".", "/"), fieldPath); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// strictStatusFromEnv returns whether the changes of the status fields fail the upgrade hop, see UPGRADE_STATE_DIFF_STRICT_STATUS
+func strictStatusFromEnv() (bool, error) {
+	strict, err := strconv.ParseBool(utils.GetEnv(constants.UPGRADE_STATE_DIFF_STRICT_STATUS_ENV, "false"))
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %s: %v", constants.UPGRADE_STATE_DIFF_STRICT_STATUS_ENV, err)
+	}
+	return strict, nil
+}
+
+// ignoredStateFieldsFromEnv returns the default ignored fields and those from the comma separated UPGRADE_STATE_DIFF_IGNORE
+func ignoredStateFieldsFromEnv() []string {
+	ignored := append([]string{}, defaultIgnoredStateFields...)
+	for _, pattern := range strings.Split(utils.GetEnv(constants.UPGRADE_STATE_DIFF_IGNORE_ENV, ""), ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			ignored = append(ignored, pattern)
+		}
+	}
+	return ignored
+}
+
+func writeUpgradeArtifact(name string, data interface{}) error {
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(artifactDir, os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(artifactDir, name), content, 0644)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
+)
+
+func TestParseUpgradeMatrix(t *testing.T) {
+	hops, err := parseUpgradeMatrix("main->my-org:feature, my-org:feature->my-org:release/v2")
+	if err != nil {
+		t.Fatalf("failed to parse the upgrade matrix: %v", err)
+	}
+	expected := []upgradeHop{
+		{From: upgradeRef{"redhat-appstudio", "main"}, To: upgradeRef{"my-org", "feature"}},
+		{From: upgradeRef{"my-org", "feature"}, To: upgradeRef{"my-org", "release/v2"}},
+	}
+	if !reflect.DeepEqual(expected, hops) {
+		t.Errorf("expected the hops %v, got %v", expected, hops)
+	}
+
+	for matrix, expectedErr := range map[string]string{
+		"":                              "no upgrade hops",
+		"main":                          "expected <from>-><to>",
+		"main->my-org:":                 "invalid upgrade ref",
+		"main->feature,other->feature2": "doesn't start from redhat-appstudio:feature",
+	} {
+		if _, err := parseUpgradeMatrix(matrix); err == nil || !strings.Contains(err.Error(), expectedErr) {
+			t.Errorf("%q: expected an error containing %q, got %v", matrix, expectedErr, err)
+		}
+	}
+}
+
+func TestUpgradeHopsFromEnv(t *testing.T) {
+	t.Setenv(constants.UPGRADE_MATRIX_ENV, "")
+	t.Setenv("UPGRADE_BRANCH", "")
+	if _, err := upgradeHopsFromEnv(); err == nil {
+		t.Errorf("expected an error without UPGRADE_MATRIX and UPGRADE_BRANCH")
+	}
+
+	// the single hop of the previous upgrade workflow
+	t.Setenv("UPGRADE_BRANCH", "new-feature")
+	t.Setenv("UPGRADE_FORK_ORGANIZATION", "contributor")
+	hops, err := upgradeHopsFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []upgradeHop{{From: upgradeRef{"redhat-appstudio", "main"}, To: upgradeRef{"contributor", "new-feature"}}}
+	if !reflect.DeepEqual(expected, hops) {
+		t.Errorf("expected the hops %v, got %v", expected, hops)
+	}
+}
+
+func TestDiffClusterState(t *testing.T) {
+	condition := func(conditionType, status, lastTransitionTime string) map[string]interface{} {
+		return map[string]interface{}{"type": conditionType, "status": status, "lastTransitionTime": lastTransitionTime}
+	}
+	before := &clusterState{Objects: map[string]clusterObjectState{
+		"components.appstudio.redhat.com upgrade-namespace/comp": {
+			Spec: map[string]interface{}{"componentName": "comp", "replicas": int64(1)},
+			Status: map[string]interface{}{"conditions": []interface{}{
+				condition("Created", "True", "2023-10-01T10:00:00Z"),
+				condition("Updated", "True", "2023-10-01T10:00:00Z"),
+			}},
+		},
+		"usersignups.toolchain.dev.openshift.com toolchain-host-operator/mig-prov": {Spec: map[string]interface{}{"username": "mig-prov"}},
+	}}
+	after := &clusterState{Objects: map[string]clusterObjectState{
+		"components.appstudio.redhat.com upgrade-namespace/comp": {
+			Spec: map[string]interface{}{"componentName": "comp", "replicas": int64(1)},
+			// the conditions are reordered, one of them is updated and there's a new status field
+			Status: map[string]interface{}{"devfile": "schemaVersion: 2.2.0", "conditions": []interface{}{
+				condition("Updated", "False", "2023-10-02T10:00:00Z"),
+				condition("Created", "True", "2023-10-02T10:00:00Z"),
+			}},
+		},
+		"spaces.toolchain.dev.openshift.com toolchain-host-operator/mig-appst-space": {Spec: map[string]interface{}{"tierName": "appstudio"}},
+	}}
+
+	// the spec of the component changes as well
+	after.Objects["components.appstudio.redhat.com upgrade-namespace/comp"].Spec["replicas"] = int64(2)
+
+	changes := diffClusterState(before, after, defaultIgnoredStateFields, false)
+	expected := []stateChange{
+		{Object: "components.appstudio.redhat.com upgrade-namespace/comp", Type: stateChangeFieldChanged, Field: "spec.replicas", Before: int64(1), After: int64(2), Unexpected: true},
+		{Object: "components.appstudio.redhat.com upgrade-namespace/comp", Type: stateChangeFieldAdded, Field: "status.devfile", After: "schemaVersion: 2.2.0"},
+		{Object: "components.appstudio.redhat.com upgrade-namespace/comp", Type: stateChangeFieldChanged, Field: "status.conditions.Updated.status", Before: "True", After: "False"},
+		{Object: "spaces.toolchain.dev.openshift.com toolchain-host-operator/mig-appst-space", Type: stateChangeObjectAdded},
+		{Object: "usersignups.toolchain.dev.openshift.com toolchain-host-operator/mig-prov", Type: stateChangeObjectRemoved, Unexpected: true},
+	}
+	// the fields are sorted, so the changes of the component are compared regardless of their order
+	if len(changes) != len(expected) {
+		t.Fatalf("expected the changes\n%+v\ngot\n%+v", expected, changes)
+	}
+	for _, e := range expected {
+		found := false
+		for _, c := range changes {
+			if reflect.DeepEqual(e, c) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected the change %+v in\n%+v", e, changes)
+		}
+	}
+
+	diff := stateDiff{Changes: changes}
+	if unexpected := diff.unexpected(); len(unexpected) != 2 {
+		t.Errorf("expected the updated spec and the removed user signup to be unexpected, got %+v", unexpected)
+	}
+
+	// the updated condition is unexpected only if the status is checked strictly, the new status field is still expected
+	diff = stateDiff{Changes: diffClusterState(before, after, defaultIgnoredStateFields, true)}
+	if unexpected := diff.unexpected(); len(unexpected) != 3 {
+		t.Errorf("expected the updated spec, the updated condition and the removed user signup to be unexpected, got %+v", unexpected)
+	}
+
+	// the changes of the ignored fields aren't reported
+	changes = diffClusterState(before, after, append([]string{"spec.replicas", "status.conditions.*.status", "status.devfile"}, defaultIgnoredStateFields...), true)
+	for _, c := range changes {
+		if c.Field != "" {
+			t.Errorf("unexpected change of an ignored field %+v", c)
+		}
+	}
+}
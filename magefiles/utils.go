@@ -3,14 +3,14 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"os/exec"
-	"regexp"
+	"path/filepath"
 	"strings"
-	"sync"
 	"text/template"
 	"time"
 
@@ -22,13 +22,12 @@ import (
 	plumbingHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	sprig "github.com/go-task/slim-sprig"
 	"github.com/magefile/mage/sh"
+	"github.com/redhat-appstudio/e2e-tests/pkg/clients/notifier"
 	"github.com/redhat-appstudio/e2e-tests/pkg/clients/slack"
+	"github.com/redhat-appstudio/e2e-tests/pkg/framework"
 	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
-	"github.com/redhat-appstudio/image-controller/pkg/quay"
 )
 
-const quayPrefixesToDeleteRegexp = "e2e-demos|has-e2e|multi-comp|build-e2e"
-
 func getRemoteAndBranchNameFromPRLink(url string) (remote, branchName string, err error) {
 	ghRes := &GithubPRInfo{}
 	if err := sendHttpRequestAndParseResponse(url, "GET", ghRes); err != nil {
@@ -167,181 +166,9 @@ func renderTemplate(destination, templatePath string, templateData interface{},
 	return nil
 }
 
-func cleanupQuayReposAndRobots(quayService quay.QuayService, quayOrg string) error {
-	r, err := regexp.Compile(fmt.Sprintf(`^(%s)`, quayPrefixesToDeleteRegexp))
-	if err != nil {
-		return err
-	}
-
-	repos, err := quayService.GetAllRepositories(quayOrg)
-	if err != nil {
-		return err
-	}
-
-	// Key is the repo name without slashes which is the same as robot name
-	// Value is the repo name with slashes
-	reposMap := make(map[string]string)
-
-	for _, repo := range repos {
-		if r.MatchString(repo.Name) {
-			sanitizedRepoName := strings.ReplaceAll(repo.Name, "/", "") // repo name without slashes
-			reposMap[sanitizedRepoName] = repo.Name
-		}
-	}
-
-	robots, err := quayService.GetAllRobotAccounts(quayOrg)
-	if err != nil {
-		return err
-	}
-
-	r, err = regexp.Compile(fmt.Sprintf(`^%s\+(%s)`, quayOrg, quayPrefixesToDeleteRegexp))
-	if err != nil {
-		return err
-	}
-
-	const timeFormat = "Mon, 02 Jan 2006 15:04:05 -0700"
-
-	// Deletes robots and their repos with correct prefix if created more than 24 hours ago
-	for _, robot := range robots {
-		parsed, err := time.Parse(timeFormat, robot.Created)
-		if err != nil {
-			return err
-		}
-
-		// If robot.Name has correct prefix and was created more than 24 hours ago
-		if r.MatchString(robot.Name) && time.Since(parsed) > 24*time.Hour {
-			// Robot name without the name of org which is the same as previous sanitizedRepoName
-			// redhat-appstudio-qe+e2e-demos turns to e2e-demos
-			splitRobotName := strings.Split(robot.Name, "+")
-			if len(splitRobotName) != 2 {
-				return fmt.Errorf("failed to split robot name into 2 parts, got %d parts", len(splitRobotName))
-			}
-			sanitizedRepoName := splitRobotName[1] // Same as robot shortname
-			if repo, exists := reposMap[sanitizedRepoName]; exists {
-				deleted, err := quayService.DeleteRepository(quayOrg, repo)
-				if err != nil {
-					return fmt.Errorf("failed to delete repository %s, error: %s", repo, err)
-				}
-				if !deleted {
-					fmt.Printf("repository %s has already been deleted, skipping\n", repo)
-				}
-			}
-			// DeleteRobotAccount uses robot shortname, so e2e-demos instead of redhat-appstudio-qe+e2e-demos
-			deleted, err := quayService.DeleteRobotAccount(quayOrg, splitRobotName[1])
-			if err != nil {
-				return fmt.Errorf("failed to delete robot account %s, error: %s", robot.Name, err)
-			}
-			if !deleted {
-				fmt.Printf("robot account %s has already been deleted, skipping\n", robot.Name)
-			}
-		}
-	}
-	return nil
-}
-
-func cleanupQuayTags(quayService quay.QuayService, organization, repository string) error {
-	workerCount := 10
-	var wg sync.WaitGroup
-
-	var allTags []quay.Tag
-	var errors []error
-
-	page := 1
-	for {
-		tags, hasAdditional, err := quayService.GetTagsFromPage(organization, repository, page)
-		page++
-		if err != nil {
-			errors = append(errors, fmt.Errorf("error getting tags of `%s` repository of `%s` organization on page `%d`, error: %s", repository, organization, page, err))
-			continue
-		}
-		allTags = append(allTags, tags...)
-		if !hasAdditional {
-			break
-		}
-	}
-
-	wg.Add(workerCount)
-
-	var errorsMutex sync.Mutex
-	for i := 0; i < workerCount; i++ {
-		go func(startIdx int, allTags []quay.Tag, errors []error, errorsMutex *sync.Mutex, wg *sync.WaitGroup) {
-			defer wg.Done()
-			for idx := startIdx; idx < len(allTags); idx += workerCount {
-				tag := allTags[idx]
-				if time.Unix(tag.StartTS, 0).Before(time.Now().AddDate(0, 0, -7)) {
-					deleted, err := quayService.DeleteTag(organization, repository, tag.Name)
-					if err != nil {
-						errorsMutex.Lock()
-						errors = append(errors, fmt.Errorf("error during deletion of tag `%s` in repository `%s` of organization `%s`, error: `%s`", tag.Name, repository, organization, err))
-						errorsMutex.Unlock()
-					} else if !deleted {
-						fmt.Printf("tag `%s` in repository `%s` of organization `%s` was not deleted\n", tag.Name, repository, organization)
-					}
-				}
-			}
-		}(i, allTags, errors, &errorsMutex, &wg)
-	}
-
-	wg.Wait()
-
-	if len(errors) == 0 {
-		return nil
-	}
-
-	var errBuilder strings.Builder
-	for _, err := range errors {
-		errBuilder.WriteString(fmt.Sprintf("%s\n", err))
-	}
-	return fmt.Errorf("encountered errors during CleanupQuayTags: %s", errBuilder.String())
-}
-
-func repoNameStartsWithPrefix(prefixes []string, repoName string) bool {
-	for _, prefix := range prefixes {
-		hasPrefix := strings.HasPrefix(repoName, prefix)
-		if hasPrefix {
-			return true
-		}
-	}
-	return false
-}
-
-// Deletes the private repos older than 7 days
-func cleanupPrivateRepos(quayService quay.QuayService, quayOrg string, repoNamePrefixes []string) error {
-	repos, err := quayService.GetAllRepositories(quayOrg)
-	if err != nil {
-		return err
-	}
-	foundError := false
-	var errors []error
-	for _, repo := range repos {
-		if !repo.IsPublic && repoNameStartsWithPrefix(repoNamePrefixes, repo.Name) {
-			lastModifiedTime := int64(repo.LastModified)
-			currentTime := time.Now().Unix()
-			timeDiff := int64(7 * 24 * 3600)
-			if currentTime-lastModifiedTime > timeDiff {
-				_, err := quayService.DeleteRepository(quayOrg, repo.Name)
-				if err != nil {
-					foundError = true
-					errors = append(errors, fmt.Errorf("failed to delete repository %s with error: %v", repo.Name, err))
-					continue
-				}
-				fmt.Printf("Deleted repo %s successfully\n", repo.Name)
-			}
-		}
-	}
-	if foundError {
-		var errBuilder strings.Builder
-		for _, err := range errors {
-			errBuilder.WriteString(fmt.Sprintf("%s\n", err))
-		}
-		return fmt.Errorf("encountered errors during cleanup of private repos: %s", errBuilder.String())
-	}
-	return nil
-}
-
 func MergePRInRemote(branch string, forkOrganization string, repoPath string) error {
 	if branch == "" {
-		klog.Fatal("The branch for upgrade is empty!")
+		return fmt.Errorf("the branch for upgrade is empty")
 	}
 	var auth = &plumbingHttp.BasicAuth{
 		Username: "123",
@@ -350,12 +177,12 @@ func MergePRInRemote(branch string, forkOrganization string, repoPath string) er
 
 	repo, err := git.PlainOpen(repoPath)
 	if err != nil {
-		klog.Fatal(err)
+		return err
 	}
 
 	branches, err := repo.Branches()
 	if err != nil {
-		klog.Fatal(err)
+		return err
 	}
 
 	var previewBranchRef *plumbing.Reference
@@ -366,12 +193,15 @@ func MergePRInRemote(branch string, forkOrganization string, repoPath string) er
 		return nil
 	})
 	if err != nil {
-		klog.Fatal(err)
+		return err
+	}
+	if previewBranchRef == nil {
+		return fmt.Errorf("no preview branch found in %s", repoPath)
 	}
 
 	wt, err := repo.Worktree()
 	if err != nil {
-		klog.Fatal(err)
+		return err
 	}
 
 	err = wt.Checkout(&git.CheckoutOptions{
@@ -379,59 +209,176 @@ func MergePRInRemote(branch string, forkOrganization string, repoPath string) er
 	})
 	klog.Infof("Preview branch name: %s", previewBranchRef.Name())
 	if err != nil {
-		klog.Fatal(err)
+		return err
 	}
 	klog.Infof("Fork organization: %s", forkOrganization)
 	if forkOrganization == "redhat-appstudio" {
 		// Cloned repository have as origin set redhat-appstudio organization
 		err = mergeBranch(repoPath, "remotes/origin/"+branch)
 	} else {
+		// the remote of the fork can exist already from a previous upgrade hop
+		remoteName := "forked_repo_" + forkOrganization
 		repoURL := fmt.Sprintf("https://github.com/%s/infra-deployments.git", forkOrganization)
 		_, err = repo.CreateRemote(&config.RemoteConfig{
-			Name: "forked_repo",
+			Name: remoteName,
 			URLs: []string{repoURL},
 		})
-		if err != nil {
-			klog.Fatal(err)
+		if err != nil && err != git.ErrRemoteExists {
+			return err
 		}
 
 		err = repo.Fetch(&git.FetchOptions{
-			RemoteName: "forked_repo",
+			RemoteName: remoteName,
 		})
-		if err != nil {
-			klog.Fatal(err)
+		if err != nil && err != git.NoErrAlreadyUpToDate {
+			return err
 		}
-		err = mergeBranch(repoPath, "remotes/forked_repo/"+branch)
+		err = mergeBranch(repoPath, "remotes/"+remoteName+"/"+branch)
 	}
 	if err != nil {
-		klog.Fatal(err)
+		return err
 	}
 
-	err = repo.Push(&git.PushOptions{
+	return repo.Push(&git.PushOptions{
 		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", previewBranchRef.Name().String(), previewBranchRef.Name().String()))},
 		RemoteName: "qe",
 		Auth:       auth,
 	})
-	if err != nil {
-		klog.Fatal(err)
-	}
-
-	return nil
 }
 
 func mergeBranch(repoPath string, branchToMerge string) error {
-	_, err := exec.Command("git", "-C", repoPath, "merge", branchToMerge, "-Xtheirs", "-q").Output()
+	output, err := exec.Command("git", "-C", repoPath, "merge", branchToMerge, "-Xtheirs", "-q").CombinedOutput()
 	if err != nil {
-		klog.Fatal(err)
+		return fmt.Errorf("failed to merge %s: %v: %s", branchToMerge, err, output)
 	}
 	return nil
 }
 
+// HandleErrorWithAlert reports the error to the notification sinks configured by NOTIFIERS_CONFIG
+// (the dedicated Slack channel by default) which accept its severity level.
 func HandleErrorWithAlert(err error, errLevel slack.ErrorSeverityLevel) error {
-	klog.Warning(err.Error() + " - this issue will be reported to a dedicated Slack channel")
+	klog.Warning(err.Error() + " - this issue will be reported to the configured notification sinks")
 
-	if slackErr := slack.ReportIssue(err.Error(), errLevel); slackErr != nil {
-		return fmt.Errorf("failed report an error (%s) to a Slack channel: %s", err, slackErr)
+	router, configErr := notifier.NewRouterFromEnv()
+	if configErr != nil {
+		return fmt.Errorf("failed to report an error (%s): invalid notifiers config: %s", err, configErr)
+	}
+	if notifyErr := router.Notify(notifier.NewAlert(err.Error(), errLevel)); notifyErr != nil {
+		return fmt.Errorf("failed to report an error (%s): %s", err, notifyErr)
 	}
 	return nil
 }
+
+// postRunSummary posts the summary of the test run to Slack, to the channel and thread configured for the job
+// in the YAML file given by SLACK_RUN_SUMMARY_CONFIG. If SLACK_RUN_SUMMARY_DRY_RUN is "true", the message payload
+// is written into $ARTIFACT_DIR/slack-run-summary.json instead. Nothing is posted unless one of the env vars is set.
+func postRunSummary() error {
+	configPath := os.Getenv("SLACK_RUN_SUMMARY_CONFIG")
+	dryRun := os.Getenv("SLACK_RUN_SUMMARY_DRY_RUN") == "true"
+	if configPath == "" && !dryRun {
+		return nil
+	}
+
+	config := &slack.RunSummaryConfig{}
+	if configPath != "" {
+		var err error
+		if config, err = slack.LoadRunSummaryConfig(configPath); err != nil {
+			return err
+		}
+	}
+	if dryRun {
+		config.DryRunFile = filepath.Join(artifactDir, "slack-run-summary.json")
+	}
+	if planning != nil {
+		channel, _, err := config.Destination(jobName)
+		if err != nil {
+			return err
+		}
+		planning.record(ciPlanStep{Action: ciPlanActionPostRunSummary, Target: channel, Value: config.DryRunFile})
+		return nil
+	}
+
+	summary, err := newRunSummary(filepath.Join(artifactDir, "rp_preproc", "results", "xunit.xml"))
+	if err != nil {
+		return err
+	}
+	summary.JobName = jobName
+	if jobID := os.Getenv("PROW_JOB_ID"); jobID != "" {
+		// GetProwJobURL returns the error message if it fails to get the URL
+		if jobURL := slack.GetProwJobURL(jobID); strings.HasPrefix(jobURL, "https://") {
+			summary.JobURL = jobURL
+			summary.ArtifactsURL = prowArtifactsURL(jobURL)
+		}
+	}
+	return slack.PostRunSummary(summary, config)
+}
+
+// newRunSummary summarizes the JUnit report generated by framework.GenerateCustomJUnitReport, which has
+// the suite of every spec as its classname and properties with the failure category and flakiness.
+func newRunSummary(junitReportPath string) (slack.RunSummary, error) {
+	summary := slack.RunSummary{}
+	content, err := os.ReadFile(junitReportPath)
+	if err != nil {
+		return summary, err
+	}
+	report := framework.CustomJUnitTestSuites{}
+	if err := xml.Unmarshal(content, &report); err != nil {
+		return summary, fmt.Errorf("failed to parse the JUnit report %s: %v", junitReportPath, err)
+	}
+
+	suites := map[string]*slack.SuiteSummary{}
+	for _, suite := range report.TestSuites {
+		for _, testCase := range suite.TestCases {
+			suiteSummary, ok := suites[testCase.Classname]
+			if !ok {
+				suiteSummary = &slack.SuiteSummary{Name: testCase.Classname}
+				suites[testCase.Classname] = suiteSummary
+			}
+
+			switch {
+			case testCase.Failure != nil || testCase.Error != nil:
+				suiteSummary.Failed++
+				failingSpec := slack.FailingSpec{Suite: testCase.Classname, Name: testCase.Name, Category: testCaseProperty(testCase, "FailureCategory")}
+				if testCase.Failure != nil {
+					failingSpec.Message = testCase.Failure.Message
+				} else {
+					failingSpec.Message = testCase.Error.Message
+				}
+				summary.FailingSpecs = append(summary.FailingSpecs, failingSpec)
+			case testCase.Skipped != nil:
+				suiteSummary.Skipped++
+			default:
+				suiteSummary.Passed++
+				if testCaseProperty(testCase, "Flaky") == "true" {
+					suiteSummary.Flaky++
+				}
+			}
+		}
+	}
+	for _, suiteSummary := range suites {
+		summary.Suites = append(summary.Suites, *suiteSummary)
+	}
+	return summary, nil
+}
+
+func testCaseProperty(testCase framework.CustomJUnitTestCase, name string) string {
+	if testCase.Properties == nil {
+		return ""
+	}
+	for _, property := range testCase.Properties.Properties {
+		if property.Name == name {
+			return property.Value
+		}
+	}
+	return ""
+}
+
+// prowArtifactsURL turns the URL of a Prow job (https://prow.ci.openshift.org/view/gs/<bucket>/<path>)
+// into the URL of its artifacts in the OpenShift CI GCS browser.
+func prowArtifactsURL(jobURL string) string {
+	_, path, found := strings.Cut(jobURL, "/view/gs/")
+	if !found {
+		return ""
+	}
+	return fmt.Sprintf("https://gcsweb-ci.apps.ci.l2s4.p1.openshiftapps.com/gcs/%s/artifacts/", strings.TrimSuffix(path, "/"))
+}
@@ -9,8 +9,13 @@ import (
 
 // Obtain the Openshift ingress specs
 func (s *SuiteController) GetOpenshiftIngress() (ingress *openshiftApi.Ingress, err error) {
+	return s.GetOpenshiftIngressWithContext(context.Background())
+}
+
+// GetOpenshiftIngressWithContext is like GetOpenshiftIngress but it stops as soon as the given context is cancelled.
+func (s *SuiteController) GetOpenshiftIngressWithContext(ctx context.Context) (ingress *openshiftApi.Ingress, err error) {
 	var ing = &openshiftApi.Ingress{}
-	if err := s.KubeRest().Get(context.Background(), types.NamespacedName{Name: "cluster"}, ing); err != nil {
+	if err := s.KubeRest().Get(ctx, types.NamespacedName{Name: "cluster"}, ing); err != nil {
 		return nil, err
 	}
 
@@ -10,23 +10,43 @@ import (
 
 // Create and return a configmap by cm name and namespace from the cluster
 func (s *SuiteController) CreateConfigMap(cm *corev1.ConfigMap, namespace string) (*corev1.ConfigMap, error) {
-	return s.KubeInterface().CoreV1().ConfigMaps(namespace).Create(context.Background(), cm, metav1.CreateOptions{})
+	return s.CreateConfigMapWithContext(context.Background(), cm, namespace)
+}
+
+// CreateConfigMapWithContext is like CreateConfigMap but it stops as soon as the given context is cancelled.
+func (s *SuiteController) CreateConfigMapWithContext(ctx context.Context, cm *corev1.ConfigMap, namespace string) (*corev1.ConfigMap, error) {
+	return s.KubeInterface().CoreV1().ConfigMaps(namespace).Create(ctx, cm, metav1.CreateOptions{})
 }
 
 // Update and return a configmap by configmap cm name and namespace from the cluster
 func (s *SuiteController) UpdateConfigMap(cm *corev1.ConfigMap, namespace string) (*corev1.ConfigMap, error) {
-	return s.KubeInterface().CoreV1().ConfigMaps(namespace).Update(context.Background(), cm, metav1.UpdateOptions{})
+	return s.UpdateConfigMapWithContext(context.Background(), cm, namespace)
+}
+
+// UpdateConfigMapWithContext is like UpdateConfigMap but it stops as soon as the given context is cancelled.
+func (s *SuiteController) UpdateConfigMapWithContext(ctx context.Context, cm *corev1.ConfigMap, namespace string) (*corev1.ConfigMap, error) {
+	return s.KubeInterface().CoreV1().ConfigMaps(namespace).Update(ctx, cm, metav1.UpdateOptions{})
 }
 
 // Get a configmap by name and namespace from the cluster
 func (s *SuiteController) GetConfigMap(name, namespace string) (*corev1.ConfigMap, error) {
-	return s.KubeInterface().CoreV1().ConfigMaps(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	return s.GetConfigMapWithContext(context.Background(), name, namespace)
+}
+
+// GetConfigMapWithContext is like GetConfigMap but it stops as soon as the given context is cancelled.
+func (s *SuiteController) GetConfigMapWithContext(ctx context.Context, name, namespace string) (*corev1.ConfigMap, error) {
+	return s.KubeInterface().CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
 // DeleteConfigMaps delete a ConfigMap. Optionally, it can avoid returning an error if the resource did not exist:
 // - specify 'false' if it's likely the ConfigMap has already been deleted (for example, because the Namespace was deleted)
 func (s *SuiteController) DeleteConfigMap(name, namespace string, returnErrorOnNotFound bool) error {
-	err := s.KubeInterface().CoreV1().ConfigMaps(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+	return s.DeleteConfigMapWithContext(context.Background(), name, namespace, returnErrorOnNotFound)
+}
+
+// DeleteConfigMapWithContext is like DeleteConfigMap but it stops as soon as the given context is cancelled.
+func (s *SuiteController) DeleteConfigMapWithContext(ctx context.Context, name, namespace string, returnErrorOnNotFound bool) error {
+	err := s.KubeInterface().CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil && k8sErrors.IsNotFound(err) && !returnErrorOnNotFound {
 		err = nil // Ignore not found errors, if requested
 	}
@@ -30,3 +30,8 @@ func NewSuiteController(kubeC *kubeCl.CustomClient) (*SuiteController, error) {
 		gh,
 	}, nil
 }
+
+// GithubClient returns the client used to interact with GitHub APIs.
+func (s *SuiteController) GithubClient() *github.Github {
+	return s.Github
+}
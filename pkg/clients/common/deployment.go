@@ -11,13 +11,18 @@ import (
 
 // GetAppDeploymentByName returns the deployment for a given component name
 func (h *SuiteController) GetDeployment(deploymentName string, namespace string) (*appsv1.Deployment, error) {
+	return h.GetDeploymentWithContext(context.Background(), deploymentName, namespace)
+}
+
+// GetDeploymentWithContext is like GetDeployment but it stops as soon as the given context is cancelled.
+func (h *SuiteController) GetDeploymentWithContext(ctx context.Context, deploymentName string, namespace string) (*appsv1.Deployment, error) {
 	namespacedName := types.NamespacedName{
 		Name:      deploymentName,
 		Namespace: namespace,
 	}
 
 	deployment := &appsv1.Deployment{}
-	err := h.KubeRest().Get(context.Background(), namespacedName, deployment)
+	err := h.KubeRest().Get(ctx, namespacedName, deployment)
 	if err != nil {
 		return &appsv1.Deployment{}, err
 	}
@@ -26,6 +31,11 @@ func (h *SuiteController) GetDeployment(deploymentName string, namespace string)
 
 // Checks and waits for a kubernetes deployment object to be completed or not
 func (h *SuiteController) DeploymentIsCompleted(deploymentName, namespace string, readyReplicas int32) wait.ConditionFunc {
+	return h.DeploymentIsCompletedWithContext(context.Background(), deploymentName, namespace, readyReplicas)
+}
+
+// DeploymentIsCompletedWithContext is like DeploymentIsCompleted but it stops as soon as the given context is cancelled.
+func (h *SuiteController) DeploymentIsCompletedWithContext(ctx context.Context, deploymentName, namespace string, readyReplicas int32) wait.ConditionFunc {
 	return func() (bool, error) {
 		namespacedName := types.NamespacedName{
 			Name:      deploymentName,
@@ -33,7 +43,7 @@ func (h *SuiteController) DeploymentIsCompleted(deploymentName, namespace string
 		}
 
 		deployment := &appsv1.Deployment{}
-		err := h.KubeRest().Get(context.Background(), namespacedName, deployment)
+		err := h.KubeRest().Get(ctx, namespacedName, deployment)
 		if err != nil && !k8sErrors.IsNotFound(err) {
 			return false, err
 		}
@@ -0,0 +1,210 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	toolchainApi "github.com/codeready-toolchain/api/api/v1alpha1"
+	openshiftApi "github.com/openshift/api/config/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	appstudioApi "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	"github.com/redhat-appstudio/e2e-tests/pkg/clients/github"
+	kubeCl "github.com/redhat-appstudio/e2e-tests/pkg/clients/kubernetes"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ClusterClient provides information about the cluster.
+type ClusterClient interface {
+	GetOpenshiftIngress() (ingress *openshiftApi.Ingress, err error)
+	GetOpenshiftIngressWithContext(ctx context.Context) (ingress *openshiftApi.Ingress, err error)
+}
+
+// ConfigMapClient operates with ConfigMaps.
+type ConfigMapClient interface {
+	CreateConfigMap(cm *corev1.ConfigMap, namespace string) (*corev1.ConfigMap, error)
+	CreateConfigMapWithContext(ctx context.Context, cm *corev1.ConfigMap, namespace string) (*corev1.ConfigMap, error)
+	UpdateConfigMap(cm *corev1.ConfigMap, namespace string) (*corev1.ConfigMap, error)
+	UpdateConfigMapWithContext(ctx context.Context, cm *corev1.ConfigMap, namespace string) (*corev1.ConfigMap, error)
+	GetConfigMap(name, namespace string) (*corev1.ConfigMap, error)
+	GetConfigMapWithContext(ctx context.Context, name, namespace string) (*corev1.ConfigMap, error)
+	DeleteConfigMap(name, namespace string, returnErrorOnNotFound bool) error
+	DeleteConfigMapWithContext(ctx context.Context, name, namespace string, returnErrorOnNotFound bool) error
+}
+
+// DeploymentClient operates with Deployments.
+type DeploymentClient interface {
+	GetDeployment(deploymentName string, namespace string) (*appsv1.Deployment, error)
+	GetDeploymentWithContext(ctx context.Context, deploymentName string, namespace string) (*appsv1.Deployment, error)
+	DeploymentIsCompleted(deploymentName, namespace string, readyReplicas int32) wait.ConditionFunc
+	DeploymentIsCompletedWithContext(ctx context.Context, deploymentName, namespace string, readyReplicas int32) wait.ConditionFunc
+}
+
+// NamespaceClient operates with Namespaces.
+type NamespaceClient interface {
+	DeleteNamespace(namespace string) error
+	DeleteNamespaceWithContext(ctx context.Context, namespace string) error
+	ListNamespaceScopedResourcesAsString(namespace string, k8sInterface kubernetes.Interface, dynamicInterface dynamic.Interface) string
+	ListNamespaceScopedResourcesAsStringWithContext(ctx context.Context, namespace string, k8sInterface kubernetes.Interface, dynamicInterface dynamic.Interface) string
+	CreateTestNamespace(name string) (*corev1.Namespace, error)
+	CreateTestNamespaceWithContext(ctx context.Context, name string) (*corev1.Namespace, error)
+	GetNamespace(namespace string) (*corev1.Namespace, error)
+	GetNamespaceWithContext(ctx context.Context, namespace string) (*corev1.Namespace, error)
+}
+
+// PodClient operates with Pods.
+type PodClient interface {
+	GetPod(namespace, podName string) (*corev1.Pod, error)
+	GetPodWithContext(ctx context.Context, namespace, podName string) (*corev1.Pod, error)
+	IsPodRunning(podName, namespace string) wait.ConditionFunc
+	IsPodRunningWithContext(ctx context.Context, podName, namespace string) wait.ConditionFunc
+	IsPodSuccessful(podName, namespace string) wait.ConditionFunc
+	IsPodSuccessfulWithContext(ctx context.Context, podName, namespace string) wait.ConditionFunc
+	ListPods(namespace, labelKey, labelValue string, selectionLimit int64) (*corev1.PodList, error)
+	ListPodsWithContext(ctx context.Context, namespace, labelKey, labelValue string, selectionLimit int64) (*corev1.PodList, error)
+	WaitForPodSelector(fn func(podName, namespace string) wait.ConditionFunc, namespace, labelKey string, labelValue string, timeout int, selectionLimit int64) error
+	WaitForPodSelectorWithContext(ctx context.Context, fn func(podName, namespace string) wait.ConditionFunc, namespace, labelKey string, labelValue string, timeout int, selectionLimit int64) error
+	ListAllPods(namespace string) (*corev1.PodList, error)
+	ListAllPodsWithContext(ctx context.Context, namespace string) (*corev1.PodList, error)
+	GetPodLogs(pod *corev1.Pod) map[string][]byte
+	StorePod(pod *corev1.Pod) error
+	StoreAllPods(namespace string) error
+	StoreAllPodsWithContext(ctx context.Context, namespace string) error
+}
+
+// ProxyPluginClient operates with toolchain ProxyPlugins.
+type ProxyPluginClient interface {
+	CreateProxyPlugin(proxyPluginName, proxyPluginNamespace, routeName, routeNamespace string) (*toolchainApi.ProxyPlugin, error)
+	CreateProxyPluginWithContext(ctx context.Context, proxyPluginName, proxyPluginNamespace, routeName, routeNamespace string) (*toolchainApi.ProxyPlugin, error)
+	DeleteProxyPlugin(proxyPluginName, proxyPluginNamespace string) (bool, error)
+	DeleteProxyPluginWithContext(ctx context.Context, proxyPluginName, proxyPluginNamespace string) (bool, error)
+}
+
+// RBACClient operates with Roles and RoleBindings.
+type RBACClient interface {
+	ListRoles(namespace string) (*rbacv1.RoleList, error)
+	ListRolesWithContext(ctx context.Context, namespace string) (*rbacv1.RoleList, error)
+	ListRoleBindings(namespace string) (*rbacv1.RoleBindingList, error)
+	ListRoleBindingsWithContext(ctx context.Context, namespace string) (*rbacv1.RoleBindingList, error)
+	GetRole(roleName, namespace string) (*rbacv1.Role, error)
+	GetRoleWithContext(ctx context.Context, roleName, namespace string) (*rbacv1.Role, error)
+	GetRoleBinding(rolebindingName, namespace string) (*rbacv1.RoleBinding, error)
+	GetRoleBindingWithContext(ctx context.Context, rolebindingName, namespace string) (*rbacv1.RoleBinding, error)
+	CreateRole(roleName, namespace string, roleRules map[string][]string) (*rbacv1.Role, error)
+	CreateRoleWithContext(ctx context.Context, roleName, namespace string, roleRules map[string][]string) (*rbacv1.Role, error)
+	CreateRoleBinding(roleBindingName, namespace, subjectKind, serviceAccountName, serviceAccountNamespace, roleRefKind, roleRefName, roleRefApiGroup string) (*rbacv1.RoleBinding, error)
+	CreateRoleBindingWithContext(ctx context.Context, roleBindingName, namespace, subjectKind, serviceAccountName, serviceAccountNamespace, roleRefKind, roleRefName, roleRefApiGroup string) (*rbacv1.RoleBinding, error)
+}
+
+// ResourceQuotaClient operates with ResourceQuotas.
+type ResourceQuotaClient interface {
+	GetResourceQuota(namespace, ResourceQuotaName string) (*corev1.ResourceQuota, error)
+	GetResourceQuotaWithContext(ctx context.Context, namespace, ResourceQuotaName string) (*corev1.ResourceQuota, error)
+	GetResourceQuotaInfo(test, namespace, resourceQuotaName string) error
+	GetResourceQuotaInfoWithContext(ctx context.Context, test, namespace, resourceQuotaName string) error
+}
+
+// RouteClient operates with OpenShift Routes.
+type RouteClient interface {
+	GetOpenshiftRoute(routeName string, routeNamespace string) (*routev1.Route, error)
+	GetOpenshiftRouteWithContext(ctx context.Context, routeName string, routeNamespace string) (*routev1.Route, error)
+	GetOpenshiftRouteByComponentName(componentName string, componentNamespace string) (*routev1.Route, error)
+	GetOpenshiftRouteByComponentNameWithContext(ctx context.Context, componentName string, componentNamespace string) (*routev1.Route, error)
+	RouteHostnameIsAccessible(routeName string, namespace string) wait.ConditionFunc
+	RouteHostnameIsAccessibleWithContext(ctx context.Context, routeName string, namespace string) wait.ConditionFunc
+	RouteEndpointIsAccessible(route *routev1.Route, endpoint string) error
+}
+
+// SecretClient operates with Secrets.
+type SecretClient interface {
+	CreateSecret(ns string, secret *corev1.Secret) (*corev1.Secret, error)
+	CreateSecretWithContext(ctx context.Context, ns string, secret *corev1.Secret) (*corev1.Secret, error)
+	GetSecret(ns string, name string) (*corev1.Secret, error)
+	GetSecretWithContext(ctx context.Context, ns string, name string) (*corev1.Secret, error)
+	DeleteSecret(ns string, name string) error
+	DeleteSecretWithContext(ctx context.Context, ns string, name string) error
+	LinkSecretToServiceAccount(ns, secret, serviceaccount string, addImagePullSecrets bool) error
+	LinkSecretToServiceAccountWithContext(ctx context.Context, ns, secret, serviceaccount string, addImagePullSecrets bool) error
+	UnlinkSecretFromServiceAccount(namespace, secretName, serviceAccount string, rmImagePullSecrets bool) error
+	UnlinkSecretFromServiceAccountWithContext(ctx context.Context, namespace, secretName, serviceAccount string, rmImagePullSecrets bool) error
+	CreateRegistryAuthSecret(secretName, namespace, secretStringData string) (*corev1.Secret, error)
+	CreateRegistryAuthSecretWithContext(ctx context.Context, secretName, namespace, secretStringData string) (*corev1.Secret, error)
+	CreateRegistryJsonSecret(name, namespace, authKey, keyName string) (*corev1.Secret, error)
+	CreateRegistryJsonSecretWithContext(ctx context.Context, name, namespace, authKey, keyName string) (*corev1.Secret, error)
+	AddRegistryAuthSecretToSA(registryAuth, namespace string) error
+	AddRegistryAuthSecretToSAWithContext(ctx context.Context, registryAuth, namespace string) error
+}
+
+// ServiceClient operates with Services.
+type ServiceClient interface {
+	GetServiceByName(serviceName string, serviceNamespace string) (*corev1.Service, error)
+	GetServiceByNameWithContext(ctx context.Context, serviceName string, serviceNamespace string) (*corev1.Service, error)
+}
+
+// ServiceAccountClient operates with ServiceAccounts.
+type ServiceAccountClient interface {
+	GetServiceAccount(saName, namespace string) (*corev1.ServiceAccount, error)
+	GetServiceAccountWithContext(ctx context.Context, saName, namespace string) (*corev1.ServiceAccount, error)
+	ServiceAccountPresent(saName, namespace string) wait.ConditionFunc
+	ServiceAccountPresentWithContext(ctx context.Context, saName, namespace string) wait.ConditionFunc
+	CreateServiceAccount(name, namespace string, serviceAccountSecretList []corev1.ObjectReference, labels map[string]string) (*corev1.ServiceAccount, error)
+	CreateServiceAccountWithContext(ctx context.Context, name, namespace string, serviceAccountSecretList []corev1.ObjectReference, labels map[string]string) (*corev1.ServiceAccount, error)
+	DeleteAllServiceAccountsInASpecificNamespace(namespace string) error
+	DeleteAllServiceAccountsInASpecificNamespaceWithContext(ctx context.Context, namespace string) error
+}
+
+// SnapshotEnvironmentBindingClient operates with SnapshotEnvironmentBindings.
+type SnapshotEnvironmentBindingClient interface {
+	GetSnapshotEnvironmentBinding(applicationName string, namespace string, environment *appstudioApi.Environment) (*appstudioApi.SnapshotEnvironmentBinding, error)
+	GetSnapshotEnvironmentBindingWithContext(ctx context.Context, applicationName string, namespace string, environment *appstudioApi.Environment) (*appstudioApi.SnapshotEnvironmentBinding, error)
+	DeleteAllSnapshotEnvBindingsInASpecificNamespace(namespace string, timeout time.Duration) error
+	DeleteAllSnapshotEnvBindingsInASpecificNamespaceWithContext(ctx context.Context, namespace string, timeout time.Duration) error
+	ListAllSnapshotEnvBindings(namespace string) (*appstudioApi.SnapshotEnvironmentBindingList, error)
+	ListAllSnapshotEnvBindingsWithContext(ctx context.Context, namespace string) (*appstudioApi.SnapshotEnvironmentBindingList, error)
+	StoreSnapshotEnvBinding(snapshotEnvBinding *appstudioApi.SnapshotEnvironmentBinding) error
+	StoreAllSnapshotEnvironmentBindings(namespace string) error
+	StoreAllSnapshotEnvironmentBindingsWithContext(ctx context.Context, namespace string) error
+}
+
+// SpaceBindingClient operates with toolchain SpaceBindings.
+type SpaceBindingClient interface {
+	CreateSpaceBinding(murName, spaceName, spaceRole string) (*toolchainApi.SpaceBinding, error)
+	CreateSpaceBindingWithContext(ctx context.Context, murName, spaceName, spaceRole string) (*toolchainApi.SpaceBinding, error)
+	CheckWorkspaceShare(user, namespace string) error
+	CheckWorkspaceShareWithContext(ctx context.Context, user, namespace string) error
+}
+
+// TestStatusClient reports the status of the e2e tests.
+type TestStatusClient interface {
+	HaveTestsSucceeded(snapshot *appstudioApi.Snapshot) bool
+	HaveTestsFinished(snapshot *appstudioApi.Snapshot) bool
+	MarkTestsSucceeded(snapshot *appstudioApi.Snapshot) (*appstudioApi.Snapshot, error)
+	MarkTestsSucceededWithContext(ctx context.Context, snapshot *appstudioApi.Snapshot) (*appstudioApi.Snapshot, error)
+}
+
+// Interface groups all the operations available for the non RHTAP/AppStudio Kubernetes APIs. It is implemented by SuiteController.
+type Interface interface {
+	kubeCl.Interface
+	ClusterClient
+	ConfigMapClient
+	DeploymentClient
+	NamespaceClient
+	PodClient
+	ProxyPluginClient
+	RBACClient
+	ResourceQuotaClient
+	RouteClient
+	SecretClient
+	ServiceClient
+	ServiceAccountClient
+	SnapshotEnvironmentBindingClient
+	SpaceBindingClient
+	TestStatusClient
+	GithubClient() *github.Github
+}
+
+var _ Interface = &SuiteController{}
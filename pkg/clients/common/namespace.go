@@ -19,23 +19,29 @@ import (
 
 // DeleteNamespace deletes the give namespace.
 func (s *SuiteController) DeleteNamespace(namespace string) error {
-	_, err := s.KubeInterface().CoreV1().Namespaces().Get(context.Background(), namespace, metav1.GetOptions{})
+	return s.DeleteNamespaceWithContext(context.Background(), namespace)
+}
+
+// DeleteNamespaceWithContext is like DeleteNamespace but it stops as soon as the given context is cancelled.
+func (s *SuiteController) DeleteNamespaceWithContext(ctx context.Context, namespace string) error {
+	_, err := s.KubeInterface().CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return fmt.Errorf("could not check for namespace '%s' existence: %v", namespace, err)
 	}
 
-	if err := s.KubeInterface().CoreV1().Namespaces().Delete(context.Background(), namespace, metav1.DeleteOptions{}); err != nil {
+	if err := s.KubeInterface().CoreV1().Namespaces().Delete(ctx, namespace, metav1.DeleteOptions{}); err != nil {
 		return fmt.Errorf("unable to delete namespace '%s': %v", namespace, err)
 	}
 
 	// Wait for the namespace to no longer exist. The namespace may remain stuck in 'Terminating' state
 	// if it contains with finalizers that are not handled. We detect this case here, and report any resources still
 	// in the Namespace.
-	if err := utils.WaitUntil(s.namespaceDoesNotExist(namespace), time.Minute*10); err != nil {
+	target := utils.WaitTarget{Description: fmt.Sprintf("namespace %s to be deleted", namespace)}
+	if err := utils.WaitUntilTargetWithContext(ctx, target, s.namespaceDoesNotExist(ctx, namespace), time.Second, time.Minute*10); err != nil {
 
 		// On failure to delete, list all namespace-scoped resources still in the namespace.
-		resourcesInNamespace := s.ListNamespaceScopedResourcesAsString(namespace, s.KubeInterface(), s.DynamicClient())
+		resourcesInNamespace := s.ListNamespaceScopedResourcesAsStringWithContext(ctx, namespace, s.KubeInterface(), s.DynamicClient())
 
 		return fmt.Errorf("namespace was not deleted in expected timeframe: '%s': %v. Remaining resources in namespace: %s", namespace, err, resourcesInNamespace)
 	}
@@ -45,6 +51,11 @@ func (s *SuiteController) DeleteNamespace(namespace string) error {
 
 // ListNamespaceScopedResourcesAsString returns a list of resources in a namespace as a string, for test debugging purposes.
 func (s *SuiteController) ListNamespaceScopedResourcesAsString(namespace string, k8sInterface kubernetes.Interface, dynamicInterface dynamic.Interface) string {
+	return s.ListNamespaceScopedResourcesAsStringWithContext(context.Background(), namespace, k8sInterface, dynamicInterface)
+}
+
+// ListNamespaceScopedResourcesAsStringWithContext is like ListNamespaceScopedResourcesAsString but it stops as soon as the given context is cancelled.
+func (s *SuiteController) ListNamespaceScopedResourcesAsStringWithContext(ctx context.Context, namespace string, k8sInterface kubernetes.Interface, dynamicInterface dynamic.Interface) string {
 	crdList, err := k8sInterface.Discovery().ServerPreferredNamespacedResources()
 	if err != nil {
 		// Ignore errors: this function is for diagnostic purposes only.
@@ -89,7 +100,7 @@ func (s *SuiteController) ListNamespaceScopedResourcesAsString(namespace string,
 				Resource: apiResource.Name,
 			}
 
-			unstructuredList, err := dynamicInterface.Resource(gvr).Namespace(namespace).List(context.Background(), metav1.ListOptions{})
+			unstructuredList, err := dynamicInterface.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
 			if err != nil {
 				// Ignore errors: this function is for diagnostic purposes only.
 				continue
@@ -111,8 +122,13 @@ func (s *SuiteController) ListNamespaceScopedResourcesAsString(namespace string,
 
 // CreateTestNamespace creates a namespace where Application and Component CR will be created
 func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, error) {
+	return s.CreateTestNamespaceWithContext(context.Background(), name)
+}
+
+// CreateTestNamespaceWithContext is like CreateTestNamespace but it stops as soon as the given context is cancelled.
+func (s *SuiteController) CreateTestNamespaceWithContext(ctx context.Context, name string) (*corev1.Namespace, error) {
 	// Check if the E2E test namespace already exists
-	ns, err := s.KubeInterface().CoreV1().Namespaces().Get(context.Background(), name, metav1.GetOptions{})
+	ns, err := s.KubeInterface().CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
 
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
@@ -122,7 +138,7 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 					Name:   name,
 					Labels: map[string]string{constants.ArgoCDLabelKey: constants.ArgoCDLabelValue},
 				}}
-			ns, err = s.KubeInterface().CoreV1().Namespaces().Create(context.Background(), &nsTemplate, metav1.CreateOptions{})
+			ns, err = s.KubeInterface().CoreV1().Namespaces().Create(ctx, &nsTemplate, metav1.CreateOptions{})
 			if err != nil {
 				return nil, fmt.Errorf("error when creating %s namespace: %v", name, err)
 			}
@@ -136,14 +152,14 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 		}
 		// Update test namespace labels in case they are missing argoCD label
 		ns.Labels[constants.ArgoCDLabelKey] = constants.ArgoCDLabelValue
-		ns, err = s.KubeInterface().CoreV1().Namespaces().Update(context.Background(), ns, metav1.UpdateOptions{})
+		ns, err = s.KubeInterface().CoreV1().Namespaces().Update(ctx, ns, metav1.UpdateOptions{})
 		if err != nil {
 			return nil, fmt.Errorf("error when updating labels in '%s' namespace: %v", name, err)
 		}
 	}
 
 	// Create ServiceAccount which is used by Pipelines but created by Toolchain host operator
-	_, err = s.KubeInterface().CoreV1().ServiceAccounts(name).Get(context.Background(), constants.DefaultPipelineServiceAccount, metav1.GetOptions{})
+	_, err = s.KubeInterface().CoreV1().ServiceAccounts(name).Get(ctx, constants.DefaultPipelineServiceAccount, metav1.GetOptions{})
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			saTemplate := corev1.ServiceAccount{
@@ -151,7 +167,7 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 					Name: constants.DefaultPipelineServiceAccount,
 				},
 			}
-			_, err = s.KubeInterface().CoreV1().ServiceAccounts(name).Create(context.Background(), &saTemplate, metav1.CreateOptions{})
+			_, err = s.KubeInterface().CoreV1().ServiceAccounts(name).Create(ctx, &saTemplate, metav1.CreateOptions{})
 			if err != nil {
 				return nil, fmt.Errorf("error when creating %s serviceaccount: %v", constants.DefaultPipelineServiceAccount, err)
 			}
@@ -160,7 +176,7 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 		}
 	}
 
-	_, err = s.KubeInterface().RbacV1().RoleBindings(name).Get(context.Background(), constants.DefaultPipelineServiceAccountRoleBinding, metav1.GetOptions{})
+	_, err = s.KubeInterface().RbacV1().RoleBindings(name).Get(ctx, constants.DefaultPipelineServiceAccountRoleBinding, metav1.GetOptions{})
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			roleBindingTemplate := rbacv1.RoleBinding{
@@ -178,7 +194,7 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 					Name: constants.DefaultPipelineServiceAccountClusterRole,
 				},
 			}
-			_, err = s.KubeInterface().RbacV1().RoleBindings(name).Create(context.Background(), &roleBindingTemplate, metav1.CreateOptions{})
+			_, err = s.KubeInterface().RbacV1().RoleBindings(name).Create(ctx, &roleBindingTemplate, metav1.CreateOptions{})
 			if err != nil {
 				return nil, fmt.Errorf("error when creating %s roleBinding: %v", constants.DefaultPipelineServiceAccountRoleBinding, err)
 			}
@@ -189,7 +205,8 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 
 	// Argo CD role/rolebinding need to be present in the namespace before we create GitOpsDeployments.
 	// - These role bindings are created in namespaces labeled with 'argocd.argoproj.io/managed-by' (see above)
-	if err := utils.WaitUntil(s.argoCDNamespaceRBACPresent(name), time.Second*120); err != nil {
+	target := utils.WaitTarget{Description: fmt.Sprintf("argo CD role and roleBinding in %s namespace", name)}
+	if err := utils.WaitUntilTargetWithContext(ctx, target, s.argoCDNamespaceRBACPresent(ctx, name), time.Second, time.Second*120); err != nil {
 		return nil, fmt.Errorf("argo CD Namespace RBAC was never present in '%s': %v", name, err)
 	}
 
@@ -197,10 +214,10 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 }
 
 // namespaceDoesNotExist returns a condition that can be used to wait for the namespace to not exist
-func (s *SuiteController) namespaceDoesNotExist(namespace string) wait.ConditionFunc {
+func (s *SuiteController) namespaceDoesNotExist(ctx context.Context, namespace string) wait.ConditionFunc {
 	return func() (bool, error) {
 
-		_, err := s.KubeInterface().CoreV1().Namespaces().Get(context.Background(), namespace, metav1.GetOptions{})
+		_, err := s.KubeInterface().CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
 
 		return err != nil && k8sErrors.IsNotFound(err), nil
 	}
@@ -208,5 +225,10 @@ func (s *SuiteController) namespaceDoesNotExist(namespace string) wait.Condition
 
 // GetNamespace returns the requested Namespace object
 func (s *SuiteController) GetNamespace(namespace string) (*corev1.Namespace, error) {
-	return s.KubeInterface().CoreV1().Namespaces().Get(context.Background(), namespace, metav1.GetOptions{})
+	return s.GetNamespaceWithContext(context.Background(), namespace)
+}
+
+// GetNamespaceWithContext is like GetNamespace but it stops as soon as the given context is cancelled.
+func (s *SuiteController) GetNamespaceWithContext(ctx context.Context, namespace string) (*corev1.Namespace, error) {
+	return s.KubeInterface().CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
 }
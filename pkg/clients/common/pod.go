@@ -16,12 +16,22 @@ import (
 
 // GetPod returns the pod object from a given namespace and pod name
 func (s *SuiteController) GetPod(namespace, podName string) (*corev1.Pod, error) {
-	return s.KubeInterface().CoreV1().Pods(namespace).Get(context.Background(), podName, metav1.GetOptions{})
+	return s.GetPodWithContext(context.Background(), namespace, podName)
+}
+
+// GetPodWithContext is like GetPod but it stops as soon as the given context is cancelled.
+func (s *SuiteController) GetPodWithContext(ctx context.Context, namespace, podName string) (*corev1.Pod, error) {
+	return s.KubeInterface().CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
 }
 
 func (s *SuiteController) IsPodRunning(podName, namespace string) wait.ConditionFunc {
+	return s.IsPodRunningWithContext(context.Background(), podName, namespace)
+}
+
+// IsPodRunningWithContext is like IsPodRunning but it stops as soon as the given context is cancelled.
+func (s *SuiteController) IsPodRunningWithContext(ctx context.Context, podName, namespace string) wait.ConditionFunc {
 	return func() (bool, error) {
-		pod, err := s.GetPod(namespace, podName)
+		pod, err := s.GetPodWithContext(ctx, namespace, podName)
 		if err != nil {
 			return false, nil
 		}
@@ -37,8 +47,13 @@ func (s *SuiteController) IsPodRunning(podName, namespace string) wait.Condition
 
 // Checks phases of a given pod name in a given namespace
 func (s *SuiteController) IsPodSuccessful(podName, namespace string) wait.ConditionFunc {
+	return s.IsPodSuccessfulWithContext(context.Background(), podName, namespace)
+}
+
+// IsPodSuccessfulWithContext is like IsPodSuccessful but it stops as soon as the given context is cancelled.
+func (s *SuiteController) IsPodSuccessfulWithContext(ctx context.Context, podName, namespace string) wait.ConditionFunc {
 	return func() (bool, error) {
-		pod, err := s.GetPod(namespace, podName)
+		pod, err := s.GetPodWithContext(ctx, namespace, podName)
 		if err != nil {
 			return false, nil
 		}
@@ -54,19 +69,31 @@ func (s *SuiteController) IsPodSuccessful(podName, namespace string) wait.Condit
 
 // ListPods return a list of pods from a namespace by labels and selection limits
 func (s *SuiteController) ListPods(namespace, labelKey, labelValue string, selectionLimit int64) (*corev1.PodList, error) {
+	return s.ListPodsWithContext(context.Background(), namespace, labelKey, labelValue, selectionLimit)
+}
+
+// ListPodsWithContext is like ListPods but it stops as soon as the given context is cancelled.
+func (s *SuiteController) ListPodsWithContext(ctx context.Context, namespace, labelKey, labelValue string, selectionLimit int64) (*corev1.PodList, error) {
 	labelSelector := metav1.LabelSelector{MatchLabels: map[string]string{labelKey: labelValue}}
 	listOptions := metav1.ListOptions{
 		LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
 		Limit:         selectionLimit,
 	}
-	return s.KubeInterface().CoreV1().Pods(namespace).List(context.Background(), listOptions)
+	return s.KubeInterface().CoreV1().Pods(namespace).List(ctx, listOptions)
 }
 
 // Wait for a pod selector until exists
 func (s *SuiteController) WaitForPodSelector(
 	fn func(podName, namespace string) wait.ConditionFunc, namespace, labelKey string, labelValue string,
 	timeout int, selectionLimit int64) error {
-	podList, err := s.ListPods(namespace, labelKey, labelValue, selectionLimit)
+	return s.WaitForPodSelectorWithContext(context.Background(), fn, namespace, labelKey, labelValue, timeout, selectionLimit)
+}
+
+// WaitForPodSelectorWithContext is like WaitForPodSelector but it stops as soon as the given context is cancelled.
+func (s *SuiteController) WaitForPodSelectorWithContext(ctx context.Context,
+	fn func(podName, namespace string) wait.ConditionFunc, namespace, labelKey string, labelValue string,
+	timeout int, selectionLimit int64) error {
+	podList, err := s.ListPodsWithContext(ctx, namespace, labelKey, labelValue, selectionLimit)
 	if err != nil {
 		return err
 	}
@@ -75,7 +102,8 @@ func (s *SuiteController) WaitForPodSelector(
 	}
 
 	for i := range podList.Items {
-		if err := utils.WaitUntil(fn(podList.Items[i].Name, namespace), time.Duration(timeout)*time.Second); err != nil {
+		target := utils.WaitTarget{Description: fmt.Sprintf("pod %s/%s", namespace, podList.Items[i].Name)}
+		if err := utils.WaitUntilTargetWithContext(ctx, target, fn(podList.Items[i].Name, namespace), time.Second, time.Duration(timeout)*time.Second); err != nil {
 			return err
 		}
 	}
@@ -84,7 +112,12 @@ func (s *SuiteController) WaitForPodSelector(
 
 // ListAllPods returns a list of all pods in a namespace.
 func (s *SuiteController) ListAllPods(namespace string) (*corev1.PodList, error) {
-	return s.KubeInterface().CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{})
+	return s.ListAllPodsWithContext(context.Background(), namespace)
+}
+
+// ListAllPodsWithContext is like ListAllPods but it stops as soon as the given context is cancelled.
+func (s *SuiteController) ListAllPodsWithContext(ctx context.Context, namespace string) (*corev1.PodList, error) {
+	return s.KubeInterface().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
 }
 
 func (s *SuiteController) GetPodLogs(pod *corev1.Pod) map[string][]byte {
@@ -114,7 +147,12 @@ func (s *SuiteController) StorePod(pod *corev1.Pod) error {
 
 // StoreAllPods stores all pods in a given namespace.
 func (s *SuiteController) StoreAllPods(namespace string) error {
-	podList, err := s.ListAllPods(namespace)
+	return s.StoreAllPodsWithContext(context.Background(), namespace)
+}
+
+// StoreAllPodsWithContext is like StoreAllPods but it stops as soon as the given context is cancelled.
+func (s *SuiteController) StoreAllPodsWithContext(ctx context.Context, namespace string) error {
+	podList, err := s.ListAllPodsWithContext(ctx, namespace)
 	if err != nil {
 		return err
 	}
@@ -11,15 +11,21 @@ import (
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 )
 
 // CreateProxyPlugin creates an object of ProxyPlugin for the OpenShift route target
 func (s *SuiteController) CreateProxyPlugin(proxyPluginName, proxyPluginNamespace, routeName, routeNamespace string) (*toolchainv1alpha1.ProxyPlugin, error) {
+	return s.CreateProxyPluginWithContext(context.Background(), proxyPluginName, proxyPluginNamespace, routeName, routeNamespace)
+}
+
+// CreateProxyPluginWithContext is like CreateProxyPlugin but it stops as soon as the given context is cancelled.
+func (s *SuiteController) CreateProxyPluginWithContext(ctx context.Context, proxyPluginName, proxyPluginNamespace, routeName, routeNamespace string) (*toolchainv1alpha1.ProxyPlugin, error) {
 	// Create the ProxyPlugin object
 	proxyPlugin := common.NewProxyPlugin(proxyPluginName, proxyPluginNamespace, routeName, routeNamespace)
 
-	if err := s.KubeRest().Create(context.Background(), proxyPlugin); err != nil {
+	if err := s.KubeRest().Create(ctx, proxyPlugin); err != nil {
 		return nil, fmt.Errorf("unable to create proxy plugin due to %v", err)
 	}
 	return proxyPlugin, nil
@@ -27,6 +33,11 @@ func (s *SuiteController) CreateProxyPlugin(proxyPluginName, proxyPluginNamespac
 
 // DeleteProxyPlugin deletes the ProxyPlugin object
 func (s *SuiteController) DeleteProxyPlugin(proxyPluginName, proxyPluginNamespace string) (bool, error) {
+	return s.DeleteProxyPluginWithContext(context.Background(), proxyPluginName, proxyPluginNamespace)
+}
+
+// DeleteProxyPluginWithContext is like DeleteProxyPlugin but it stops as soon as the given context is cancelled.
+func (s *SuiteController) DeleteProxyPluginWithContext(ctx context.Context, proxyPluginName, proxyPluginNamespace string) (bool, error) {
 	proxyPlugin := &toolchainv1alpha1.ProxyPlugin{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      proxyPluginName,
@@ -34,11 +45,15 @@ func (s *SuiteController) DeleteProxyPlugin(proxyPluginName, proxyPluginNamespac
 		},
 	}
 
-	if err := s.KubeRest().Delete(context.Background(), proxyPlugin); err != nil {
+	if err := s.KubeRest().Delete(ctx, proxyPlugin); err != nil {
 		return false, err
 	}
-	err := utils.WaitUntil(func() (done bool, err error) {
-		err = s.KubeRest().Get(context.Background(), types.NamespacedName{
+	target := utils.WaitTarget{
+		Description:  fmt.Sprintf("proxyplugin %s/%s to be deleted", proxyPluginNamespace, proxyPluginName),
+		LastObserved: func() *unstructured.Unstructured { return utils.ObservedObject("ProxyPlugin", proxyPlugin) },
+	}
+	err := utils.WaitUntilTargetWithContext(ctx, target, func() (done bool, err error) {
+		err = s.KubeRest().Get(ctx, types.NamespacedName{
 			Namespace: proxyPluginNamespace,
 			Name:      proxyPluginName,
 		}, proxyPlugin)
@@ -50,7 +65,7 @@ func (s *SuiteController) DeleteProxyPlugin(proxyPluginName, proxyPluginNamespac
 			return false, fmt.Errorf("deletion of proxy plugin has been timedout:: %v", err)
 		}
 		return false, nil
-	}, 5*time.Minute)
+	}, time.Second, 5*time.Minute)
 
 	if err != nil {
 		return false, err
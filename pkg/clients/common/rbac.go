@@ -11,29 +11,49 @@ import (
 )
 
 func (s *SuiteController) ListRoles(namespace string) (*rbacv1.RoleList, error) {
+	return s.ListRolesWithContext(context.Background(), namespace)
+}
+
+// ListRolesWithContext is like ListRoles but it stops as soon as the given context is cancelled.
+func (s *SuiteController) ListRolesWithContext(ctx context.Context, namespace string) (*rbacv1.RoleList, error) {
 	listOptions := metav1.ListOptions{}
-	return s.KubeInterface().RbacV1().Roles(namespace).List(context.Background(), listOptions)
+	return s.KubeInterface().RbacV1().Roles(namespace).List(ctx, listOptions)
 }
 
 func (s *SuiteController) ListRoleBindings(namespace string) (*rbacv1.RoleBindingList, error) {
+	return s.ListRoleBindingsWithContext(context.Background(), namespace)
+}
+
+// ListRoleBindingsWithContext is like ListRoleBindings but it stops as soon as the given context is cancelled.
+func (s *SuiteController) ListRoleBindingsWithContext(ctx context.Context, namespace string) (*rbacv1.RoleBindingList, error) {
 	listOptions := metav1.ListOptions{}
-	return s.KubeInterface().RbacV1().RoleBindings(namespace).List(context.Background(), listOptions)
+	return s.KubeInterface().RbacV1().RoleBindings(namespace).List(ctx, listOptions)
 }
 
 func (s *SuiteController) GetRole(roleName, namespace string) (*rbacv1.Role, error) {
-	return s.KubeInterface().RbacV1().Roles(namespace).Get(context.Background(), roleName, metav1.GetOptions{})
+	return s.GetRoleWithContext(context.Background(), roleName, namespace)
+}
+
+// GetRoleWithContext is like GetRole but it stops as soon as the given context is cancelled.
+func (s *SuiteController) GetRoleWithContext(ctx context.Context, roleName, namespace string) (*rbacv1.Role, error) {
+	return s.KubeInterface().RbacV1().Roles(namespace).Get(ctx, roleName, metav1.GetOptions{})
 }
 
 func (s *SuiteController) GetRoleBinding(rolebindingName, namespace string) (*rbacv1.RoleBinding, error) {
-	return s.KubeInterface().RbacV1().RoleBindings(namespace).Get(context.Background(), rolebindingName, metav1.GetOptions{})
+	return s.GetRoleBindingWithContext(context.Background(), rolebindingName, namespace)
+}
+
+// GetRoleBindingWithContext is like GetRoleBinding but it stops as soon as the given context is cancelled.
+func (s *SuiteController) GetRoleBindingWithContext(ctx context.Context, rolebindingName, namespace string) (*rbacv1.RoleBinding, error) {
+	return s.KubeInterface().RbacV1().RoleBindings(namespace).Get(ctx, rolebindingName, metav1.GetOptions{})
 }
 
 // argoCDNamespaceRBACPresent returns a condition which waits for the Argo CD role/rolebindings to be set on the namespace.
 //   - This Role/RoleBinding allows Argo cd to deploy into the namespace (which is referred to as 'managing the namespace'), and
 //     is created by the GitOps Operator.
-func (s *SuiteController) argoCDNamespaceRBACPresent(namespace string) wait.ConditionFunc {
+func (s *SuiteController) argoCDNamespaceRBACPresent(ctx context.Context, namespace string) wait.ConditionFunc {
 	return func() (bool, error) {
-		roles, err := s.ListRoles(namespace)
+		roles, err := s.ListRolesWithContext(ctx, namespace)
 		if err != nil || roles == nil {
 			return false, nil
 		}
@@ -51,7 +71,7 @@ func (s *SuiteController) argoCDNamespaceRBACPresent(namespace string) wait.Cond
 
 		// The namespace should contain a 'gitops-service-argocd-' RoleBinding
 		roleBindingFound := false
-		roleBindings, err := s.ListRoleBindings(namespace)
+		roleBindings, err := s.ListRoleBindingsWithContext(ctx, namespace)
 		if err != nil || roleBindings == nil {
 			return false, nil
 		}
@@ -67,6 +87,11 @@ func (s *SuiteController) argoCDNamespaceRBACPresent(namespace string) wait.Cond
 
 // CreateRole creates a role with the provided name and namespace using the given list of rules
 func (s *SuiteController) CreateRole(roleName, namespace string, roleRules map[string][]string) (*rbacv1.Role, error) {
+	return s.CreateRoleWithContext(context.Background(), roleName, namespace, roleRules)
+}
+
+// CreateRoleWithContext is like CreateRole but it stops as soon as the given context is cancelled.
+func (s *SuiteController) CreateRoleWithContext(ctx context.Context, roleName, namespace string, roleRules map[string][]string) (*rbacv1.Role, error) {
 	rules := &rbacv1.PolicyRule{
 		APIGroups: roleRules["apiGroupsList"],
 		Resources: roleRules["roleResources"],
@@ -81,7 +106,7 @@ func (s *SuiteController) CreateRole(roleName, namespace string, roleRules map[s
 			*rules,
 		},
 	}
-	createdRole, err := s.KubeInterface().RbacV1().Roles(namespace).Create(context.Background(), role, metav1.CreateOptions{})
+	createdRole, err := s.KubeInterface().RbacV1().Roles(namespace).Create(ctx, role, metav1.CreateOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +115,11 @@ func (s *SuiteController) CreateRole(roleName, namespace string, roleRules map[s
 
 // CreateRoleBinding creates an object of Role Binding in namespace with service account provided and role reference api group.
 func (s *SuiteController) CreateRoleBinding(roleBindingName, namespace, subjectKind, serviceAccountName, serviceAccountNamespace, roleRefKind, roleRefName, roleRefApiGroup string) (*rbacv1.RoleBinding, error) {
+	return s.CreateRoleBindingWithContext(context.Background(), roleBindingName, namespace, subjectKind, serviceAccountName, serviceAccountNamespace, roleRefKind, roleRefName, roleRefApiGroup)
+}
+
+// CreateRoleBindingWithContext is like CreateRoleBinding but it stops as soon as the given context is cancelled.
+func (s *SuiteController) CreateRoleBindingWithContext(ctx context.Context, roleBindingName, namespace, subjectKind, serviceAccountName, serviceAccountNamespace, roleRefKind, roleRefName, roleRefApiGroup string) (*rbacv1.RoleBinding, error) {
 	roleBindingSubjects := []rbacv1.Subject{
 		{
 			Kind:      subjectKind,
@@ -113,7 +143,7 @@ func (s *SuiteController) CreateRoleBinding(roleBindingName, namespace, subjectK
 		RoleRef:  roleBindingRoleRef,
 	}
 
-	createdRoleBinding, err := s.KubeInterface().RbacV1().RoleBindings(namespace).Create(context.Background(), roleBinding, metav1.CreateOptions{})
+	createdRoleBinding, err := s.KubeInterface().RbacV1().RoleBindings(namespace).Create(ctx, roleBinding, metav1.CreateOptions{})
 	if err != nil {
 		return nil, err
 	}
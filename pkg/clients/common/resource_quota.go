@@ -10,12 +10,22 @@ import (
 
 // GetResourceQuota returns the ResourceQuota object from a given namespace and ResourceQuota name
 func (s *SuiteController) GetResourceQuota(namespace, ResourceQuotaName string) (*corev1.ResourceQuota, error) {
-	return s.KubeInterface().CoreV1().ResourceQuotas(namespace).Get(context.Background(), ResourceQuotaName, metav1.GetOptions{})
+	return s.GetResourceQuotaWithContext(context.Background(), namespace, ResourceQuotaName)
+}
+
+// GetResourceQuotaWithContext is like GetResourceQuota but it stops as soon as the given context is cancelled.
+func (s *SuiteController) GetResourceQuotaWithContext(ctx context.Context, namespace, ResourceQuotaName string) (*corev1.ResourceQuota, error) {
+	return s.KubeInterface().CoreV1().ResourceQuotas(namespace).Get(ctx, ResourceQuotaName, metav1.GetOptions{})
 }
 
 // GetResourceQuotaInfo returns the available resources and its usage in a given test, namespace, and ResourceQuota name
 func (s *SuiteController) GetResourceQuotaInfo(test, namespace, resourceQuotaName string) error {
-	rq, err := s.GetResourceQuota(namespace, resourceQuotaName)
+	return s.GetResourceQuotaInfoWithContext(context.Background(), test, namespace, resourceQuotaName)
+}
+
+// GetResourceQuotaInfoWithContext is like GetResourceQuotaInfo but it stops as soon as the given context is cancelled.
+func (s *SuiteController) GetResourceQuotaInfoWithContext(ctx context.Context, test, namespace, resourceQuotaName string) error {
+	rq, err := s.GetResourceQuotaWithContext(ctx, namespace, resourceQuotaName)
 	if err != nil {
 		GinkgoWriter.Printf("failed to get ResourceQuota %s in namespace %s: %v\n", resourceQuotaName, namespace, err)
 		return err
@@ -14,13 +14,18 @@ import (
 
 // GetOpenshiftRoute returns the route for a given component name
 func (h *SuiteController) GetOpenshiftRoute(routeName string, routeNamespace string) (*routev1.Route, error) {
+	return h.GetOpenshiftRouteWithContext(context.Background(), routeName, routeNamespace)
+}
+
+// GetOpenshiftRouteWithContext is like GetOpenshiftRoute but it stops as soon as the given context is cancelled.
+func (h *SuiteController) GetOpenshiftRouteWithContext(ctx context.Context, routeName string, routeNamespace string) (*routev1.Route, error) {
 	namespacedName := types.NamespacedName{
 		Name:      routeName,
 		Namespace: routeNamespace,
 	}
 
 	route := &routev1.Route{}
-	err := h.KubeRest().Get(context.Background(), namespacedName, route)
+	err := h.KubeRest().Get(ctx, namespacedName, route)
 	if err != nil {
 		return &routev1.Route{}, err
 	}
@@ -30,10 +35,15 @@ func (h *SuiteController) GetOpenshiftRoute(routeName string, routeNamespace str
 // GetOpenshiftRouteByComponentName returns a route associated with the given component
 // Routes that belong to a given component will have the following label: 'app.kubernetes.io/name: <component-name>'
 func (h *SuiteController) GetOpenshiftRouteByComponentName(componentName string, componentNamespace string) (*routev1.Route, error) {
+	return h.GetOpenshiftRouteByComponentNameWithContext(context.Background(), componentName, componentNamespace)
+}
+
+// GetOpenshiftRouteByComponentNameWithContext is like GetOpenshiftRouteByComponentName but it stops as soon as the given context is cancelled.
+func (h *SuiteController) GetOpenshiftRouteByComponentNameWithContext(ctx context.Context, componentName string, componentNamespace string) (*routev1.Route, error) {
 	listOptions := metav1.ListOptions{
 		LabelSelector: fmt.Sprintf("app.kubernetes.io/name=%s", componentName),
 	}
-	routeList, err := h.CustomClient.RouteClient().RouteV1().Routes(componentNamespace).List(context.Background(), listOptions)
+	routeList, err := h.CustomClient.RouteClient().RouteV1().Routes(componentNamespace).List(ctx, listOptions)
 	if err != nil {
 		return &routev1.Route{}, err
 	}
@@ -44,13 +54,18 @@ func (h *SuiteController) GetOpenshiftRouteByComponentName(componentName string,
 }
 
 func (h *SuiteController) RouteHostnameIsAccessible(routeName string, namespace string) wait.ConditionFunc {
+	return h.RouteHostnameIsAccessibleWithContext(context.Background(), routeName, namespace)
+}
+
+// RouteHostnameIsAccessibleWithContext is like RouteHostnameIsAccessible but it stops as soon as the given context is cancelled.
+func (h *SuiteController) RouteHostnameIsAccessibleWithContext(ctx context.Context, routeName string, namespace string) wait.ConditionFunc {
 	return func() (bool, error) {
 		namespacedName := types.NamespacedName{
 			Name:      routeName,
 			Namespace: namespace,
 		}
 		route := &routev1.Route{}
-		if err := h.KubeRest().Get(context.Background(), namespacedName, route); err != nil {
+		if err := h.KubeRest().Get(ctx, namespacedName, route); err != nil {
 			return false, nil
 		}
 
@@ -16,24 +16,44 @@ import (
 
 // Creates a new secret in a specified namespace
 func (s *SuiteController) CreateSecret(ns string, secret *corev1.Secret) (*corev1.Secret, error) {
-	return s.KubeInterface().CoreV1().Secrets(ns).Create(context.Background(), secret, metav1.CreateOptions{})
+	return s.CreateSecretWithContext(context.Background(), ns, secret)
+}
+
+// CreateSecretWithContext is like CreateSecret but it stops as soon as the given context is cancelled.
+func (s *SuiteController) CreateSecretWithContext(ctx context.Context, ns string, secret *corev1.Secret) (*corev1.Secret, error) {
+	return s.KubeInterface().CoreV1().Secrets(ns).Create(ctx, secret, metav1.CreateOptions{})
 }
 
 // Check if a secret exists, return secret and error
 func (s *SuiteController) GetSecret(ns string, name string) (*corev1.Secret, error) {
-	return s.KubeInterface().CoreV1().Secrets(ns).Get(context.Background(), name, metav1.GetOptions{})
+	return s.GetSecretWithContext(context.Background(), ns, name)
+}
+
+// GetSecretWithContext is like GetSecret but it stops as soon as the given context is cancelled.
+func (s *SuiteController) GetSecretWithContext(ctx context.Context, ns string, name string) (*corev1.Secret, error) {
+	return s.KubeInterface().CoreV1().Secrets(ns).Get(ctx, name, metav1.GetOptions{})
 }
 
 // Deleted a secret in a specified namespace
 func (s *SuiteController) DeleteSecret(ns string, name string) error {
-	return s.KubeInterface().CoreV1().Secrets(ns).Delete(context.Background(), name, metav1.DeleteOptions{})
+	return s.DeleteSecretWithContext(context.Background(), ns, name)
+}
+
+// DeleteSecretWithContext is like DeleteSecret but it stops as soon as the given context is cancelled.
+func (s *SuiteController) DeleteSecretWithContext(ctx context.Context, ns string, name string) error {
+	return s.KubeInterface().CoreV1().Secrets(ns).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
 // Links a secret to a specified serviceaccount, if argument addImagePullSecrets is true secret will be added also to ImagePullSecrets of SA.
 func (s *SuiteController) LinkSecretToServiceAccount(ns, secret, serviceaccount string, addImagePullSecrets bool) error {
+	return s.LinkSecretToServiceAccountWithContext(context.Background(), ns, secret, serviceaccount, addImagePullSecrets)
+}
+
+// LinkSecretToServiceAccountWithContext is like LinkSecretToServiceAccount but it stops as soon as the given context is cancelled.
+func (s *SuiteController) LinkSecretToServiceAccountWithContext(ctx context.Context, ns, secret, serviceaccount string, addImagePullSecrets bool) error {
 	timeout := 20 * time.Second
-	return wait.PollUntilContextTimeout(context.Background(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		serviceAccountObject, err := s.KubeInterface().CoreV1().ServiceAccounts(ns).Get(context.Background(), serviceaccount, metav1.GetOptions{})
+	return wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		serviceAccountObject, err := s.KubeInterface().CoreV1().ServiceAccounts(ns).Get(ctx, serviceaccount, metav1.GetOptions{})
 		if err != nil {
 			return false, err
 		}
@@ -47,7 +67,7 @@ func (s *SuiteController) LinkSecretToServiceAccount(ns, secret, serviceaccount
 		if addImagePullSecrets {
 			serviceAccountObject.ImagePullSecrets = append(serviceAccountObject.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
 		}
-		_, err = s.KubeInterface().CoreV1().ServiceAccounts(ns).Update(context.Background(), serviceAccountObject, metav1.UpdateOptions{})
+		_, err = s.KubeInterface().CoreV1().ServiceAccounts(ns).Update(ctx, serviceAccountObject, metav1.UpdateOptions{})
 		if err != nil {
 			return false, nil
 		}
@@ -57,7 +77,12 @@ func (s *SuiteController) LinkSecretToServiceAccount(ns, secret, serviceaccount
 
 // UnlinkSecretFromServiceAccount unlinks secret from service account
 func (s *SuiteController) UnlinkSecretFromServiceAccount(namespace, secretName, serviceAccount string, rmImagePullSecrets bool) error {
-	serviceAccountObject, err := s.KubeInterface().CoreV1().ServiceAccounts(namespace).Get(context.Background(), serviceAccount, metav1.GetOptions{})
+	return s.UnlinkSecretFromServiceAccountWithContext(context.Background(), namespace, secretName, serviceAccount, rmImagePullSecrets)
+}
+
+// UnlinkSecretFromServiceAccountWithContext is like UnlinkSecretFromServiceAccount but it stops as soon as the given context is cancelled.
+func (s *SuiteController) UnlinkSecretFromServiceAccountWithContext(ctx context.Context, namespace, secretName, serviceAccount string, rmImagePullSecrets bool) error {
+	serviceAccountObject, err := s.KubeInterface().CoreV1().ServiceAccounts(namespace).Get(ctx, serviceAccount, metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
@@ -77,7 +102,7 @@ func (s *SuiteController) UnlinkSecretFromServiceAccount(namespace, secretName,
 			}
 		}
 	}
-	_, err = s.KubeInterface().CoreV1().ServiceAccounts(namespace).Update(context.Background(), serviceAccountObject, metav1.UpdateOptions{})
+	_, err = s.KubeInterface().CoreV1().ServiceAccounts(namespace).Update(ctx, serviceAccountObject, metav1.UpdateOptions{})
 	if err != nil {
 		return err
 	}
@@ -87,6 +112,11 @@ func (s *SuiteController) UnlinkSecretFromServiceAccount(namespace, secretName,
 
 // CreateRegistryAuthSecret create a docker registry secret in a given ns
 func (s *SuiteController) CreateRegistryAuthSecret(secretName, namespace, secretStringData string) (*corev1.Secret, error) {
+	return s.CreateRegistryAuthSecretWithContext(context.Background(), secretName, namespace, secretStringData)
+}
+
+// CreateRegistryAuthSecretWithContext is like CreateRegistryAuthSecret but it stops as soon as the given context is cancelled.
+func (s *SuiteController) CreateRegistryAuthSecretWithContext(ctx context.Context, secretName, namespace, secretStringData string) (*corev1.Secret, error) {
 	rawDecodedTextStringData, err := base64.StdEncoding.DecodeString(secretStringData)
 	if err != nil {
 		return nil, err
@@ -100,7 +130,7 @@ func (s *SuiteController) CreateRegistryAuthSecret(secretName, namespace, secret
 		Type:       corev1.SecretTypeDockerConfigJson,
 		StringData: map[string]string{corev1.DockerConfigJsonKey: string(rawDecodedTextStringData)},
 	}
-	er := s.KubeRest().Create(context.Background(), secret)
+	er := s.KubeRest().Create(ctx, secret)
 	if er != nil {
 		return nil, er
 	}
@@ -109,12 +139,17 @@ func (s *SuiteController) CreateRegistryAuthSecret(secretName, namespace, secret
 
 // CreateRegistryJsonSecret creates a secret for registry repository in namespace given with key passed.
 func (s *SuiteController) CreateRegistryJsonSecret(name, namespace, authKey, keyName string) (*corev1.Secret, error) {
+	return s.CreateRegistryJsonSecretWithContext(context.Background(), name, namespace, authKey, keyName)
+}
+
+// CreateRegistryJsonSecretWithContext is like CreateRegistryJsonSecret but it stops as soon as the given context is cancelled.
+func (s *SuiteController) CreateRegistryJsonSecretWithContext(ctx context.Context, name, namespace, authKey, keyName string) (*corev1.Secret, error) {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
 		Type:       corev1.SecretTypeDockerConfigJson,
 		Data:       map[string][]byte{".dockerconfigjson": []byte(fmt.Sprintf("{\"auths\":{\"quay.io\":{\"username\":\"%s\",\"password\":\"%s\",\"auth\":\"dGVzdDp0ZXN0\",\"email\":\"\"}}}", keyName, authKey))},
 	}
-	err := s.KubeRest().Create(context.Background(), secret)
+	err := s.KubeRest().Create(ctx, secret)
 	if err != nil {
 		return nil, err
 	}
@@ -123,17 +158,22 @@ func (s *SuiteController) CreateRegistryJsonSecret(name, namespace, authKey, key
 
 // AddRegistryAuthSecretToSA adds registry auth secret to service account
 func (s *SuiteController) AddRegistryAuthSecretToSA(registryAuth, namespace string) error {
+	return s.AddRegistryAuthSecretToSAWithContext(context.Background(), registryAuth, namespace)
+}
+
+// AddRegistryAuthSecretToSAWithContext is like AddRegistryAuthSecretToSA but it stops as soon as the given context is cancelled.
+func (s *SuiteController) AddRegistryAuthSecretToSAWithContext(ctx context.Context, registryAuth, namespace string) error {
 	quayToken := utils.GetEnv(registryAuth, "")
 	if quayToken == "" {
 		return errors.New("failed to get registry auth secret")
 	}
 
-	_, err := s.CreateRegistryAuthSecret(RegistryAuthSecretName, namespace, quayToken)
+	_, err := s.CreateRegistryAuthSecretWithContext(ctx, RegistryAuthSecretName, namespace, quayToken)
 	if err != nil {
 		return err
 	}
 
-	err = s.LinkSecretToServiceAccount(namespace, RegistryAuthSecretName, DefaultPipelineServiceAccount, true)
+	err = s.LinkSecretToServiceAccountWithContext(ctx, namespace, RegistryAuthSecretName, DefaultPipelineServiceAccount, true)
 	if err != nil {
 		return err
 	}
@@ -9,13 +9,18 @@ import (
 
 // GetServiceByName returns the service for a given component name
 func (h *SuiteController) GetServiceByName(serviceName string, serviceNamespace string) (*corev1.Service, error) {
+	return h.GetServiceByNameWithContext(context.Background(), serviceName, serviceNamespace)
+}
+
+// GetServiceByNameWithContext is like GetServiceByName but it stops as soon as the given context is cancelled.
+func (h *SuiteController) GetServiceByNameWithContext(ctx context.Context, serviceName string, serviceNamespace string) (*corev1.Service, error) {
 	namespacedName := types.NamespacedName{
 		Name:      serviceName,
 		Namespace: serviceNamespace,
 	}
 
 	service := &corev1.Service{}
-	err := h.KubeRest().Get(context.Background(), namespacedName, service)
+	err := h.KubeRest().Get(ctx, namespacedName, service)
 	if err != nil {
 		return &corev1.Service{}, err
 	}
@@ -10,12 +10,22 @@ import (
 )
 
 func (s *SuiteController) GetServiceAccount(saName, namespace string) (*corev1.ServiceAccount, error) {
-	return s.KubeInterface().CoreV1().ServiceAccounts(namespace).Get(context.Background(), saName, metav1.GetOptions{})
+	return s.GetServiceAccountWithContext(context.Background(), saName, namespace)
+}
+
+// GetServiceAccountWithContext is like GetServiceAccount but it stops as soon as the given context is cancelled.
+func (s *SuiteController) GetServiceAccountWithContext(ctx context.Context, saName, namespace string) (*corev1.ServiceAccount, error) {
+	return s.KubeInterface().CoreV1().ServiceAccounts(namespace).Get(ctx, saName, metav1.GetOptions{})
 }
 
 func (s *SuiteController) ServiceAccountPresent(saName, namespace string) wait.ConditionFunc {
+	return s.ServiceAccountPresentWithContext(context.Background(), saName, namespace)
+}
+
+// ServiceAccountPresentWithContext is like ServiceAccountPresent but it stops as soon as the given context is cancelled.
+func (s *SuiteController) ServiceAccountPresentWithContext(ctx context.Context, saName, namespace string) wait.ConditionFunc {
 	return func() (bool, error) {
-		_, err := s.GetServiceAccount(saName, namespace)
+		_, err := s.GetServiceAccountWithContext(ctx, saName, namespace)
 		if err != nil {
 			return false, nil
 		}
@@ -25,6 +35,11 @@ func (s *SuiteController) ServiceAccountPresent(saName, namespace string) wait.C
 
 // CreateServiceAccount creates a service account with the provided name and namespace using the given list of secrets.
 func (s *SuiteController) CreateServiceAccount(name, namespace string, serviceAccountSecretList []corev1.ObjectReference, labels map[string]string) (*corev1.ServiceAccount, error) {
+	return s.CreateServiceAccountWithContext(context.Background(), name, namespace, serviceAccountSecretList, labels)
+}
+
+// CreateServiceAccountWithContext is like CreateServiceAccount but it stops as soon as the given context is cancelled.
+func (s *SuiteController) CreateServiceAccountWithContext(ctx context.Context, name, namespace string, serviceAccountSecretList []corev1.ObjectReference, labels map[string]string) (*corev1.ServiceAccount, error) {
 	serviceAccount := &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -33,10 +48,15 @@ func (s *SuiteController) CreateServiceAccount(name, namespace string, serviceAc
 		},
 		Secrets: serviceAccountSecretList,
 	}
-	return s.KubeInterface().CoreV1().ServiceAccounts(namespace).Create(context.Background(), serviceAccount, metav1.CreateOptions{})
+	return s.KubeInterface().CoreV1().ServiceAccounts(namespace).Create(ctx, serviceAccount, metav1.CreateOptions{})
 }
 
 // DeleteAllServiceAccountsInASpecificNamespace deletes all ServiceAccount from a given namespace
 func (h *SuiteController) DeleteAllServiceAccountsInASpecificNamespace(namespace string) error {
-	return h.KubeRest().DeleteAllOf(context.Background(), &corev1.ServiceAccount{}, client.InNamespace(namespace))
+	return h.DeleteAllServiceAccountsInASpecificNamespaceWithContext(context.Background(), namespace)
+}
+
+// DeleteAllServiceAccountsInASpecificNamespaceWithContext is like DeleteAllServiceAccountsInASpecificNamespace but it stops as soon as the given context is cancelled.
+func (h *SuiteController) DeleteAllServiceAccountsInASpecificNamespaceWithContext(ctx context.Context, namespace string) error {
+	return h.KubeRest().DeleteAllOf(ctx, &corev1.ServiceAccount{}, client.InNamespace(namespace))
 }
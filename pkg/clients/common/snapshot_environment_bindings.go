@@ -8,17 +8,23 @@ import (
 	appservice "github.com/redhat-appstudio/application-api/api/v1alpha1"
 	"github.com/redhat-appstudio/e2e-tests/pkg/logs"
 	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	rclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // GetSnapshotEnvironmentBinding returns the SnapshotEnvironmentBinding related to the given App and Environment
 func (s *SuiteController) GetSnapshotEnvironmentBinding(applicationName string, namespace string, environment *appservice.Environment) (*appservice.SnapshotEnvironmentBinding, error) {
+	return s.GetSnapshotEnvironmentBindingWithContext(context.Background(), applicationName, namespace, environment)
+}
+
+// GetSnapshotEnvironmentBindingWithContext is like GetSnapshotEnvironmentBinding but it stops as soon as the given context is cancelled.
+func (s *SuiteController) GetSnapshotEnvironmentBindingWithContext(ctx context.Context, applicationName string, namespace string, environment *appservice.Environment) (*appservice.SnapshotEnvironmentBinding, error) {
 	snapshotEnvironmentBindingList := &appservice.SnapshotEnvironmentBindingList{}
 	opts := []rclient.ListOption{
 		rclient.InNamespace(namespace),
 	}
 
-	err := s.KubeRest().List(context.Background(), snapshotEnvironmentBindingList, opts...)
+	err := s.KubeRest().List(ctx, snapshotEnvironmentBindingList, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -34,23 +40,42 @@ func (s *SuiteController) GetSnapshotEnvironmentBinding(applicationName string,
 
 // DeleteAllSnapshotEnvBindingsInASpecificNamespace removes all snapshotEnvironmentBindings from a specific namespace. Useful when creating a lot of resources and want to remove all of them
 func (s *SuiteController) DeleteAllSnapshotEnvBindingsInASpecificNamespace(namespace string, timeout time.Duration) error {
-	if err := s.KubeRest().DeleteAllOf(context.Background(), &appservice.SnapshotEnvironmentBinding{}, rclient.InNamespace(namespace)); err != nil {
+	return s.DeleteAllSnapshotEnvBindingsInASpecificNamespaceWithContext(context.Background(), namespace, timeout)
+}
+
+// DeleteAllSnapshotEnvBindingsInASpecificNamespaceWithContext is like DeleteAllSnapshotEnvBindingsInASpecificNamespace but it stops as soon as the given context is cancelled.
+func (s *SuiteController) DeleteAllSnapshotEnvBindingsInASpecificNamespaceWithContext(ctx context.Context, namespace string, timeout time.Duration) error {
+	if err := s.KubeRest().DeleteAllOf(ctx, &appservice.SnapshotEnvironmentBinding{}, rclient.InNamespace(namespace)); err != nil {
 		return fmt.Errorf("error deleting snapshotEnvironmentBindings from the namespace %s: %+v", namespace, err)
 	}
 
 	snapshotEnvironmentBindingList := &appservice.SnapshotEnvironmentBindingList{}
-	return utils.WaitUntil(func() (done bool, err error) {
-		if err := s.KubeRest().List(context.Background(), snapshotEnvironmentBindingList, &rclient.ListOptions{Namespace: namespace}); err != nil {
+	target := utils.WaitTarget{
+		Description: fmt.Sprintf("all snapshotEnvironmentBindings in %s namespace to be deleted", namespace),
+		LastObserved: func() *unstructured.Unstructured {
+			if len(snapshotEnvironmentBindingList.Items) == 0 {
+				return nil
+			}
+			return utils.ObservedObject("SnapshotEnvironmentBinding", &snapshotEnvironmentBindingList.Items[0])
+		},
+	}
+	return utils.WaitUntilTargetWithContext(ctx, target, func() (done bool, err error) {
+		if err := s.KubeRest().List(ctx, snapshotEnvironmentBindingList, &rclient.ListOptions{Namespace: namespace}); err != nil {
 			return false, nil
 		}
 		return len(snapshotEnvironmentBindingList.Items) == 0, nil
-	}, timeout)
+	}, time.Second, timeout)
 }
 
 // ListAllSnapshotEnvBindings returns a list of all SnapshotEnvBindings in a given namespace.
 func (s *SuiteController) ListAllSnapshotEnvBindings(namespace string) (*appservice.SnapshotEnvironmentBindingList, error) {
+	return s.ListAllSnapshotEnvBindingsWithContext(context.Background(), namespace)
+}
+
+// ListAllSnapshotEnvBindingsWithContext is like ListAllSnapshotEnvBindings but it stops as soon as the given context is cancelled.
+func (s *SuiteController) ListAllSnapshotEnvBindingsWithContext(ctx context.Context, namespace string) (*appservice.SnapshotEnvironmentBindingList, error) {
 	snapshotEnvironmentBindingList := &appservice.SnapshotEnvironmentBindingList{}
-	err := s.KubeRest().List(context.Background(), snapshotEnvironmentBindingList, &rclient.ListOptions{Namespace: namespace})
+	err := s.KubeRest().List(ctx, snapshotEnvironmentBindingList, &rclient.ListOptions{Namespace: namespace})
 
 	return snapshotEnvironmentBindingList, err
 }
@@ -62,7 +87,12 @@ func (s *SuiteController) StoreSnapshotEnvBinding(snapshotEnvBinding *appservice
 
 // StoreAllSnapshotEnvironmentBindings stores all SnapshotEnvBindings in a given namespace.
 func (s *SuiteController) StoreAllSnapshotEnvironmentBindings(namespace string) error {
-	snapshotEnvBindingsList, err := s.ListAllSnapshotEnvBindings(namespace)
+	return s.StoreAllSnapshotEnvironmentBindingsWithContext(context.Background(), namespace)
+}
+
+// StoreAllSnapshotEnvironmentBindingsWithContext is like StoreAllSnapshotEnvironmentBindings but it stops as soon as the given context is cancelled.
+func (s *SuiteController) StoreAllSnapshotEnvironmentBindingsWithContext(ctx context.Context, namespace string) error {
+	snapshotEnvBindingsList, err := s.ListAllSnapshotEnvBindingsWithContext(ctx, namespace)
 	if err != nil {
 		return err
 	}
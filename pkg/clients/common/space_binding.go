@@ -11,6 +11,11 @@ import (
 
 // CreateSpaceBinding creates SpaceBinding resource for the given murName and spaceName
 func (s *SuiteController) CreateSpaceBinding(murName, spaceName, spaceRole string) (*toolchainApi.SpaceBinding, error) {
+	return s.CreateSpaceBindingWithContext(context.Background(), murName, spaceName, spaceRole)
+}
+
+// CreateSpaceBindingWithContext is like CreateSpaceBinding but it stops as soon as the given context is cancelled.
+func (s *SuiteController) CreateSpaceBindingWithContext(ctx context.Context, murName, spaceName, spaceRole string) (*toolchainApi.SpaceBinding, error) {
 	namePrefix := fmt.Sprintf("%s-%s", murName, spaceName)
 	if len(namePrefix) > 50 {
 		namePrefix = namePrefix[0:50]
@@ -31,7 +36,7 @@ func (s *SuiteController) CreateSpaceBinding(murName, spaceName, spaceRole strin
 		},
 	}
 
-	err := s.KubeRest().Create(context.Background(), spaceBinding)
+	err := s.KubeRest().Create(ctx, spaceBinding)
 	if err != nil {
 		return &toolchainApi.SpaceBinding{}, err
 	}
@@ -41,7 +46,12 @@ func (s *SuiteController) CreateSpaceBinding(murName, spaceName, spaceRole strin
 
 // CheckWorkspaceShare checks if the given user was added to given namespace
 func (s *SuiteController) CheckWorkspaceShare(user, namespace string) error {
-	ns, err := s.GetNamespace(namespace)
+	return s.CheckWorkspaceShareWithContext(context.Background(), user, namespace)
+}
+
+// CheckWorkspaceShareWithContext is like CheckWorkspaceShare but it stops as soon as the given context is cancelled.
+func (s *SuiteController) CheckWorkspaceShareWithContext(ctx context.Context, user, namespace string) error {
+	ns, err := s.GetNamespaceWithContext(ctx, namespace)
 	if err != nil {
 		return nil
 	}
@@ -20,6 +20,11 @@ func (s *SuiteController) HaveTestsFinished(snapshot *appstudioApi.Snapshot) boo
 }
 
 func (s *SuiteController) MarkTestsSucceeded(snapshot *appstudioApi.Snapshot) (*appstudioApi.Snapshot, error) {
+	return s.MarkTestsSucceededWithContext(context.Background(), snapshot)
+}
+
+// MarkTestsSucceededWithContext is like MarkTestsSucceeded but it stops as soon as the given context is cancelled.
+func (s *SuiteController) MarkTestsSucceededWithContext(ctx context.Context, snapshot *appstudioApi.Snapshot) (*appstudioApi.Snapshot, error) {
 	patch := client.MergeFrom(snapshot.DeepCopy())
 	meta.SetStatusCondition(&snapshot.Status.Conditions, metav1.Condition{
 		Type:    "AppStudioTestSucceeded",
@@ -27,7 +32,7 @@ func (s *SuiteController) MarkTestsSucceeded(snapshot *appstudioApi.Snapshot) (*
 		Reason:  "Passed",
 		Message: "Snapshot Passed",
 	})
-	err := s.KubeRest().Status().Patch(context.Background(), snapshot, patch)
+	err := s.KubeRest().Status().Patch(ctx, snapshot, patch)
 	if err != nil {
 		return nil, err
 	}
@@ -59,3 +59,25 @@ func (g *Github) ExistsRef(repository, branchName string) (bool, error) {
 	}
 	return true, nil
 }
+
+// ListBranches returns all the branches of the repository.
+func (g *Github) ListBranches(repository string) ([]*github.Branch, error) {
+	opt := &github.BranchListOptions{
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	var allBranches []*github.Branch
+	for {
+		branches, resp, err := g.client.Repositories.ListBranches(context.Background(), g.organization, repository, opt)
+		if err != nil {
+			return nil, fmt.Errorf("error when listing the branches of the repo '%s': %+v", repository, err)
+		}
+		allBranches = append(allBranches, branches...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return allBranches, nil
+}
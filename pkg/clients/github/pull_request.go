@@ -39,6 +39,28 @@ func (g *Github) ListPullRequests(repository string) ([]*github.PullRequest, err
 	return prs, nil
 }
 
+// ListAllPullRequests returns all the open pull requests of the repository, not only the first page of them.
+func (g *Github) ListAllPullRequests(repository string) ([]*github.PullRequest, error) {
+	opt := &github.PullRequestListOptions{
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	var allPrs []*github.PullRequest
+	for {
+		prs, resp, err := g.client.PullRequests.List(context.Background(), g.organization, repository, opt)
+		if err != nil {
+			return nil, fmt.Errorf("error when listing pull requests for the repo %s: %v", repository, err)
+		}
+		allPrs = append(allPrs, prs...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return allPrs, nil
+}
+
 func (g *Github) ListPullRequestCommentsSince(repository string, prNumber int, since time.Time) ([]*github.IssueComment, error) {
 	comments, _, err := g.client.Issues.ListComments(context.Background(), g.organization, repository, prNumber, &github.IssueListCommentsOptions{
 		Since:     &since,
@@ -0,0 +1,68 @@
+package gitops
+
+import (
+	"time"
+
+	codereadytoolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	appservice "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	kubeCl "github.com/redhat-appstudio/e2e-tests/pkg/clients/kubernetes"
+)
+
+// DeploymentTargetClaimClient operates with DeploymentTargetClaims.
+type DeploymentTargetClaimClient interface {
+	GetDeploymentTargetClaimsList(namespace string) (*appservice.DeploymentTargetClaimList, error)
+	StoreDeploymentTargetClaim(deploymentTargetClaim *appservice.DeploymentTargetClaim) error
+	StoreAllDeploymentTargetClaims(namespace string) error
+}
+
+// DeploymentTargetClassClient operates with DeploymentTargetClasses.
+type DeploymentTargetClassClient interface {
+	HaveAvailableDeploymentTargetClassExist() (*appservice.DeploymentTargetClass, error)
+	CreateDeploymentTargetClass() (*appservice.DeploymentTargetClass, error)
+	DeleteDeploymentTargetClass() error
+	ListAllDeploymentTargetClasses(namespace string) (*appservice.DeploymentTargetClassList, error)
+	StoreDeploymentTargetClass(deploymentTargetClass *appservice.DeploymentTargetClass) error
+	StoreAllDeploymentTargetClasses(namespace string) error
+}
+
+// DeploymentTargetClient operates with DeploymentTargets.
+type DeploymentTargetClient interface {
+	GetDeploymentTargetsList(namespace string) (*appservice.DeploymentTargetList, error)
+	StoreDeploymentTarget(deploymentTarget *appservice.DeploymentTarget) error
+	StoreAllDeploymentTargets(namespace string) error
+}
+
+// EnvironmentClient operates with Environments.
+type EnvironmentClient interface {
+	GetEnvironmentsList(namespace string) (*appservice.EnvironmentList, error)
+	GetEphemeralEnvironment(applicationName, snapshotName, integrationTestScenarioName, namespace string) (*appservice.Environment, error)
+	CreateEphemeralEnvironment(name string, namespace string, targetNamespace string, serverApi string, clusterCredentialsSecret string, clusterType appservice.ConfigurationClusterType, kubeIngressDomain string) (*appservice.Environment, error)
+	CreatePocEnvironment(name string, namespace string) (*appservice.Environment, error)
+	DeleteAllEnvironmentsInASpecificNamespace(namespace string, timeout time.Duration) error
+	ListAllEnvironments(namespace string) (*appservice.EnvironmentList, error)
+	StoreEnvironment(environment *appservice.Environment) error
+	StoreAllEnvironments(namespace string) error
+}
+
+// SpaceClient operates with toolchain Spaces.
+type SpaceClient interface {
+	GetSpaces(namespace string) (*codereadytoolchainv1alpha1.SpaceList, error)
+}
+
+// SpaceRequestClient operates with toolchain SpaceRequests.
+type SpaceRequestClient interface {
+	GetSpaceRequests(namespace string) (*codereadytoolchainv1alpha1.SpaceRequestList, error)
+}
+
+// Interface groups all the operations available for the GitOps service. It is implemented by GitopsController.
+type Interface interface {
+	kubeCl.Interface
+	DeploymentTargetClaimClient
+	DeploymentTargetClassClient
+	DeploymentTargetClient
+	EnvironmentClient
+	SpaceClient
+	SpaceRequestClient
+}
+
+var _ Interface = &GitopsController{}
@@ -9,8 +9,10 @@ import (
 	"github.com/redhat-appstudio/e2e-tests/pkg/logs"
 	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
 	"github.com/redhat-appstudio/e2e-tests/pkg/utils/gitops"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils/watcher"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	rclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -18,10 +20,15 @@ import (
 
 // GetApplication returns an application given a name and namespace from kubernetes cluster.
 func (h *HasController) GetApplication(name string, namespace string) (*appservice.Application, error) {
+	return h.GetApplicationWithContext(context.Background(), name, namespace)
+}
+
+// GetApplicationWithContext is like GetApplication but it stops as soon as the given context is cancelled.
+func (h *HasController) GetApplicationWithContext(ctx context.Context, name string, namespace string) (*appservice.Application, error) {
 	application := appservice.Application{
 		Spec: appservice.ApplicationSpec{},
 	}
-	if err := h.KubeRest().Get(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, &application); err != nil {
+	if err := h.KubeRest().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &application); err != nil {
 		return nil, err
 	}
 
@@ -30,8 +37,13 @@ func (h *HasController) GetApplication(name string, namespace string) (*appservi
 
 // ApplicationDevfilePresent check if devfile exists in the application status.
 func (h *HasController) ApplicationDevfilePresent(application *appservice.Application) wait.ConditionFunc {
+	return h.ApplicationDevfilePresentWithContext(context.Background(), application)
+}
+
+// ApplicationDevfilePresentWithContext is like ApplicationDevfilePresent but it stops as soon as the given context is cancelled.
+func (h *HasController) ApplicationDevfilePresentWithContext(ctx context.Context, application *appservice.Application) wait.ConditionFunc {
 	return func() (bool, error) {
-		app, err := h.GetApplication(application.Name, application.Namespace)
+		app, err := h.GetApplicationWithContext(ctx, application.Name, application.Namespace)
 		if err != nil {
 			return false, nil
 		}
@@ -50,11 +62,21 @@ func (s *HasController) ApplicationGitopsRepoExists(devfileContent string) wait.
 
 // CreateApplication creates an application in the kubernetes cluster with 10 minutes default time for creation.
 func (h *HasController) CreateApplication(name string, namespace string) (*appservice.Application, error) {
-	return h.CreateApplicationWithTimeout(name, namespace, time.Minute*10)
+	return h.CreateApplicationWithContext(context.Background(), name, namespace)
+}
+
+// CreateApplicationWithContext is like CreateApplication but it stops as soon as the given context is cancelled.
+func (h *HasController) CreateApplicationWithContext(ctx context.Context, name string, namespace string) (*appservice.Application, error) {
+	return h.CreateApplicationWithTimeoutWithContext(ctx, name, namespace, time.Minute*10)
 }
 
 // CreateHasApplicationWithTimeout creates an application in the kubernetes cluster with a custom default time for creation.
 func (h *HasController) CreateApplicationWithTimeout(name string, namespace string, timeout time.Duration) (*appservice.Application, error) {
+	return h.CreateApplicationWithTimeoutWithContext(context.Background(), name, namespace, timeout)
+}
+
+// CreateApplicationWithTimeoutWithContext is like CreateApplicationWithTimeout but it stops as soon as the given context is cancelled.
+func (h *HasController) CreateApplicationWithTimeoutWithContext(ctx context.Context, name string, namespace string, timeout time.Duration) (*appservice.Application, error) {
 	application := &appservice.Application{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -65,76 +87,112 @@ func (h *HasController) CreateApplicationWithTimeout(name string, namespace stri
 		},
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*1)
+	createCtx, cancel := context.WithTimeout(ctx, time.Minute*1)
 	defer cancel()
-	if err := h.KubeRest().Create(ctx, application); err != nil {
+	if err := h.KubeRest().Create(createCtx, application); err != nil {
 		return nil, err
 	}
 
-	if err := utils.WaitUntil(h.ApplicationDevfilePresent(application), timeout); err != nil {
-		application = h.refreshApplicationForErrorDebug(application)
-		return nil, fmt.Errorf("timed out when waiting for devfile content creation for application %s in %s namespace: %+v. applicattion: %s", name, namespace, err, utils.ToPrettyJSONString(application))
+	w := watcher.New[appservice.Application](h.DynamicClient(), appservice.GroupVersion.WithResource("applications"), namespace)
+	w.Name = name
+	w.State = func(app *appservice.Application) string {
+		return watcher.ConditionsState(app.Status.Conditions)
+	}
+	app, err := w.Until(ctx, timeout, func(app *appservice.Application) (bool, error) {
+		return app.Status.Devfile != "", nil
+	})
+	if err != nil {
+		storeWaitTimeoutError(err)
+		return nil, fmt.Errorf("timed out when waiting for devfile content creation for application %s in %s namespace: %w", name, namespace, err)
 	}
 
-	return application, nil
+	return app, nil
 }
 
 // DeleteApplication delete a HAS Application resource from the namespace.
 // Optionally, it can avoid returning an error if the resource did not exist:
 // - specify 'false', if it's likely the Application has already been deleted (for example, because the Namespace was deleted)
 func (h *HasController) DeleteApplication(name string, namespace string, reportErrorOnNotFound bool) error {
+	return h.DeleteApplicationWithContext(context.Background(), name, namespace, reportErrorOnNotFound)
+}
+
+// DeleteApplicationWithContext is like DeleteApplication but it stops as soon as the given context is cancelled.
+func (h *HasController) DeleteApplicationWithContext(ctx context.Context, name string, namespace string, reportErrorOnNotFound bool) error {
 	application := appservice.Application{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
 		},
 	}
-	if err := h.KubeRest().Delete(context.Background(), &application); err != nil {
+	if err := h.KubeRest().Delete(ctx, &application); err != nil {
 		if !k8sErrors.IsNotFound(err) || (k8sErrors.IsNotFound(err) && reportErrorOnNotFound) {
 			return fmt.Errorf("error deleting an application: %+v", err)
 		}
 	}
-	return utils.WaitUntil(h.ApplicationDeleted(&application), 1*time.Minute)
+	var observed *appservice.Application
+	target := utils.WaitTarget{
+		Description:  fmt.Sprintf("application %s/%s to be deleted", namespace, name),
+		LastObserved: func() *unstructured.Unstructured { return utils.ObservedObject("Application", observed) },
+	}
+	return utils.WaitUntilTargetWithContext(ctx, target, func() (bool, error) {
+		a, err := h.GetApplicationWithContext(ctx, name, namespace)
+		if err == nil {
+			observed = a
+		}
+		return err != nil && k8sErrors.IsNotFound(err), nil
+	}, time.Second, 1*time.Minute)
 }
 
 // ApplicationDeleted check if a given application object was deleted successfully from the kubernetes cluster.
 func (h *HasController) ApplicationDeleted(application *appservice.Application) wait.ConditionFunc {
+	return h.ApplicationDeletedWithContext(context.Background(), application)
+}
+
+// ApplicationDeletedWithContext is like ApplicationDeleted but it stops as soon as the given context is cancelled.
+func (h *HasController) ApplicationDeletedWithContext(ctx context.Context, application *appservice.Application) wait.ConditionFunc {
 	return func() (bool, error) {
-		_, err := h.GetApplication(application.Name, application.Namespace)
+		_, err := h.GetApplicationWithContext(ctx, application.Name, application.Namespace)
 		return err != nil && k8sErrors.IsNotFound(err), nil
 	}
 }
 
 // DeleteAllApplicationsInASpecificNamespace removes all application CRs from a specific namespace. Useful when creating a lot of resources and want to remove all of them
 func (h *HasController) DeleteAllApplicationsInASpecificNamespace(namespace string, timeout time.Duration) error {
-	if err := h.KubeRest().DeleteAllOf(context.Background(), &appservice.Application{}, rclient.InNamespace(namespace)); err != nil {
+	return h.DeleteAllApplicationsInASpecificNamespaceWithContext(context.Background(), namespace, timeout)
+}
+
+// DeleteAllApplicationsInASpecificNamespaceWithContext is like DeleteAllApplicationsInASpecificNamespace but it stops as soon as the given context is cancelled.
+func (h *HasController) DeleteAllApplicationsInASpecificNamespaceWithContext(ctx context.Context, namespace string, timeout time.Duration) error {
+	if err := h.KubeRest().DeleteAllOf(ctx, &appservice.Application{}, rclient.InNamespace(namespace)); err != nil {
 		return fmt.Errorf("error deleting applications from the namespace %s: %+v", namespace, err)
 	}
 
-	return utils.WaitUntil(func() (done bool, err error) {
-		applicationList, err := h.ListAllApplications(namespace)
+	var remaining *appservice.Application
+	target := utils.WaitTarget{
+		Description:  fmt.Sprintf("all applications in %s namespace to be deleted", namespace),
+		LastObserved: func() *unstructured.Unstructured { return utils.ObservedObject("Application", remaining) },
+	}
+	return utils.WaitUntilTargetWithContext(ctx, target, func() (done bool, err error) {
+		applicationList, err := h.ListAllApplicationsWithContext(ctx, namespace)
 		if err != nil {
 			return false, nil
 		}
+		if len(applicationList.Items) > 0 {
+			remaining = &applicationList.Items[0]
+		}
 		return len(applicationList.Items) == 0, nil
-	}, timeout)
-}
-
-// refreshApplicationForErrorDebug return the latest application object from the kubernetes cluster.
-func (h *HasController) refreshApplicationForErrorDebug(application *appservice.Application) *appservice.Application {
-	retApp := &appservice.Application{}
-
-	if err := h.KubeRest().Get(context.Background(), rclient.ObjectKeyFromObject(application), retApp); err != nil {
-		return application
-	}
-
-	return retApp
+	}, time.Second, timeout)
 }
 
 // ListAllApplications returns a list of all Applications in a given namespace.
 func (h *HasController) ListAllApplications(namespace string) (*appservice.ApplicationList, error) {
+	return h.ListAllApplicationsWithContext(context.Background(), namespace)
+}
+
+// ListAllApplicationsWithContext is like ListAllApplications but it stops as soon as the given context is cancelled.
+func (h *HasController) ListAllApplicationsWithContext(ctx context.Context, namespace string) (*appservice.ApplicationList, error) {
 	applicationList := &appservice.ApplicationList{}
-	err := h.KubeRest().List(context.Background(), applicationList, &rclient.ListOptions{Namespace: namespace})
+	err := h.KubeRest().List(ctx, applicationList, &rclient.ListOptions{Namespace: namespace})
 
 	return applicationList, err
 }
@@ -146,7 +204,12 @@ func (h *HasController) StoreApplication(application *appservice.Application) er
 
 // StoreAllApplications stores all Applications in a given namespace.
 func (h *HasController) StoreAllApplications(namespace string) error {
-	applicationList, err := h.ListAllApplications(namespace)
+	return h.StoreAllApplicationsWithContext(context.Background(), namespace)
+}
+
+// StoreAllApplicationsWithContext is like StoreAllApplications but it stops as soon as the given context is cancelled.
+func (h *HasController) StoreAllApplicationsWithContext(ctx context.Context, namespace string) error {
+	applicationList, err := h.ListAllApplicationsWithContext(ctx, namespace)
 	if err != nil {
 		return err
 	}
@@ -9,17 +9,23 @@ import (
 	"github.com/redhat-appstudio/e2e-tests/pkg/logs"
 	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	rclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // GetComponentDetectionQuery return the status from the ComponentDetectionQuery Custom Resource object
 func (h *HasController) GetComponentDetectionQuery(name, namespace string) (*appservice.ComponentDetectionQuery, error) {
+	return h.GetComponentDetectionQueryWithContext(context.Background(), name, namespace)
+}
+
+// GetComponentDetectionQueryWithContext is like GetComponentDetectionQuery but it stops as soon as the given context is cancelled.
+func (h *HasController) GetComponentDetectionQueryWithContext(ctx context.Context, name, namespace string) (*appservice.ComponentDetectionQuery, error) {
 	componentDetectionQuery := &appservice.ComponentDetectionQuery{
 		Spec: appservice.ComponentDetectionQuerySpec{},
 	}
 
-	if err := h.KubeRest().Get(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, componentDetectionQuery); err != nil {
+	if err := h.KubeRest().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, componentDetectionQuery); err != nil {
 		return nil, err
 	}
 
@@ -28,11 +34,21 @@ func (h *HasController) GetComponentDetectionQuery(name, namespace string) (*app
 
 // CreateComponentDetectionQuery create a has componentdetectionquery from a given name, namespace, and git source
 func (h *HasController) CreateComponentDetectionQuery(name string, namespace string, gitSourceURL string, gitSourceRevision string, gitSourceContext string, secret string, isMultiComponent bool) (*appservice.ComponentDetectionQuery, error) {
-	return h.CreateComponentDetectionQueryWithTimeout(name, namespace, gitSourceURL, gitSourceRevision, gitSourceContext, secret, isMultiComponent, 6*time.Minute)
+	return h.CreateComponentDetectionQueryWithContext(context.Background(), name, namespace, gitSourceURL, gitSourceRevision, gitSourceContext, secret, isMultiComponent)
+}
+
+// CreateComponentDetectionQueryWithContext is like CreateComponentDetectionQuery but it stops as soon as the given context is cancelled.
+func (h *HasController) CreateComponentDetectionQueryWithContext(ctx context.Context, name string, namespace string, gitSourceURL string, gitSourceRevision string, gitSourceContext string, secret string, isMultiComponent bool) (*appservice.ComponentDetectionQuery, error) {
+	return h.CreateComponentDetectionQueryWithTimeoutWithContext(ctx, name, namespace, gitSourceURL, gitSourceRevision, gitSourceContext, secret, isMultiComponent, 6*time.Minute)
 }
 
 // CreateComponentDetectionQueryWithTimeout create a has componentdetectionquery from a given name, namespace, and git source and waits for it to be read
 func (h *HasController) CreateComponentDetectionQueryWithTimeout(name string, namespace string, gitSourceURL string, gitSourceRevision string, gitSourceContext string, secret string, isMultiComponent bool, timeout time.Duration) (*appservice.ComponentDetectionQuery, error) {
+	return h.CreateComponentDetectionQueryWithTimeoutWithContext(context.Background(), name, namespace, gitSourceURL, gitSourceRevision, gitSourceContext, secret, isMultiComponent, timeout)
+}
+
+// CreateComponentDetectionQueryWithTimeoutWithContext is like CreateComponentDetectionQueryWithTimeout but it stops as soon as the given context is cancelled.
+func (h *HasController) CreateComponentDetectionQueryWithTimeoutWithContext(ctx context.Context, name string, namespace string, gitSourceURL string, gitSourceRevision string, gitSourceContext string, secret string, isMultiComponent bool, timeout time.Duration) (*appservice.ComponentDetectionQuery, error) {
 	componentDetectionQuery := &appservice.ComponentDetectionQuery{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -49,14 +65,20 @@ func (h *HasController) CreateComponentDetectionQueryWithTimeout(name string, na
 		},
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*1)
+	createCtx, cancel := context.WithTimeout(ctx, time.Minute*1)
 	defer cancel()
-	if err := h.KubeRest().Create(ctx, componentDetectionQuery); err != nil {
+	if err := h.KubeRest().Create(createCtx, componentDetectionQuery); err != nil {
 		return nil, err
 	}
 
-	err := utils.WaitUntil(func() (done bool, err error) {
-		componentDetectionQuery, err = h.GetComponentDetectionQuery(componentDetectionQuery.Name, componentDetectionQuery.Namespace)
+	target := utils.WaitTarget{
+		Description: fmt.Sprintf("componentdetectionquery %s/%s to detect the components", componentDetectionQuery.Namespace, componentDetectionQuery.Name),
+		LastObserved: func() *unstructured.Unstructured {
+			return utils.ObservedObject("ComponentDetectionQuery", componentDetectionQuery)
+		},
+	}
+	err := utils.WaitUntilTargetWithContext(ctx, target, func() (done bool, err error) {
+		componentDetectionQuery, err = h.GetComponentDetectionQueryWithContext(ctx, componentDetectionQuery.Name, componentDetectionQuery.Namespace)
 		if err != nil {
 			return false, err
 		}
@@ -66,10 +88,10 @@ func (h *HasController) CreateComponentDetectionQueryWithTimeout(name string, na
 			}
 		}
 		return false, nil
-	}, timeout)
+	}, time.Second, timeout)
 
 	if err != nil {
-		return nil, fmt.Errorf("error waiting for cdq to be ready: %v", err)
+		return nil, fmt.Errorf("error waiting for cdq to be ready: %w", err)
 	}
 
 	return componentDetectionQuery, nil
@@ -77,23 +99,41 @@ func (h *HasController) CreateComponentDetectionQueryWithTimeout(name string, na
 
 // DeleteAllComponentDetectionQueriesInASpecificNamespace removes all CDQs CRs from a specific namespace. Useful when creating a lot of resources and want to remove all of them
 func (h *HasController) DeleteAllComponentDetectionQueriesInASpecificNamespace(namespace string, timeout time.Duration) error {
-	if err := h.KubeRest().DeleteAllOf(context.Background(), &appservice.ComponentDetectionQuery{}, rclient.InNamespace(namespace)); err != nil {
+	return h.DeleteAllComponentDetectionQueriesInASpecificNamespaceWithContext(context.Background(), namespace, timeout)
+}
+
+// DeleteAllComponentDetectionQueriesInASpecificNamespaceWithContext is like DeleteAllComponentDetectionQueriesInASpecificNamespace but it stops as soon as the given context is cancelled.
+func (h *HasController) DeleteAllComponentDetectionQueriesInASpecificNamespaceWithContext(ctx context.Context, namespace string, timeout time.Duration) error {
+	if err := h.KubeRest().DeleteAllOf(ctx, &appservice.ComponentDetectionQuery{}, rclient.InNamespace(namespace)); err != nil {
 		return fmt.Errorf("error deleting component detection queries from the namespace %s: %+v", namespace, err)
 	}
 
-	return utils.WaitUntil(func() (done bool, err error) {
-		componentDetectionQueriesList, err := h.ListAllComponentDetectionQueries(namespace)
+	var remaining *appservice.ComponentDetectionQuery
+	target := utils.WaitTarget{
+		Description:  fmt.Sprintf("all componentdetectionqueries in %s namespace to be deleted", namespace),
+		LastObserved: func() *unstructured.Unstructured { return utils.ObservedObject("ComponentDetectionQuery", remaining) },
+	}
+	return utils.WaitUntilTargetWithContext(ctx, target, func() (done bool, err error) {
+		componentDetectionQueriesList, err := h.ListAllComponentDetectionQueriesWithContext(ctx, namespace)
 		if err != nil {
 			return false, nil
 		}
+		if len(componentDetectionQueriesList.Items) > 0 {
+			remaining = &componentDetectionQueriesList.Items[0]
+		}
 		return len(componentDetectionQueriesList.Items) == 0, nil
-	}, timeout)
+	}, time.Second, timeout)
 }
 
 // ListAllComponentDetectionQueries returns a list of all ComponentDetectionQueries in a given namespace.
 func (h *HasController) ListAllComponentDetectionQueries(namespace string) (*appservice.ComponentDetectionQueryList, error) {
+	return h.ListAllComponentDetectionQueriesWithContext(context.Background(), namespace)
+}
+
+// ListAllComponentDetectionQueriesWithContext is like ListAllComponentDetectionQueries but it stops as soon as the given context is cancelled.
+func (h *HasController) ListAllComponentDetectionQueriesWithContext(ctx context.Context, namespace string) (*appservice.ComponentDetectionQueryList, error) {
 	componentDetectionQueryList := &appservice.ComponentDetectionQueryList{}
-	err := h.KubeRest().List(context.Background(), componentDetectionQueryList, &rclient.ListOptions{Namespace: namespace})
+	err := h.KubeRest().List(ctx, componentDetectionQueryList, &rclient.ListOptions{Namespace: namespace})
 	return componentDetectionQueryList, err
 }
 
@@ -104,7 +144,12 @@ func (h *HasController) StoreComponentDetectionQuery(ComponentDetectionQuery *ap
 
 // StoreAllComponentDetectionQueries stores all ComponentDetectionQueries in a given namespace.
 func (h *HasController) StoreAllComponentDetectionQueries(namespace string) error {
-	componentDetectionQueryList, err := h.ListAllComponentDetectionQueries(namespace)
+	return h.StoreAllComponentDetectionQueriesWithContext(context.Background(), namespace)
+}
+
+// StoreAllComponentDetectionQueriesWithContext is like StoreAllComponentDetectionQueries but it stops as soon as the given context is cancelled.
+func (h *HasController) StoreAllComponentDetectionQueriesWithContext(ctx context.Context, namespace string) error {
+	componentDetectionQueryList, err := h.ListAllComponentDetectionQueriesWithContext(ctx, namespace)
 	if err != nil {
 		return err
 	}
@@ -119,7 +164,12 @@ func (h *HasController) StoreAllComponentDetectionQueries(namespace string) erro
 
 // UpdateComponent updates a component
 func (h *HasController) UpdateComponent(component *appservice.Component) error {
-	err := h.KubeRest().Update(context.Background(), component, &rclient.UpdateOptions{})
+	return h.UpdateComponentWithContext(context.Background(), component)
+}
+
+// UpdateComponentWithContext is like UpdateComponent but it stops as soon as the given context is cancelled.
+func (h *HasController) UpdateComponentWithContext(ctx context.Context, component *appservice.Component) error {
+	err := h.KubeRest().Update(ctx, component, &rclient.UpdateOptions{})
 
 	if err != nil {
 		return err
@@ -15,9 +15,12 @@ import (
 	"github.com/redhat-appstudio/e2e-tests/pkg/logs"
 	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
 	"github.com/redhat-appstudio/e2e-tests/pkg/utils/build"
+	tektonutils "github.com/redhat-appstudio/e2e-tests/pkg/utils/tekton"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils/watcher"
 	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -35,8 +38,13 @@ const (
 
 // GetComponent return a component object from kubernetes cluster
 func (h *HasController) GetComponent(name string, namespace string) (*appservice.Component, error) {
+	return h.GetComponentWithContext(context.Background(), name, namespace)
+}
+
+// GetComponentWithContext is like GetComponent but it stops as soon as the given context is cancelled.
+func (h *HasController) GetComponentWithContext(ctx context.Context, name string, namespace string) (*appservice.Component, error) {
 	component := &appservice.Component{}
-	if err := h.KubeRest().Get(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, component); err != nil {
+	if err := h.KubeRest().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, component); err != nil {
 		return nil, err
 	}
 
@@ -45,11 +53,16 @@ func (h *HasController) GetComponent(name string, namespace string) (*appservice
 
 // GetComponentByApplicationName returns a component from kubernetes cluster given a application name.
 func (h *HasController) GetComponentByApplicationName(applicationName string, namespace string) (*appservice.Component, error) {
+	return h.GetComponentByApplicationNameWithContext(context.Background(), applicationName, namespace)
+}
+
+// GetComponentByApplicationNameWithContext is like GetComponentByApplicationName but it stops as soon as the given context is cancelled.
+func (h *HasController) GetComponentByApplicationNameWithContext(ctx context.Context, applicationName string, namespace string) (*appservice.Component, error) {
 	components := &appservice.ComponentList{}
 	opts := []rclient.ListOption{
 		rclient.InNamespace(namespace),
 	}
-	err := h.KubeRest().List(context.Background(), components, opts...)
+	err := h.KubeRest().List(ctx, components, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -64,11 +77,21 @@ func (h *HasController) GetComponentByApplicationName(applicationName string, na
 
 // GetComponentPipeline returns the pipeline for a given component labels
 func (h *HasController) GetComponentPipelineRun(componentName string, applicationName string, namespace, sha string) (*pipeline.PipelineRun, error) {
-	return h.GetComponentPipelineRunWithType(componentName, applicationName, namespace, "", sha)
+	return h.GetComponentPipelineRunWithContext(context.Background(), componentName, applicationName, namespace, sha)
+}
+
+// GetComponentPipelineRunWithContext is like GetComponentPipelineRun but it stops as soon as the given context is cancelled.
+func (h *HasController) GetComponentPipelineRunWithContext(ctx context.Context, componentName string, applicationName string, namespace, sha string) (*pipeline.PipelineRun, error) {
+	return h.GetComponentPipelineRunWithTypeWithContext(ctx, componentName, applicationName, namespace, "", sha)
 }
 
 // GetComponentPipeline returns the pipeline for a given component labels with pipeline type within label "pipelines.appstudio.openshift.io/type" ("build", "test")
 func (h *HasController) GetComponentPipelineRunWithType(componentName string, applicationName string, namespace, pipelineType string, sha string) (*pipeline.PipelineRun, error) {
+	return h.GetComponentPipelineRunWithTypeWithContext(context.Background(), componentName, applicationName, namespace, pipelineType, sha)
+}
+
+// GetComponentPipelineRunWithTypeWithContext is like GetComponentPipelineRunWithType but it stops as soon as the given context is cancelled.
+func (h *HasController) GetComponentPipelineRunWithTypeWithContext(ctx context.Context, componentName string, applicationName string, namespace, pipelineType string, sha string) (*pipeline.PipelineRun, error) {
 	pipelineRunLabels := map[string]string{"appstudio.openshift.io/component": componentName, "appstudio.openshift.io/application": applicationName}
 	if pipelineType != "" {
 		pipelineRunLabels["pipelines.appstudio.openshift.io/type"] = pipelineType
@@ -79,7 +102,7 @@ func (h *HasController) GetComponentPipelineRunWithType(componentName string, ap
 	}
 
 	list := &pipeline.PipelineRunList{}
-	err := h.KubeRest().List(context.Background(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(pipelineRunLabels), Namespace: namespace})
+	err := h.KubeRest().List(ctx, list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(pipelineRunLabels), Namespace: namespace})
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return nil, fmt.Errorf("error listing pipelineruns in %s namespace: %v", namespace, err)
@@ -99,10 +122,15 @@ func (h *HasController) GetComponentPipelineRunWithType(componentName string, ap
 
 // GetAllPipelineRunsForApplication returns the pipelineruns for a given application in the namespace
 func (h *HasController) GetAllPipelineRunsForApplication(applicationName, namespace string) (*pipeline.PipelineRunList, error) {
+	return h.GetAllPipelineRunsForApplicationWithContext(context.Background(), applicationName, namespace)
+}
+
+// GetAllPipelineRunsForApplicationWithContext is like GetAllPipelineRunsForApplication but it stops as soon as the given context is cancelled.
+func (h *HasController) GetAllPipelineRunsForApplicationWithContext(ctx context.Context, applicationName, namespace string) (*pipeline.PipelineRunList, error) {
 	pipelineRunLabels := map[string]string{"appstudio.openshift.io/application": applicationName}
 
 	list := &pipeline.PipelineRunList{}
-	err := h.KubeRest().List(context.Background(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(pipelineRunLabels), Namespace: namespace})
+	err := h.KubeRest().List(ctx, list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(pipelineRunLabels), Namespace: namespace})
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return nil, fmt.Errorf("error listing pipelineruns in %s namespace: %v", namespace, err)
@@ -127,21 +155,33 @@ type RetryOptions struct {
 }
 
 // Waits for a given component to be finished and in case of hitting issue: https://issues.redhat.com/browse/SRVKP-2749 do a given retries.
-func (h *HasController) WaitForComponentPipelineToBeFinished(component *appservice.Component, sha string, t *tekton.TektonController, r *RetryOptions) error {
+func (h *HasController) WaitForComponentPipelineToBeFinished(component *appservice.Component, sha string, t tekton.PipelineRunClient, r *RetryOptions) error {
+	return h.WaitForComponentPipelineToBeFinishedWithContext(context.Background(), component, sha, t, r)
+}
+
+// WaitForComponentPipelineToBeFinishedWithContext is like WaitForComponentPipelineToBeFinished but it stops as soon as the given context is cancelled.
+func (h *HasController) WaitForComponentPipelineToBeFinishedWithContext(ctx context.Context, component *appservice.Component, sha string, t tekton.PipelineRunClient, r *RetryOptions) error {
 	attempts := 1
 	app := component.Spec.Application
-	var pr *pipeline.PipelineRun
 
 	for {
-		err := wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 30*time.Minute, true, func(ctx context.Context) (done bool, err error) {
-			pr, err = h.GetComponentPipelineRun(component.GetName(), app, component.GetNamespace(), sha)
-
-			if err != nil {
-				GinkgoWriter.Printf("PipelineRun has not been created yet for the Component %s/%s\n", component.GetNamespace(), component.GetName())
+		// the PipelineRun of the current attempt, nil until one is observed
+		var pr *pipeline.PipelineRun
+		pipelineRunLabels := map[string]string{"appstudio.openshift.io/component": component.GetName(), "appstudio.openshift.io/application": app}
+		if sha != "" {
+			pipelineRunLabels["pipelinesascode.tekton.dev/sha"] = sha
+		}
+		w := watcher.New[pipeline.PipelineRun](h.DynamicClient(), pipeline.SchemeGroupVersion.WithResource("pipelineruns"), component.GetNamespace())
+		w.LabelSelector = labels.SelectorFromSet(pipelineRunLabels)
+		w.State = tektonutils.PipelineRunState
+
+		GinkgoWriter.Printf("Waiting for the PipelineRun of the Component %s/%s to finish\n", component.GetNamespace(), component.GetName())
+		_, err := w.Until(ctx, 30*time.Minute, func(pipelineRun *pipeline.PipelineRun) (bool, error) {
+			// skip the PipelineRun deleted by a previous retrigger
+			if pipelineRun.GetDeletionTimestamp() != nil {
 				return false, nil
 			}
-
-			GinkgoWriter.Printf("PipelineRun %s reason: %s\n", pr.Name, pr.GetStatusCondition().GetCondition(apis.ConditionSucceeded).GetReason())
+			pr = pipelineRun
 
 			if !pr.IsDone() {
 				return false, nil
@@ -152,10 +192,11 @@ func (h *HasController) WaitForComponentPipelineToBeFinished(component *appservi
 			}
 
 			var prLogs string
+			var err error
 			if err = t.StorePipelineRun(pr); err != nil {
 				GinkgoWriter.Printf("failed to store PipelineRun %s:%s: %s\n", pr.GetNamespace(), pr.GetName(), err.Error())
 			}
-			if prLogs, err = t.GetPipelineRunLogs(pr.Name, pr.Namespace); err != nil {
+			if prLogs, err = t.GetPipelineRunLogsWithContext(ctx, pr.Name, pr.Namespace); err != nil {
 				GinkgoWriter.Printf("failed to get logs for PipelineRun %s:%s: %s\n", pr.GetNamespace(), pr.GetName(), err.Error())
 			}
 
@@ -163,6 +204,10 @@ func (h *HasController) WaitForComponentPipelineToBeFinished(component *appservi
 		})
 
 		if err != nil {
+			if pr == nil {
+				// no PipelineRun of the Component was observed, there is nothing to retrigger
+				return err
+			}
 			GinkgoWriter.Printf("attempt %d/%d: PipelineRun %q failed: %+v", attempts, r.Retries+1, pr.GetName(), err)
 			// CouldntGetTask: Retry the PipelineRun only in case we hit the known issue https://issues.redhat.com/browse/SRVKP-2749
 			// TaskRunImagePullFailed: Retry in case of https://issues.redhat.com/browse/RHTAPBUGS-985 and https://github.com/tektoncd/pipeline/issues/7184
@@ -170,7 +215,7 @@ func (h *HasController) WaitForComponentPipelineToBeFinished(component *appservi
 				return err
 			}
 
-			if sha, err = h.RetriggerComponentPipelineRun(component, pr); err != nil {
+			if sha, err = h.RetriggerComponentPipelineRunWithContext(ctx, component, pr); err != nil {
 				return fmt.Errorf("unable to retrigger component %s:%s: %+v", component.GetNamespace(), component.GetName(), err)
 			}
 			attempts++
@@ -184,6 +229,11 @@ func (h *HasController) WaitForComponentPipelineToBeFinished(component *appservi
 
 // Universal method to create a component in the kubernetes clusters.
 func (h *HasController) CreateComponent(componentSpec appservice.ComponentSpec, namespace string, outputContainerImage string, secret string, applicationName string, skipInitialChecks bool, annotations map[string]string) (*appservice.Component, error) {
+	return h.CreateComponentWithContext(context.Background(), componentSpec, namespace, outputContainerImage, secret, applicationName, skipInitialChecks, annotations)
+}
+
+// CreateComponentWithContext is like CreateComponent but it stops as soon as the given context is cancelled.
+func (h *HasController) CreateComponentWithContext(ctx context.Context, componentSpec appservice.ComponentSpec, namespace string, outputContainerImage string, secret string, applicationName string, skipInitialChecks bool, annotations map[string]string) (*appservice.Component, error) {
 	componentObject := &appservice.Component{
 		ObjectMeta: metav1.ObjectMeta{
 			// adding default label because of the BuildPipelineSelector in build test
@@ -214,25 +264,32 @@ func (h *HasController) CreateComponent(componentSpec appservice.ComponentSpec,
 		componentObject.Annotations = utils.MergeMaps(componentObject.Annotations, constants.ImageControllerAnnotationRequestPublicRepo)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*1)
+	createCtx, cancel := context.WithTimeout(ctx, time.Minute*1)
 	defer cancel()
-	if err := h.KubeRest().Create(ctx, componentObject); err != nil {
+	if err := h.KubeRest().Create(createCtx, componentObject); err != nil {
 		return nil, err
 	}
-	if err := utils.WaitUntil(h.ComponentReady(componentObject), time.Minute*10); err != nil {
-		componentObject = h.refreshComponentForErrorDebug(componentObject)
-		return nil, fmt.Errorf("timed out when waiting for component %s to be ready in %s namespace. component: %s", componentSpec.ComponentName, namespace, utils.ToPrettyJSONString(componentObject))
+	w := h.componentWatcher(componentObject)
+	if _, err := w.Until(ctx, time.Minute*10, componentReady); err != nil {
+		storeWaitTimeoutError(err)
+		return nil, fmt.Errorf("timed out when waiting for component %s to be ready in %s namespace: %w", componentSpec.ComponentName, namespace, err)
 	}
 
-	if utils.WaitUntil(h.CheckForImageAnnotation(componentObject), time.Minute*5) != nil {
-		componentObject = h.refreshComponentForErrorDebug(componentObject)
-		return nil, fmt.Errorf("timed out when waiting for image-controller annotations to be updated on component %s in namespace %s. component: %s", componentSpec.ComponentName, namespace, utils.ToPrettyJSONString(componentObject))
+	readyComponent, err := w.Until(ctx, time.Minute*5, imageAnnotationPresent)
+	if err != nil {
+		storeWaitTimeoutError(err)
+		return nil, fmt.Errorf("timed out when waiting for image-controller annotations to be updated on component %s in namespace %s: %w", componentSpec.ComponentName, namespace, err)
 	}
-	return componentObject, nil
+	return readyComponent, nil
 }
 
 // CreateComponentWithDockerSource creates a component based on container image source.
 func (h *HasController) CreateComponentWithDockerSource(applicationName, componentName, namespace, gitSourceURL, containerImageSource, outputContainerImage, secret string) (*appservice.Component, error) {
+	return h.CreateComponentWithDockerSourceWithContext(context.Background(), applicationName, componentName, namespace, gitSourceURL, containerImageSource, outputContainerImage, secret)
+}
+
+// CreateComponentWithDockerSourceWithContext is like CreateComponentWithDockerSource but it stops as soon as the given context is cancelled.
+func (h *HasController) CreateComponentWithDockerSourceWithContext(ctx context.Context, applicationName, componentName, namespace, gitSourceURL, containerImageSource, outputContainerImage, secret string) (*appservice.Component, error) {
 	component := &appservice.Component{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      componentName,
@@ -256,7 +313,7 @@ func (h *HasController) CreateComponentWithDockerSource(applicationName, compone
 			Route:          "",
 		},
 	}
-	err := h.KubeRest().Create(context.Background(), component)
+	err := h.KubeRest().Create(ctx, component)
 	if err != nil {
 		return nil, err
 	}
@@ -265,9 +322,14 @@ func (h *HasController) CreateComponentWithDockerSource(applicationName, compone
 
 // ScaleDeploymentReplicas scales the replicas of a given deployment
 func (h *HasController) ScaleComponentReplicas(component *appservice.Component, replicas *int) (*appservice.Component, error) {
+	return h.ScaleComponentReplicasWithContext(context.Background(), component, replicas)
+}
+
+// ScaleComponentReplicasWithContext is like ScaleComponentReplicas but it stops as soon as the given context is cancelled.
+func (h *HasController) ScaleComponentReplicasWithContext(ctx context.Context, component *appservice.Component, replicas *int) (*appservice.Component, error) {
 	component.Spec.Replicas = replicas
 
-	err := h.KubeRest().Update(context.Background(), component, &rclient.UpdateOptions{})
+	err := h.KubeRest().Update(ctx, component, &rclient.UpdateOptions{})
 	if err != nil {
 		return &appservice.Component{}, err
 	}
@@ -276,6 +338,11 @@ func (h *HasController) ScaleComponentReplicas(component *appservice.Component,
 
 // DeleteComponent delete an has component from a given name and namespace
 func (h *HasController) DeleteComponent(name string, namespace string, reportErrorOnNotFound bool) error {
+	return h.DeleteComponentWithContext(context.Background(), name, namespace, reportErrorOnNotFound)
+}
+
+// DeleteComponentWithContext is like DeleteComponent but it stops as soon as the given context is cancelled.
+func (h *HasController) DeleteComponentWithContext(ctx context.Context, name string, namespace string, reportErrorOnNotFound bool) error {
 	// temporary logs
 	start := time.Now()
 	GinkgoWriter.Printf("Start to delete component '%s' at %s\n", name, start.Format(time.RFC3339))
@@ -286,14 +353,25 @@ func (h *HasController) DeleteComponent(name string, namespace string, reportErr
 			Namespace: namespace,
 		},
 	}
-	if err := h.KubeRest().Delete(context.Background(), &component); err != nil {
+	if err := h.KubeRest().Delete(ctx, &component); err != nil {
 		if !k8sErrors.IsNotFound(err) || (k8sErrors.IsNotFound(err) && reportErrorOnNotFound) {
 			return fmt.Errorf("error deleting a component: %+v", err)
 		}
 	}
 
+	var observed *appservice.Component
+	target := utils.WaitTarget{
+		Description:  fmt.Sprintf("component %s/%s to be deleted", namespace, name),
+		LastObserved: func() *unstructured.Unstructured { return utils.ObservedObject("Component", observed) },
+	}
 	// RHTAPBUGS-978: temporary timeout to 15min
-	err := utils.WaitUntil(h.ComponentDeleted(&component), 15*time.Minute)
+	err := utils.WaitUntilTargetWithContext(ctx, target, func() (bool, error) {
+		c, err := h.GetComponentWithContext(ctx, name, namespace)
+		if err == nil {
+			observed = c
+		}
+		return err != nil && k8sErrors.IsNotFound(err), nil
+	}, time.Second, 15*time.Minute)
 
 	// temporary logs
 	deletionTime := time.Since(start).Minutes()
@@ -304,22 +382,36 @@ func (h *HasController) DeleteComponent(name string, namespace string, reportErr
 
 // DeleteAllComponentsInASpecificNamespace removes all component CRs from a specific namespace. Useful when creating a lot of resources and want to remove all of them
 func (h *HasController) DeleteAllComponentsInASpecificNamespace(namespace string, timeout time.Duration) error {
+	return h.DeleteAllComponentsInASpecificNamespaceWithContext(context.Background(), namespace, timeout)
+}
+
+// DeleteAllComponentsInASpecificNamespaceWithContext is like DeleteAllComponentsInASpecificNamespace but it stops as soon as the given context is cancelled.
+func (h *HasController) DeleteAllComponentsInASpecificNamespaceWithContext(ctx context.Context, namespace string, timeout time.Duration) error {
 	// temporary logs
 	start := time.Now()
 	GinkgoWriter.Println("Start to delete all components in namespace '%s' at %s", namespace, start.String())
 
-	if err := h.KubeRest().DeleteAllOf(context.Background(), &appservice.Component{}, rclient.InNamespace(namespace)); err != nil {
+	if err := h.KubeRest().DeleteAllOf(ctx, &appservice.Component{}, rclient.InNamespace(namespace)); err != nil {
 		return fmt.Errorf("error deleting components from the namespace %s: %+v", namespace, err)
 	}
 
 	componentList := &appservice.ComponentList{}
+	target := utils.WaitTarget{
+		Description: fmt.Sprintf("all components in %s namespace to be deleted", namespace),
+		LastObserved: func() *unstructured.Unstructured {
+			if len(componentList.Items) == 0 {
+				return nil
+			}
+			return utils.ObservedObject("Component", &componentList.Items[0])
+		},
+	}
 
-	err := utils.WaitUntil(func() (done bool, err error) {
-		if err := h.KubeRest().List(context.Background(), componentList, &rclient.ListOptions{Namespace: namespace}); err != nil {
+	err := utils.WaitUntilTargetWithContext(ctx, target, func() (done bool, err error) {
+		if err := h.KubeRest().List(ctx, componentList, &rclient.ListOptions{Namespace: namespace}); err != nil {
 			return false, nil
 		}
 		return len(componentList.Items) == 0, nil
-	}, timeout)
+	}, time.Second, timeout)
 
 	// temporary logs
 	deletionTime := time.Since(start).Minutes()
@@ -330,8 +422,13 @@ func (h *HasController) DeleteAllComponentsInASpecificNamespace(namespace string
 
 // Waits for a component to be reconciled in the application service.
 func (h *HasController) ComponentReady(component *appservice.Component) wait.ConditionFunc {
+	return h.ComponentReadyWithContext(context.Background(), component)
+}
+
+// ComponentReadyWithContext is like ComponentReady but it stops as soon as the given context is cancelled.
+func (h *HasController) ComponentReadyWithContext(ctx context.Context, component *appservice.Component) wait.ConditionFunc {
 	return func() (bool, error) {
-		messages, err := h.GetComponentConditionStatusMessages(component.Name, component.Namespace)
+		messages, err := h.GetComponentConditionStatusMessagesWithContext(ctx, component.Name, component.Namespace)
 		if err != nil {
 			return false, nil
 		}
@@ -344,17 +441,60 @@ func (h *HasController) ComponentReady(component *appservice.Component) wait.Con
 	}
 }
 
+// componentWatcher returns a watcher of a given component which reports the changes of its conditions.
+func (h *HasController) componentWatcher(component *appservice.Component) *watcher.Watcher[appservice.Component] {
+	w := watcher.New[appservice.Component](h.DynamicClient(), appservice.GroupVersion.WithResource("components"), component.Namespace)
+	w.Name = component.Name
+	w.State = func(c *appservice.Component) string {
+		return watcher.ConditionsState(c.Status.Conditions)
+	}
+	return w
+}
+
+// storeWaitTimeoutError stores the last observed state from a failed wait as artifacts of the current spec.
+func storeWaitTimeoutError(err error) {
+	if storeErr := logs.StoreWaitTimeoutError(err); storeErr != nil {
+		GinkgoWriter.Printf("failed to store the last observed state: %v\n", storeErr)
+	}
+}
+
+// componentReady is the watch condition equivalent to ComponentReady.
+func componentReady(component *appservice.Component) (bool, error) {
+	for _, condition := range component.Status.Conditions {
+		if strings.Contains(condition.Message, "success") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// imageAnnotationPresent is the watch condition equivalent to CheckForImageAnnotation.
+func imageAnnotationPresent(component *appservice.Component) (bool, error) {
+	annotations := component.GetAnnotations()
+	return build.IsImageAnnotationPresent(annotations) && build.ImageRepoCreationSucceeded(annotations), nil
+}
+
 // Waits for a component until is deleted and if not will return an error
 func (h *HasController) ComponentDeleted(component *appservice.Component) wait.ConditionFunc {
+	return h.ComponentDeletedWithContext(context.Background(), component)
+}
+
+// ComponentDeletedWithContext is like ComponentDeleted but it stops as soon as the given context is cancelled.
+func (h *HasController) ComponentDeletedWithContext(ctx context.Context, component *appservice.Component) wait.ConditionFunc {
 	return func() (bool, error) {
-		_, err := h.GetComponent(component.Name, component.Namespace)
+		_, err := h.GetComponentWithContext(ctx, component.Name, component.Namespace)
 		return err != nil && k8sErrors.IsNotFound(err), nil
 	}
 }
 
 // Get the message from the status of a component. Usefull for debugging purposes.
 func (h *HasController) GetComponentConditionStatusMessages(name, namespace string) (messages []string, err error) {
-	c, err := h.GetComponent(name, namespace)
+	return h.GetComponentConditionStatusMessagesWithContext(context.Background(), name, namespace)
+}
+
+// GetComponentConditionStatusMessagesWithContext is like GetComponentConditionStatusMessages but it stops as soon as the given context is cancelled.
+func (h *HasController) GetComponentConditionStatusMessagesWithContext(ctx context.Context, name, namespace string) (messages []string, err error) {
+	c, err := h.GetComponentWithContext(ctx, name, namespace)
 	if err != nil {
 		return messages, fmt.Errorf("error getting HAS component: %v", err)
 	}
@@ -366,7 +506,12 @@ func (h *HasController) GetComponentConditionStatusMessages(name, namespace stri
 
 // Universal method to retrigger pipelineruns in kubernetes cluster
 func (h *HasController) RetriggerComponentPipelineRun(component *appservice.Component, pr *pipeline.PipelineRun) (sha string, err error) {
-	if err = h.KubeRest().Delete(context.Background(), pr); err != nil {
+	return h.RetriggerComponentPipelineRunWithContext(context.Background(), component, pr)
+}
+
+// RetriggerComponentPipelineRunWithContext is like RetriggerComponentPipelineRun but it stops as soon as the given context is cancelled.
+func (h *HasController) RetriggerComponentPipelineRunWithContext(ctx context.Context, component *appservice.Component, pr *pipeline.PipelineRun) (sha string, err error) {
+	if err = h.KubeRest().Delete(ctx, pr); err != nil {
 		return "", fmt.Errorf("failed to delete PipelineRun %q from %q namespace with error: %v", pr.GetName(), pr.GetNamespace(), err)
 	}
 
@@ -409,12 +554,12 @@ func (h *HasController) RetriggerComponentPipelineRun(component *appservice.Comp
 		// in Component CR
 	} else {
 		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			component, err := h.GetComponent(component.GetName(), component.GetNamespace())
+			component, err := h.GetComponentWithContext(ctx, component.GetName(), component.GetNamespace())
 			if err != nil {
 				return fmt.Errorf("failed to get component for PipelineRun %q in %q namespace: %+v", pr.GetName(), pr.GetNamespace(), err)
 			}
 			component.Annotations = utils.MergeMaps(component.Annotations, constants.ComponentTriggerSimpleBuildAnnotation)
-			if err = h.KubeRest().Update(context.Background(), component); err != nil {
+			if err = h.KubeRest().Update(ctx, component); err != nil {
 				return fmt.Errorf("failed to update Component %q in %q namespace", component.GetName(), component.GetNamespace())
 			}
 			return err
@@ -424,7 +569,7 @@ func (h *HasController) RetriggerComponentPipelineRun(component *appservice.Comp
 			return "", err
 		}
 	}
-	watch, err := h.PipelineClient().TektonV1().PipelineRuns(component.GetNamespace()).Watch(context.Background(), metav1.ListOptions{})
+	watch, err := h.PipelineClient().TektonV1().PipelineRuns(component.GetNamespace()).Watch(ctx, metav1.ListOptions{})
 	if err != nil {
 		return "", fmt.Errorf("error when initiating watch for new PipelineRun after retriggering it for component %s:%s", component.GetNamespace(), component.GetName())
 	}
@@ -453,21 +598,14 @@ func (h *HasController) RetriggerComponentPipelineRun(component *appservice.Comp
 	return sha, nil
 }
 
-// refreshComponentForErrorDebug returns the latest component object from the kubernetes cluster.
-func (h *HasController) refreshComponentForErrorDebug(component *appservice.Component) *appservice.Component {
-	retComp := &appservice.Component{}
-	key := rclient.ObjectKeyFromObject(component)
-	err := h.KubeRest().Get(context.Background(), key, retComp)
-	if err != nil {
-		//TODO let's log this somehow, but return the original component obj, as that is better than nothing
-		return component
-	}
-	return retComp
+func (h *HasController) CheckForImageAnnotation(component *appservice.Component) wait.ConditionFunc {
+	return h.CheckForImageAnnotationWithContext(context.Background(), component)
 }
 
-func (h *HasController) CheckForImageAnnotation(component *appservice.Component) wait.ConditionFunc {
+// CheckForImageAnnotationWithContext is like CheckForImageAnnotation but it stops as soon as the given context is cancelled.
+func (h *HasController) CheckForImageAnnotationWithContext(ctx context.Context, component *appservice.Component) wait.ConditionFunc {
 	return func() (bool, error) {
-		componentCR, err := h.GetComponent(component.Name, component.Namespace)
+		componentCR, err := h.GetComponentWithContext(ctx, component.Name, component.Namespace)
 		if err != nil {
 			klog.Errorf("failed to get component %s with error: %+v", component.Name, err)
 			return false, nil
@@ -479,7 +617,12 @@ func (h *HasController) CheckForImageAnnotation(component *appservice.Component)
 
 // Gets value of a specified annotation in a component
 func (h *HasController) GetComponentAnnotation(componentName, annotationKey, namespace string) (string, error) {
-	component, err := h.GetComponent(componentName, namespace)
+	return h.GetComponentAnnotationWithContext(context.Background(), componentName, annotationKey, namespace)
+}
+
+// GetComponentAnnotationWithContext is like GetComponentAnnotation but it stops as soon as the given context is cancelled.
+func (h *HasController) GetComponentAnnotationWithContext(ctx context.Context, componentName, annotationKey, namespace string) (string, error) {
+	component, err := h.GetComponentWithContext(ctx, componentName, namespace)
 	if err != nil {
 		return "", fmt.Errorf("error when getting component: %+v", err)
 	}
@@ -488,14 +631,19 @@ func (h *HasController) GetComponentAnnotation(componentName, annotationKey, nam
 
 // Sets annotation in a component
 func (h *HasController) SetComponentAnnotation(componentName, annotationKey, annotationValue, namespace string) error {
-	component, err := h.GetComponent(componentName, namespace)
+	return h.SetComponentAnnotationWithContext(context.Background(), componentName, annotationKey, annotationValue, namespace)
+}
+
+// SetComponentAnnotationWithContext is like SetComponentAnnotation but it stops as soon as the given context is cancelled.
+func (h *HasController) SetComponentAnnotationWithContext(ctx context.Context, componentName, annotationKey, annotationValue, namespace string) error {
+	component, err := h.GetComponentWithContext(ctx, componentName, namespace)
 	if err != nil {
 		return fmt.Errorf("error when getting component: %+v", err)
 	}
 	newAnnotations := component.GetAnnotations()
 	newAnnotations[annotationKey] = annotationValue
 	component.SetAnnotations(newAnnotations)
-	err = h.KubeRest().Update(context.Background(), component)
+	err = h.KubeRest().Update(ctx, component)
 	if err != nil {
 		return fmt.Errorf("error when updating component: %+v", err)
 	}
@@ -504,9 +652,14 @@ func (h *HasController) SetComponentAnnotation(componentName, annotationKey, ann
 
 // StoreComponent stores a given Component as an artifact.
 func (h *HasController) StoreComponent(component *appservice.Component) error {
+	return h.StoreComponentWithContext(context.Background(), component)
+}
+
+// StoreComponentWithContext is like StoreComponent but it stops as soon as the given context is cancelled.
+func (h *HasController) StoreComponentWithContext(ctx context.Context, component *appservice.Component) error {
 	artifacts := make(map[string][]byte)
 
-	componentConditionStatus, err := h.GetComponentConditionStatusMessages(component.Name, component.Namespace)
+	componentConditionStatus, err := h.GetComponentConditionStatusMessagesWithContext(ctx, component.Name, component.Namespace)
 	if err != nil {
 		return err
 	}
@@ -527,13 +680,18 @@ func (h *HasController) StoreComponent(component *appservice.Component) error {
 
 // StoreAllComponents stores all Components in a given namespace.
 func (h *HasController) StoreAllComponents(namespace string) error {
+	return h.StoreAllComponentsWithContext(context.Background(), namespace)
+}
+
+// StoreAllComponentsWithContext is like StoreAllComponents but it stops as soon as the given context is cancelled.
+func (h *HasController) StoreAllComponentsWithContext(ctx context.Context, namespace string) error {
 	componentList := &appservice.ComponentList{}
-	if err := h.KubeRest().List(context.Background(), componentList, &rclient.ListOptions{Namespace: namespace}); err != nil {
+	if err := h.KubeRest().List(ctx, componentList, &rclient.ListOptions{Namespace: namespace}); err != nil {
 		return err
 	}
 
 	for _, component := range componentList.Items {
-		if err := h.StoreComponent(&component); err != nil {
+		if err := h.StoreComponentWithContext(ctx, &component); err != nil {
 			return err
 		}
 	}
@@ -542,6 +700,11 @@ func (h *HasController) StoreAllComponents(namespace string) error {
 
 // specific for tests/remote-secret/image-repository-cr-image-pull-remote-secret.go
 func (h *HasController) CreateComponentWithoutGenerateAnnotation(componentSpec appservice.ComponentSpec, namespace string, secret string, applicationName string, skipInitialChecks bool) (*appservice.Component, error) {
+	return h.CreateComponentWithoutGenerateAnnotationWithContext(context.Background(), componentSpec, namespace, secret, applicationName, skipInitialChecks)
+}
+
+// CreateComponentWithoutGenerateAnnotationWithContext is like CreateComponentWithoutGenerateAnnotation but it stops as soon as the given context is cancelled.
+func (h *HasController) CreateComponentWithoutGenerateAnnotationWithContext(ctx context.Context, componentSpec appservice.ComponentSpec, namespace string, secret string, applicationName string, skipInitialChecks bool) (*appservice.Component, error) {
 	componentObject := &appservice.Component{
 		ObjectMeta: metav1.ObjectMeta{
 			// adding default label because of the BuildPipelineSelector in build test
@@ -561,14 +724,15 @@ func (h *HasController) CreateComponentWithoutGenerateAnnotation(componentSpec a
 		componentObject.Spec.TargetPort = 8081
 	}
 
-	if err := h.KubeRest().Create(context.Background(), componentObject); err != nil {
+	if err := h.KubeRest().Create(ctx, componentObject); err != nil {
 		return nil, err
 	}
 
-	if err := utils.WaitUntil(h.ComponentReady(componentObject), time.Minute*10); err != nil {
-		componentObject = h.refreshComponentForErrorDebug(componentObject)
-		return nil, fmt.Errorf("timed out when waiting for component %s to be ready in %s namespace. component: %s", componentSpec.ComponentName, namespace, utils.ToPrettyJSONString(componentObject))
+	readyComponent, err := h.componentWatcher(componentObject).Until(ctx, time.Minute*10, componentReady)
+	if err != nil {
+		storeWaitTimeoutError(err)
+		return nil, fmt.Errorf("timed out when waiting for component %s to be ready in %s namespace: %w", componentSpec.ComponentName, namespace, err)
 	}
 
-	return componentObject, nil
+	return readyComponent, nil
 }
@@ -0,0 +1,111 @@
+package has
+
+import (
+	"context"
+	"time"
+
+	appservice "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	kubeCl "github.com/redhat-appstudio/e2e-tests/pkg/clients/kubernetes"
+	"github.com/redhat-appstudio/e2e-tests/pkg/clients/tekton"
+	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// ApplicationClient operates with application-service Application resources.
+type ApplicationClient interface {
+	GetApplication(name string, namespace string) (*appservice.Application, error)
+	GetApplicationWithContext(ctx context.Context, name string, namespace string) (*appservice.Application, error)
+	ApplicationDevfilePresent(application *appservice.Application) wait.ConditionFunc
+	ApplicationDevfilePresentWithContext(ctx context.Context, application *appservice.Application) wait.ConditionFunc
+	ApplicationGitopsRepoExists(devfileContent string) wait.ConditionFunc
+	CreateApplication(name string, namespace string) (*appservice.Application, error)
+	CreateApplicationWithContext(ctx context.Context, name string, namespace string) (*appservice.Application, error)
+	CreateApplicationWithTimeout(name string, namespace string, timeout time.Duration) (*appservice.Application, error)
+	CreateApplicationWithTimeoutWithContext(ctx context.Context, name string, namespace string, timeout time.Duration) (*appservice.Application, error)
+	DeleteApplication(name string, namespace string, reportErrorOnNotFound bool) error
+	DeleteApplicationWithContext(ctx context.Context, name string, namespace string, reportErrorOnNotFound bool) error
+	ApplicationDeleted(application *appservice.Application) wait.ConditionFunc
+	ApplicationDeletedWithContext(ctx context.Context, application *appservice.Application) wait.ConditionFunc
+	DeleteAllApplicationsInASpecificNamespace(namespace string, timeout time.Duration) error
+	DeleteAllApplicationsInASpecificNamespaceWithContext(ctx context.Context, namespace string, timeout time.Duration) error
+	ListAllApplications(namespace string) (*appservice.ApplicationList, error)
+	ListAllApplicationsWithContext(ctx context.Context, namespace string) (*appservice.ApplicationList, error)
+	StoreApplication(application *appservice.Application) error
+	StoreAllApplications(namespace string) error
+	StoreAllApplicationsWithContext(ctx context.Context, namespace string) error
+}
+
+// ComponentClient operates with application-service Component resources.
+type ComponentClient interface {
+	GetComponent(name string, namespace string) (*appservice.Component, error)
+	GetComponentWithContext(ctx context.Context, name string, namespace string) (*appservice.Component, error)
+	GetComponentByApplicationName(applicationName string, namespace string) (*appservice.Component, error)
+	GetComponentByApplicationNameWithContext(ctx context.Context, applicationName string, namespace string) (*appservice.Component, error)
+	GetComponentPipelineRun(componentName string, applicationName string, namespace, sha string) (*pipeline.PipelineRun, error)
+	GetComponentPipelineRunWithContext(ctx context.Context, componentName string, applicationName string, namespace, sha string) (*pipeline.PipelineRun, error)
+	GetComponentPipelineRunWithType(componentName string, applicationName string, namespace, pipelineType string, sha string) (*pipeline.PipelineRun, error)
+	GetComponentPipelineRunWithTypeWithContext(ctx context.Context, componentName string, applicationName string, namespace, pipelineType string, sha string) (*pipeline.PipelineRun, error)
+	GetAllPipelineRunsForApplication(applicationName, namespace string) (*pipeline.PipelineRunList, error)
+	GetAllPipelineRunsForApplicationWithContext(ctx context.Context, applicationName, namespace string) (*pipeline.PipelineRunList, error)
+	WaitForComponentPipelineToBeFinished(component *appservice.Component, sha string, t tekton.PipelineRunClient, r *RetryOptions) error
+	WaitForComponentPipelineToBeFinishedWithContext(ctx context.Context, component *appservice.Component, sha string, t tekton.PipelineRunClient, r *RetryOptions) error
+	CreateComponent(componentSpec appservice.ComponentSpec, namespace string, outputContainerImage string, secret string, applicationName string, skipInitialChecks bool, annotations map[string]string) (*appservice.Component, error)
+	CreateComponentWithContext(ctx context.Context, componentSpec appservice.ComponentSpec, namespace string, outputContainerImage string, secret string, applicationName string, skipInitialChecks bool, annotations map[string]string) (*appservice.Component, error)
+	CreateComponentWithDockerSource(applicationName, componentName, namespace, gitSourceURL, containerImageSource, outputContainerImage, secret string) (*appservice.Component, error)
+	CreateComponentWithDockerSourceWithContext(ctx context.Context, applicationName, componentName, namespace, gitSourceURL, containerImageSource, outputContainerImage, secret string) (*appservice.Component, error)
+	ScaleComponentReplicas(component *appservice.Component, replicas *int) (*appservice.Component, error)
+	ScaleComponentReplicasWithContext(ctx context.Context, component *appservice.Component, replicas *int) (*appservice.Component, error)
+	DeleteComponent(name string, namespace string, reportErrorOnNotFound bool) error
+	DeleteComponentWithContext(ctx context.Context, name string, namespace string, reportErrorOnNotFound bool) error
+	DeleteAllComponentsInASpecificNamespace(namespace string, timeout time.Duration) error
+	DeleteAllComponentsInASpecificNamespaceWithContext(ctx context.Context, namespace string, timeout time.Duration) error
+	ComponentReady(component *appservice.Component) wait.ConditionFunc
+	ComponentReadyWithContext(ctx context.Context, component *appservice.Component) wait.ConditionFunc
+	ComponentDeleted(component *appservice.Component) wait.ConditionFunc
+	ComponentDeletedWithContext(ctx context.Context, component *appservice.Component) wait.ConditionFunc
+	GetComponentConditionStatusMessages(name, namespace string) (messages []string, err error)
+	GetComponentConditionStatusMessagesWithContext(ctx context.Context, name, namespace string) (messages []string, err error)
+	RetriggerComponentPipelineRun(component *appservice.Component, pr *pipeline.PipelineRun) (sha string, err error)
+	RetriggerComponentPipelineRunWithContext(ctx context.Context, component *appservice.Component, pr *pipeline.PipelineRun) (sha string, err error)
+	CheckForImageAnnotation(component *appservice.Component) wait.ConditionFunc
+	CheckForImageAnnotationWithContext(ctx context.Context, component *appservice.Component) wait.ConditionFunc
+	GetComponentAnnotation(componentName, annotationKey, namespace string) (string, error)
+	GetComponentAnnotationWithContext(ctx context.Context, componentName, annotationKey, namespace string) (string, error)
+	SetComponentAnnotation(componentName, annotationKey, annotationValue, namespace string) error
+	SetComponentAnnotationWithContext(ctx context.Context, componentName, annotationKey, annotationValue, namespace string) error
+	StoreComponent(component *appservice.Component) error
+	StoreComponentWithContext(ctx context.Context, component *appservice.Component) error
+	StoreAllComponents(namespace string) error
+	StoreAllComponentsWithContext(ctx context.Context, namespace string) error
+	CreateComponentWithoutGenerateAnnotation(componentSpec appservice.ComponentSpec, namespace string, secret string, applicationName string, skipInitialChecks bool) (*appservice.Component, error)
+	CreateComponentWithoutGenerateAnnotationWithContext(ctx context.Context, componentSpec appservice.ComponentSpec, namespace string, secret string, applicationName string, skipInitialChecks bool) (*appservice.Component, error)
+}
+
+// ComponentDetectionQueryClient operates with application-service ComponentDetectionQuery resources.
+type ComponentDetectionQueryClient interface {
+	GetComponentDetectionQuery(name, namespace string) (*appservice.ComponentDetectionQuery, error)
+	GetComponentDetectionQueryWithContext(ctx context.Context, name, namespace string) (*appservice.ComponentDetectionQuery, error)
+	CreateComponentDetectionQuery(name string, namespace string, gitSourceURL string, gitSourceRevision string, gitSourceContext string, secret string, isMultiComponent bool) (*appservice.ComponentDetectionQuery, error)
+	CreateComponentDetectionQueryWithContext(ctx context.Context, name string, namespace string, gitSourceURL string, gitSourceRevision string, gitSourceContext string, secret string, isMultiComponent bool) (*appservice.ComponentDetectionQuery, error)
+	CreateComponentDetectionQueryWithTimeout(name string, namespace string, gitSourceURL string, gitSourceRevision string, gitSourceContext string, secret string, isMultiComponent bool, timeout time.Duration) (*appservice.ComponentDetectionQuery, error)
+	CreateComponentDetectionQueryWithTimeoutWithContext(ctx context.Context, name string, namespace string, gitSourceURL string, gitSourceRevision string, gitSourceContext string, secret string, isMultiComponent bool, timeout time.Duration) (*appservice.ComponentDetectionQuery, error)
+	DeleteAllComponentDetectionQueriesInASpecificNamespace(namespace string, timeout time.Duration) error
+	DeleteAllComponentDetectionQueriesInASpecificNamespaceWithContext(ctx context.Context, namespace string, timeout time.Duration) error
+	ListAllComponentDetectionQueries(namespace string) (*appservice.ComponentDetectionQueryList, error)
+	ListAllComponentDetectionQueriesWithContext(ctx context.Context, namespace string) (*appservice.ComponentDetectionQueryList, error)
+	StoreComponentDetectionQuery(ComponentDetectionQuery *appservice.ComponentDetectionQuery) error
+	StoreAllComponentDetectionQueries(namespace string) error
+	StoreAllComponentDetectionQueriesWithContext(ctx context.Context, namespace string) error
+	UpdateComponent(component *appservice.Component) error
+	UpdateComponentWithContext(ctx context.Context, component *appservice.Component) error
+}
+
+// Interface groups all the operations available for the application-service. It is implemented by HasController.
+type Interface interface {
+	kubeCl.Interface
+	ApplicationClient
+	ComponentClient
+	ComponentDetectionQueryClient
+}
+
+var _ Interface = &HasController{}
@@ -0,0 +1,20 @@
+package imagecontroller
+
+import (
+	kubeCl "github.com/redhat-appstudio/e2e-tests/pkg/clients/kubernetes"
+	"github.com/redhat-appstudio/image-controller/api/v1alpha1"
+)
+
+// ImageRepositoryClient operates with ImageRepositories.
+type ImageRepositoryClient interface {
+	CreateImageRepositoryCR(name, namespace, applicationName, componentName string) (*v1alpha1.ImageRepository, error)
+	GetImageRepositoryCR(name, namespace string) (*v1alpha1.ImageRepository, error)
+}
+
+// Interface groups all the operations available for the image-controller. It is implemented by ImageController.
+type Interface interface {
+	kubeCl.Interface
+	ImageRepositoryClient
+}
+
+var _ Interface = &ImageController{}
@@ -15,6 +15,11 @@ import (
 
 // CreateIntegrationTestScenario creates new integrationTestScenario.
 func (i *IntegrationController) CreateIntegrationTestScenario(applicationName, namespace, bundleURL, pipelineName string) (*integrationv1alpha1.IntegrationTestScenario, error) {
+	return i.CreateIntegrationTestScenarioWithContext(context.Background(), applicationName, namespace, bundleURL, pipelineName)
+}
+
+// CreateIntegrationTestScenarioWithContext is like CreateIntegrationTestScenario but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) CreateIntegrationTestScenarioWithContext(ctx context.Context, applicationName, namespace, bundleURL, pipelineName string) (*integrationv1alpha1.IntegrationTestScenario, error) {
 	integrationTestScenario := &integrationv1alpha1.IntegrationTestScenario{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "example-pass-" + util.GenerateRandomString(4),
@@ -28,7 +33,7 @@ func (i *IntegrationController) CreateIntegrationTestScenario(applicationName, n
 		},
 	}
 
-	err := i.KubeRest().Create(context.Background(), integrationTestScenario)
+	err := i.KubeRest().Create(ctx, integrationTestScenario)
 	if err != nil {
 		return nil, err
 	}
@@ -38,6 +43,11 @@ func (i *IntegrationController) CreateIntegrationTestScenario(applicationName, n
 // CreateIntegrationTestScenarioWithEnvironment will create an IntegrationTestScenario with a
 // user-supplied environment embedded in its Spec.Environment
 func (i *IntegrationController) CreateIntegrationTestScenarioWithEnvironment(applicationName, namespace, gitURL, revision, pathInRepo string, environment *appservice.Environment) (*integrationv1beta1.IntegrationTestScenario, error) {
+	return i.CreateIntegrationTestScenarioWithEnvironmentWithContext(context.Background(), applicationName, namespace, gitURL, revision, pathInRepo, environment)
+}
+
+// CreateIntegrationTestScenarioWithEnvironmentWithContext is like CreateIntegrationTestScenarioWithEnvironment but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) CreateIntegrationTestScenarioWithEnvironmentWithContext(ctx context.Context, applicationName, namespace, gitURL, revision, pathInRepo string, environment *appservice.Environment) (*integrationv1beta1.IntegrationTestScenario, error) {
 	integrationTestScenario := &integrationv1beta1.IntegrationTestScenario{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "example-pass-with-env-" + util.GenerateRandomString(4),
@@ -78,7 +88,7 @@ func (i *IntegrationController) CreateIntegrationTestScenarioWithEnvironment(app
 		},
 	}
 
-	err := i.KubeRest().Create(context.Background(), integrationTestScenario)
+	err := i.KubeRest().Create(ctx, integrationTestScenario)
 	if err != nil {
 		return nil, fmt.Errorf("error occurred when creating the IntegrationTestScenario: %+v", err)
 	}
@@ -87,6 +97,11 @@ func (i *IntegrationController) CreateIntegrationTestScenarioWithEnvironment(app
 
 // CreateIntegrationTestScenario_beta1 creates new beta1 version integrationTestScenario.
 func (i *IntegrationController) CreateIntegrationTestScenario_beta1(applicationName, namespace, gitURL, revision, pathInRepo string) (*integrationv1beta1.IntegrationTestScenario, error) {
+	return i.CreateIntegrationTestScenario_beta1WithContext(context.Background(), applicationName, namespace, gitURL, revision, pathInRepo)
+}
+
+// CreateIntegrationTestScenario_beta1WithContext is like CreateIntegrationTestScenario_beta1 but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) CreateIntegrationTestScenario_beta1WithContext(ctx context.Context, applicationName, namespace, gitURL, revision, pathInRepo string) (*integrationv1beta1.IntegrationTestScenario, error) {
 	integrationTestScenario := &integrationv1beta1.IntegrationTestScenario{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "my-integration-test-" + util.GenerateRandomString(4),
@@ -115,7 +130,7 @@ func (i *IntegrationController) CreateIntegrationTestScenario_beta1(applicationN
 		},
 	}
 
-	err := i.KubeRest().Create(context.Background(), integrationTestScenario)
+	err := i.KubeRest().Create(ctx, integrationTestScenario)
 	if err != nil {
 		return nil, err
 	}
@@ -124,12 +139,17 @@ func (i *IntegrationController) CreateIntegrationTestScenario_beta1(applicationN
 
 // Get return the status from the Application Custom Resource object.
 func (i *IntegrationController) GetIntegrationTestScenarios(applicationName, namespace string) (*[]integrationv1beta1.IntegrationTestScenario, error) {
+	return i.GetIntegrationTestScenariosWithContext(context.Background(), applicationName, namespace)
+}
+
+// GetIntegrationTestScenariosWithContext is like GetIntegrationTestScenarios but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) GetIntegrationTestScenariosWithContext(ctx context.Context, applicationName, namespace string) (*[]integrationv1beta1.IntegrationTestScenario, error) {
 	opts := []client.ListOption{
 		client.InNamespace(namespace),
 	}
 
 	integrationTestScenarioList := &integrationv1beta1.IntegrationTestScenarioList{}
-	err := i.KubeRest().List(context.Background(), integrationTestScenarioList, opts...)
+	err := i.KubeRest().List(ctx, integrationTestScenarioList, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -145,6 +165,11 @@ func (i *IntegrationController) GetIntegrationTestScenarios(applicationName, nam
 
 // DeleteIntegrationTestScenario removes given testScenario from specified namespace.
 func (i *IntegrationController) DeleteIntegrationTestScenario(testScenario *integrationv1beta1.IntegrationTestScenario, namespace string) error {
-	err := i.KubeRest().Delete(context.Background(), testScenario)
+	return i.DeleteIntegrationTestScenarioWithContext(context.Background(), testScenario, namespace)
+}
+
+// DeleteIntegrationTestScenarioWithContext is like DeleteIntegrationTestScenario but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) DeleteIntegrationTestScenarioWithContext(ctx context.Context, testScenario *integrationv1beta1.IntegrationTestScenario, namespace string) error {
+	err := i.KubeRest().Delete(ctx, testScenario)
 	return err
 }
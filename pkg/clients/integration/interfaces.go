@@ -0,0 +1,87 @@
+package integration
+
+import (
+	"context"
+	"time"
+
+	appstudioApi "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	kubeCl "github.com/redhat-appstudio/e2e-tests/pkg/clients/kubernetes"
+	integrationv1alpha1 "github.com/redhat-appstudio/integration-service/api/v1alpha1"
+	integrationv1beta1 "github.com/redhat-appstudio/integration-service/api/v1beta1"
+	intgteststat "github.com/redhat-appstudio/integration-service/pkg/integrationteststatus"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// IntegrationTestScenarioClient operates with IntegrationTestScenarios.
+type IntegrationTestScenarioClient interface {
+	CreateIntegrationTestScenario(applicationName, namespace, bundleURL, pipelineName string) (*integrationv1alpha1.IntegrationTestScenario, error)
+	CreateIntegrationTestScenarioWithContext(ctx context.Context, applicationName, namespace, bundleURL, pipelineName string) (*integrationv1alpha1.IntegrationTestScenario, error)
+	CreateIntegrationTestScenarioWithEnvironment(applicationName, namespace, gitURL, revision, pathInRepo string, environment *appstudioApi.Environment) (*integrationv1beta1.IntegrationTestScenario, error)
+	CreateIntegrationTestScenarioWithEnvironmentWithContext(ctx context.Context, applicationName, namespace, gitURL, revision, pathInRepo string, environment *appstudioApi.Environment) (*integrationv1beta1.IntegrationTestScenario, error)
+	CreateIntegrationTestScenario_beta1(applicationName, namespace, gitURL, revision, pathInRepo string) (*integrationv1beta1.IntegrationTestScenario, error)
+	CreateIntegrationTestScenario_beta1WithContext(ctx context.Context, applicationName, namespace, gitURL, revision, pathInRepo string) (*integrationv1beta1.IntegrationTestScenario, error)
+	GetIntegrationTestScenarios(applicationName, namespace string) (*[]integrationv1beta1.IntegrationTestScenario, error)
+	GetIntegrationTestScenariosWithContext(ctx context.Context, applicationName, namespace string) (*[]integrationv1beta1.IntegrationTestScenario, error)
+	DeleteIntegrationTestScenario(testScenario *integrationv1beta1.IntegrationTestScenario, namespace string) error
+	DeleteIntegrationTestScenarioWithContext(ctx context.Context, testScenario *integrationv1beta1.IntegrationTestScenario, namespace string) error
+}
+
+// PipelineRunClient operates with build and integration PipelineRuns.
+type PipelineRunClient interface {
+	CreateIntegrationPipelineRun(snapshotName, namespace, componentName, integrationTestScenarioName string) (*tektonv1.PipelineRun, error)
+	CreateIntegrationPipelineRunWithContext(ctx context.Context, snapshotName, namespace, componentName, integrationTestScenarioName string) (*tektonv1.PipelineRun, error)
+	GetBuildPipelineRun(componentName, applicationName, namespace string, pacBuild bool, sha string) (*tektonv1.PipelineRun, error)
+	GetBuildPipelineRunWithContext(ctx context.Context, componentName, applicationName, namespace string, pacBuild bool, sha string) (*tektonv1.PipelineRun, error)
+	GetIntegrationPipelineRun(integrationTestScenarioName string, snapshotName string, namespace string) (*tektonv1.PipelineRun, error)
+	GetIntegrationPipelineRunWithContext(ctx context.Context, integrationTestScenarioName string, snapshotName string, namespace string) (*tektonv1.PipelineRun, error)
+	WaitForIntegrationPipelineToGetStarted(testScenarioName, snapshotName, appNamespace string) (*tektonv1.PipelineRun, error)
+	WaitForIntegrationPipelineToGetStartedWithContext(ctx context.Context, testScenarioName, snapshotName, appNamespace string) (*tektonv1.PipelineRun, error)
+	WaitForIntegrationPipelineToBeFinished(testScenario *integrationv1beta1.IntegrationTestScenario, snapshot *appstudioApi.Snapshot, appNamespace string) error
+	WaitForIntegrationPipelineToBeFinishedWithContext(ctx context.Context, testScenario *integrationv1beta1.IntegrationTestScenario, snapshot *appstudioApi.Snapshot, appNamespace string) error
+	WaitForAllIntegrationPipelinesToBeFinished(testNamespace, applicationName string, snapshot *appstudioApi.Snapshot) error
+	WaitForAllIntegrationPipelinesToBeFinishedWithContext(ctx context.Context, testNamespace, applicationName string, snapshot *appstudioApi.Snapshot) error
+	WaitForFinalizerToGetRemovedFromAllIntegrationPipelineRuns(testNamespace, applicationName string, snapshot *appstudioApi.Snapshot) error
+	WaitForFinalizerToGetRemovedFromAllIntegrationPipelineRunsWithContext(ctx context.Context, testNamespace, applicationName string, snapshot *appstudioApi.Snapshot) error
+	WaitForFinalizerToGetRemovedFromIntegrationPipeline(testScenario *integrationv1beta1.IntegrationTestScenario, snapshot *appstudioApi.Snapshot, appNamespace string) error
+	WaitForFinalizerToGetRemovedFromIntegrationPipelineWithContext(ctx context.Context, testScenario *integrationv1beta1.IntegrationTestScenario, snapshot *appstudioApi.Snapshot, appNamespace string) error
+	GetAnnotationIfExists(testNamespace, applicationName, componentName, annotationKey string) (string, error)
+	GetAnnotationIfExistsWithContext(ctx context.Context, testNamespace, applicationName, componentName, annotationKey string) (string, error)
+	WaitForBuildPipelineRunToGetAnnotated(testNamespace, applicationName, componentName, annotationKey string) error
+	WaitForBuildPipelineRunToGetAnnotatedWithContext(ctx context.Context, testNamespace, applicationName, componentName, annotationKey string) error
+}
+
+// SnapshotClient operates with Snapshots.
+type SnapshotClient interface {
+	CreateSnapshotWithComponents(snapshotName, componentName, applicationName, namespace string, snapshotComponents []appstudioApi.SnapshotComponent) (*appstudioApi.Snapshot, error)
+	CreateSnapshotWithComponentsWithContext(ctx context.Context, snapshotName, componentName, applicationName, namespace string, snapshotComponents []appstudioApi.SnapshotComponent) (*appstudioApi.Snapshot, error)
+	CreateSnapshotWithImage(componentName, applicationName, namespace, containerImage string) (*appstudioApi.Snapshot, error)
+	CreateSnapshotWithImageWithContext(ctx context.Context, componentName, applicationName, namespace, containerImage string) (*appstudioApi.Snapshot, error)
+	GetSnapshotByComponent(namespace string) (*appstudioApi.Snapshot, error)
+	GetSnapshotByComponentWithContext(ctx context.Context, namespace string) (*appstudioApi.Snapshot, error)
+	GetSnapshot(snapshotName, pipelineRunName, componentName, namespace string) (*appstudioApi.Snapshot, error)
+	GetSnapshotWithContext(ctx context.Context, snapshotName, pipelineRunName, componentName, namespace string) (*appstudioApi.Snapshot, error)
+	DeleteSnapshot(hasSnapshot *appstudioApi.Snapshot, namespace string) error
+	DeleteSnapshotWithContext(ctx context.Context, hasSnapshot *appstudioApi.Snapshot, namespace string) error
+	PatchSnapshot(oldSnapshot *appstudioApi.Snapshot, newSnapshot *appstudioApi.Snapshot) error
+	PatchSnapshotWithContext(ctx context.Context, oldSnapshot *appstudioApi.Snapshot, newSnapshot *appstudioApi.Snapshot) error
+	DeleteAllSnapshotsInASpecificNamespace(namespace string, timeout time.Duration) error
+	DeleteAllSnapshotsInASpecificNamespaceWithContext(ctx context.Context, namespace string, timeout time.Duration) error
+	WaitForSnapshotToGetCreated(snapshotName, pipelinerunName, componentName, testNamespace string) (*appstudioApi.Snapshot, error)
+	WaitForSnapshotToGetCreatedWithContext(ctx context.Context, snapshotName, pipelinerunName, componentName, testNamespace string) (*appstudioApi.Snapshot, error)
+	ListAllSnapshots(namespace string) (*appstudioApi.SnapshotList, error)
+	ListAllSnapshotsWithContext(ctx context.Context, namespace string) (*appstudioApi.SnapshotList, error)
+	StoreSnapshot(snapshot *appstudioApi.Snapshot) error
+	StoreAllSnapshots(namespace string) error
+	StoreAllSnapshotsWithContext(ctx context.Context, namespace string) error
+	GetIntegrationTestStatusDetailFromSnapshot(snapshot *appstudioApi.Snapshot, scenarioName string) (*intgteststat.IntegrationTestStatusDetail, error)
+}
+
+// Interface groups all the operations available for the integration-service. It is implemented by IntegrationController.
+type Interface interface {
+	kubeCl.Interface
+	IntegrationTestScenarioClient
+	PipelineRunClient
+	SnapshotClient
+}
+
+var _ Interface = &IntegrationController{}
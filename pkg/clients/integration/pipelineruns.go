@@ -8,15 +8,14 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	appstudioApi "github.com/redhat-appstudio/application-api/api/v1alpha1"
-	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
 	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
 	"github.com/redhat-appstudio/e2e-tests/pkg/utils/tekton"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils/watcher"
 	integrationv1beta1 "github.com/redhat-appstudio/integration-service/api/v1beta1"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"knative.dev/pkg/apis"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -24,6 +23,11 @@ import (
 
 // CreateIntegrationPipelineRun creates new integrationPipelineRun.
 func (i *IntegrationController) CreateIntegrationPipelineRun(snapshotName, namespace, componentName, integrationTestScenarioName string) (*tektonv1.PipelineRun, error) {
+	return i.CreateIntegrationPipelineRunWithContext(context.Background(), snapshotName, namespace, componentName, integrationTestScenarioName)
+}
+
+// CreateIntegrationPipelineRunWithContext is like CreateIntegrationPipelineRun but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) CreateIntegrationPipelineRunWithContext(ctx context.Context, snapshotName, namespace, componentName, integrationTestScenarioName string) (*tektonv1.PipelineRun, error) {
 	testpipelineRun := &tektonv1.PipelineRun{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "component-pipelinerun" + "-",
@@ -52,7 +56,7 @@ func (i *IntegrationController) CreateIntegrationPipelineRun(snapshotName, names
 			},
 		},
 	}
-	err := i.KubeRest().Create(context.Background(), testpipelineRun)
+	err := i.KubeRest().Create(ctx, testpipelineRun)
 	if err != nil {
 		return nil, err
 	}
@@ -62,55 +66,75 @@ func (i *IntegrationController) CreateIntegrationPipelineRun(snapshotName, names
 // GetComponentPipeline returns the pipeline for a given component labels.
 // In case of failure, this function retries till it gets timed out.
 func (i *IntegrationController) GetBuildPipelineRun(componentName, applicationName, namespace string, pacBuild bool, sha string) (*tektonv1.PipelineRun, error) {
-	var pipelineRun *tektonv1.PipelineRun
-
-	err := wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 20*time.Minute, true, func(ctx context.Context) (done bool, err error) {
-		pipelineRunLabels := map[string]string{"appstudio.openshift.io/component": componentName, "appstudio.openshift.io/application": applicationName, "pipelines.appstudio.openshift.io/type": "build"}
+	return i.GetBuildPipelineRunWithContext(context.Background(), componentName, applicationName, namespace, pacBuild, sha)
+}
 
-		if sha != "" {
-			pipelineRunLabels["pipelinesascode.tekton.dev/sha"] = sha
-		}
+// GetBuildPipelineRunWithContext is like GetBuildPipelineRun but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) GetBuildPipelineRunWithContext(ctx context.Context, componentName, applicationName, namespace string, pacBuild bool, sha string) (*tektonv1.PipelineRun, error) {
+	pipelineRunLabels := map[string]string{"appstudio.openshift.io/component": componentName, "appstudio.openshift.io/application": applicationName, "pipelines.appstudio.openshift.io/type": "build"}
 
-		list := &tektonv1.PipelineRunList{}
-		err = i.KubeRest().List(context.Background(), list, &client.ListOptions{LabelSelector: labels.SelectorFromSet(pipelineRunLabels), Namespace: namespace})
+	if sha != "" {
+		pipelineRunLabels["pipelinesascode.tekton.dev/sha"] = sha
+	}
 
-		if err != nil && !k8sErrors.IsNotFound(err) {
-			GinkgoWriter.Printf("error listing pipelineruns in %s namespace: %v", namespace, err)
-			return false, nil
-		}
+	// wait until the first pipelineRun shows up, then pick the latest one
+	_, err := i.pipelineRunWatcher(namespace, pipelineRunLabels).Until(ctx, 20*time.Minute, func(pipelineRun *tektonv1.PipelineRun) (bool, error) {
+		return true, nil
+	})
+	if err != nil {
+		GinkgoWriter.Printf("no pipelinerun found for component %s %s", componentName, utils.GetAdditionalInfo(applicationName, namespace))
+		return &tektonv1.PipelineRun{}, err
+	}
 
-		if len(list.Items) > 0 {
-			// sort PipelineRuns by StartTime in ascending order
-			sort.Slice(list.Items, func(i, j int) bool {
-				return list.Items[i].Status.StartTime.Before(list.Items[j].Status.StartTime)
-			})
-			// get latest pipelineRun
-			pipelineRun = &list.Items[len(list.Items)-1]
-			return true, nil
-		}
+	list := &tektonv1.PipelineRunList{}
+	err = i.KubeRest().List(ctx, list, &client.ListOptions{LabelSelector: labels.SelectorFromSet(pipelineRunLabels), Namespace: namespace})
+	if err != nil {
+		return &tektonv1.PipelineRun{}, fmt.Errorf("error listing pipelineruns in %s namespace: %v", namespace, err)
+	}
+	if len(list.Items) == 0 {
+		return &tektonv1.PipelineRun{}, fmt.Errorf("no pipelinerun found for component %s %s", componentName, utils.GetAdditionalInfo(applicationName, namespace))
+	}
 
-		pipelineRun = &tektonv1.PipelineRun{}
-		GinkgoWriter.Printf("no pipelinerun found for component %s %s", componentName, utils.GetAdditionalInfo(applicationName, namespace))
-		return false, nil
+	// sort PipelineRuns by StartTime in ascending order
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].Status.StartTime.Before(list.Items[j].Status.StartTime)
 	})
+	// get latest pipelineRun
+	return &list.Items[len(list.Items)-1], nil
+}
 
-	return pipelineRun, err
+// pipelineRunWatcher returns a watcher of the pipelineRuns with given labels which reports the changes of their Succeeded condition.
+func (i *IntegrationController) pipelineRunWatcher(namespace string, pipelineRunLabels map[string]string) *watcher.Watcher[tektonv1.PipelineRun] {
+	w := watcher.New[tektonv1.PipelineRun](i.DynamicClient(), tektonv1.SchemeGroupVersion.WithResource("pipelineruns"), namespace)
+	w.LabelSelector = labels.SelectorFromSet(pipelineRunLabels)
+	w.State = tekton.PipelineRunState
+	return w
+}
+
+// integrationPipelineRunLabels returns the labels of the integration pipelineRun for a given scenario and snapshot.
+func integrationPipelineRunLabels(integrationTestScenarioName, snapshotName string) map[string]string {
+	return map[string]string{
+		"pipelines.appstudio.openshift.io/type": "test",
+		"test.appstudio.openshift.io/scenario":  integrationTestScenarioName,
+		"appstudio.openshift.io/snapshot":       snapshotName,
+	}
 }
 
 // GetIntegrationPipelineRun returns the integration pipelineRun
 // for a given scenario, snapshot labels.
 func (i *IntegrationController) GetIntegrationPipelineRun(integrationTestScenarioName string, snapshotName string, namespace string) (*tektonv1.PipelineRun, error) {
+	return i.GetIntegrationPipelineRunWithContext(context.Background(), integrationTestScenarioName, snapshotName, namespace)
+}
+
+// GetIntegrationPipelineRunWithContext is like GetIntegrationPipelineRun but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) GetIntegrationPipelineRunWithContext(ctx context.Context, integrationTestScenarioName string, snapshotName string, namespace string) (*tektonv1.PipelineRun, error) {
 	opts := []client.ListOption{
 		client.InNamespace(namespace),
-		client.MatchingLabels{
-			"pipelines.appstudio.openshift.io/type": "test",
-			"test.appstudio.openshift.io/scenario":  integrationTestScenarioName,
-			"appstudio.openshift.io/snapshot":       snapshotName,
-		},
+		client.MatchingLabels(integrationPipelineRunLabels(integrationTestScenarioName, snapshotName)),
 	}
 
 	list := &tektonv1.PipelineRunList{}
-	err := i.KubeRest().List(context.Background(), list, opts...)
+	err := i.KubeRest().List(ctx, list, opts...)
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return nil, fmt.Errorf("error listing pipelineruns in %s namespace", namespace)
@@ -126,20 +150,18 @@ func (i *IntegrationController) GetIntegrationPipelineRun(integrationTestScenari
 // WaitForIntegrationPipelineToGetStarted wait for given integration pipeline to get started.
 // In case of failure, this function retries till it gets timed out.
 func (i *IntegrationController) WaitForIntegrationPipelineToGetStarted(testScenarioName, snapshotName, appNamespace string) (*tektonv1.PipelineRun, error) {
-	var testPipelinerun *tektonv1.PipelineRun
+	return i.WaitForIntegrationPipelineToGetStartedWithContext(context.Background(), testScenarioName, snapshotName, appNamespace)
+}
 
-	err := wait.PollUntilContextTimeout(context.Background(), time.Second*2, time.Minute*5, true, func(ctx context.Context) (done bool, err error) {
-		testPipelinerun, err = i.GetIntegrationPipelineRun(testScenarioName, snapshotName, appNamespace)
-		if err != nil {
-			GinkgoWriter.Println("PipelineRun has not been created yet for test scenario %s and snapshot %s/%s", testScenarioName, appNamespace, snapshotName)
-			return false, nil
-		}
-		if !testPipelinerun.HasStarted() {
-			GinkgoWriter.Println("pipelinerun %s/%s hasn't started yet", testPipelinerun.GetNamespace(), testPipelinerun.GetName())
-			return false, nil
-		}
-		return true, nil
+// WaitForIntegrationPipelineToGetStartedWithContext is like WaitForIntegrationPipelineToGetStarted but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) WaitForIntegrationPipelineToGetStartedWithContext(ctx context.Context, testScenarioName, snapshotName, appNamespace string) (*tektonv1.PipelineRun, error) {
+	GinkgoWriter.Printf("Waiting for the PipelineRun of test scenario %s and snapshot %s/%s to start\n", testScenarioName, appNamespace, snapshotName)
+	testPipelinerun, err := i.pipelineRunWatcher(appNamespace, integrationPipelineRunLabels(testScenarioName, snapshotName)).Until(ctx, time.Minute*5, func(pipelineRun *tektonv1.PipelineRun) (bool, error) {
+		return pipelineRun.HasStarted(), nil
 	})
+	if testPipelinerun == nil {
+		testPipelinerun = &tektonv1.PipelineRun{}
+	}
 
 	return testPipelinerun, err
 }
@@ -147,39 +169,40 @@ func (i *IntegrationController) WaitForIntegrationPipelineToGetStarted(testScena
 // WaitForIntegrationPipelineToBeFinished wait for given integration pipeline to finish.
 // In case of failure, this function retries till it gets timed out.
 func (i *IntegrationController) WaitForIntegrationPipelineToBeFinished(testScenario *integrationv1beta1.IntegrationTestScenario, snapshot *appstudioApi.Snapshot, appNamespace string) error {
-	return wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 20*time.Minute, true, func(ctx context.Context) (done bool, err error) {
-		pipelineRun, err := i.GetIntegrationPipelineRun(testScenario.Name, snapshot.Name, appNamespace)
-		if err != nil {
-			GinkgoWriter.Println("PipelineRun has not been created yet for test scenario %s and snapshot %s/%s", testScenario.GetName(), snapshot.GetNamespace(), snapshot.GetName())
+	return i.WaitForIntegrationPipelineToBeFinishedWithContext(context.Background(), testScenario, snapshot, appNamespace)
+}
+
+// WaitForIntegrationPipelineToBeFinishedWithContext is like WaitForIntegrationPipelineToBeFinished but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) WaitForIntegrationPipelineToBeFinishedWithContext(ctx context.Context, testScenario *integrationv1beta1.IntegrationTestScenario, snapshot *appstudioApi.Snapshot, appNamespace string) error {
+	GinkgoWriter.Printf("Waiting for the PipelineRun of test scenario %s and snapshot %s/%s to finish\n", testScenario.GetName(), snapshot.GetNamespace(), snapshot.GetName())
+	_, err := i.pipelineRunWatcher(appNamespace, integrationPipelineRunLabels(testScenario.Name, snapshot.Name)).Until(ctx, 20*time.Minute, func(pipelineRun *tektonv1.PipelineRun) (bool, error) {
+		if !pipelineRun.IsDone() {
 			return false, nil
 		}
-		for _, condition := range pipelineRun.Status.Conditions {
-			GinkgoWriter.Printf("PipelineRun %s reason: %s\n", pipelineRun.Name, condition.Reason)
-
-			if !pipelineRun.IsDone() {
-				return false, nil
-			}
-
-			if pipelineRun.GetStatusCondition().GetCondition(apis.ConditionSucceeded).IsTrue() {
-				return true, nil
-			} else {
-				return false, fmt.Errorf(tekton.GetFailedPipelineRunLogs(i.KubeRest(), i.KubeInterface(), pipelineRun))
-			}
+
+		if pipelineRun.GetStatusCondition().GetCondition(apis.ConditionSucceeded).IsTrue() {
+			return true, nil
 		}
-		return false, nil
+		return false, fmt.Errorf(tekton.GetFailedPipelineRunLogs(i.KubeRest(), i.KubeInterface(), pipelineRun))
 	})
+	return err
 }
 
 // WaitForAllIntegrationPipelinesToBeFinished wait for all integration pipelines to finish.
 func (i *IntegrationController) WaitForAllIntegrationPipelinesToBeFinished(testNamespace, applicationName string, snapshot *appstudioApi.Snapshot) error {
-	integrationTestScenarios, err := i.GetIntegrationTestScenarios(applicationName, testNamespace)
+	return i.WaitForAllIntegrationPipelinesToBeFinishedWithContext(context.Background(), testNamespace, applicationName, snapshot)
+}
+
+// WaitForAllIntegrationPipelinesToBeFinishedWithContext is like WaitForAllIntegrationPipelinesToBeFinished but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) WaitForAllIntegrationPipelinesToBeFinishedWithContext(ctx context.Context, testNamespace, applicationName string, snapshot *appstudioApi.Snapshot) error {
+	integrationTestScenarios, err := i.GetIntegrationTestScenariosWithContext(ctx, applicationName, testNamespace)
 	if err != nil {
 		return fmt.Errorf("unable to get IntegrationTestScenarios for Application %s/%s. Error: %v", testNamespace, applicationName, err)
 	}
 
 	for _, testScenario := range *integrationTestScenarios {
 		GinkgoWriter.Printf("Integration test scenario %s is found\n", testScenario.Name)
-		err = i.WaitForIntegrationPipelineToBeFinished(&testScenario, snapshot, testNamespace)
+		err = i.WaitForIntegrationPipelineToBeFinishedWithContext(ctx, &testScenario, snapshot, testNamespace)
 		if err != nil {
 			return fmt.Errorf("error occurred while waiting for Integration PLR (associated with IntegrationTestScenario: %s) to get finished in %s namespace. Error: %v", testScenario.Name, testNamespace, err)
 		}
@@ -192,7 +215,12 @@ func (i *IntegrationController) WaitForAllIntegrationPipelinesToBeFinished(testN
 // the given finalizer to get removed from all integration pipelinesruns
 // that are related to the given application and namespace.
 func (i *IntegrationController) WaitForFinalizerToGetRemovedFromAllIntegrationPipelineRuns(testNamespace, applicationName string, snapshot *appstudioApi.Snapshot) error {
-	integrationTestScenarios, err := i.GetIntegrationTestScenarios(applicationName, testNamespace)
+	return i.WaitForFinalizerToGetRemovedFromAllIntegrationPipelineRunsWithContext(context.Background(), testNamespace, applicationName, snapshot)
+}
+
+// WaitForFinalizerToGetRemovedFromAllIntegrationPipelineRunsWithContext is like WaitForFinalizerToGetRemovedFromAllIntegrationPipelineRuns but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) WaitForFinalizerToGetRemovedFromAllIntegrationPipelineRunsWithContext(ctx context.Context, testNamespace, applicationName string, snapshot *appstudioApi.Snapshot) error {
+	integrationTestScenarios, err := i.GetIntegrationTestScenariosWithContext(ctx, applicationName, testNamespace)
 	if err != nil {
 		return fmt.Errorf("unable to get IntegrationTestScenarios for Application %s/%s. Error: %v", testNamespace, applicationName, err)
 	}
@@ -200,7 +228,7 @@ func (i *IntegrationController) WaitForFinalizerToGetRemovedFromAllIntegrationPi
 	for _, testScenario := range *integrationTestScenarios {
 		testScenario := testScenario
 		GinkgoWriter.Printf("Integration test scenario %s is found\n", testScenario.Name)
-		err = i.WaitForFinalizerToGetRemovedFromIntegrationPipeline(&testScenario, snapshot, testNamespace)
+		err = i.WaitForFinalizerToGetRemovedFromIntegrationPipelineWithContext(ctx, &testScenario, snapshot, testNamespace)
 		if err != nil {
 			return fmt.Errorf("error occurred while waiting for Integration PLR (associated with IntegrationTestScenario: %s) to NOT have the finalizer. Error: %v", testScenario.Name, err)
 		}
@@ -212,24 +240,25 @@ func (i *IntegrationController) WaitForFinalizerToGetRemovedFromAllIntegrationPi
 // WaitForFinalizerToGetRemovedFromIntegrationPipeline waits for the
 // given finalizer to get removed from the given integration pipelinerun
 func (i *IntegrationController) WaitForFinalizerToGetRemovedFromIntegrationPipeline(testScenario *integrationv1beta1.IntegrationTestScenario, snapshot *appstudioApi.Snapshot, appNamespace string) error {
-	return wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 10*time.Minute, true, func(ctx context.Context) (done bool, err error) {
-		pipelineRun, err := i.GetIntegrationPipelineRun(testScenario.Name, snapshot.Name, appNamespace)
-		if err != nil {
-			GinkgoWriter.Println("PipelineRun has not been created yet for test scenario %s and snapshot %s/%s", testScenario.GetName(), snapshot.GetNamespace(), snapshot.GetName())
-			return false, nil
-		}
-		if controllerutil.ContainsFinalizer(pipelineRun, "test.appstudio.openshift.io/pipelinerun") {
-			GinkgoWriter.Printf("build pipelineRun %s/%s still contains the finalizer: %s", pipelineRun.GetNamespace(), pipelineRun.GetName(), "test.appstudio.openshift.io/pipelinerun")
-			return false, nil
-		}
+	return i.WaitForFinalizerToGetRemovedFromIntegrationPipelineWithContext(context.Background(), testScenario, snapshot, appNamespace)
+}
 
-		return true, nil
+// WaitForFinalizerToGetRemovedFromIntegrationPipelineWithContext is like WaitForFinalizerToGetRemovedFromIntegrationPipeline but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) WaitForFinalizerToGetRemovedFromIntegrationPipelineWithContext(ctx context.Context, testScenario *integrationv1beta1.IntegrationTestScenario, snapshot *appstudioApi.Snapshot, appNamespace string) error {
+	_, err := i.pipelineRunWatcher(appNamespace, integrationPipelineRunLabels(testScenario.Name, snapshot.Name)).Until(ctx, 10*time.Minute, func(pipelineRun *tektonv1.PipelineRun) (bool, error) {
+		return !controllerutil.ContainsFinalizer(pipelineRun, "test.appstudio.openshift.io/pipelinerun"), nil
 	})
+	return err
 }
 
 // GetAnnotationIfExists returns the value of a given annotation within a pipelinerun, if it exists.
 func (i *IntegrationController) GetAnnotationIfExists(testNamespace, applicationName, componentName, annotationKey string) (string, error) {
-	pipelineRun, err := i.GetBuildPipelineRun(componentName, applicationName, testNamespace, false, "")
+	return i.GetAnnotationIfExistsWithContext(context.Background(), testNamespace, applicationName, componentName, annotationKey)
+}
+
+// GetAnnotationIfExistsWithContext is like GetAnnotationIfExists but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) GetAnnotationIfExistsWithContext(ctx context.Context, testNamespace, applicationName, componentName, annotationKey string) (string, error) {
+	pipelineRun, err := i.GetBuildPipelineRunWithContext(ctx, componentName, applicationName, testNamespace, false, "")
 	if err != nil {
 		return "", fmt.Errorf("pipelinerun for Component %s/%s can't be gotten successfully. Error: %v", testNamespace, componentName, err)
 	}
@@ -239,18 +268,20 @@ func (i *IntegrationController) GetAnnotationIfExists(testNamespace, application
 // WaitForBuildPipelineRunToGetAnnotated waits for given build pipeline to get annotated with a specific annotation.
 // In case of failure, this function retries till it gets timed out.
 func (i *IntegrationController) WaitForBuildPipelineRunToGetAnnotated(testNamespace, applicationName, componentName, annotationKey string) error {
-	return wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 5*time.Minute, true, func(ctx context.Context) (done bool, err error) {
-		pipelineRun, err := i.GetBuildPipelineRun(componentName, applicationName, testNamespace, false, "")
-		if err != nil {
-			GinkgoWriter.Printf("pipelinerun for Component %s/%s can't be gotten successfully. Error: %v", testNamespace, componentName, err)
-			return false, nil
-		}
+	return i.WaitForBuildPipelineRunToGetAnnotatedWithContext(context.Background(), testNamespace, applicationName, componentName, annotationKey)
+}
+
+// WaitForBuildPipelineRunToGetAnnotatedWithContext is like WaitForBuildPipelineRunToGetAnnotated but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) WaitForBuildPipelineRunToGetAnnotatedWithContext(ctx context.Context, testNamespace, applicationName, componentName, annotationKey string) error {
+	pipelineRunLabels := map[string]string{"appstudio.openshift.io/component": componentName, "appstudio.openshift.io/application": applicationName, "pipelines.appstudio.openshift.io/type": "build"}
 
-		annotationValue, _ := i.GetAnnotationIfExists(testNamespace, applicationName, componentName, annotationKey)
-		if annotationValue == "" {
-			GinkgoWriter.Printf("build pipelinerun %s/%s doesn't contain annotation %s yet", testNamespace, pipelineRun.Name, annotationKey)
+	_, err := i.pipelineRunWatcher(testNamespace, pipelineRunLabels).Until(ctx, 5*time.Minute, func(pipelineRun *tektonv1.PipelineRun) (bool, error) {
+		if pipelineRun.GetAnnotations()[annotationKey] == "" {
 			return false, nil
 		}
-		return true, nil
+		// the annotation is expected on the latest build pipelineRun
+		annotationValue, err := i.GetAnnotationIfExistsWithContext(ctx, testNamespace, applicationName, componentName, annotationKey)
+		return err == nil && annotationValue != "", nil
 	})
+	return err
 }
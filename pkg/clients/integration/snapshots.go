@@ -6,15 +6,14 @@ import (
 	"time"
 
 	"github.com/devfile/library/v2/pkg/util"
-	. "github.com/onsi/ginkgo/v2"
 	appstudioApi "github.com/redhat-appstudio/application-api/api/v1alpha1"
-	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
 	"github.com/redhat-appstudio/e2e-tests/pkg/logs"
 	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils/watcher"
 	intgteststat "github.com/redhat-appstudio/integration-service/pkg/integrationteststatus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -23,6 +22,11 @@ const SnapshotTestsStatusAnnotation = "test.appstudio.openshift.io/status"
 
 // CreateSnapshotWithComponents creates a Snapshot using the given parameters.
 func (i *IntegrationController) CreateSnapshotWithComponents(snapshotName, componentName, applicationName, namespace string, snapshotComponents []appstudioApi.SnapshotComponent) (*appstudioApi.Snapshot, error) {
+	return i.CreateSnapshotWithComponentsWithContext(context.Background(), snapshotName, componentName, applicationName, namespace, snapshotComponents)
+}
+
+// CreateSnapshotWithComponentsWithContext is like CreateSnapshotWithComponents but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) CreateSnapshotWithComponentsWithContext(ctx context.Context, snapshotName, componentName, applicationName, namespace string, snapshotComponents []appstudioApi.SnapshotComponent) (*appstudioApi.Snapshot, error) {
 	snapshot := &appstudioApi.Snapshot{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      snapshotName,
@@ -38,11 +42,16 @@ func (i *IntegrationController) CreateSnapshotWithComponents(snapshotName, compo
 			Components:  snapshotComponents,
 		},
 	}
-	return snapshot, i.KubeRest().Create(context.Background(), snapshot)
+	return snapshot, i.KubeRest().Create(ctx, snapshot)
 }
 
 // CreateSnapshotWithImage creates a snapshot using an image.
 func (i *IntegrationController) CreateSnapshotWithImage(componentName, applicationName, namespace, containerImage string) (*appstudioApi.Snapshot, error) {
+	return i.CreateSnapshotWithImageWithContext(context.Background(), componentName, applicationName, namespace, containerImage)
+}
+
+// CreateSnapshotWithImageWithContext is like CreateSnapshotWithImage but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) CreateSnapshotWithImageWithContext(ctx context.Context, componentName, applicationName, namespace, containerImage string) (*appstudioApi.Snapshot, error) {
 	snapshotComponents := []appstudioApi.SnapshotComponent{
 		{
 			Name:           componentName,
@@ -52,11 +61,16 @@ func (i *IntegrationController) CreateSnapshotWithImage(componentName, applicati
 
 	snapshotName := "snapshot-sample-" + util.GenerateRandomString(4)
 
-	return i.CreateSnapshotWithComponents(snapshotName, componentName, applicationName, namespace, snapshotComponents)
+	return i.CreateSnapshotWithComponentsWithContext(ctx, snapshotName, componentName, applicationName, namespace, snapshotComponents)
 }
 
 // GetSnapshotByComponent returns the first snapshot in namespace if exist, else will return nil
 func (i *IntegrationController) GetSnapshotByComponent(namespace string) (*appstudioApi.Snapshot, error) {
+	return i.GetSnapshotByComponentWithContext(context.Background(), namespace)
+}
+
+// GetSnapshotByComponentWithContext is like GetSnapshotByComponent but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) GetSnapshotByComponentWithContext(ctx context.Context, namespace string) (*appstudioApi.Snapshot, error) {
 	snapshot := &appstudioApi.SnapshotList{}
 	opts := []client.ListOption{
 		client.MatchingLabels{
@@ -64,7 +78,7 @@ func (i *IntegrationController) GetSnapshotByComponent(namespace string) (*appst
 		},
 		client.InNamespace(namespace),
 	}
-	err := i.KubeRest().List(context.Background(), snapshot, opts...)
+	err := i.KubeRest().List(ctx, snapshot, opts...)
 
 	if err == nil && len(snapshot.Items) > 0 {
 		return &snapshot.Items[0], nil
@@ -76,7 +90,11 @@ func (i *IntegrationController) GetSnapshotByComponent(namespace string) (*appst
 // It will search for the Snapshot based on the Snapshot name, associated PipelineRun name or Component name
 // In the case the List operation fails, an error will be returned.
 func (i *IntegrationController) GetSnapshot(snapshotName, pipelineRunName, componentName, namespace string) (*appstudioApi.Snapshot, error) {
-	ctx := context.Background()
+	return i.GetSnapshotWithContext(context.Background(), snapshotName, pipelineRunName, componentName, namespace)
+}
+
+// GetSnapshotWithContext is like GetSnapshot but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) GetSnapshotWithContext(ctx context.Context, snapshotName, pipelineRunName, componentName, namespace string) (*appstudioApi.Snapshot, error) {
 	// If Snapshot name is provided, try to get the resource directly
 	if len(snapshotName) > 0 {
 		snapshot := &appstudioApi.Snapshot{}
@@ -114,53 +132,85 @@ func (i *IntegrationController) GetSnapshot(snapshotName, pipelineRunName, compo
 
 // DeleteSnapshot removes given snapshot from specified namespace.
 func (i *IntegrationController) DeleteSnapshot(hasSnapshot *appstudioApi.Snapshot, namespace string) error {
-	err := i.KubeRest().Delete(context.Background(), hasSnapshot)
+	return i.DeleteSnapshotWithContext(context.Background(), hasSnapshot, namespace)
+}
+
+// DeleteSnapshotWithContext is like DeleteSnapshot but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) DeleteSnapshotWithContext(ctx context.Context, hasSnapshot *appstudioApi.Snapshot, namespace string) error {
+	err := i.KubeRest().Delete(ctx, hasSnapshot)
 	return err
 }
 
 // PatchSnapshot patches the given snapshot with the provided patch.
 func (i *IntegrationController) PatchSnapshot(oldSnapshot *appstudioApi.Snapshot, newSnapshot *appstudioApi.Snapshot) error {
+	return i.PatchSnapshotWithContext(context.Background(), oldSnapshot, newSnapshot)
+}
+
+// PatchSnapshotWithContext is like PatchSnapshot but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) PatchSnapshotWithContext(ctx context.Context, oldSnapshot *appstudioApi.Snapshot, newSnapshot *appstudioApi.Snapshot) error {
 	patch := client.MergeFrom(oldSnapshot)
-	err := i.KubeRest().Patch(context.Background(), newSnapshot, patch)
+	err := i.KubeRest().Patch(ctx, newSnapshot, patch)
 	return err
 }
 
 // DeleteAllSnapshotsInASpecificNamespace removes all snapshots from a specific namespace. Useful when creating a lot of resources and want to remove all of them
 func (i *IntegrationController) DeleteAllSnapshotsInASpecificNamespace(namespace string, timeout time.Duration) error {
-	if err := i.KubeRest().DeleteAllOf(context.Background(), &appstudioApi.Snapshot{}, client.InNamespace(namespace)); err != nil {
+	return i.DeleteAllSnapshotsInASpecificNamespaceWithContext(context.Background(), namespace, timeout)
+}
+
+// DeleteAllSnapshotsInASpecificNamespaceWithContext is like DeleteAllSnapshotsInASpecificNamespace but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) DeleteAllSnapshotsInASpecificNamespaceWithContext(ctx context.Context, namespace string, timeout time.Duration) error {
+	if err := i.KubeRest().DeleteAllOf(ctx, &appstudioApi.Snapshot{}, client.InNamespace(namespace)); err != nil {
 		return fmt.Errorf("error deleting snapshots from the namespace %s: %+v", namespace, err)
 	}
 
-	return utils.WaitUntil(func() (done bool, err error) {
-		snapshotList, err := i.ListAllSnapshots(namespace)
+	var remaining *appstudioApi.Snapshot
+	target := utils.WaitTarget{
+		Description:  fmt.Sprintf("all snapshots in %s namespace to be deleted", namespace),
+		LastObserved: func() *unstructured.Unstructured { return utils.ObservedObject("Snapshot", remaining) },
+	}
+	return utils.WaitUntilTargetWithContext(ctx, target, func() (done bool, err error) {
+		snapshotList, err := i.ListAllSnapshotsWithContext(ctx, namespace)
 		if err != nil {
 			return false, nil
 		}
+		if len(snapshotList.Items) > 0 {
+			remaining = &snapshotList.Items[0]
+		}
 		return len(snapshotList.Items) == 0, nil
-	}, timeout)
+	}, time.Second, timeout)
 }
 
 // WaitForSnapshotToGetCreated wait for the Snapshot to get created successfully.
 func (i *IntegrationController) WaitForSnapshotToGetCreated(snapshotName, pipelinerunName, componentName, testNamespace string) (*appstudioApi.Snapshot, error) {
-	var snapshot *appstudioApi.Snapshot
+	return i.WaitForSnapshotToGetCreatedWithContext(context.Background(), snapshotName, pipelinerunName, componentName, testNamespace)
+}
 
-	err := wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 10*time.Minute, true, func(ctx context.Context) (done bool, err error) {
-		snapshot, err = i.GetSnapshot(snapshotName, pipelinerunName, componentName, testNamespace)
-		if err != nil {
-			GinkgoWriter.Printf("unable to get the Snapshot within the namespace %s. Error: %v", testNamespace, err)
-			return false, nil
-		}
+// WaitForSnapshotToGetCreatedWithContext is like WaitForSnapshotToGetCreated but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) WaitForSnapshotToGetCreatedWithContext(ctx context.Context, snapshotName, pipelinerunName, componentName, testNamespace string) (*appstudioApi.Snapshot, error) {
+	w := watcher.New[appstudioApi.Snapshot](i.DynamicClient(), appstudioApi.GroupVersion.WithResource("snapshots"), testNamespace)
+	w.Name = snapshotName
+	w.State = func(snapshot *appstudioApi.Snapshot) string {
+		return watcher.ConditionsState(snapshot.Status.Conditions)
+	}
 
-		return true, nil
+	// same lookup as GetSnapshot: by name if provided, otherwise by the pipelineRun or the component label
+	return w.Until(ctx, 10*time.Minute, func(snapshot *appstudioApi.Snapshot) (bool, error) {
+		return len(snapshotName) > 0 ||
+			(len(pipelinerunName) > 0 && snapshot.Labels["appstudio.openshift.io/build-pipelinerun"] == pipelinerunName) ||
+			(len(componentName) > 0 && snapshot.Labels["appstudio.openshift.io/component"] == componentName), nil
 	})
-
-	return snapshot, err
 }
 
 // ListAllSnapshots returns a list of all Snapshots in a given namespace.
 func (i *IntegrationController) ListAllSnapshots(namespace string) (*appstudioApi.SnapshotList, error) {
+	return i.ListAllSnapshotsWithContext(context.Background(), namespace)
+}
+
+// ListAllSnapshotsWithContext is like ListAllSnapshots but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) ListAllSnapshotsWithContext(ctx context.Context, namespace string) (*appstudioApi.SnapshotList, error) {
 	snapshotList := &appstudioApi.SnapshotList{}
-	err := i.KubeRest().List(context.Background(), snapshotList, &client.ListOptions{Namespace: namespace})
+	err := i.KubeRest().List(ctx, snapshotList, &client.ListOptions{Namespace: namespace})
 
 	return snapshotList, err
 }
@@ -172,7 +222,12 @@ func (i *IntegrationController) StoreSnapshot(snapshot *appstudioApi.Snapshot) e
 
 // StoreAllSnapshots stores all Snapshots in a given namespace.
 func (i *IntegrationController) StoreAllSnapshots(namespace string) error {
-	snapshotList, err := i.ListAllSnapshots(namespace)
+	return i.StoreAllSnapshotsWithContext(context.Background(), namespace)
+}
+
+// StoreAllSnapshotsWithContext is like StoreAllSnapshots but it stops as soon as the given context is cancelled.
+func (i *IntegrationController) StoreAllSnapshotsWithContext(ctx context.Context, namespace string) error {
+	snapshotList, err := i.ListAllSnapshotsWithContext(ctx, namespace)
 	if err != nil {
 		return err
 	}
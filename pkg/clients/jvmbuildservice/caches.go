@@ -8,20 +8,27 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 
 	"github.com/redhat-appstudio/e2e-tests/pkg/clients/common"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
 	"github.com/redhat-appstudio/jvm-build-service/pkg/apis/jvmbuildservice/v1alpha1"
 
 	v1 "k8s.io/api/apps/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // WaitForCache waits for cache to exist.
-func (j *JvmbuildserviceController) WaitForCache(commonctrl *common.SuiteController, testNamespace string) error {
-	return wait.PollUntilContextTimeout(context.Background(), 5*time.Second, 5*time.Minute, true, func(ctx context.Context) (bool, error) {
+func (j *JvmbuildserviceController) WaitForCache(commonctrl common.DeploymentClient, testNamespace string) error {
+	var observed *v1.Deployment
+	target := utils.WaitTarget{
+		Description:  fmt.Sprintf("JBS cache deployment %s/%s to be available", testNamespace, v1alpha1.CacheDeploymentName),
+		LastObserved: func() *unstructured.Unstructured { return utils.ObservedObject("Deployment", observed) },
+	}
+	return utils.WaitUntilTargetWithContext(context.Background(), target, func() (bool, error) {
 		cache, err := commonctrl.GetDeployment(v1alpha1.CacheDeploymentName, testNamespace)
 		if err != nil {
 			GinkgoWriter.Printf("failed to get JBS cache deployment: %s\n", err.Error())
 			return false, nil
 		}
+		observed = cache
 		if cache.Status.AvailableReplicas > 0 {
 			GinkgoWriter.Printf("JBS cache is available\n")
 			return true, nil
@@ -33,5 +40,5 @@ func (j *JvmbuildserviceController) WaitForCache(commonctrl *common.SuiteControl
 		}
 		GinkgoWriter.Printf("JBS cache %s/%s is progressing\n", testNamespace, v1alpha1.CacheDeploymentName)
 		return false, nil
-	})
+	}, 5*time.Second, 5*time.Minute)
 }
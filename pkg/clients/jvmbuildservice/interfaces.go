@@ -0,0 +1,47 @@
+package jvmbuildservice
+
+import (
+	"github.com/redhat-appstudio/e2e-tests/pkg/clients/common"
+	kubeCl "github.com/redhat-appstudio/e2e-tests/pkg/clients/kubernetes"
+	"github.com/redhat-appstudio/jvm-build-service/pkg/apis/jvmbuildservice/v1alpha1"
+)
+
+// ArtifactBuildClient operates with ArtifactBuilds.
+type ArtifactBuildClient interface {
+	ListArtifactBuilds(namespace string) (*v1alpha1.ArtifactBuildList, error)
+	DeleteArtifactBuild(name, namespace string) error
+}
+
+// CacheClient operates with the JVM build service cache.
+type CacheClient interface {
+	WaitForCache(commonctrl common.DeploymentClient, testNamespace string) error
+}
+
+// DependencyBuildClient operates with DependencyBuilds.
+type DependencyBuildClient interface {
+	ListDependencyBuilds(namespace string) (*v1alpha1.DependencyBuildList, error)
+	DeleteDependencyBuild(name, namespace string) error
+}
+
+// JBSConfigClient operates with JBSConfigs.
+type JBSConfigClient interface {
+	CreateJBSConfig(name, namespace string) (*v1alpha1.JBSConfig, error)
+	DeleteJBSConfig(name string, namespace string) error
+}
+
+// RebuiltArtifactClient operates with RebuiltArtifacts.
+type RebuiltArtifactClient interface {
+	ListRebuiltArtifacts(namespace string) (*v1alpha1.RebuiltArtifactList, error)
+}
+
+// Interface groups all the operations available for the JVM build service. It is implemented by JvmbuildserviceController.
+type Interface interface {
+	kubeCl.Interface
+	ArtifactBuildClient
+	CacheClient
+	DependencyBuildClient
+	JBSConfigClient
+	RebuiltArtifactClient
+}
+
+var _ Interface = &JvmbuildserviceController{}
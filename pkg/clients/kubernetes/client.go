@@ -48,7 +48,7 @@ const (
 )
 
 type CustomClient struct {
-	kubeClient            *kubernetes.Clientset
+	kubeClient            kubernetes.Interface
 	crClient              crclient.Client
 	pipelineClient        pipelineclientset.Interface
 	dynamicClient         dynamic.Interface
@@ -56,6 +56,19 @@ type CustomClient struct {
 	routeClient           routeclientset.Interface
 }
 
+// Interface provides access to the clients wrapped by CustomClient. It is embedded in the interfaces
+// of the controllers from pkg/clients, so their implementations can be swapped out.
+type Interface interface {
+	KubeInterface() kubernetes.Interface
+	KubeRest() crclient.Client
+	PipelineClient() pipelineclientset.Interface
+	JvmbuildserviceClient() jvmbuildserviceclientset.Interface
+	RouteClient() routeclientset.Interface
+	DynamicClient() dynamic.Interface
+}
+
+var _ Interface = &CustomClient{}
+
 type K8SClient struct {
 	AsKubeAdmin       *CustomClient
 	AsKubeDeveloper   *CustomClient
@@ -0,0 +1,43 @@
+package client
+
+import (
+	routefake "github.com/openshift/client-go/route/clientset/versioned/fake"
+	routescheme "github.com/openshift/client-go/route/clientset/versioned/scheme"
+	jvmbuildservicefake "github.com/redhat-appstudio/jvm-build-service/pkg/client/clientset/versioned/fake"
+	jvmbuildservicescheme "github.com/redhat-appstudio/jvm-build-service/pkg/client/clientset/versioned/scheme"
+	pipelinefake "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	pipelinescheme "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// NewFakeCustomClient creates a CustomClient backed by in-memory fake clients, so the controllers from pkg/clients
+// can be used in unit tests without a cluster.
+// Every given object is stored in the controller-runtime and dynamic clients and additionally in each typed clientset
+// that knows its type (e.g. a PipelineRun is returned both by KubeRest() and PipelineClient()).
+// Note that the fake clients don't share a storage, so changes done through one of them are not visible in the others.
+func NewFakeCustomClient(objects ...runtime.Object) *CustomClient {
+	return &CustomClient{
+		kubeClient:            kubefake.NewSimpleClientset(filterObjectsForScheme(clientgoscheme.Scheme, objects)...),
+		crClient:              crfake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build(),
+		pipelineClient:        pipelinefake.NewSimpleClientset(filterObjectsForScheme(pipelinescheme.Scheme, objects)...),
+		dynamicClient:         dynamicfake.NewSimpleDynamicClient(scheme, objects...),
+		jvmbuildserviceClient: jvmbuildservicefake.NewSimpleClientset(filterObjectsForScheme(jvmbuildservicescheme.Scheme, objects)...),
+		routeClient:           routefake.NewSimpleClientset(filterObjectsForScheme(routescheme.Scheme, objects)...),
+	}
+}
+
+// filterObjectsForScheme returns only the objects whose type is registered in the given scheme,
+// since the typed fake clientsets panic when seeded with unknown types.
+func filterObjectsForScheme(s *runtime.Scheme, objects []runtime.Object) []runtime.Object {
+	filtered := []runtime.Object{}
+	for _, obj := range objects {
+		if _, _, err := s.ObjectKinds(obj); err == nil {
+			filtered = append(filtered, obj)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,310 @@
+package notifier
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/redhat-appstudio/e2e-tests/pkg/clients/slack"
+	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
+	"github.com/redhat-appstudio/e2e-tests/pkg/framework"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	SlackSinkType   = "slack"
+	WebhookSinkType = "webhook"
+	FileSinkType    = "file"
+
+	// AlertWebhookResource is the resource of the payloads sent by the webhook sinks
+	AlertWebhookResource = "e2e-alert"
+
+	webhookTimeout = 30 * time.Second
+)
+
+// severityRank orders the severity levels, the alerts are routed to the sinks accepting their severity or a lower one
+var severityRank = map[slack.ErrorSeverityLevel]int{
+	slack.ErrorSeverityLevelInfo:    0,
+	slack.ErrorSeverityLevelWarning: 1,
+	slack.ErrorSeverityLevelError:   2,
+	slack.ErrorSeverityLevelFatal:   3,
+}
+
+// Alert is a problem which occurred while bootstrapping the cluster or running the tests in CI.
+type Alert struct {
+	Message  string                   `json:"message"`
+	Severity slack.ErrorSeverityLevel `json:"severity"`
+	// JobName and JobID identify the Prow job which raised the alert
+	JobName string    `json:"jobName,omitempty"`
+	JobID   string    `json:"jobID,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// NewAlert creates an alert of the current Prow job.
+func NewAlert(msg string, severity slack.ErrorSeverityLevel) Alert {
+	return Alert{Message: msg, Severity: severity, JobName: os.Getenv("JOB_NAME"), JobID: os.Getenv("PROW_JOB_ID"), Time: time.Now()}
+}
+
+// Notifier delivers the alerts to a sink.
+type Notifier interface {
+	Notify(alert Alert) error
+}
+
+// SlackNotifier posts the alerts to the #app-studio-ci-reports Slack channel.
+type SlackNotifier struct{}
+
+func (SlackNotifier) Notify(alert Alert) error {
+	return slack.ReportIssue(alert.Message, alert.Severity)
+}
+
+// WebhookNotifier sends the alerts as JSON payloads signed with HMAC-SHA256 (see framework.GoWebHook)
+// in the "X-GoWebHooks-Verification" header, so the receivers can verify them with the shared secret.
+type WebhookNotifier struct {
+	URL     string
+	Secret  string
+	Headers map[string]string
+	// Insecure disables the verification of the TLS certificate of the receiver
+	Insecure bool
+	// Client sends the payloads, NewWebhookClient is used if not set
+	Client *http.Client
+}
+
+// NewWebhookClient returns a client with its own transport, so disabling the TLS verification of the receiver
+// doesn't affect the other clients. GoWebHook.Send can't be used for that, as it changes http.DefaultTransport.
+func NewWebhookClient(insecure bool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if insecure {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+	}
+	return &http.Client{Timeout: webhookTimeout, Transport: transport}
+}
+
+func (n WebhookNotifier) Notify(alert Alert) error {
+	hook := &framework.GoWebHook{}
+	hook.Create(alert, AlertWebhookResource, n.Secret)
+
+	req, err := http.NewRequest(http.MethodPost, n.URL, bytes.NewReader(hook.PreparedData))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Charset", "utf-8")
+	req.Header.Add(framework.DefaultSignatureHeader, hook.ResultingSha)
+	for name, value := range n.Headers {
+		req.Header.Add(name, value)
+	}
+
+	client := n.Client
+	if client == nil {
+		client = NewWebhookClient(n.Insecure)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("webhook %s responded with status code %d: %s", n.URL, resp.StatusCode, body)
+	}
+	return nil
+}
+
+// FileNotifier appends the alerts to a file, one JSON object per line.
+type FileNotifier struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func (n *FileNotifier) Notify(alert Alert) error {
+	line, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(n.Path), os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(n.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// SinkConfig configures a sink the alerts are sent to.
+type SinkConfig struct {
+	// Name identifies the sink in the errors, the type is used if not set
+	Name string `json:"name,omitempty"`
+	// Type is one of "slack", "webhook" and "file"
+	Type string `json:"type"`
+	// MinSeverity is the lowest severity of the alerts sent to the sink, all the alerts are sent if not set
+	MinSeverity slack.ErrorSeverityLevel `json:"minSeverity,omitempty"`
+	// Severities lists the severities of the alerts sent to the sink, it takes precedence over MinSeverity
+	Severities []slack.ErrorSeverityLevel `json:"severities,omitempty"`
+
+	// URL of the webhook receiver
+	URL string `json:"url,omitempty"`
+	// SecretEnv is the environment variable with the secret the webhook payloads are signed with
+	SecretEnv string            `json:"secretEnv,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Insecure  bool              `json:"insecure,omitempty"`
+
+	// Path of the file the alerts are appended to
+	Path string `json:"path,omitempty"`
+}
+
+// Config lists the sinks the alerts are sent to.
+type Config struct {
+	Sinks []SinkConfig `json:"sinks"`
+}
+
+// DefaultConfig sends all the alerts to Slack.
+func DefaultConfig() *Config {
+	return &Config{Sinks: []SinkConfig{{Type: SlackSinkType}}}
+}
+
+// LoadConfig reads the configuration from a YAML file.
+func LoadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{}
+	if err := yaml.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("failed to parse the notifiers config %s: %v", path, err)
+	}
+	return config, nil
+}
+
+// ConfigFromEnv reads the configuration from the file given by the NOTIFIERS_CONFIG environment variable,
+// falling back to the DefaultConfig if it is not set.
+func ConfigFromEnv() (*Config, error) {
+	path := os.Getenv(constants.NOTIFIERS_CONFIG_ENV)
+	if path == "" {
+		return DefaultConfig(), nil
+	}
+	return LoadConfig(path)
+}
+
+type route struct {
+	name     string
+	accepts  func(slack.ErrorSeverityLevel) bool
+	notifier Notifier
+}
+
+// Router sends the alerts to the sinks accepting their severity.
+type Router struct {
+	routes []route
+}
+
+// NewRouter creates the notifiers of the configured sinks.
+func NewRouter(config *Config) (*Router, error) {
+	router := &Router{}
+	for i, sink := range config.Sinks {
+		name := sink.Name
+		if name == "" {
+			name = fmt.Sprintf("%s sink #%d", sink.Type, i+1)
+		}
+		accepts, err := sink.severityFilter()
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", name, err)
+		}
+		notifier, err := sink.notifier()
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", name, err)
+		}
+		router.Add(name, accepts, notifier)
+	}
+	return router, nil
+}
+
+// NewRouterFromEnv creates the router of the sinks configured by the NOTIFIERS_CONFIG environment variable.
+func NewRouterFromEnv() (*Router, error) {
+	config, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewRouter(config)
+}
+
+// Add routes the alerts accepted by the given function to the notifier.
+func (r *Router) Add(name string, accepts func(slack.ErrorSeverityLevel) bool, notifier Notifier) {
+	r.routes = append(r.routes, route{name: name, accepts: accepts, notifier: notifier})
+}
+
+// Notify sends the alert to all the sinks accepting its severity. A failing sink doesn't prevent the alert
+// from being sent to the other ones, the errors of all the failed sinks are returned.
+func (r *Router) Notify(alert Alert) error {
+	errs := []error{}
+	for _, route := range r.routes {
+		if !route.accepts(alert.Severity) {
+			continue
+		}
+		if err := route.notifier.Notify(alert); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", route.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s SinkConfig) severityFilter() (func(slack.ErrorSeverityLevel) bool, error) {
+	for _, severity := range append([]slack.ErrorSeverityLevel{s.MinSeverity}, s.Severities...) {
+		if _, ok := severityRank[severity]; severity != "" && !ok {
+			return nil, fmt.Errorf("unknown severity %q", severity)
+		}
+	}
+
+	if len(s.Severities) > 0 {
+		return func(severity slack.ErrorSeverityLevel) bool {
+			for _, accepted := range s.Severities {
+				if accepted == severity {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+	minRank := severityRank[s.MinSeverity]
+	return func(severity slack.ErrorSeverityLevel) bool {
+		return severityRank[severity] >= minRank
+	}, nil
+}
+
+func (s SinkConfig) notifier() (Notifier, error) {
+	switch s.Type {
+	case SlackSinkType:
+		return SlackNotifier{}, nil
+	case WebhookSinkType:
+		if s.URL == "" {
+			return nil, fmt.Errorf("the url of the webhook is not set")
+		}
+		notifier := WebhookNotifier{URL: s.URL, Headers: s.Headers, Insecure: s.Insecure, Client: NewWebhookClient(s.Insecure)}
+		if s.SecretEnv != "" {
+			notifier.Secret = os.Getenv(s.SecretEnv)
+			if notifier.Secret == "" {
+				return nil, fmt.Errorf("the environment variable %s with the webhook secret is not set", s.SecretEnv)
+			}
+		}
+		return notifier, nil
+	case FileSinkType:
+		if s.Path == "" {
+			return nil, fmt.Errorf("the path of the file is not set")
+		}
+		return &FileNotifier{Path: s.Path}, nil
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", s.Type)
+	}
+}
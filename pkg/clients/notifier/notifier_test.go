@@ -0,0 +1,127 @@
+package notifier
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/redhat-appstudio/e2e-tests/pkg/clients/slack"
+	"github.com/redhat-appstudio/e2e-tests/pkg/framework"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouterSeverityRouting(t *testing.T) {
+	received := []framework.GoWebHookPayload{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mac := hmac.New(sha256.New, []byte("webhook-secret"))
+		mac.Write(body)
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), r.Header.Get(framework.DefaultSignatureHeader))
+		assert.Equal(t, "e2e", r.Header.Get("X-Team"))
+
+		payload := framework.GoWebHookPayload{}
+		assert.NoError(t, json.Unmarshal(body, &payload))
+		received = append(received, payload)
+	}))
+	defer server.Close()
+
+	t.Setenv("TEST_WEBHOOK_SECRET", "webhook-secret")
+	alertsFile := filepath.Join(t.TempDir(), "alerts", "alerts.jsonl")
+	configFile := filepath.Join(t.TempDir(), "notifiers.yaml")
+	assert.NoError(t, os.WriteFile(configFile, []byte(`
+sinks:
+- type: webhook
+  url: `+server.URL+`
+  secretEnv: TEST_WEBHOOK_SECRET
+  headers:
+    X-Team: e2e
+  minSeverity: Error
+- type: file
+  path: `+alertsFile+`
+  severities: [Info, Fatal]
+`), 0644))
+
+	config, err := LoadConfig(configFile)
+	assert.NoError(t, err)
+	router, err := NewRouter(config)
+	assert.NoError(t, err)
+
+	for _, severity := range []slack.ErrorSeverityLevel{slack.ErrorSeverityLevelInfo, slack.ErrorSeverityLevelWarning, slack.ErrorSeverityLevelError, slack.ErrorSeverityLevelFatal} {
+		assert.NoError(t, router.Notify(Alert{Message: "failed to register SprayProxy", Severity: severity}))
+	}
+
+	if assert.Len(t, received, 2) {
+		assert.Equal(t, AlertWebhookResource, received[0].Resource)
+		assert.Equal(t, "Error", received[0].Data.(map[string]interface{})["severity"])
+		assert.Equal(t, "Fatal", received[1].Data.(map[string]interface{})["severity"])
+	}
+
+	content, err := os.ReadFile(alertsFile)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if assert.Len(t, lines, 2) {
+		alert := Alert{}
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &alert))
+		assert.Equal(t, slack.ErrorSeverityLevel(slack.ErrorSeverityLevelInfo), alert.Severity)
+		assert.Equal(t, "failed to register SprayProxy", alert.Message)
+		assert.NoError(t, json.Unmarshal([]byte(lines[1]), &alert))
+		assert.Equal(t, slack.ErrorSeverityLevel(slack.ErrorSeverityLevelFatal), alert.Severity)
+	}
+}
+
+func TestRouterReportsFailedSinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	alertsFile := filepath.Join(t.TempDir(), "alerts.jsonl")
+	router, err := NewRouter(&Config{Sinks: []SinkConfig{
+		{Name: "broken receiver", Type: WebhookSinkType, URL: server.URL},
+		{Type: FileSinkType, Path: alertsFile},
+	}})
+	assert.NoError(t, err)
+
+	err = router.Notify(Alert{Message: "cluster bootstrap failed", Severity: slack.ErrorSeverityLevelError})
+	assert.ErrorContains(t, err, "broken receiver: webhook "+server.URL+" responded with status code 500")
+	// the failing webhook doesn't prevent the alert from being written into the file
+	assert.FileExists(t, alertsFile)
+}
+
+func TestNewRouterInvalidConfig(t *testing.T) {
+	for _, sink := range []SinkConfig{
+		{Type: "email"},
+		{Type: WebhookSinkType},
+		{Type: WebhookSinkType, URL: "https://example.com", SecretEnv: "UNSET_WEBHOOK_SECRET"},
+		{Type: FileSinkType},
+		{Type: SlackSinkType, MinSeverity: "Critical"},
+	} {
+		_, err := NewRouter(&Config{Sinks: []SinkConfig{sink}})
+		assert.Error(t, err, "sink %+v", sink)
+	}
+}
+
+func TestInsecureWebhookKeepsDefaultTransport(t *testing.T) {
+	received := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+	}))
+	defer server.Close()
+	defaultTLSConfig := http.DefaultTransport.(*http.Transport).TLSClientConfig
+
+	// the certificate of the test server isn't trusted
+	assert.Error(t, WebhookNotifier{URL: server.URL}.Notify(NewAlert("cluster not ready", slack.ErrorSeverityLevelError)))
+	assert.NoError(t, WebhookNotifier{URL: server.URL, Insecure: true}.Notify(NewAlert("cluster not ready", slack.ErrorSeverityLevelError)))
+
+	assert.Equal(t, 1, received)
+	assert.True(t, defaultTLSConfig == http.DefaultTransport.(*http.Transport).TLSClientConfig, "http.DefaultTransport was changed")
+}
@@ -0,0 +1,69 @@
+package release
+
+import (
+	"context"
+	"time"
+
+	kubeCl "github.com/redhat-appstudio/e2e-tests/pkg/clients/kubernetes"
+	releaseApi "github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/release-service/tekton/utils"
+	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbac "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ReleasePlanClient operates with ReleasePlans and ReleasePlanAdmissions.
+type ReleasePlanClient interface {
+	CreateReleasePlan(name, namespace, application, targetNamespace, autoReleaseLabel string) (*releaseApi.ReleasePlan, error)
+	CreateReleasePlanWithContext(ctx context.Context, name, namespace, application, targetNamespace, autoReleaseLabel string) (*releaseApi.ReleasePlan, error)
+	CreateReleasePlanAdmission(name, namespace, environment, origin, policy, serviceAccount string, applications []string, autoRelease bool, pipelineRef *utils.PipelineRef, data *runtime.RawExtension) (*releaseApi.ReleasePlanAdmission, error)
+	CreateReleasePlanAdmissionWithContext(ctx context.Context, name, namespace, environment, origin, policy, serviceAccount string, applications []string, autoRelease bool, pipelineRef *utils.PipelineRef, data *runtime.RawExtension) (*releaseApi.ReleasePlanAdmission, error)
+	GetReleasePlan(name, namespace string) (*releaseApi.ReleasePlan, error)
+	GetReleasePlanWithContext(ctx context.Context, name, namespace string) (*releaseApi.ReleasePlan, error)
+	GetReleasePlanAdmission(name, namespace string) (*releaseApi.ReleasePlanAdmission, error)
+	GetReleasePlanAdmissionWithContext(ctx context.Context, name, namespace string) (*releaseApi.ReleasePlanAdmission, error)
+	DeleteReleasePlan(name, namespace string, failOnNotFound bool) error
+	DeleteReleasePlanWithContext(ctx context.Context, name, namespace string, failOnNotFound bool) error
+	DeleteReleasePlanAdmission(name, namespace string, failOnNotFound bool) error
+	DeleteReleasePlanAdmissionWithContext(ctx context.Context, name, namespace string, failOnNotFound bool) error
+}
+
+// ReleaseClient operates with Releases.
+type ReleaseClient interface {
+	CreateRelease(name, namespace, snapshot, releasePlan string) (*releaseApi.Release, error)
+	CreateReleaseWithContext(ctx context.Context, name, namespace, snapshot, releasePlan string) (*releaseApi.Release, error)
+	CreateReleasePipelineRoleBindingForServiceAccount(namespace string, serviceAccount *corev1.ServiceAccount) (*rbac.RoleBinding, error)
+	CreateReleasePipelineRoleBindingForServiceAccountWithContext(ctx context.Context, namespace string, serviceAccount *corev1.ServiceAccount) (*rbac.RoleBinding, error)
+	GetRelease(releaseName, snapshotName, namespace string) (*releaseApi.Release, error)
+	GetReleaseWithContext(ctx context.Context, releaseName, snapshotName, namespace string) (*releaseApi.Release, error)
+	GetReleases(namespace string) (*releaseApi.ReleaseList, error)
+	GetReleasesWithContext(ctx context.Context, namespace string) (*releaseApi.ReleaseList, error)
+	GetFirstReleaseInNamespace(namespace string) (*releaseApi.Release, error)
+	GetFirstReleaseInNamespaceWithContext(ctx context.Context, namespace string) (*releaseApi.Release, error)
+	GetPipelineRunInNamespace(namespace, releaseName, releaseNamespace string) (*pipeline.PipelineRun, error)
+	GetPipelineRunInNamespaceWithContext(ctx context.Context, namespace, releaseName, releaseNamespace string) (*pipeline.PipelineRun, error)
+	WaitForReleaseToGetCreated(releaseName, snapshotName, namespace string, timeout time.Duration) (*releaseApi.Release, error)
+	WaitForReleaseToGetCreatedWithContext(ctx context.Context, releaseName, snapshotName, namespace string, timeout time.Duration) (*releaseApi.Release, error)
+	WaitForReleaseToGetFinished(releaseName, snapshotName, namespace string, timeout time.Duration) (*releaseApi.Release, error)
+	WaitForReleaseToGetFinishedWithContext(ctx context.Context, releaseName, snapshotName, namespace string, timeout time.Duration) (*releaseApi.Release, error)
+	WaitForReleasePipelineToGetStarted(managedNamespace, releaseName, releaseNamespace string, timeout time.Duration) (*pipeline.PipelineRun, error)
+	WaitForReleasePipelineToGetStartedWithContext(ctx context.Context, managedNamespace, releaseName, releaseNamespace string, timeout time.Duration) (*pipeline.PipelineRun, error)
+	WaitForReleasePipelineToBeFinished(managedNamespace, releaseName, releaseNamespace string, timeout time.Duration) (*pipeline.PipelineRun, error)
+	WaitForReleasePipelineToBeFinishedWithContext(ctx context.Context, managedNamespace, releaseName, releaseNamespace string, timeout time.Duration) (*pipeline.PipelineRun, error)
+}
+
+// PyxisClient operates with the Pyxis API.
+type PyxisClient interface {
+	GetPyxisImageByImageID(pyxisStageImagesApiEndpoint, imageID string, pyxisCertDecoded, pyxisKeyDecoded []byte) ([]byte, error)
+}
+
+// Interface groups all the operations available for the release-service. It is implemented by ReleaseController.
+type Interface interface {
+	kubeCl.Interface
+	ReleasePlanClient
+	ReleaseClient
+	PyxisClient
+}
+
+var _ Interface = &ReleaseController{}
@@ -16,6 +16,11 @@ import (
 
 // CreateReleasePlan creates a new ReleasePlan using the given parameters.
 func (r *ReleaseController) CreateReleasePlan(name, namespace, application, targetNamespace, autoReleaseLabel string) (*releaseApi.ReleasePlan, error) {
+	return r.CreateReleasePlanWithContext(context.Background(), name, namespace, application, targetNamespace, autoReleaseLabel)
+}
+
+// CreateReleasePlanWithContext is like CreateReleasePlan but it stops as soon as the given context is cancelled.
+func (r *ReleaseController) CreateReleasePlanWithContext(ctx context.Context, name, namespace, application, targetNamespace, autoReleaseLabel string) (*releaseApi.ReleasePlan, error) {
 	var releasePlan *releaseApi.ReleasePlan = &releaseApi.ReleasePlan{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: name,
@@ -37,11 +42,16 @@ func (r *ReleaseController) CreateReleasePlan(name, namespace, application, targ
 		releasePlan.ObjectMeta.Labels[releaseMetadata.AutoReleaseLabel] = "false"
 	}
 
-	return releasePlan, r.KubeRest().Create(context.Background(), releasePlan)
+	return releasePlan, r.KubeRest().Create(ctx, releasePlan)
 }
 
 // CreateReleasePlanAdmission creates a new ReleasePlanAdmission using the given parameters.
 func (r *ReleaseController) CreateReleasePlanAdmission(name, namespace, environment, origin, policy, serviceAccount string, applications []string, autoRelease bool, pipelineRef *utils.PipelineRef, data *runtime.RawExtension) (*releaseApi.ReleasePlanAdmission, error) {
+	return r.CreateReleasePlanAdmissionWithContext(context.Background(), name, namespace, environment, origin, policy, serviceAccount, applications, autoRelease, pipelineRef, data)
+}
+
+// CreateReleasePlanAdmissionWithContext is like CreateReleasePlanAdmission but it stops as soon as the given context is cancelled.
+func (r *ReleaseController) CreateReleasePlanAdmissionWithContext(ctx context.Context, name, namespace, environment, origin, policy, serviceAccount string, applications []string, autoRelease bool, pipelineRef *utils.PipelineRef, data *runtime.RawExtension) (*releaseApi.ReleasePlanAdmission, error) {
 	releasePlanAdmission := &releaseApi.ReleasePlanAdmission{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -63,14 +73,19 @@ func (r *ReleaseController) CreateReleasePlanAdmission(name, namespace, environm
 		},
 	}
 
-	return releasePlanAdmission, r.KubeRest().Create(context.Background(), releasePlanAdmission)
+	return releasePlanAdmission, r.KubeRest().Create(ctx, releasePlanAdmission)
 }
 
 // GetReleasePlan returns the ReleasePlan with the given name in the given namespace.
 func (r *ReleaseController) GetReleasePlan(name, namespace string) (*releaseApi.ReleasePlan, error) {
+	return r.GetReleasePlanWithContext(context.Background(), name, namespace)
+}
+
+// GetReleasePlanWithContext is like GetReleasePlan but it stops as soon as the given context is cancelled.
+func (r *ReleaseController) GetReleasePlanWithContext(ctx context.Context, name, namespace string) (*releaseApi.ReleasePlan, error) {
 	releasePlan := &releaseApi.ReleasePlan{}
 
-	err := r.KubeRest().Get(context.Background(), types.NamespacedName{
+	err := r.KubeRest().Get(ctx, types.NamespacedName{
 		Name:      name,
 		Namespace: namespace,
 	}, releasePlan)
@@ -80,9 +95,14 @@ func (r *ReleaseController) GetReleasePlan(name, namespace string) (*releaseApi.
 
 // GetReleasePlanAdmission returns the ReleasePlanAdmission with the given name in the given namespace.
 func (r *ReleaseController) GetReleasePlanAdmission(name, namespace string) (*releaseApi.ReleasePlanAdmission, error) {
+	return r.GetReleasePlanAdmissionWithContext(context.Background(), name, namespace)
+}
+
+// GetReleasePlanAdmissionWithContext is like GetReleasePlanAdmission but it stops as soon as the given context is cancelled.
+func (r *ReleaseController) GetReleasePlanAdmissionWithContext(ctx context.Context, name, namespace string) (*releaseApi.ReleasePlanAdmission, error) {
 	releasePlanAdmission := &releaseApi.ReleasePlanAdmission{}
 
-	err := r.KubeRest().Get(context.Background(), types.NamespacedName{
+	err := r.KubeRest().Get(ctx, types.NamespacedName{
 		Name:      name,
 		Namespace: namespace,
 	}, releasePlanAdmission)
@@ -92,13 +112,18 @@ func (r *ReleaseController) GetReleasePlanAdmission(name, namespace string) (*re
 
 // DeleteReleasePlan deletes a given ReleasePlan name in given namespace.
 func (r *ReleaseController) DeleteReleasePlan(name, namespace string, failOnNotFound bool) error {
+	return r.DeleteReleasePlanWithContext(context.Background(), name, namespace, failOnNotFound)
+}
+
+// DeleteReleasePlanWithContext is like DeleteReleasePlan but it stops as soon as the given context is cancelled.
+func (r *ReleaseController) DeleteReleasePlanWithContext(ctx context.Context, name, namespace string, failOnNotFound bool) error {
 	releasePlan := &releaseApi.ReleasePlan{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
 		},
 	}
-	err := r.KubeRest().Delete(context.Background(), releasePlan)
+	err := r.KubeRest().Delete(ctx, releasePlan)
 	if err != nil && !failOnNotFound && k8sErrors.IsNotFound(err) {
 		err = nil
 	}
@@ -109,13 +134,18 @@ func (r *ReleaseController) DeleteReleasePlan(name, namespace string, failOnNotF
 // Optionally, it can avoid returning an error if the resource did not exist:
 // specify 'false', if it's likely the ReleasePlanAdmission has already been deleted (for example, because the Namespace was deleted)
 func (r *ReleaseController) DeleteReleasePlanAdmission(name, namespace string, failOnNotFound bool) error {
+	return r.DeleteReleasePlanAdmissionWithContext(context.Background(), name, namespace, failOnNotFound)
+}
+
+// DeleteReleasePlanAdmissionWithContext is like DeleteReleasePlanAdmission but it stops as soon as the given context is cancelled.
+func (r *ReleaseController) DeleteReleasePlanAdmissionWithContext(ctx context.Context, name, namespace string, failOnNotFound bool) error {
 	releasePlanAdmission := releaseApi.ReleasePlanAdmission{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
 		},
 	}
-	err := r.KubeRest().Delete(context.Background(), &releasePlanAdmission)
+	err := r.KubeRest().Delete(ctx, &releasePlanAdmission)
 	if err != nil && !failOnNotFound && k8sErrors.IsNotFound(err) {
 		err = nil
 	}
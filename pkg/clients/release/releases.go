@@ -3,18 +3,28 @@ package release
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils/tekton"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils/watcher"
 	releaseApi "github.com/redhat-appstudio/release-service/api/v1alpha1"
 	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // CreateRelease creates a new Release using the given parameters.
 func (r *ReleaseController) CreateRelease(name, namespace, snapshot, releasePlan string) (*releaseApi.Release, error) {
+	return r.CreateReleaseWithContext(context.Background(), name, namespace, snapshot, releasePlan)
+}
+
+// CreateReleaseWithContext is like CreateRelease but it stops as soon as the given context is cancelled.
+func (r *ReleaseController) CreateReleaseWithContext(ctx context.Context, name, namespace, snapshot, releasePlan string) (*releaseApi.Release, error) {
 	release := &releaseApi.Release{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -26,12 +36,17 @@ func (r *ReleaseController) CreateRelease(name, namespace, snapshot, releasePlan
 		},
 	}
 
-	return release, r.KubeRest().Create(context.Background(), release)
+	return release, r.KubeRest().Create(ctx, release)
 }
 
 // CreateReleasePipelineRoleBindingForServiceAccount creates a RoleBinding for the passed serviceAccount to enable
 // retrieving the necessary CRs from the passed namespace.
 func (r *ReleaseController) CreateReleasePipelineRoleBindingForServiceAccount(namespace string, serviceAccount *corev1.ServiceAccount) (*rbac.RoleBinding, error) {
+	return r.CreateReleasePipelineRoleBindingForServiceAccountWithContext(context.Background(), namespace, serviceAccount)
+}
+
+// CreateReleasePipelineRoleBindingForServiceAccountWithContext is like CreateReleasePipelineRoleBindingForServiceAccount but it stops as soon as the given context is cancelled.
+func (r *ReleaseController) CreateReleasePipelineRoleBindingForServiceAccountWithContext(ctx context.Context, namespace string, serviceAccount *corev1.ServiceAccount) (*rbac.RoleBinding, error) {
 	roleBinding := &rbac.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "release-service-pipeline-rolebinding-",
@@ -50,7 +65,7 @@ func (r *ReleaseController) CreateReleasePipelineRoleBindingForServiceAccount(na
 			},
 		},
 	}
-	err := r.KubeRest().Create(context.Background(), roleBinding)
+	err := r.KubeRest().Create(ctx, roleBinding)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +75,11 @@ func (r *ReleaseController) CreateReleasePipelineRoleBindingForServiceAccount(na
 // GetRelease returns the release with in the given namespace.
 // It can find a Release CR based on provided name or a name of an associated Snapshot
 func (r *ReleaseController) GetRelease(releaseName, snapshotName, namespace string) (*releaseApi.Release, error) {
-	ctx := context.Background()
+	return r.GetReleaseWithContext(context.Background(), releaseName, snapshotName, namespace)
+}
+
+// GetReleaseWithContext is like GetRelease but it stops as soon as the given context is cancelled.
+func (r *ReleaseController) GetReleaseWithContext(ctx context.Context, releaseName, snapshotName, namespace string) (*releaseApi.Release, error) {
 	if len(releaseName) > 0 {
 		release := &releaseApi.Release{}
 		err := r.KubeRest().Get(ctx, types.NamespacedName{Name: releaseName, Namespace: namespace}, release)
@@ -73,7 +92,7 @@ func (r *ReleaseController) GetRelease(releaseName, snapshotName, namespace stri
 	opts := []client.ListOption{
 		client.InNamespace(namespace),
 	}
-	if err := r.KubeRest().List(context.Background(), releaseList, opts...); err != nil {
+	if err := r.KubeRest().List(ctx, releaseList, opts...); err != nil {
 		return nil, err
 	}
 	for _, r := range releaseList.Items {
@@ -86,18 +105,28 @@ func (r *ReleaseController) GetRelease(releaseName, snapshotName, namespace stri
 
 // GetReleases returns the list of Release CR in the given namespace.
 func (r *ReleaseController) GetReleases(namespace string) (*releaseApi.ReleaseList, error) {
+	return r.GetReleasesWithContext(context.Background(), namespace)
+}
+
+// GetReleasesWithContext is like GetReleases but it stops as soon as the given context is cancelled.
+func (r *ReleaseController) GetReleasesWithContext(ctx context.Context, namespace string) (*releaseApi.ReleaseList, error) {
 	releaseList := &releaseApi.ReleaseList{}
 	opts := []client.ListOption{
 		client.InNamespace(namespace),
 	}
-	err := r.KubeRest().List(context.Background(), releaseList, opts...)
+	err := r.KubeRest().List(ctx, releaseList, opts...)
 
 	return releaseList, err
 }
 
 // GetFirstReleaseInNamespace returns the first Release from  list of releases in the given namespace.
 func (r *ReleaseController) GetFirstReleaseInNamespace(namespace string) (*releaseApi.Release, error) {
-	releaseList, err := r.GetReleases(namespace)
+	return r.GetFirstReleaseInNamespaceWithContext(context.Background(), namespace)
+}
+
+// GetFirstReleaseInNamespaceWithContext is like GetFirstReleaseInNamespace but it stops as soon as the given context is cancelled.
+func (r *ReleaseController) GetFirstReleaseInNamespaceWithContext(ctx context.Context, namespace string) (*releaseApi.Release, error) {
+	releaseList, err := r.GetReleasesWithContext(ctx, namespace)
 
 	if err != nil || len(releaseList.Items) < 1 {
 		return nil, fmt.Errorf("could not find any Releases in namespace %s: %+v", namespace, err)
@@ -107,6 +136,11 @@ func (r *ReleaseController) GetFirstReleaseInNamespace(namespace string) (*relea
 
 // GetPipelineRunInNamespace returns the Release PipelineRun referencing the given release.
 func (r *ReleaseController) GetPipelineRunInNamespace(namespace, releaseName, releaseNamespace string) (*pipeline.PipelineRun, error) {
+	return r.GetPipelineRunInNamespaceWithContext(context.Background(), namespace, releaseName, releaseNamespace)
+}
+
+// GetPipelineRunInNamespaceWithContext is like GetPipelineRunInNamespace but it stops as soon as the given context is cancelled.
+func (r *ReleaseController) GetPipelineRunInNamespaceWithContext(ctx context.Context, namespace, releaseName, releaseNamespace string) (*pipeline.PipelineRun, error) {
 	pipelineRuns := &pipeline.PipelineRunList{}
 	opts := []client.ListOption{
 		client.MatchingLabels{
@@ -116,7 +150,7 @@ func (r *ReleaseController) GetPipelineRunInNamespace(namespace, releaseName, re
 		client.InNamespace(namespace),
 	}
 
-	err := r.KubeRest().List(context.Background(), pipelineRuns, opts...)
+	err := r.KubeRest().List(ctx, pipelineRuns, opts...)
 
 	if err == nil && len(pipelineRuns.Items) > 0 {
 		return &pipelineRuns.Items[0], nil
@@ -124,3 +158,91 @@ func (r *ReleaseController) GetPipelineRunInNamespace(namespace, releaseName, re
 
 	return nil, fmt.Errorf("couldn't find PipelineRun in managed namespace '%s' for a release '%s' in '%s' namespace because of err:'%w'", namespace, releaseName, releaseNamespace, err)
 }
+
+// WaitForReleaseToGetCreated waits for the Release to get created and returns it.
+// The Release is found by its name or, if the name is empty, by the name of the associated Snapshot.
+// If both are empty, the first Release in the namespace is returned.
+func (r *ReleaseController) WaitForReleaseToGetCreated(releaseName, snapshotName, namespace string, timeout time.Duration) (*releaseApi.Release, error) {
+	return r.WaitForReleaseToGetCreatedWithContext(context.Background(), releaseName, snapshotName, namespace, timeout)
+}
+
+// WaitForReleaseToGetCreatedWithContext is like WaitForReleaseToGetCreated but it stops as soon as the given context is cancelled.
+func (r *ReleaseController) WaitForReleaseToGetCreatedWithContext(ctx context.Context, releaseName, snapshotName, namespace string, timeout time.Duration) (*releaseApi.Release, error) {
+	return r.releaseWatcher(releaseName, namespace).Until(ctx, timeout, func(release *releaseApi.Release) (bool, error) {
+		return len(snapshotName) == 0 || release.Spec.Snapshot == snapshotName, nil
+	})
+}
+
+// WaitForReleaseToGetFinished waits for the Release to be marked as released and returns it.
+// The Release is found the same way as in WaitForReleaseToGetCreated. An error is returned if the Release fails.
+func (r *ReleaseController) WaitForReleaseToGetFinished(releaseName, snapshotName, namespace string, timeout time.Duration) (*releaseApi.Release, error) {
+	return r.WaitForReleaseToGetFinishedWithContext(context.Background(), releaseName, snapshotName, namespace, timeout)
+}
+
+// WaitForReleaseToGetFinishedWithContext is like WaitForReleaseToGetFinished but it stops as soon as the given context is cancelled.
+func (r *ReleaseController) WaitForReleaseToGetFinishedWithContext(ctx context.Context, releaseName, snapshotName, namespace string, timeout time.Duration) (*releaseApi.Release, error) {
+	return r.releaseWatcher(releaseName, namespace).Until(ctx, timeout, func(release *releaseApi.Release) (bool, error) {
+		if len(snapshotName) > 0 && release.Spec.Snapshot != snapshotName {
+			return false, nil
+		}
+		if release.IsReleased() {
+			return true, nil
+		}
+		if release.HasReleaseFinished() {
+			return false, fmt.Errorf("release %s/%s has finished without being released: %s", release.GetNamespace(), release.GetName(), watcher.ConditionsState(release.Status.Conditions))
+		}
+		return false, nil
+	})
+}
+
+// WaitForReleasePipelineToGetStarted waits for the Release PipelineRun referencing the given release to start and returns it.
+func (r *ReleaseController) WaitForReleasePipelineToGetStarted(managedNamespace, releaseName, releaseNamespace string, timeout time.Duration) (*pipeline.PipelineRun, error) {
+	return r.WaitForReleasePipelineToGetStartedWithContext(context.Background(), managedNamespace, releaseName, releaseNamespace, timeout)
+}
+
+// WaitForReleasePipelineToGetStartedWithContext is like WaitForReleasePipelineToGetStarted but it stops as soon as the given context is cancelled.
+func (r *ReleaseController) WaitForReleasePipelineToGetStartedWithContext(ctx context.Context, managedNamespace, releaseName, releaseNamespace string, timeout time.Duration) (*pipeline.PipelineRun, error) {
+	return r.releasePipelineRunWatcher(managedNamespace, releaseName, releaseNamespace).Until(ctx, timeout, func(pipelineRun *pipeline.PipelineRun) (bool, error) {
+		return pipelineRun.HasStarted(), nil
+	})
+}
+
+// WaitForReleasePipelineToBeFinished waits for the Release PipelineRun referencing the given release to succeed and returns it.
+// An error containing the logs of the failed TaskRun is returned if the PipelineRun fails.
+func (r *ReleaseController) WaitForReleasePipelineToBeFinished(managedNamespace, releaseName, releaseNamespace string, timeout time.Duration) (*pipeline.PipelineRun, error) {
+	return r.WaitForReleasePipelineToBeFinishedWithContext(context.Background(), managedNamespace, releaseName, releaseNamespace, timeout)
+}
+
+// WaitForReleasePipelineToBeFinishedWithContext is like WaitForReleasePipelineToBeFinished but it stops as soon as the given context is cancelled.
+func (r *ReleaseController) WaitForReleasePipelineToBeFinishedWithContext(ctx context.Context, managedNamespace, releaseName, releaseNamespace string, timeout time.Duration) (*pipeline.PipelineRun, error) {
+	return r.releasePipelineRunWatcher(managedNamespace, releaseName, releaseNamespace).Until(ctx, timeout, func(pipelineRun *pipeline.PipelineRun) (bool, error) {
+		if !pipelineRun.IsDone() {
+			return false, nil
+		}
+		if pipelineRun.GetStatusCondition().GetCondition(apis.ConditionSucceeded).IsTrue() {
+			return true, nil
+		}
+		return false, fmt.Errorf(tekton.GetFailedPipelineRunLogs(r.KubeRest(), r.KubeInterface(), pipelineRun))
+	})
+}
+
+// releaseWatcher returns a watcher of the Releases in a given namespace (or only the one with the given name) which reports the changes of their conditions.
+func (r *ReleaseController) releaseWatcher(releaseName, namespace string) *watcher.Watcher[releaseApi.Release] {
+	w := watcher.New[releaseApi.Release](r.DynamicClient(), releaseApi.GroupVersion.WithResource("releases"), namespace)
+	w.Name = releaseName
+	w.State = func(release *releaseApi.Release) string {
+		return watcher.ConditionsState(release.Status.Conditions)
+	}
+	return w
+}
+
+// releasePipelineRunWatcher returns a watcher of the Release PipelineRuns referencing the given release which reports the changes of their Succeeded condition.
+func (r *ReleaseController) releasePipelineRunWatcher(managedNamespace, releaseName, releaseNamespace string) *watcher.Watcher[pipeline.PipelineRun] {
+	w := watcher.New[pipeline.PipelineRun](r.DynamicClient(), pipeline.SchemeGroupVersion.WithResource("pipelineruns"), managedNamespace)
+	w.LabelSelector = labels.SelectorFromSet(map[string]string{
+		"release.appstudio.openshift.io/name":      releaseName,
+		"release.appstudio.openshift.io/namespace": releaseNamespace,
+	})
+	w.State = tekton.PipelineRunState
+	return w
+}
@@ -0,0 +1,26 @@
+package remotesecret
+
+import (
+	kubeCl "github.com/redhat-appstudio/e2e-tests/pkg/clients/kubernetes"
+	rs "github.com/redhat-appstudio/remote-secret/api/v1beta1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// RemoteSecretClient operates with RemoteSecrets.
+type RemoteSecretClient interface {
+	CreateRemoteSecret(name, namespace string, targets []rs.RemoteSecretTarget, secretType v1.SecretType, labels map[string]string) (*rs.RemoteSecret, error)
+	CreateRemoteSecretWithLabelsAndAnnotations(name, namespace string, targetSecretName string, labels map[string]string, annotations map[string]string) (*rs.RemoteSecret, error)
+	GetRemoteSecret(name, namespace string) (*rs.RemoteSecret, error)
+	GetTargetSecretName(targets []rs.TargetStatus, targetNamespace string) string
+	CreateUploadSecret(name, namespace string, remoteSecretName string, secretType v1.SecretType, stringData map[string]string) (*v1.Secret, error)
+	GetImageRepositoryRemoteSecret(name, applicationName, componentName, namespace string) (*rs.RemoteSecret, error)
+	RemoteSecretTargetsContainsNamespace(name string, rs *rs.RemoteSecret) bool
+}
+
+// Interface groups all the operations available for the remote secret. It is implemented by RemoteSecretController.
+type Interface interface {
+	kubeCl.Interface
+	RemoteSecretClient
+}
+
+var _ Interface = &RemoteSecretController{}
@@ -0,0 +1,257 @@
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
+	"github.com/slack-go/slack"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// maxTopFailingSpecs limits the number of failing specs listed in the run summary
+	maxTopFailingSpecs = 10
+	// maxSectionTextLength is the limit of the text of a Block Kit section
+	maxSectionTextLength = 3000
+)
+
+// RunSummary describes the results of a test run.
+type RunSummary struct {
+	// JobName is the name of the Prow job, used for picking the channel and thread
+	JobName      string
+	JobURL       string
+	ArtifactsURL string
+	Suites       []SuiteSummary
+	FailingSpecs []FailingSpec
+}
+
+// SuiteSummary holds the results of the specs of a suite.
+type SuiteSummary struct {
+	Name    string
+	Passed  int
+	Failed  int
+	Skipped int
+	Flaky   int
+}
+
+// FailingSpec is a spec which failed in the test run.
+type FailingSpec struct {
+	Suite string
+	Name  string
+	// Category is the root-cause class of the failure, see framework.FailureClassifier
+	Category string
+	Message  string
+}
+
+// RunSummaryConfig configures where the run summaries are posted.
+type RunSummaryConfig struct {
+	// Channel is the ID of the channel for the jobs not matching any of the Jobs, the #app-studio-ci-reports channel by default
+	Channel string `json:"channel,omitempty"`
+	// Jobs configure the channel and thread of the jobs, the first one matching the job name is used
+	Jobs []JobSummaryConfig `json:"jobs,omitempty"`
+	// DryRunFile is the path of the file the payload is written into instead of posting it, if set
+	DryRunFile string `json:"dryRunFile,omitempty"`
+}
+
+// JobSummaryConfig configures where the run summaries of the matching jobs are posted.
+type JobSummaryConfig struct {
+	// JobNamePattern is a regular expression matching the job names
+	JobNamePattern string `json:"jobNamePattern"`
+	Channel        string `json:"channel,omitempty"`
+	// ThreadTS is the timestamp of the message the summaries are posted as replies to
+	ThreadTS string `json:"threadTS,omitempty"`
+}
+
+// RunSummaryPayload is the message posted to Slack.
+type RunSummaryPayload struct {
+	Channel  string        `json:"channel"`
+	ThreadTS string        `json:"thread_ts,omitempty"`
+	Text     string        `json:"text"`
+	Blocks   []slack.Block `json:"blocks"`
+}
+
+// LoadRunSummaryConfig reads the configuration from a YAML file.
+func LoadRunSummaryConfig(path string) (*RunSummaryConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &RunSummaryConfig{}
+	if err := yaml.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("failed to parse the run summary config %s: %v", path, err)
+	}
+	return config, nil
+}
+
+// Destination returns the channel and thread the summary of the given job should be posted to.
+func (c *RunSummaryConfig) Destination(jobName string) (string, string, error) {
+	channel := c.Channel
+	if channel == "" {
+		channel = constants.SlackCIReportsChannelID
+	}
+	for _, job := range c.Jobs {
+		matches, err := regexp.MatchString(job.JobNamePattern, jobName)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid job name pattern %q: %v", job.JobNamePattern, err)
+		}
+		if !matches {
+			continue
+		}
+		if job.Channel != "" {
+			channel = job.Channel
+		}
+		return channel, job.ThreadTS, nil
+	}
+	return channel, "", nil
+}
+
+// Payload renders the summary as a Block Kit message for the configured channel and thread.
+func (s RunSummary) Payload(config *RunSummaryConfig) (*RunSummaryPayload, error) {
+	channel, threadTS, err := config.Destination(s.JobName)
+	if err != nil {
+		return nil, err
+	}
+	return &RunSummaryPayload{Channel: channel, ThreadTS: threadTS, Text: s.headline(), Blocks: s.Blocks()}, nil
+}
+
+func (s RunSummary) totals() SuiteSummary {
+	totals := SuiteSummary{}
+	for _, suite := range s.Suites {
+		totals.Passed += suite.Passed
+		totals.Failed += suite.Failed
+		totals.Skipped += suite.Skipped
+		totals.Flaky += suite.Flaky
+	}
+	return totals
+}
+
+// headline is the plain text of the message, shown in the notifications
+func (s RunSummary) headline() string {
+	totals := s.totals()
+	status := ":white_check_mark: E2E tests passed"
+	if totals.Failed > 0 {
+		status = ":x: E2E tests failed"
+	}
+	if s.JobName != "" {
+		status += " in " + s.JobName
+	}
+	return fmt.Sprintf("%s: %d passed, %d failed, %d skipped, %d flaky", status, totals.Passed, totals.Failed, totals.Skipped, totals.Flaky)
+}
+
+// Blocks renders the summary as Block Kit blocks: the totals, a table of the suites, the top failing specs and the links.
+func (s RunSummary) Blocks() []slack.Block {
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, "*"+s.headline()+"*", false, false), nil, nil),
+	}
+
+	suites := append([]SuiteSummary{}, s.Suites...)
+	// the suites with failures go first
+	sort.SliceStable(suites, func(i, j int) bool {
+		if suites[i].Failed != suites[j].Failed {
+			return suites[i].Failed > suites[j].Failed
+		}
+		return suites[i].Name < suites[j].Name
+	})
+	if len(suites) > 0 {
+		lines := []string{"*Results per suite*"}
+		for _, suite := range suites {
+			emoji := ":white_check_mark:"
+			if suite.Failed > 0 {
+				emoji = ":x:"
+			}
+			line := fmt.Sprintf("%s `%s` %d passed, %d failed, %d skipped", emoji, suite.Name, suite.Passed, suite.Failed, suite.Skipped)
+			if suite.Flaky > 0 {
+				line += fmt.Sprintf(", %d flaky", suite.Flaky)
+			}
+			lines = append(lines, line)
+		}
+		blocks = append(blocks, slack.NewDividerBlock(), markdownSection(strings.Join(lines, "\n")))
+	}
+
+	if len(s.FailingSpecs) > 0 {
+		lines := []string{"*Top failing specs*"}
+		for i, spec := range s.FailingSpecs {
+			if i == maxTopFailingSpecs {
+				lines = append(lines, fmt.Sprintf("_... and %d more_", len(s.FailingSpecs)-maxTopFailingSpecs))
+				break
+			}
+			line := fmt.Sprintf("• `%s` %s", spec.Suite, strings.TrimSpace(spec.Name))
+			if spec.Category != "" {
+				line += fmt.Sprintf(" _(%s)_", spec.Category)
+			}
+			if message := firstLine(spec.Message); message != "" {
+				line += fmt.Sprintf("\n    %s", message)
+			}
+			lines = append(lines, line)
+		}
+		blocks = append(blocks, slack.NewDividerBlock(), markdownSection(strings.Join(lines, "\n")))
+	}
+
+	links := []slack.MixedElement{}
+	if s.JobURL != "" {
+		links = append(links, slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("<%s|*View Prow job*>", s.JobURL), false, false))
+	}
+	if s.ArtifactsURL != "" {
+		links = append(links, slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("<%s|*Artifacts*>", s.ArtifactsURL), false, false))
+	}
+	if len(links) > 0 {
+		blocks = append(blocks, slack.NewContextBlock("", links...))
+	}
+	return blocks
+}
+
+func markdownSection(text string) *slack.SectionBlock {
+	return slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, truncate(text, maxSectionTextLength), false, false), nil, nil)
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return truncate(line, 200)
+}
+
+// truncate shortens the text to the given number of characters, cutting it between the runes so it stays valid UTF-8
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length-3]) + "..."
+}
+
+// PostRunSummary posts the summary to the channel and thread configured for the job.
+// In the dry-run mode (the DryRunFile of the config is set) the payload is written into the file instead.
+func PostRunSummary(summary RunSummary, config *RunSummaryConfig) error {
+	payload, err := summary.Payload(config)
+	if err != nil {
+		return err
+	}
+
+	if config.DryRunFile != "" {
+		content, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(config.DryRunFile), os.ModePerm); err != nil {
+			return err
+		}
+		return os.WriteFile(config.DryRunFile, content, 0644)
+	}
+
+	options := []slack.MsgOption{
+		slack.MsgOptionText(payload.Text, false),
+		slack.MsgOptionBlocks(payload.Blocks...),
+		slack.MsgOptionAsUser(true),
+	}
+	if payload.ThreadTS != "" {
+		options = append(options, slack.MsgOptionTS(payload.ThreadTS))
+	}
+	api := slack.New(os.Getenv(constants.SLACK_BOT_TOKEN_ENV))
+	_, _, err = api.PostMessage(payload.Channel, options...)
+	return err
+}
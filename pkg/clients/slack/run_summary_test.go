@@ -0,0 +1,24 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateKeepsValidUTF8(t *testing.T) {
+	bullets := strings.Repeat("• failed spec\n", 300)
+
+	section := markdownSection(bullets)
+	assert.True(t, utf8.ValidString(section.Text.Text))
+	assert.Equal(t, maxSectionTextLength, utf8.RuneCountInString(section.Text.Text))
+	assert.True(t, strings.HasSuffix(section.Text.Text, "..."))
+
+	line := firstLine(strings.Repeat("•", 250))
+	assert.True(t, utf8.ValidString(line))
+	assert.Equal(t, strings.Repeat("•", 197)+"...", line)
+
+	assert.Equal(t, "• short", firstLine("• short\nsecond line"))
+}
@@ -18,7 +18,7 @@ func ReportIssue(msg string, errLevel ErrorSeverityLevel) error {
 
 	jobID := os.Getenv("PROW_JOB_ID")
 	if jobID != "" {
-		msg += fmt.Sprintf("\n<%s|*View logs*>", getProwJobURL(jobID))
+		msg += fmt.Sprintf("\n<%s|*View logs*>", GetProwJobURL(jobID))
 	}
 
 	_, _, err := api.PostMessage(
@@ -34,7 +34,7 @@ func getMessageHeader(errLevel ErrorSeverityLevel) string {
 	return fmt.Sprintf("%s %s %s", alertEmojiType[errLevel], headerMsg, alertEmojiType[errLevel])
 }
 
-func getProwJobURL(jobID string) string {
+func GetProwJobURL(jobID string) string {
 	r, err := http.Get(fmt.Sprintf("https://prow.ci.openshift.org/prowjob?prowjob=%s", jobID))
 	errTemplate := "failed to get prow job URL:"
 	if err != nil {
@@ -0,0 +1,55 @@
+package spi
+
+import (
+	kubeCl "github.com/redhat-appstudio/e2e-tests/pkg/clients/kubernetes"
+	spi "github.com/redhat-appstudio/service-provider-integration-operator/api/v1beta1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// AccessCheckClient operates with SPIAccessChecks.
+type AccessCheckClient interface {
+	CreateSPIAccessCheck(name, namespace, repoURL string) (*spi.SPIAccessCheck, error)
+	GetSPIAccessCheck(name, namespace string) (*spi.SPIAccessCheck, error)
+	DeleteAllAccessChecksInASpecificNamespace(namespace string) error
+}
+
+// AccessTokenBindingClient operates with SPIAccessTokenBindings.
+type AccessTokenBindingClient interface {
+	CreateSPIAccessTokenBinding(name, namespace, repoURL, secretName string, secretType v1.SecretType) (*spi.SPIAccessTokenBinding, error)
+	CreateSPIAccessTokenBindingWithSA(name, namespace, serviceAccountName, repoURL, secretName string, isImagePullSecret, isManagedServiceAccount bool) (*spi.SPIAccessTokenBinding, error)
+	GetSPIAccessTokenBinding(name, namespace string) (*spi.SPIAccessTokenBinding, error)
+	DeleteAllBindingTokensInASpecificNamespace(namespace string) error
+}
+
+// AccessTokenClient operates with SPIAccessTokens.
+type AccessTokenClient interface {
+	GetSPIAccessToken(name, namespace string) (*spi.SPIAccessToken, error)
+	InjectManualSPIToken(namespace string, repoUrl string, oauthCredentials string, secretType v1.SecretType, secretName string) string
+	DeleteAllAccessTokensInASpecificNamespace(namespace string) error
+	DeleteAllAccessTokenDataInASpecificNamespace(namespace string) error
+}
+
+// FileContentRequestClient operates with SPIFileContentRequests.
+type FileContentRequestClient interface {
+	CreateSPIFileContentRequest(name, namespace, repoURL, filePath string) (*spi.SPIFileContentRequest, error)
+	GetSPIFileContentRequest(name, namespace string) (*spi.SPIFileContentRequest, error)
+	IsSPIFileContentRequestInDeliveredPhase(SPIFcr *spi.SPIFileContentRequest)
+}
+
+// UploadClient uploads tokens to SPI.
+type UploadClient interface {
+	UploadWithK8sSecret(secretName, namespace, spiTokenName, providerURL, username, tokenData string) (*v1.Secret, error)
+	UploadWithRestEndpoint(uploadURL string, oauthCredentials string, bearerToken string) (int, error)
+}
+
+// Interface groups all the operations available for the SPI. It is implemented by SPIController.
+type Interface interface {
+	kubeCl.Interface
+	AccessCheckClient
+	AccessTokenBindingClient
+	AccessTokenClient
+	FileContentRequestClient
+	UploadClient
+}
+
+var _ Interface = &SPIController{}
@@ -17,13 +17,18 @@ type Bundles struct {
 
 // NewBundles returns new Bundles.
 func (t *TektonController) NewBundles() (*Bundles, error) {
+	return t.NewBundlesWithContext(context.Background())
+}
+
+// NewBundlesWithContext is like NewBundles but it stops as soon as the given context is cancelled.
+func (t *TektonController) NewBundlesWithContext(ctx context.Context) (*Bundles, error) {
 	namespacedName := types.NamespacedName{
 		Name:      "build-pipeline-selector",
 		Namespace: "build-service",
 	}
 	bundles := &Bundles{}
 	pipelineSelector := &buildservice.BuildPipelineSelector{}
-	err := t.KubeRest().Get(context.Background(), namespacedName, pipelineSelector)
+	err := t.KubeRest().Get(ctx, namespacedName, pipelineSelector)
 	if err != nil {
 		return nil, err
 	}
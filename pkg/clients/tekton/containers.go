@@ -8,10 +8,10 @@ import (
 )
 
 // fetchContainerLog fetches logs of a given container.
-func (t *TektonController) fetchContainerLog(podName, containerName, namespace string) (string, error) {
+func (t *TektonController) fetchContainerLog(ctx context.Context, podName, containerName, namespace string) (string, error) {
 	podClient := t.KubeInterface().CoreV1().Pods(namespace)
 	req := podClient.GetLogs(podName, &corev1.PodLogOptions{Container: containerName})
-	readCloser, err := req.Stream(context.Background())
+	readCloser, err := req.Stream(ctx)
 	log := ""
 	if err != nil {
 		return log, err
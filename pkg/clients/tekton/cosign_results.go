@@ -2,22 +2,28 @@ package tekton
 
 import (
 	"context"
+	"fmt"
 	"time"
 
-	"k8s.io/apimachinery/pkg/util/wait"
-
 	g "github.com/onsi/ginkgo/v2"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
 	"github.com/redhat-appstudio/e2e-tests/pkg/utils/tekton"
 )
 
 // AwaitAttestationAndSignature awaits attestation and signature.
 func (t *TektonController) AwaitAttestationAndSignature(image string, timeout time.Duration) error {
-	return wait.PollUntilContextTimeout(context.Background(), time.Second, timeout, true, func(ctx context.Context) (done bool, err error) {
+	return t.AwaitAttestationAndSignatureWithContext(context.Background(), image, timeout)
+}
+
+// AwaitAttestationAndSignatureWithContext is like AwaitAttestationAndSignature but it stops as soon as the given context is cancelled.
+func (t *TektonController) AwaitAttestationAndSignatureWithContext(ctx context.Context, image string, timeout time.Duration) error {
+	target := utils.WaitTarget{Description: fmt.Sprintf("the attestation and signature of image %s", image)}
+	return utils.WaitUntilTargetWithContext(ctx, target, func() (done bool, err error) {
 		if _, err := tekton.FindCosignResultsForImage(image); err != nil {
 			g.GinkgoWriter.Printf("failed to get cosign result for image %s: %+v\n", image, err)
 			return false, nil
 		}
 
 		return true, nil
-	})
+	}, time.Second, timeout)
 }
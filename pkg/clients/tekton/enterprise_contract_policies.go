@@ -11,6 +11,11 @@ import (
 
 // CreateEnterpriseContractPolicy creates an EnterpriseContractPolicy in a specified namespace.
 func (t *TektonController) CreateEnterpriseContractPolicy(name, namespace string, ecpolicy ecp.EnterpriseContractPolicySpec) (*ecp.EnterpriseContractPolicy, error) {
+	return t.CreateEnterpriseContractPolicyWithContext(context.Background(), name, namespace, ecpolicy)
+}
+
+// CreateEnterpriseContractPolicyWithContext is like CreateEnterpriseContractPolicy but it stops as soon as the given context is cancelled.
+func (t *TektonController) CreateEnterpriseContractPolicyWithContext(ctx context.Context, name, namespace string, ecpolicy ecp.EnterpriseContractPolicySpec) (*ecp.EnterpriseContractPolicy, error) {
 	ec := &ecp.EnterpriseContractPolicy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -18,11 +23,16 @@ func (t *TektonController) CreateEnterpriseContractPolicy(name, namespace string
 		},
 		Spec: ecpolicy,
 	}
-	return ec, t.KubeRest().Create(context.Background(), ec)
+	return ec, t.KubeRest().Create(ctx, ec)
 }
 
 // CreateOrUpdatePolicyConfiguration creates new policy if it doesn't exist, otherwise updates the existing one, in a specified namespace.
 func (t *TektonController) CreateOrUpdatePolicyConfiguration(namespace string, policy ecp.EnterpriseContractPolicySpec) error {
+	return t.CreateOrUpdatePolicyConfigurationWithContext(context.Background(), namespace, policy)
+}
+
+// CreateOrUpdatePolicyConfigurationWithContext is like CreateOrUpdatePolicyConfiguration but it stops as soon as the given context is cancelled.
+func (t *TektonController) CreateOrUpdatePolicyConfigurationWithContext(ctx context.Context, namespace string, policy ecp.EnterpriseContractPolicySpec) error {
 	ecPolicy := ecp.EnterpriseContractPolicy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "ec-policy",
@@ -31,7 +41,7 @@ func (t *TektonController) CreateOrUpdatePolicyConfiguration(namespace string, p
 	}
 
 	// fetch to see if it exists
-	err := t.KubeRest().Get(context.Background(), crclient.ObjectKey{
+	err := t.KubeRest().Get(ctx, crclient.ObjectKey{
 		Namespace: namespace,
 		Name:      "ec-policy",
 	}, &ecPolicy)
@@ -48,12 +58,12 @@ func (t *TektonController) CreateOrUpdatePolicyConfiguration(namespace string, p
 	ecPolicy.Spec = policy
 	if !exists {
 		// it doesn't, so create
-		if err := t.KubeRest().Create(context.Background(), &ecPolicy); err != nil {
+		if err := t.KubeRest().Create(ctx, &ecPolicy); err != nil {
 			return err
 		}
 	} else {
 		// it does, so update
-		if err := t.KubeRest().Update(context.Background(), &ecPolicy); err != nil {
+		if err := t.KubeRest().Update(ctx, &ecPolicy); err != nil {
 			return err
 		}
 	}
@@ -63,13 +73,18 @@ func (t *TektonController) CreateOrUpdatePolicyConfiguration(namespace string, p
 
 // GetEnterpriseContractPolicy gets an EnterpriseContractPolicy from specified a namespace
 func (t *TektonController) GetEnterpriseContractPolicy(name, namespace string) (*ecp.EnterpriseContractPolicy, error) {
+	return t.GetEnterpriseContractPolicyWithContext(context.Background(), name, namespace)
+}
+
+// GetEnterpriseContractPolicyWithContext is like GetEnterpriseContractPolicy but it stops as soon as the given context is cancelled.
+func (t *TektonController) GetEnterpriseContractPolicyWithContext(ctx context.Context, name, namespace string) (*ecp.EnterpriseContractPolicy, error) {
 	defaultEcPolicy := ecp.EnterpriseContractPolicy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
 		},
 	}
-	err := t.KubeRest().Get(context.Background(), crclient.ObjectKey{
+	err := t.KubeRest().Get(ctx, crclient.ObjectKey{
 		Namespace: namespace,
 		Name:      name,
 	}, &defaultEcPolicy)
@@ -79,13 +94,18 @@ func (t *TektonController) GetEnterpriseContractPolicy(name, namespace string) (
 
 // DeleteEnterpriseContractPolicy deletes enterprise contract policy.
 func (t *TektonController) DeleteEnterpriseContractPolicy(name string, namespace string, failOnNotFound bool) error {
+	return t.DeleteEnterpriseContractPolicyWithContext(context.Background(), name, namespace, failOnNotFound)
+}
+
+// DeleteEnterpriseContractPolicyWithContext is like DeleteEnterpriseContractPolicy but it stops as soon as the given context is cancelled.
+func (t *TektonController) DeleteEnterpriseContractPolicyWithContext(ctx context.Context, name string, namespace string, failOnNotFound bool) error {
 	ecPolicy := ecp.EnterpriseContractPolicy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
 		},
 	}
-	err := t.KubeRest().Delete(context.Background(), &ecPolicy)
+	err := t.KubeRest().Delete(ctx, &ecPolicy)
 	if err != nil && !failOnNotFound && errors.IsNotFound(err) {
 		err = nil
 	}
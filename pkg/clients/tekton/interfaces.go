@@ -0,0 +1,163 @@
+package tekton
+
+import (
+	"context"
+	"time"
+
+	ecp "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	kubeCl "github.com/redhat-appstudio/e2e-tests/pkg/clients/kubernetes"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils/tekton"
+	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BundleClient operates with Tekton bundles.
+type BundleClient interface {
+	NewBundles() (*Bundles, error)
+	NewBundlesWithContext(ctx context.Context) (*Bundles, error)
+}
+
+// CosignResultClient operates with cosign signatures and attestations.
+type CosignResultClient interface {
+	AwaitAttestationAndSignature(image string, timeout time.Duration) error
+	AwaitAttestationAndSignatureWithContext(ctx context.Context, image string, timeout time.Duration) error
+}
+
+// EnterpriseContractPolicyClient operates with EnterpriseContractPolicies.
+type EnterpriseContractPolicyClient interface {
+	CreateEnterpriseContractPolicy(name, namespace string, ecpolicy ecp.EnterpriseContractPolicySpec) (*ecp.EnterpriseContractPolicy, error)
+	CreateEnterpriseContractPolicyWithContext(ctx context.Context, name, namespace string, ecpolicy ecp.EnterpriseContractPolicySpec) (*ecp.EnterpriseContractPolicy, error)
+	CreateOrUpdatePolicyConfiguration(namespace string, policy ecp.EnterpriseContractPolicySpec) error
+	CreateOrUpdatePolicyConfigurationWithContext(ctx context.Context, namespace string, policy ecp.EnterpriseContractPolicySpec) error
+	GetEnterpriseContractPolicy(name, namespace string) (*ecp.EnterpriseContractPolicy, error)
+	GetEnterpriseContractPolicyWithContext(ctx context.Context, name, namespace string) (*ecp.EnterpriseContractPolicy, error)
+	DeleteEnterpriseContractPolicy(name string, namespace string, failOnNotFound bool) error
+	DeleteEnterpriseContractPolicyWithContext(ctx context.Context, name string, namespace string, failOnNotFound bool) error
+}
+
+// PersistentVolumeClaimClient operates with PersistentVolumeClaims used by pipelines.
+type PersistentVolumeClaimClient interface {
+	CreatePVCInAccessMode(name, namespace string, accessMode corev1.PersistentVolumeAccessMode) (*corev1.PersistentVolumeClaim, error)
+	CreatePVCInAccessModeWithContext(ctx context.Context, name, namespace string, accessMode corev1.PersistentVolumeAccessMode) (*corev1.PersistentVolumeClaim, error)
+}
+
+// PipelineRunClient operates with Tekton PipelineRuns.
+type PipelineRunClient interface {
+	CreatePipelineRun(pipelineRun *pipeline.PipelineRun, ns string) (*pipeline.PipelineRun, error)
+	CreatePipelineRunWithContext(ctx context.Context, pipelineRun *pipeline.PipelineRun, ns string) (*pipeline.PipelineRun, error)
+	RunPipeline(g tekton.PipelineRunGenerator, namespace string, taskTimeout int) (*pipeline.PipelineRun, error)
+	RunPipelineWithContext(ctx context.Context, g tekton.PipelineRunGenerator, namespace string, taskTimeout int) (*pipeline.PipelineRun, error)
+	GetPipelineRun(pipelineRunName, namespace string) (*pipeline.PipelineRun, error)
+	GetPipelineRunWithContext(ctx context.Context, pipelineRunName, namespace string) (*pipeline.PipelineRun, error)
+	GetPipelineRunLogs(pipelineRunName, namespace string) (string, error)
+	GetPipelineRunLogsWithContext(ctx context.Context, pipelineRunName, namespace string) (string, error)
+	GetPipelineRunWatch(ctx context.Context, namespace string) (watch.Interface, error)
+	WatchPipelineRun(pipelineRunName, namespace string, taskTimeout int) error
+	WatchPipelineRunWithContext(ctx context.Context, pipelineRunName, namespace string, taskTimeout int) error
+	WatchPipelineRunSucceeded(pipelineRunName, namespace string, taskTimeout int) error
+	WatchPipelineRunSucceededWithContext(ctx context.Context, pipelineRunName, namespace string, taskTimeout int) error
+	CheckPipelineRunStarted(pipelineRunName, namespace string) wait.ConditionFunc
+	CheckPipelineRunStartedWithContext(ctx context.Context, pipelineRunName, namespace string) wait.ConditionFunc
+	CheckPipelineRunFinished(pipelineRunName, namespace string) wait.ConditionFunc
+	CheckPipelineRunFinishedWithContext(ctx context.Context, pipelineRunName, namespace string) wait.ConditionFunc
+	CheckPipelineRunSucceeded(pipelineRunName, namespace string) wait.ConditionFunc
+	CheckPipelineRunSucceededWithContext(ctx context.Context, pipelineRunName, namespace string) wait.ConditionFunc
+	ListAllPipelineRuns(ns string) (*pipeline.PipelineRunList, error)
+	ListAllPipelineRunsWithContext(ctx context.Context, ns string) (*pipeline.PipelineRunList, error)
+	DeletePipelineRun(name, ns string) error
+	DeletePipelineRunWithContext(ctx context.Context, name, ns string) error
+	DeleteAllPipelineRunsInASpecificNamespace(ns string) error
+	DeleteAllPipelineRunsInASpecificNamespaceWithContext(ctx context.Context, ns string) error
+	StorePipelineRun(pipelineRun *pipeline.PipelineRun) error
+	StorePipelineRunWithContext(ctx context.Context, pipelineRun *pipeline.PipelineRun) error
+	StoreAllPipelineRuns(namespace string) error
+	StoreAllPipelineRunsWithContext(ctx context.Context, namespace string) error
+	AddFinalizerToPipelineRun(pipelineRun *pipeline.PipelineRun, finalizerName string) error
+	AddFinalizerToPipelineRunWithContext(ctx context.Context, pipelineRun *pipeline.PipelineRun, finalizerName string) error
+	RemoveFinalizerFromPipelineRun(pipelineRun *pipeline.PipelineRun, finalizerName string) error
+	RemoveFinalizerFromPipelineRunWithContext(ctx context.Context, pipelineRun *pipeline.PipelineRun, finalizerName string) error
+}
+
+// PipelineClient operates with Tekton Pipelines.
+type PipelineClient interface {
+	CreatePipeline(pipeline *pipeline.Pipeline, ns string) (*pipeline.Pipeline, error)
+	CreatePipelineWithContext(ctx context.Context, pipeline *pipeline.Pipeline, ns string) (*pipeline.Pipeline, error)
+	DeletePipeline(name, ns string) error
+	DeletePipelineWithContext(ctx context.Context, name, ns string) error
+}
+
+// RekorHostClient provides the Rekor host used by Tekton Chains.
+type RekorHostClient interface {
+	GetRekorHost() (rekorHost string, err error)
+	GetRekorHostWithContext(ctx context.Context) (rekorHost string, err error)
+}
+
+// RepositoryClient operates with Pipelines as Code Repositories.
+type RepositoryClient interface {
+	GetRepositoryParams(name, namespace string) ([]pacv1alpha1.Params, error)
+	GetRepositoryParamsWithContext(ctx context.Context, name, namespace string) ([]pacv1alpha1.Params, error)
+}
+
+// SigningSecretClient operates with the Tekton Chains signing secret.
+type SigningSecretClient interface {
+	CreateOrUpdateSigningSecret(publicKey []byte, name, namespace string) (err error)
+	CreateOrUpdateSigningSecretWithContext(ctx context.Context, publicKey []byte, name, namespace string) (err error)
+}
+
+// TaskRunClient operates with Tekton TaskRuns.
+type TaskRunClient interface {
+	CreateTaskRunCopy(name, namespace, serviceAccountName, srcImageURL, destImageURL string) (*pipeline.TaskRun, error)
+	CreateTaskRunCopyWithContext(ctx context.Context, name, namespace, serviceAccountName, srcImageURL, destImageURL string) (*pipeline.TaskRun, error)
+	GetTaskRun(name, namespace string) (*pipeline.TaskRun, error)
+	GetTaskRunWithContext(ctx context.Context, name, namespace string) (*pipeline.TaskRun, error)
+	GetTaskRunLogs(pipelineRunName, pipelineTaskName, namespace string) (map[string]string, error)
+	GetTaskRunLogsWithContext(ctx context.Context, pipelineRunName, pipelineTaskName, namespace string) (map[string]string, error)
+	GetTaskRunFromPipelineRun(c crclient.Client, pr *pipeline.PipelineRun, pipelineTaskName string) (*pipeline.TaskRun, error)
+	GetTaskRunFromPipelineRunWithContext(ctx context.Context, c crclient.Client, pr *pipeline.PipelineRun, pipelineTaskName string) (*pipeline.TaskRun, error)
+	GetTaskRunResult(c crclient.Client, pr *pipeline.PipelineRun, pipelineTaskName string, result string) (string, error)
+	GetTaskRunResultWithContext(ctx context.Context, c crclient.Client, pr *pipeline.PipelineRun, pipelineTaskName string, result string) (string, error)
+	GetTaskRunStatus(c crclient.Client, pr *pipeline.PipelineRun, pipelineTaskName string) (*pipeline.PipelineRunTaskRunStatus, error)
+	GetTaskRunStatusWithContext(ctx context.Context, c crclient.Client, pr *pipeline.PipelineRun, pipelineTaskName string) (*pipeline.PipelineRunTaskRunStatus, error)
+	DeleteAllTaskRunsInASpecificNamespace(namespace string) error
+	DeleteAllTaskRunsInASpecificNamespaceWithContext(ctx context.Context, namespace string) error
+}
+
+// TaskClient operates with Tekton Tasks.
+type TaskClient interface {
+	CreateTask(task *pipeline.Task, ns string) (*pipeline.Task, error)
+	CreateTaskWithContext(ctx context.Context, task *pipeline.Task, ns string) (*pipeline.Task, error)
+	CreateSkopeoCopyTask(namespace string) error
+	GetTask(name, namespace string) (*pipeline.Task, error)
+	GetTaskWithContext(ctx context.Context, name, namespace string) (*pipeline.Task, error)
+	DeleteAllTasksInASpecificNamespace(namespace string) error
+	DeleteAllTasksInASpecificNamespaceWithContext(ctx context.Context, namespace string) error
+}
+
+// ChainsPublicKeyClient provides the Tekton Chains public key.
+type ChainsPublicKeyClient interface {
+	GetTektonChainsPublicKey() ([]byte, error)
+	GetTektonChainsPublicKeyWithContext(ctx context.Context) ([]byte, error)
+}
+
+// Interface groups all the operations available for the Tekton. It is implemented by TektonController.
+type Interface interface {
+	kubeCl.Interface
+	BundleClient
+	CosignResultClient
+	EnterpriseContractPolicyClient
+	PersistentVolumeClaimClient
+	PipelineRunClient
+	PipelineClient
+	RekorHostClient
+	RepositoryClient
+	SigningSecretClient
+	TaskRunClient
+	TaskClient
+	ChainsPublicKeyClient
+}
+
+var _ Interface = &TektonController{}
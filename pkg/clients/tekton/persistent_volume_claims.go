@@ -10,6 +10,11 @@ import (
 
 // CreatePVCInAccessMode creates a PVC with mode as passed in arguments.
 func (t *TektonController) CreatePVCInAccessMode(name, namespace string, accessMode corev1.PersistentVolumeAccessMode) (*corev1.PersistentVolumeClaim, error) {
+	return t.CreatePVCInAccessModeWithContext(context.Background(), name, namespace, accessMode)
+}
+
+// CreatePVCInAccessModeWithContext is like CreatePVCInAccessMode but it stops as soon as the given context is cancelled.
+func (t *TektonController) CreatePVCInAccessModeWithContext(ctx context.Context, name, namespace string, accessMode corev1.PersistentVolumeAccessMode) (*corev1.PersistentVolumeClaim, error) {
 	pvc := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -27,7 +32,7 @@ func (t *TektonController) CreatePVCInAccessMode(name, namespace string, accessM
 		},
 	}
 
-	createdPVC, err := t.KubeInterface().CoreV1().PersistentVolumeClaims(namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
+	createdPVC, err := t.KubeInterface().CoreV1().PersistentVolumeClaims(namespace).Create(ctx, pvc, metav1.CreateOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -7,14 +7,15 @@ import (
 	"time"
 
 	"github.com/redhat-appstudio/e2e-tests/pkg/logs"
-	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
 
 	"github.com/redhat-appstudio/e2e-tests/pkg/utils/tekton"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils/watcher"
 	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
+	"knative.dev/pkg/apis"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -25,21 +26,42 @@ import (
 
 // CreatePipelineRun creates a tekton pipelineRun and returns the pipelineRun or error
 func (t *TektonController) CreatePipelineRun(pipelineRun *pipeline.PipelineRun, ns string) (*pipeline.PipelineRun, error) {
-	return t.PipelineClient().TektonV1().PipelineRuns(ns).Create(context.Background(), pipelineRun, metav1.CreateOptions{})
+	return t.CreatePipelineRunWithContext(context.Background(), pipelineRun, ns)
+}
+
+// CreatePipelineRunWithContext is like CreatePipelineRun but it stops as soon as the given context is cancelled.
+func (t *TektonController) CreatePipelineRunWithContext(ctx context.Context, pipelineRun *pipeline.PipelineRun, ns string) (*pipeline.PipelineRun, error) {
+	return t.PipelineClient().TektonV1().PipelineRuns(ns).Create(ctx, pipelineRun, metav1.CreateOptions{})
 }
 
 // createAndWait creates a pipelineRun and waits until it starts.
-func (t *TektonController) createAndWait(pr *pipeline.PipelineRun, namespace string, taskTimeout int) (*pipeline.PipelineRun, error) {
-	pipelineRun, err := t.CreatePipelineRun(pr, namespace)
+func (t *TektonController) createAndWait(ctx context.Context, pr *pipeline.PipelineRun, namespace string, taskTimeout int) (*pipeline.PipelineRun, error) {
+	pipelineRun, err := t.CreatePipelineRunWithContext(ctx, pr, namespace)
 	if err != nil {
 		return nil, err
 	}
 	g.GinkgoWriter.Printf("Creating Pipeline %q\n", pipelineRun.Name)
-	return pipelineRun, utils.WaitUntil(t.CheckPipelineRunStarted(pipelineRun.Name, namespace), time.Duration(taskTimeout)*time.Second)
+	_, err = t.pipelineRunWatcher(pipelineRun.Name, namespace).Until(ctx, time.Duration(taskTimeout)*time.Second, func(pr *pipeline.PipelineRun) (bool, error) {
+		return pr.Status.StartTime != nil, nil
+	})
+	return pipelineRun, err
+}
+
+// pipelineRunWatcher returns a watcher of a given pipelineRun which reports the changes of its Succeeded condition.
+func (t *TektonController) pipelineRunWatcher(pipelineRunName, namespace string) *watcher.Watcher[pipeline.PipelineRun] {
+	w := watcher.New[pipeline.PipelineRun](t.DynamicClient(), pipeline.SchemeGroupVersion.WithResource("pipelineruns"), namespace)
+	w.Name = pipelineRunName
+	w.State = tekton.PipelineRunState
+	return w
 }
 
 // RunPipeline creates a pipelineRun and waits for it to start.
 func (t *TektonController) RunPipeline(g tekton.PipelineRunGenerator, namespace string, taskTimeout int) (*pipeline.PipelineRun, error) {
+	return t.RunPipelineWithContext(context.Background(), g, namespace, taskTimeout)
+}
+
+// RunPipelineWithContext is like RunPipeline but it stops as soon as the given context is cancelled.
+func (t *TektonController) RunPipelineWithContext(ctx context.Context, g tekton.PipelineRunGenerator, namespace string, taskTimeout int) (*pipeline.PipelineRun, error) {
 	pr, err := g.Generate()
 	if err != nil {
 		return nil, err
@@ -48,7 +70,7 @@ func (t *TektonController) RunPipeline(g tekton.PipelineRunGenerator, namespace
 	for _, w := range pr.Spec.Workspaces {
 		if w.PersistentVolumeClaim != nil {
 			pvcName := w.PersistentVolumeClaim.ClaimName
-			if _, err := pvcs.Get(context.Background(), pvcName, metav1.GetOptions{}); err != nil {
+			if _, err := pvcs.Get(ctx, pvcName, metav1.GetOptions{}); err != nil {
 				if errors.IsNotFound(err) {
 					err := tekton.CreatePVC(pvcs, pvcName)
 					if err != nil {
@@ -61,18 +83,28 @@ func (t *TektonController) RunPipeline(g tekton.PipelineRunGenerator, namespace
 		}
 	}
 
-	return t.createAndWait(pr, namespace, taskTimeout)
+	return t.createAndWait(ctx, pr, namespace, taskTimeout)
 }
 
 // GetPipelineRun returns a pipelineRun with a given name.
 func (t *TektonController) GetPipelineRun(pipelineRunName, namespace string) (*pipeline.PipelineRun, error) {
-	return t.PipelineClient().TektonV1().PipelineRuns(namespace).Get(context.Background(), pipelineRunName, metav1.GetOptions{})
+	return t.GetPipelineRunWithContext(context.Background(), pipelineRunName, namespace)
+}
+
+// GetPipelineRunWithContext is like GetPipelineRun but it stops as soon as the given context is cancelled.
+func (t *TektonController) GetPipelineRunWithContext(ctx context.Context, pipelineRunName, namespace string) (*pipeline.PipelineRun, error) {
+	return t.PipelineClient().TektonV1().PipelineRuns(namespace).Get(ctx, pipelineRunName, metav1.GetOptions{})
 }
 
 // GetPipelineRunLogs returns logs of a given pipelineRun.
 func (t *TektonController) GetPipelineRunLogs(pipelineRunName, namespace string) (string, error) {
+	return t.GetPipelineRunLogsWithContext(context.Background(), pipelineRunName, namespace)
+}
+
+// GetPipelineRunLogsWithContext is like GetPipelineRunLogs but it stops as soon as the given context is cancelled.
+func (t *TektonController) GetPipelineRunLogsWithContext(ctx context.Context, pipelineRunName, namespace string) (string, error) {
 	podClient := t.KubeInterface().CoreV1().Pods(namespace)
-	podList, err := podClient.List(context.Background(), metav1.ListOptions{})
+	podList, err := podClient.List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return "", err
 	}
@@ -84,7 +116,7 @@ func (t *TektonController) GetPipelineRunLogs(pipelineRunName, namespace string)
 		for _, c := range pod.Spec.InitContainers {
 			var err error
 			var cLog string
-			cLog, err = t.fetchContainerLog(pod.Name, c.Name, namespace)
+			cLog, err = t.fetchContainerLog(ctx, pod.Name, c.Name, namespace)
 			podLog = podLog + fmt.Sprintf("\ninit container %s: \n", c.Name) + cLog
 			if err != nil {
 				return podLog, err
@@ -93,7 +125,7 @@ func (t *TektonController) GetPipelineRunLogs(pipelineRunName, namespace string)
 		for _, c := range pod.Spec.Containers {
 			var err error
 			var cLog string
-			cLog, err = t.fetchContainerLog(pod.Name, c.Name, namespace)
+			cLog, err = t.fetchContainerLog(ctx, pod.Name, c.Name, namespace)
 			podLog = podLog + fmt.Sprintf("\ncontainer %s: \n", c.Name) + cLog
 			if err != nil {
 				return podLog, err
@@ -110,20 +142,41 @@ func (t *TektonController) GetPipelineRunWatch(ctx context.Context, namespace st
 
 // WatchPipelineRun waits until pipelineRun finishes.
 func (t *TektonController) WatchPipelineRun(pipelineRunName, namespace string, taskTimeout int) error {
+	return t.WatchPipelineRunWithContext(context.Background(), pipelineRunName, namespace, taskTimeout)
+}
+
+// WatchPipelineRunWithContext is like WatchPipelineRun but it stops as soon as the given context is cancelled.
+func (t *TektonController) WatchPipelineRunWithContext(ctx context.Context, pipelineRunName, namespace string, taskTimeout int) error {
 	g.GinkgoWriter.Printf("Waiting for pipeline %q to finish\n", pipelineRunName)
-	return utils.WaitUntil(t.CheckPipelineRunFinished(pipelineRunName, namespace), time.Duration(taskTimeout)*time.Second)
+	_, err := t.pipelineRunWatcher(pipelineRunName, namespace).Until(ctx, time.Duration(taskTimeout)*time.Second, func(pr *pipeline.PipelineRun) (bool, error) {
+		return pr.Status.CompletionTime != nil, nil
+	})
+	return err
 }
 
 // WatchPipelineRunSucceeded waits until the pipelineRun succeeds.
 func (t *TektonController) WatchPipelineRunSucceeded(pipelineRunName, namespace string, taskTimeout int) error {
+	return t.WatchPipelineRunSucceededWithContext(context.Background(), pipelineRunName, namespace, taskTimeout)
+}
+
+// WatchPipelineRunSucceededWithContext is like WatchPipelineRunSucceeded but it stops as soon as the given context is cancelled.
+func (t *TektonController) WatchPipelineRunSucceededWithContext(ctx context.Context, pipelineRunName, namespace string, taskTimeout int) error {
 	g.GinkgoWriter.Printf("Waiting for pipeline %q to finish\n", pipelineRunName)
-	return utils.WaitUntil(t.CheckPipelineRunSucceeded(pipelineRunName, namespace), time.Duration(taskTimeout)*time.Second)
+	_, err := t.pipelineRunWatcher(pipelineRunName, namespace).Until(ctx, time.Duration(taskTimeout)*time.Second, func(pr *pipeline.PipelineRun) (bool, error) {
+		return pr.GetStatusCondition().GetCondition(apis.ConditionSucceeded).IsTrue(), nil
+	})
+	return err
 }
 
 // CheckPipelineRunStarted checks if pipelineRUn started.
 func (t *TektonController) CheckPipelineRunStarted(pipelineRunName, namespace string) wait.ConditionFunc {
+	return t.CheckPipelineRunStartedWithContext(context.Background(), pipelineRunName, namespace)
+}
+
+// CheckPipelineRunStartedWithContext is like CheckPipelineRunStarted but it stops as soon as the given context is cancelled.
+func (t *TektonController) CheckPipelineRunStartedWithContext(ctx context.Context, pipelineRunName, namespace string) wait.ConditionFunc {
 	return func() (bool, error) {
-		pr, err := t.GetPipelineRun(pipelineRunName, namespace)
+		pr, err := t.GetPipelineRunWithContext(ctx, pipelineRunName, namespace)
 		if err != nil {
 			return false, nil
 		}
@@ -136,8 +189,13 @@ func (t *TektonController) CheckPipelineRunStarted(pipelineRunName, namespace st
 
 // CheckPipelineRunFinished checks if pipelineRun finished.
 func (t *TektonController) CheckPipelineRunFinished(pipelineRunName, namespace string) wait.ConditionFunc {
+	return t.CheckPipelineRunFinishedWithContext(context.Background(), pipelineRunName, namespace)
+}
+
+// CheckPipelineRunFinishedWithContext is like CheckPipelineRunFinished but it stops as soon as the given context is cancelled.
+func (t *TektonController) CheckPipelineRunFinishedWithContext(ctx context.Context, pipelineRunName, namespace string) wait.ConditionFunc {
 	return func() (bool, error) {
-		pr, err := t.GetPipelineRun(pipelineRunName, namespace)
+		pr, err := t.GetPipelineRunWithContext(ctx, pipelineRunName, namespace)
 		if err != nil {
 			return false, nil
 		}
@@ -150,8 +208,13 @@ func (t *TektonController) CheckPipelineRunFinished(pipelineRunName, namespace s
 
 // CheckPipelineRunSucceeded checks if pipelineRun succeeded. Returns error if getting pipelineRun fails.
 func (t *TektonController) CheckPipelineRunSucceeded(pipelineRunName, namespace string) wait.ConditionFunc {
+	return t.CheckPipelineRunSucceededWithContext(context.Background(), pipelineRunName, namespace)
+}
+
+// CheckPipelineRunSucceededWithContext is like CheckPipelineRunSucceeded but it stops as soon as the given context is cancelled.
+func (t *TektonController) CheckPipelineRunSucceededWithContext(ctx context.Context, pipelineRunName, namespace string) wait.ConditionFunc {
 	return func() (bool, error) {
-		pr, err := t.GetPipelineRun(pipelineRunName, namespace)
+		pr, err := t.GetPipelineRunWithContext(ctx, pipelineRunName, namespace)
 		if err != nil {
 			return false, err
 		}
@@ -168,31 +231,46 @@ func (t *TektonController) CheckPipelineRunSucceeded(pipelineRunName, namespace
 
 // ListAllPipelineRuns returns a list of all pipelineRuns in a namespace.
 func (t *TektonController) ListAllPipelineRuns(ns string) (*pipeline.PipelineRunList, error) {
-	return t.PipelineClient().TektonV1().PipelineRuns(ns).List(context.Background(), metav1.ListOptions{})
+	return t.ListAllPipelineRunsWithContext(context.Background(), ns)
+}
+
+// ListAllPipelineRunsWithContext is like ListAllPipelineRuns but it stops as soon as the given context is cancelled.
+func (t *TektonController) ListAllPipelineRunsWithContext(ctx context.Context, ns string) (*pipeline.PipelineRunList, error) {
+	return t.PipelineClient().TektonV1().PipelineRuns(ns).List(ctx, metav1.ListOptions{})
 }
 
 // DeletePipelineRun deletes a pipelineRun form a given namespace.
 func (t *TektonController) DeletePipelineRun(name, ns string) error {
-	return t.PipelineClient().TektonV1().PipelineRuns(ns).Delete(context.Background(), name, metav1.DeleteOptions{})
+	return t.DeletePipelineRunWithContext(context.Background(), name, ns)
+}
+
+// DeletePipelineRunWithContext is like DeletePipelineRun but it stops as soon as the given context is cancelled.
+func (t *TektonController) DeletePipelineRunWithContext(ctx context.Context, name, ns string) error {
+	return t.PipelineClient().TektonV1().PipelineRuns(ns).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
 // DeleteAllPipelineRunsInASpecificNamespace deletes all PipelineRuns in a given namespace (removing the finalizers field, first)
 func (t *TektonController) DeleteAllPipelineRunsInASpecificNamespace(ns string) error {
+	return t.DeleteAllPipelineRunsInASpecificNamespaceWithContext(context.Background(), ns)
+}
+
+// DeleteAllPipelineRunsInASpecificNamespaceWithContext is like DeleteAllPipelineRunsInASpecificNamespace but it stops as soon as the given context is cancelled.
+func (t *TektonController) DeleteAllPipelineRunsInASpecificNamespaceWithContext(ctx context.Context, ns string) error {
 
-	pipelineRunList, err := t.ListAllPipelineRuns(ns)
+	pipelineRunList, err := t.ListAllPipelineRunsWithContext(ctx, ns)
 	if err != nil || pipelineRunList == nil {
 		return fmt.Errorf("unable to delete all PipelineRuns in '%s': %v", ns, err)
 	}
 
 	for _, pipelineRun := range pipelineRunList.Items {
-		err := wait.PollUntilContextTimeout(context.Background(), time.Second, 30*time.Second, true, func(ctx context.Context) (done bool, err error) {
+		err := wait.PollUntilContextTimeout(ctx, time.Second, 30*time.Second, true, func(ctx context.Context) (done bool, err error) {
 			pipelineRunCR := pipeline.PipelineRun{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      pipelineRun.Name,
 					Namespace: ns,
 				},
 			}
-			if err := t.KubeRest().Get(context.Background(), crclient.ObjectKeyFromObject(&pipelineRunCR), &pipelineRunCR); err != nil {
+			if err := t.KubeRest().Get(ctx, crclient.ObjectKeyFromObject(&pipelineRunCR), &pipelineRunCR); err != nil {
 				if errors.IsNotFound(err) {
 					// PipelinerRun CR is already removed
 					return true, nil
@@ -204,12 +282,12 @@ func (t *TektonController) DeleteAllPipelineRunsInASpecificNamespace(ns string)
 
 			// Remove the finalizer, so that it can be deleted.
 			pipelineRunCR.Finalizers = []string{}
-			if err := t.KubeRest().Update(context.Background(), &pipelineRunCR); err != nil {
+			if err := t.KubeRest().Update(ctx, &pipelineRunCR); err != nil {
 				g.GinkgoWriter.Printf("unable to remove finalizers from PipelineRun '%s' in '%s': %v\n", pipelineRunCR.Name, pipelineRunCR.Namespace, err)
 				return false, nil
 			}
 
-			if err := t.KubeRest().Delete(context.Background(), &pipelineRunCR); err != nil {
+			if err := t.KubeRest().Delete(ctx, &pipelineRunCR); err != nil {
 				g.GinkgoWriter.Printf("unable to delete PipelineRun '%s' in '%s': %v\n", pipelineRunCR.Name, pipelineRunCR.Namespace, err)
 				return false, nil
 			}
@@ -226,8 +304,13 @@ func (t *TektonController) DeleteAllPipelineRunsInASpecificNamespace(ns string)
 
 // StorePipelineRun stores a given PipelineRun as an artifact.
 func (t *TektonController) StorePipelineRun(pipelineRun *pipeline.PipelineRun) error {
+	return t.StorePipelineRunWithContext(context.Background(), pipelineRun)
+}
+
+// StorePipelineRunWithContext is like StorePipelineRun but it stops as soon as the given context is cancelled.
+func (t *TektonController) StorePipelineRunWithContext(ctx context.Context, pipelineRun *pipeline.PipelineRun) error {
 	artifacts := make(map[string][]byte)
-	pipelineRunLog, err := t.GetPipelineRunLogs(pipelineRun.Name, pipelineRun.Namespace)
+	pipelineRunLog, err := t.GetPipelineRunLogsWithContext(ctx, pipelineRun.Name, pipelineRun.Namespace)
 	if err != nil {
 		return err
 	}
@@ -248,14 +331,19 @@ func (t *TektonController) StorePipelineRun(pipelineRun *pipeline.PipelineRun) e
 
 // StoreAllPipelineRuns stores all PipelineRuns in a given namespace.
 func (t *TektonController) StoreAllPipelineRuns(namespace string) error {
-	pipelineRuns, err := t.ListAllPipelineRuns(namespace)
+	return t.StoreAllPipelineRunsWithContext(context.Background(), namespace)
+}
+
+// StoreAllPipelineRunsWithContext is like StoreAllPipelineRuns but it stops as soon as the given context is cancelled.
+func (t *TektonController) StoreAllPipelineRunsWithContext(ctx context.Context, namespace string) error {
+	pipelineRuns, err := t.ListAllPipelineRunsWithContext(ctx, namespace)
 	if err != nil {
 		return fmt.Errorf("got error fetching PR list: %v\n", err.Error())
 	}
 
 	for _, pipelineRun := range pipelineRuns.Items {
 		pipelineRun := pipelineRun
-		if err := t.StorePipelineRun(&pipelineRun); err != nil {
+		if err := t.StorePipelineRunWithContext(ctx, &pipelineRun); err != nil {
 			return fmt.Errorf("got error storing PR: %v\n", err.Error())
 		}
 	}
@@ -264,7 +352,11 @@ func (t *TektonController) StoreAllPipelineRuns(namespace string) error {
 }
 
 func (t *TektonController) AddFinalizerToPipelineRun(pipelineRun *pipeline.PipelineRun, finalizerName string) error {
-	ctx := context.Background()
+	return t.AddFinalizerToPipelineRunWithContext(context.Background(), pipelineRun, finalizerName)
+}
+
+// AddFinalizerToPipelineRunWithContext is like AddFinalizerToPipelineRun but it stops as soon as the given context is cancelled.
+func (t *TektonController) AddFinalizerToPipelineRunWithContext(ctx context.Context, pipelineRun *pipeline.PipelineRun, finalizerName string) error {
 	kubeClient := t.KubeRest()
 	patch := crclient.MergeFrom(pipelineRun.DeepCopy())
 	if ok := controllerutil.AddFinalizer(pipelineRun, finalizerName); ok {
@@ -277,7 +369,11 @@ func (t *TektonController) AddFinalizerToPipelineRun(pipelineRun *pipeline.Pipel
 }
 
 func (t *TektonController) RemoveFinalizerFromPipelineRun(pipelineRun *pipeline.PipelineRun, finalizerName string) error {
-	ctx := context.Background()
+	return t.RemoveFinalizerFromPipelineRunWithContext(context.Background(), pipelineRun, finalizerName)
+}
+
+// RemoveFinalizerFromPipelineRunWithContext is like RemoveFinalizerFromPipelineRun but it stops as soon as the given context is cancelled.
+func (t *TektonController) RemoveFinalizerFromPipelineRunWithContext(ctx context.Context, pipelineRun *pipeline.PipelineRun, finalizerName string) error {
 	kubeClient := t.KubeRest()
 	patch := client.MergeFrom(pipelineRun.DeepCopy())
 	if ok := controllerutil.RemoveFinalizer(pipelineRun, finalizerName); ok {
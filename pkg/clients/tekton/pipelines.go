@@ -9,10 +9,20 @@ import (
 
 // CreatePipeline creates a tekton pipeline and returns the pipeline or an error
 func (t *TektonController) CreatePipeline(pipeline *pipeline.Pipeline, ns string) (*pipeline.Pipeline, error) {
-	return t.PipelineClient().TektonV1().Pipelines(ns).Create(context.Background(), pipeline, metav1.CreateOptions{})
+	return t.CreatePipelineWithContext(context.Background(), pipeline, ns)
+}
+
+// CreatePipelineWithContext is like CreatePipeline but it stops as soon as the given context is cancelled.
+func (t *TektonController) CreatePipelineWithContext(ctx context.Context, pipeline *pipeline.Pipeline, ns string) (*pipeline.Pipeline, error) {
+	return t.PipelineClient().TektonV1().Pipelines(ns).Create(ctx, pipeline, metav1.CreateOptions{})
 }
 
 // DeletePipeline removes the pipeline from given namespace.
 func (t *TektonController) DeletePipeline(name, ns string) error {
-	return t.PipelineClient().TektonV1().Pipelines(ns).Delete(context.Background(), name, metav1.DeleteOptions{})
+	return t.DeletePipelineWithContext(context.Background(), name, ns)
+}
+
+// DeletePipelineWithContext is like DeletePipeline but it stops as soon as the given context is cancelled.
+func (t *TektonController) DeletePipelineWithContext(ctx context.Context, name, ns string) error {
+	return t.PipelineClient().TektonV1().Pipelines(ns).Delete(ctx, name, metav1.DeleteOptions{})
 }
@@ -9,8 +9,12 @@ import (
 
 // GetRekorHost returns a rekorHost.
 func (t *TektonController) GetRekorHost() (rekorHost string, err error) {
+	return t.GetRekorHostWithContext(context.Background())
+}
+
+// GetRekorHostWithContext is like GetRekorHost but it stops as soon as the given context is cancelled.
+func (t *TektonController) GetRekorHostWithContext(ctx context.Context) (rekorHost string, err error) {
 	api := t.KubeInterface().CoreV1().ConfigMaps(constants.TEKTON_CHAINS_NS)
-	ctx := context.Background()
 
 	cm, err := api.Get(ctx, "chains-config", metav1.GetOptions{})
 	if err != nil {
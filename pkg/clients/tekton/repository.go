@@ -9,7 +9,11 @@ import (
 
 // GetRepositoryParams returns a repository params list
 func (t *TektonController) GetRepositoryParams(name, namespace string) ([]pacv1alpha1.Params, error) {
-	ctx := context.Background()
+	return t.GetRepositoryParamsWithContext(context.Background(), name, namespace)
+}
+
+// GetRepositoryParamsWithContext is like GetRepositoryParams but it stops as soon as the given context is cancelled.
+func (t *TektonController) GetRepositoryParamsWithContext(ctx context.Context, name, namespace string) ([]pacv1alpha1.Params, error) {
 	repositoryObj := &pacv1alpha1.Repository{}
 	err := t.KubeRest().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, repositoryObj)
 	if err != nil {
@@ -10,8 +10,12 @@ import (
 
 // CreateOrUpdateSigningSecret creates a signing secret if it doesn't exist, otherwise updates the existing one.
 func (t *TektonController) CreateOrUpdateSigningSecret(publicKey []byte, name, namespace string) (err error) {
+	return t.CreateOrUpdateSigningSecretWithContext(context.Background(), publicKey, name, namespace)
+}
+
+// CreateOrUpdateSigningSecretWithContext is like CreateOrUpdateSigningSecret but it stops as soon as the given context is cancelled.
+func (t *TektonController) CreateOrUpdateSigningSecretWithContext(ctx context.Context, publicKey []byte, name, namespace string) (err error) {
 	api := t.KubeInterface().CoreV1().Secrets(namespace)
-	ctx := context.Background()
 
 	expectedSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{Name: name},
@@ -17,6 +17,11 @@ import (
 
 // CreateTaskRunCopy creates a TaskRun that copies one image to a second image repository.
 func (t *TektonController) CreateTaskRunCopy(name, namespace, serviceAccountName, srcImageURL, destImageURL string) (*pipeline.TaskRun, error) {
+	return t.CreateTaskRunCopyWithContext(context.Background(), name, namespace, serviceAccountName, srcImageURL, destImageURL)
+}
+
+// CreateTaskRunCopyWithContext is like CreateTaskRunCopy but it stops as soon as the given context is cancelled.
+func (t *TektonController) CreateTaskRunCopyWithContext(ctx context.Context, name, namespace, serviceAccountName, srcImageURL, destImageURL string) (*pipeline.TaskRun, error) {
 	taskRun := pipeline.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -60,7 +65,7 @@ func (t *TektonController) CreateTaskRunCopy(name, namespace, serviceAccountName
 		},
 	}
 
-	err := t.KubeRest().Create(context.Background(), &taskRun)
+	err := t.KubeRest().Create(ctx, &taskRun)
 	if err != nil {
 		return nil, err
 	}
@@ -69,6 +74,11 @@ func (t *TektonController) CreateTaskRunCopy(name, namespace, serviceAccountName
 
 // GetTaskRun returns the requested TaskRun object.
 func (t *TektonController) GetTaskRun(name, namespace string) (*pipeline.TaskRun, error) {
+	return t.GetTaskRunWithContext(context.Background(), name, namespace)
+}
+
+// GetTaskRunWithContext is like GetTaskRun but it stops as soon as the given context is cancelled.
+func (t *TektonController) GetTaskRunWithContext(ctx context.Context, name, namespace string) (*pipeline.TaskRun, error) {
 	namespacedName := types.NamespacedName{
 		Name:      name,
 		Namespace: namespace,
@@ -80,7 +90,7 @@ func (t *TektonController) GetTaskRun(name, namespace string) (*pipeline.TaskRun
 			Namespace: namespace,
 		},
 	}
-	err := t.KubeRest().Get(context.Background(), namespacedName, &taskRun)
+	err := t.KubeRest().Get(ctx, namespacedName, &taskRun)
 	if err != nil {
 		return nil, err
 	}
@@ -89,8 +99,13 @@ func (t *TektonController) GetTaskRun(name, namespace string) (*pipeline.TaskRun
 
 // GetTaskRunLogs returns logs of a specified taskRun.
 func (t *TektonController) GetTaskRunLogs(pipelineRunName, pipelineTaskName, namespace string) (map[string]string, error) {
+	return t.GetTaskRunLogsWithContext(context.Background(), pipelineRunName, pipelineTaskName, namespace)
+}
+
+// GetTaskRunLogsWithContext is like GetTaskRunLogs but it stops as soon as the given context is cancelled.
+func (t *TektonController) GetTaskRunLogsWithContext(ctx context.Context, pipelineRunName, pipelineTaskName, namespace string) (map[string]string, error) {
 	tektonClient := t.PipelineClient().TektonV1beta1().PipelineRuns(namespace)
-	pipelineRun, err := tektonClient.Get(context.Background(), pipelineRunName, metav1.GetOptions{})
+	pipelineRun, err := tektonClient.Get(ctx, pipelineRunName, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +115,7 @@ func (t *TektonController) GetTaskRunLogs(pipelineRunName, pipelineTaskName, nam
 		if childStatusReference.PipelineTaskName == pipelineTaskName {
 			taskRun := &pipeline.TaskRun{}
 			taskRunKey := types.NamespacedName{Namespace: pipelineRun.Namespace, Name: childStatusReference.Name}
-			if err := t.KubeRest().Get(context.Background(), taskRunKey, taskRun); err != nil {
+			if err := t.KubeRest().Get(ctx, taskRunKey, taskRun); err != nil {
 				return nil, err
 			}
 			podName = taskRun.Status.PodName
@@ -112,7 +127,7 @@ func (t *TektonController) GetTaskRunLogs(pipelineRunName, pipelineTaskName, nam
 	}
 
 	podClient := t.KubeInterface().CoreV1().Pods(namespace)
-	pod, err := podClient.Get(context.Background(), podName, metav1.GetOptions{})
+	pod, err := podClient.Get(ctx, podName, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -120,7 +135,7 @@ func (t *TektonController) GetTaskRunLogs(pipelineRunName, pipelineTaskName, nam
 	logs := make(map[string]string)
 	for _, container := range pod.Spec.Containers {
 		containerName := container.Name
-		if containerLogs, err := t.fetchContainerLog(podName, containerName, namespace); err == nil {
+		if containerLogs, err := t.fetchContainerLog(ctx, podName, containerName, namespace); err == nil {
 			logs[containerName] = containerLogs
 		} else {
 			logs[containerName] = "failed to get logs"
@@ -130,6 +145,11 @@ func (t *TektonController) GetTaskRunLogs(pipelineRunName, pipelineTaskName, nam
 }
 
 func (t *TektonController) GetTaskRunFromPipelineRun(c crclient.Client, pr *pipeline.PipelineRun, pipelineTaskName string) (*pipeline.TaskRun, error) {
+	return t.GetTaskRunFromPipelineRunWithContext(context.Background(), c, pr, pipelineTaskName)
+}
+
+// GetTaskRunFromPipelineRunWithContext is like GetTaskRunFromPipelineRun but it stops as soon as the given context is cancelled.
+func (t *TektonController) GetTaskRunFromPipelineRunWithContext(ctx context.Context, c crclient.Client, pr *pipeline.PipelineRun, pipelineTaskName string) (*pipeline.TaskRun, error) {
 	for _, chr := range pr.Status.ChildReferences {
 		if chr.PipelineTaskName != pipelineTaskName {
 			continue
@@ -137,7 +157,7 @@ func (t *TektonController) GetTaskRunFromPipelineRun(c crclient.Client, pr *pipe
 
 		taskRun := &pipeline.TaskRun{}
 		taskRunKey := types.NamespacedName{Namespace: pr.Namespace, Name: chr.Name}
-		if err := c.Get(context.Background(), taskRunKey, taskRun); err != nil {
+		if err := c.Get(ctx, taskRunKey, taskRun); err != nil {
 			return nil, err
 		}
 		return taskRun, nil
@@ -147,7 +167,12 @@ func (t *TektonController) GetTaskRunFromPipelineRun(c crclient.Client, pr *pipe
 }
 
 func (t *TektonController) GetTaskRunResult(c crclient.Client, pr *pipeline.PipelineRun, pipelineTaskName string, result string) (string, error) {
-	taskRun, err := t.GetTaskRunFromPipelineRun(c, pr, pipelineTaskName)
+	return t.GetTaskRunResultWithContext(context.Background(), c, pr, pipelineTaskName, result)
+}
+
+// GetTaskRunResultWithContext is like GetTaskRunResult but it stops as soon as the given context is cancelled.
+func (t *TektonController) GetTaskRunResultWithContext(ctx context.Context, c crclient.Client, pr *pipeline.PipelineRun, pipelineTaskName string, result string) (string, error) {
+	taskRun, err := t.GetTaskRunFromPipelineRunWithContext(ctx, c, pr, pipelineTaskName)
 	if err != nil {
 		return "", err
 	}
@@ -164,11 +189,16 @@ func (t *TektonController) GetTaskRunResult(c crclient.Client, pr *pipeline.Pipe
 
 // GetTaskRunStatus returns the status of a specified taskRun.
 func (t *TektonController) GetTaskRunStatus(c crclient.Client, pr *pipeline.PipelineRun, pipelineTaskName string) (*pipeline.PipelineRunTaskRunStatus, error) {
+	return t.GetTaskRunStatusWithContext(context.Background(), c, pr, pipelineTaskName)
+}
+
+// GetTaskRunStatusWithContext is like GetTaskRunStatus but it stops as soon as the given context is cancelled.
+func (t *TektonController) GetTaskRunStatusWithContext(ctx context.Context, c crclient.Client, pr *pipeline.PipelineRun, pipelineTaskName string) (*pipeline.PipelineRunTaskRunStatus, error) {
 	for _, chr := range pr.Status.ChildReferences {
 		if chr.PipelineTaskName == pipelineTaskName {
 			taskRun := &pipeline.TaskRun{}
 			taskRunKey := types.NamespacedName{Namespace: pr.Namespace, Name: chr.Name}
-			if err := c.Get(context.Background(), taskRunKey, taskRun); err != nil {
+			if err := c.Get(ctx, taskRunKey, taskRun); err != nil {
 				return nil, err
 			}
 			return &pipeline.PipelineRunTaskRunStatus{PipelineTaskName: chr.PipelineTaskName, Status: &taskRun.Status}, nil
@@ -180,5 +210,10 @@ func (t *TektonController) GetTaskRunStatus(c crclient.Client, pr *pipeline.Pipe
 
 // DeleteAllTaskRunsInASpecificNamespace removes all TaskRuns from a given repository. Useful when creating a lot of resources and wanting to remove all of them.
 func (t *TektonController) DeleteAllTaskRunsInASpecificNamespace(namespace string) error {
-	return t.KubeRest().DeleteAllOf(context.Background(), &pipeline.TaskRun{}, crclient.InNamespace(namespace))
+	return t.DeleteAllTaskRunsInASpecificNamespaceWithContext(context.Background(), namespace)
+}
+
+// DeleteAllTaskRunsInASpecificNamespaceWithContext is like DeleteAllTaskRunsInASpecificNamespace but it stops as soon as the given context is cancelled.
+func (t *TektonController) DeleteAllTaskRunsInASpecificNamespaceWithContext(ctx context.Context, namespace string) error {
+	return t.KubeRest().DeleteAllOf(ctx, &pipeline.TaskRun{}, crclient.InNamespace(namespace))
 }
@@ -12,7 +12,12 @@ import (
 
 // Create a tekton task and return the task or error.
 func (t *TektonController) CreateTask(task *pipeline.Task, ns string) (*pipeline.Task, error) {
-	return t.PipelineClient().TektonV1().Tasks(ns).Create(context.Background(), task, metav1.CreateOptions{})
+	return t.CreateTaskWithContext(context.Background(), task, ns)
+}
+
+// CreateTaskWithContext is like CreateTask but it stops as soon as the given context is cancelled.
+func (t *TektonController) CreateTaskWithContext(ctx context.Context, task *pipeline.Task, ns string) (*pipeline.Task, error) {
+	return t.PipelineClient().TektonV1().Tasks(ns).Create(ctx, task, metav1.CreateOptions{})
 }
 
 // CreateSkopeoCopyTask creates a skopeo copy task in the given namespace.
@@ -30,6 +35,11 @@ func (t *TektonController) CreateSkopeoCopyTask(namespace string) error {
 
 // GetTask returns the requested Task object.
 func (t *TektonController) GetTask(name, namespace string) (*pipeline.Task, error) {
+	return t.GetTaskWithContext(context.Background(), name, namespace)
+}
+
+// GetTaskWithContext is like GetTask but it stops as soon as the given context is cancelled.
+func (t *TektonController) GetTaskWithContext(ctx context.Context, name, namespace string) (*pipeline.Task, error) {
 	namespacedName := types.NamespacedName{
 		Name:      name,
 		Namespace: namespace,
@@ -41,7 +51,7 @@ func (t *TektonController) GetTask(name, namespace string) (*pipeline.Task, erro
 			Namespace: namespace,
 		},
 	}
-	err := t.KubeRest().Get(context.Background(), namespacedName, &task)
+	err := t.KubeRest().Get(ctx, namespacedName, &task)
 	if err != nil {
 		return nil, err
 	}
@@ -50,5 +60,10 @@ func (t *TektonController) GetTask(name, namespace string) (*pipeline.Task, erro
 
 // DeleteAllTasksInASpecificNamespace removes all Tasks from a given repository. Useful when creating a lot of resources and wanting to remove all of them.
 func (t *TektonController) DeleteAllTasksInASpecificNamespace(namespace string) error {
-	return t.KubeRest().DeleteAllOf(context.Background(), &pipeline.Task{}, crclient.InNamespace(namespace))
+	return t.DeleteAllTasksInASpecificNamespaceWithContext(context.Background(), namespace)
+}
+
+// DeleteAllTasksInASpecificNamespaceWithContext is like DeleteAllTasksInASpecificNamespace but it stops as soon as the given context is cancelled.
+func (t *TektonController) DeleteAllTasksInASpecificNamespaceWithContext(ctx context.Context, namespace string) error {
+	return t.KubeRest().DeleteAllOf(ctx, &pipeline.Task{}, crclient.InNamespace(namespace))
 }
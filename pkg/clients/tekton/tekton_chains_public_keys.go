@@ -10,11 +10,16 @@ import (
 
 // GetTektonChainsPublicKey returns a TektonChains public key.
 func (t *TektonController) GetTektonChainsPublicKey() ([]byte, error) {
+	return t.GetTektonChainsPublicKeyWithContext(context.Background())
+}
+
+// GetTektonChainsPublicKeyWithContext is like GetTektonChainsPublicKey but it stops as soon as the given context is cancelled.
+func (t *TektonController) GetTektonChainsPublicKeyWithContext(ctx context.Context) ([]byte, error) {
 	namespace := constants.TEKTON_CHAINS_NS
 	secretName := "public-key"
 	dataKey := "cosign.pub"
 
-	secret, err := t.KubeInterface().CoreV1().Secrets(namespace).Get(context.Background(), secretName, metav1.GetOptions{})
+	secret, err := t.KubeInterface().CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("couldn't get the secret %s from %s namespace: %+v", secretName, namespace, err)
 	}
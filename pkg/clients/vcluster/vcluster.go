@@ -23,14 +23,14 @@ const (
 
 type vclusterFactory struct {
 	TargetDir  string
-	KubeClient *client.CustomClient
+	KubeClient client.Interface
 }
 
 type Vcluster interface {
 	InitializeVCluster(clusterName string, targetNamespace string, host string) (kubeconfigPath string, err error)
 }
 
-func NewVclusterController(dir string, kube *client.CustomClient) Vcluster {
+func NewVclusterController(dir string, kube client.Interface) Vcluster {
 	return &vclusterFactory{
 		TargetDir:  dir,
 		KubeClient: kube,
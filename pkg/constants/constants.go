@@ -91,6 +91,46 @@ const (
 	// This variable is set by an automation in case Spray Proxy configuration fails in CI
 	SKIP_PAC_TESTS_ENV = "SKIP_PAC_TESTS"
 
+	// Maximum size (in MiB) of the artifacts stored for a single spec, 0 disables the limit
+	ARTIFACTS_SPEC_QUOTA_MB_ENV = "ARTIFACTS_SPEC_QUOTA_MB"
+
+	// Log artifacts larger than this size (in KiB) are stored gzipped, 0 disables the compression
+	ARTIFACTS_GZIP_THRESHOLD_KB_ENV = "ARTIFACTS_GZIP_THRESHOLD_KB"
+
+	// Retry policy of the suites, e.g. "build=2,integration-service=3" retries the failed specs of the suites labeled "build" once and those labeled "integration-service" twice
+	E2E_RETRY_POLICY_ENV = "E2E_RETRY_POLICY"
+
+	// Path to a JSON file with the rules classifying the failures in the JUnit report, see framework.FailureRules
+	FAILURE_CLASSIFICATION_RULES_ENV = "FAILURE_CLASSIFICATION_RULES"
+
+	// Path to a YAML file with the sinks the CI alerts are sent to, see notifier.Config. The alerts go only to Slack if not set
+	NOTIFIERS_CONFIG_ENV = "NOTIFIERS_CONFIG"
+
+	// Path to a YAML file with the pipeline bundles patched and pushed before running the tests in CI, see tekton.PipelineBundleOverride
+	TEKTON_BUNDLE_OVERRIDES_ENV = "TEKTON_BUNDLE_OVERRIDES"
+
+	// How long to wait for the ArgoCD Applications, operator Deployments and CRDs to become ready after installing the cluster, e.g. "30m"
+	CLUSTER_READINESS_TIMEOUT_ENV = "CLUSTER_READINESS_TIMEOUT"
+
+	// Backend installing RHTAP in BootstrapCluster: "preview" (infra-deployments preview mode, the default) or "manifest-bundle"
+	INSTALLER_BACKEND_ENV = "INSTALLER_BACKEND"
+
+	// Directory with the pre-rendered kustomize output applied by the "manifest-bundle" installer backend
+	MANIFEST_BUNDLE_DIR_ENV = "MANIFEST_BUNDLE_DIR"
+
+	// Comma separated upgrade hops "<from>-><to>" run by the upgrade tests, the refs are "<organization>:<branch>" or "<branch>" of redhat-appstudio,
+	// e.g. "main->my-org:feature,my-org:feature->my-org:feature-v2"
+	UPGRADE_MATRIX_ENV = "UPGRADE_MATRIX"
+
+	// Comma separated patterns of the fields of the RHTAP CRs whose changes are expected in the upgrade tests, e.g. "status.conditions.*.message"
+	UPGRADE_STATE_DIFF_IGNORE_ENV = "UPGRADE_STATE_DIFF_IGNORE"
+
+	// If "true", the changes of the status fields of the RHTAP CRs, except the new ones, fail the upgrade tests. By default only the removed CRs and the changes of their spec fail them
+	UPGRADE_STATE_DIFF_STRICT_STATUS_ENV = "UPGRADE_STATE_DIFF_STRICT_STATUS"
+
+	// Path to a YAML file with the rules selecting the resources leaked by the e2e tests, see reaper.Policy. The magefiles/reaper/default-policy.yaml is used if not set
+	REAPER_POLICY_ENV = "REAPER_POLICY"
+
 	// Test namespace's required labels
 	ArgoCDLabelKey   string = "argocd.argoproj.io/managed-by"
 	ArgoCDLabelValue string = "gitops-service-argocd"
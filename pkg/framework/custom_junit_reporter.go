@@ -34,6 +34,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2/reporters"
@@ -108,6 +109,20 @@ type CustomJUnitTestCase struct {
 	SystemOut string `xml:"system-out,omitempty"`
 	//SystemOut maps onto any captured GinkgoWriter output - maps onto SpecReport.CapturedGinkgoWriterOutput
 	SystemErr string `xml:"system-err,omitempty"`
+	//Properties captures additional information about the spec, like its labels or the number of attempts
+	Properties *JUnitProperties `xml:"properties,omitempty"`
+	//FlakyFailures are populated with the failures of the attempts of a spec which passed after being retried
+	FlakyFailures []JUnitRerunFailure `xml:"flakyFailure,omitempty"`
+	//RerunFailures are populated with the failures of the attempts before the last one of a spec which failed despite being retried
+	RerunFailures []JUnitRerunFailure `xml:"rerunFailure,omitempty"`
+}
+
+// JUnitRerunFailure is the failure of a spec attempt which was retried, modeled after the flakyFailure and rerunFailure elements
+// of the Maven Surefire reports.
+type JUnitRerunFailure struct {
+	Message    string `xml:"message,attr"`
+	Type       string `xml:"type,attr"`
+	StackTrace string `xml:"stackTrace,omitempty"`
 }
 
 func GenerateCustomJUnitReport(report types.Report, dst string) error {
@@ -142,6 +157,9 @@ func GenerateCustomJUnitReportWithConfig(report types.Report, dst string, config
 			},
 		},
 	}
+	flakySpecs := 0
+	classifier := DefaultFailureClassifier()
+	failureCategories := map[string]int{}
 	for _, spec := range report.SpecReports {
 
 		if spec.LeafNodeType != types.NodeTypeIt {
@@ -152,6 +170,41 @@ func GenerateCustomJUnitReportWithConfig(report types.Report, dst string, config
 			Classname: logs.GetClassnameFromReport(spec),
 			Time:      spec.RunTime.Seconds(),
 		}
+		properties := []JUnitProperty{}
+		// the labels let the flakiness report turn the quarantined specs into a label filter
+		if labels := spec.Labels(); len(labels) > 0 {
+			properties = append(properties, JUnitProperty{"Labels", fmt.Sprintf("[%s]", strings.Join(labels, ","))})
+		}
+		if spec.NumAttempts > 1 {
+			properties = append(properties, JUnitProperty{"Attempts", fmt.Sprintf("%d", spec.NumAttempts)})
+		}
+		if PassedAfterRetry(spec) {
+			properties = append(properties, JUnitProperty{"Flaky", "true"})
+			flakySpecs += 1
+		}
+		if !spec.State.Is(types.SpecStateSkipped | types.SpecStatePending) {
+			properties = append(properties, timingProperties(spec)...)
+		}
+		if spec.State.Is(types.SpecStateFailureStates) {
+			classification := classifier.Classify(spec)
+			properties = append(properties, JUnitProperty{"FailureCategory", classification.Category}, JUnitProperty{"SuspectedService", classification.Service})
+			failureCategories[classification.Category] += 1
+		}
+		if len(properties) > 0 {
+			test.Properties = &JUnitProperties{Properties: properties}
+		}
+		for _, failure := range AttemptFailures(spec) {
+			rerunFailure := JUnitRerunFailure{
+				Message:    failure.Failure.Message,
+				Type:       failure.State.String(),
+				StackTrace: fmt.Sprintf("%s\n%s", failure.Failure.Location.String(), failure.Failure.Location.FullStackTrace),
+			}
+			if spec.State.Is(types.SpecStatePassed) {
+				test.FlakyFailures = append(test.FlakyFailures, rerunFailure)
+			} else {
+				test.RerunFailures = append(test.RerunFailures, rerunFailure)
+			}
+		}
 		if !spec.State.Is(config.OmitTimelinesForSpecState) {
 			test.SystemErr = systemErrForUnstructuredReporters(spec)
 		}
@@ -205,6 +258,16 @@ func GenerateCustomJUnitReportWithConfig(report types.Report, dst string, config
 		suite.TestCases = append(suite.TestCases, test)
 	}
 
+	suite.Properties.Properties = append(suite.Properties.Properties, JUnitProperty{"FlakySpecs", fmt.Sprintf("%d", flakySpecs)})
+	categories := make([]string, 0, len(failureCategories))
+	for category := range failureCategories {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	for _, category := range categories {
+		suite.Properties.Properties = append(suite.Properties.Properties, JUnitProperty{"FailureCategory:" + category, fmt.Sprintf("%d", failureCategories[category])})
+	}
+
 	junitReport := CustomJUnitTestSuites{
 		Tests:      suite.Tests,
 		Skipped:    suite.Skipped,
@@ -251,8 +314,8 @@ func GenerateRPPreprocReport(report types.Report, rpPreprocParentDir string) {
 		name := logs.ShortenStringAddHash(reportSpec)
 		artifactsDirPath := artifactDir + "/" + name
 		reportPortalDirPath := rpPreprocDir + "/attachments/xunit/" + name
-		//generate folders only for failed tests
-		if !reportSpec.Failure.IsZero() {
+		//generate folders only for failed tests and the tests which passed after being retried
+		if !reportSpec.Failure.IsZero() || PassedAfterRetry(reportSpec) {
 			if reportSpec.LeafNodeType == types.NodeTypeIt {
 				if err3 := os.MkdirAll(reportPortalDirPath, os.ModePerm); err3 != nil {
 					klog.Error(err3)
@@ -261,6 +324,7 @@ func GenerateRPPreprocReport(report types.Report, rpPreprocParentDir string) {
 					writeLogInFile(reportPortalDirPath+"/stdOutErr.log", reportSpec.CapturedStdOutErr)
 					writeLogInFile(reportPortalDirPath+"/failureMessage.log", reportSpec.FailureMessage())
 					writeLogInFile(reportPortalDirPath+"/failureLocation.log", reportSpec.FailureLocation().FullStackTrace)
+					writeLogInFile(reportPortalDirPath+"/attemptFailures.log", attemptFailuresLog(reportSpec))
 				}
 			}
 		}
@@ -297,6 +361,46 @@ func GenerateRPPreprocReport(report types.Report, rpPreprocParentDir string) {
 	}
 }
 
+// PassedAfterRetry returns true if the spec failed at first, but passed when retried because of its FlakeAttempts.
+// Such specs are reported as flaky.
+func PassedAfterRetry(spec types.SpecReport) bool {
+	return spec.State.Is(types.SpecStatePassed) && spec.NumAttempts > 1
+}
+
+// AttemptFailures returns the failures of the attempts of the spec which were retried. The failure of the last attempt
+// is the failure of the spec.
+func AttemptFailures(spec types.SpecReport) []types.AdditionalFailure {
+	failures := []types.AdditionalFailure{}
+	for _, failure := range spec.AdditionalFailures {
+		// Ginkgo records the failures of the retried attempts among the additional failures, together with the failures of the cleanup nodes
+		if strings.HasPrefix(failure.Failure.Message, "Failure recorded during attempt ") {
+			failures = append(failures, failure)
+		}
+	}
+	return failures
+}
+
+// timingProperties describe the duration of the By() steps of the spec and the time it spent in the WaitUntil functions.
+func timingProperties(spec types.SpecReport) []JUnitProperty {
+	timing := logs.GetSpecTiming(spec)
+	properties := []JUnitProperty{}
+	for i, step := range timing.Steps {
+		properties = append(properties, JUnitProperty{fmt.Sprintf("Step %d: %s", i+1, step.Text), fmt.Sprintf("duration=%.3fs wait=%.3fs", step.Duration.Seconds(), step.WaitDuration.Seconds())})
+	}
+	if len(timing.Waits) > 0 {
+		properties = append(properties, JUnitProperty{"WaitDuration", fmt.Sprintf("%.3fs", timing.WaitDuration.Seconds())})
+	}
+	return properties
+}
+
+func attemptFailuresLog(spec types.SpecReport) string {
+	out := &strings.Builder{}
+	for _, failure := range AttemptFailures(spec) {
+		fmt.Fprintf(out, "%s\n%s\n%s\n\n", failure.Failure.Message, failure.Failure.Location.String(), failure.Failure.Location.FullStackTrace)
+	}
+	return out.String()
+}
+
 func writeLogInFile(filePath string, log string) {
 	// Do not create empty files
 	if len(log) != 0 {
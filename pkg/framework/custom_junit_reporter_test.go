@@ -0,0 +1,113 @@
+package framework
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateCustomJUnitReportRetries(t *testing.T) {
+	attemptFailure := types.AdditionalFailure{
+		State:   types.SpecStateFailed,
+		Failure: types.Failure{Message: "Failure recorded during attempt 1:\ntimed out waiting for the PipelineRun", Location: types.CodeLocation{FileName: "build.go", LineNumber: 42}},
+	}
+	report := types.Report{SuiteDescription: "Red Hat App Studio E2E tests", SpecReports: types.SpecReports{
+		{
+			ContainerHierarchyTexts:  []string{"[build-service-suite Build service E2E tests]"},
+			ContainerHierarchyLabels: [][]string{{"build"}},
+			LeafNodeType:             types.NodeTypeIt,
+			LeafNodeText:             "triggers a PipelineRun",
+			State:                    types.SpecStatePassed,
+			NumAttempts:              2,
+			MaxFlakeAttempts:         2,
+			AdditionalFailures:       []types.AdditionalFailure{attemptFailure, {State: types.SpecStateFailed, Failure: types.Failure{Message: "cleanup failed"}}},
+		},
+		{
+			ContainerHierarchyTexts: []string{"[build-service-suite Build service E2E tests]"},
+			LeafNodeType:            types.NodeTypeIt,
+			LeafNodeText:            "builds the image",
+			State:                   types.SpecStateFailed,
+			NumAttempts:             2,
+			MaxFlakeAttempts:        2,
+			Failure:                 types.Failure{Message: "build failed"},
+			AdditionalFailures:      []types.AdditionalFailure{attemptFailure},
+		},
+	}}
+
+	dst := filepath.Join(t.TempDir(), "xunit.xml")
+	assert.NoError(t, GenerateCustomJUnitReport(report, dst))
+	content, err := os.ReadFile(dst)
+	assert.NoError(t, err)
+	junitReport := CustomJUnitTestSuites{}
+	assert.NoError(t, xml.Unmarshal(content, &junitReport))
+
+	suite := junitReport.TestSuites[0]
+	assert.Contains(t, suite.Properties.Properties, reporters.JUnitProperty{"FlakySpecs", "1"})
+	assert.Contains(t, suite.Properties.Properties, reporters.JUnitProperty{"FailureCategory:product-bug", "1"})
+	assert.Equal(t, 1, suite.Failures)
+
+	flaky := suite.TestCases[0]
+	assert.Nil(t, flaky.Failure)
+	assert.Equal(t, []reporters.JUnitProperty{{"Labels", "[build]"}, {"Attempts", "2"}, {"Flaky", "true"}}, flaky.Properties.Properties)
+	assert.Len(t, flaky.FlakyFailures, 1)
+	assert.Equal(t, attemptFailure.Failure.Message, flaky.FlakyFailures[0].Message)
+	assert.Empty(t, flaky.RerunFailures)
+
+	failed := suite.TestCases[1]
+	assert.NotNil(t, failed.Failure)
+	assert.Equal(t, []reporters.JUnitProperty{{"Attempts", "2"}, {"FailureCategory", ProductBugFailureCategory}, {"SuspectedService", BuildService.Name}}, failed.Properties.Properties)
+	assert.Len(t, failed.RerunFailures, 1)
+	assert.Empty(t, failed.FlakyFailures)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	policy, err := ParseRetryPolicy("build=2, integration-service=3,")
+	assert.NoError(t, err)
+	assert.Equal(t, RetryPolicy{"build": 2, "integration-service": 3}, policy)
+
+	assert.Equal(t, 3, policy.Attempts(decoratorLabels([]interface{}{ginkgo.Label("build"), []interface{}{ginkgo.Label("integration-service"), ginkgo.Ordered}})))
+	assert.Equal(t, 1, policy.Attempts(decoratorLabels([]interface{}{ginkgo.Label("spi")})))
+
+	_, err = ParseRetryPolicy("build")
+	assert.Error(t, err)
+	_, err = ParseRetryPolicy("build=0")
+	assert.Error(t, err)
+
+	policy, err = ParseRetryPolicy("")
+	assert.NoError(t, err)
+	assert.Empty(t, policy)
+}
+
+func TestFailureClassifier(t *testing.T) {
+	rulesFile := filepath.Join(t.TempDir(), "rules.json")
+	assert.NoError(t, os.WriteFile(rulesFile, []byte(`{"rules": [{"category": "ec-policy", "pattern": "violations? found"}]}`), 0644))
+	t.Setenv(constants.FAILURE_CLASSIFICATION_RULES_ENV, rulesFile)
+	classifier := DefaultFailureClassifier()
+
+	spec := func(message, output string) types.SpecReport {
+		return types.SpecReport{
+			ContainerHierarchyTexts:    []string{"[enterprise-contract-suite Conformance]"},
+			LeafNodeType:               types.NodeTypeIt,
+			State:                      types.SpecStateFailed,
+			Failure:                    types.Failure{Message: message},
+			CapturedGinkgoWriterOutput: output,
+		}
+	}
+
+	assert.Equal(t, FailureClassification{Category: "quay-rate-limit", Service: "Quay"}, classifier.Classify(spec("failed to push the image", "error: toomanyrequests: too many requests to quay.io")))
+	assert.Equal(t, FailureClassification{Category: "sandbox-proxy-unavailable", Service: "Sandbox Proxy"}, classifier.Classify(spec("the sandbox proxy returned 503 Service Unavailable", "")))
+	assert.Equal(t, FailureClassification{Category: "pac-webhook-not-delivered", Service: "Pipelines as Code"}, classifier.Classify(spec("the PaC PipelineRun was not triggered after the pull request was created", "")))
+	// the service of a rule without one is suspected from the suite
+	assert.Equal(t, FailureClassification{Category: "ec-policy", Service: BuildService.Name}, classifier.Classify(spec("2 violations found", "")))
+	assert.Equal(t, ProductBugFailureCategory, classifier.Classify(spec("expected true to be false", "connection refused")).Category)
+
+	_, err := NewFailureClassifier([]FailureRule{{Category: "broken", Pattern: "("}})
+	assert.Error(t, err)
+}
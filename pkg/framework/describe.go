@@ -1,63 +1,188 @@
 package framework
 
 import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
 	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
+	"k8s.io/klog/v2"
+)
+
+// Service identifies the pods of an RHTAP service. When a spec fails, ReportFailure collects the logs of the services
+// exercised by its suite.
+type Service struct {
+	Name      string
+	Namespace string
+	// LabelSelector selects the pods of the service, all pods in the namespace are selected if empty
+	LabelSelector string
+}
+
+var (
+	ApplicationService      = Service{Name: "Application Service", Namespace: "application-service"}
+	BuildService            = Service{Name: "Build Service", Namespace: "build-service"}
+	ImageController         = Service{Name: "Image Controller", Namespace: "image-controller"}
+	JVMBuildService         = Service{Name: "JVM Build Service", Namespace: "jvm-build-service"}
+	MultiPlatformController = Service{Name: "Multi Platform Controller", Namespace: "multi-platform-controller"}
+	IntegrationService      = Service{Name: "Integration Service", Namespace: "integration-service"}
+	ReleaseService          = Service{Name: "Release Service", Namespace: "release-service"}
+	SPIService              = Service{Name: "SPI", Namespace: "spi-system"}
+	RemoteSecretService     = Service{Name: "Remote Secret", Namespace: "remotesecret"}
+	GitOpsService           = Service{Name: "GitOps Service", Namespace: "gitops"}
+	// the namespace is shared by the OpenShift Pipelines components, the labels select the pods of the tekton-chains-controller deployment
+	TektonChains = Service{Name: "Tekton Chains", Namespace: constants.TEKTON_CHAINS_NS, LabelSelector: "app.kubernetes.io/part-of=tekton-chains,app.kubernetes.io/name=controller"}
 )
 
+// defaultSuiteServices are the services whose logs are collected for the suites which don't declare any.
+var defaultSuiteServices = []Service{BuildService, JVMBuildService, ApplicationService, ImageController}
+
+// suiteServices holds the services declared by the suites, keyed by the suite name.
+var suiteServices = map[string][]Service{}
+
+// RetryPolicy maps the labels of the suites to the number of attempts of their failed specs.
+// A suite with several labels in the policy gets the highest number of attempts.
+type RetryPolicy map[string]int
+
+// retryPolicy is read from the E2E_RETRY_POLICY environment variable, the specs aren't retried unless it's set.
+var retryPolicy = retryPolicyFromEnv()
+
+func retryPolicyFromEnv() RetryPolicy {
+	policy, err := ParseRetryPolicy(os.Getenv(constants.E2E_RETRY_POLICY_ENV))
+	if err != nil {
+		klog.Errorf("ignoring the %s env var: %v", constants.E2E_RETRY_POLICY_ENV, err)
+		return RetryPolicy{}
+	}
+	return policy
+}
+
+// ParseRetryPolicy parses a comma separated list of "<label>=<attempts>" pairs, e.g. "build=2,integration-service=3".
+func ParseRetryPolicy(policy string) (RetryPolicy, error) {
+	retryPolicy := RetryPolicy{}
+	for _, rule := range strings.Split(policy, ",") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+		label, value, found := strings.Cut(rule, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid retry rule %q, expected <label>=<attempts>", rule)
+		}
+		attempts, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || attempts < 1 {
+			return nil, fmt.Errorf("invalid number of attempts in the retry rule %q", rule)
+		}
+		retryPolicy[strings.TrimSpace(label)] = attempts
+	}
+	return retryPolicy, nil
+}
+
+// Attempts returns the number of attempts of the failed specs of a suite with the given labels, 1 if they shouldn't be retried.
+func (p RetryPolicy) Attempts(labels []string) int {
+	attempts := 1
+	for _, label := range labels {
+		if p[label] > attempts {
+			attempts = p[label]
+		}
+	}
+	return attempts
+}
+
+// decoratorLabels returns the labels among the decorators passed to a container.
+func decoratorLabels(args []interface{}) []string {
+	labels := []string{}
+	for _, arg := range args {
+		switch decorator := arg.(type) {
+		case Labels:
+			labels = append(labels, decorator...)
+		case []interface{}:
+			labels = append(labels, decoratorLabels(decorator)...)
+		}
+	}
+	return labels
+}
+
+// suiteDescribe registers the services exercised by the suite and annotates the container with the suite name.
+// The specs of the suite are retried if the retry policy says so for any of the suite labels.
+func suiteDescribe(suite string, services []Service, text string, args ...interface{}) bool {
+	suiteServices[suite] = services
+	if attempts := retryPolicy.Attempts(decoratorLabels(args)); attempts > 1 {
+		args = append(args, FlakeAttempts(attempts))
+	}
+	if text != "" {
+		suite += " " + text
+	}
+	return Describe("["+suite+"]", args)
+}
+
+// SuiteServices returns the services exercised by the suite the given spec belongs to.
+func SuiteServices(spec types.SpecReport) []Service {
+	if len(spec.ContainerHierarchyTexts) == 0 {
+		return defaultSuiteServices
+	}
+	// the top level container is annotated as "[<suite> <text>]"
+	suite, _, _ := strings.Cut(strings.Trim(spec.ContainerHierarchyTexts[0], "[]"), " ")
+	if services := suiteServices[suite]; len(services) > 0 {
+		return services
+	}
+	return defaultSuiteServices
+}
+
 // ByocSuiteDescribe annotates the byoc scenarios.
 func ByocSuiteDescribe(args ...interface{}) bool {
-	return Describe("[byoc-suite]", args)
+	return suiteDescribe("byoc-suite", []Service{ApplicationService, BuildService, GitOpsService}, "", args)
 }
 
 // CommonSuiteDescribe annotates the common tests with the application label.
 func CommonSuiteDescribe(text string, args ...interface{}) bool {
-	return Describe("[common-suite "+text+"]", args, Ordered)
+	return suiteDescribe("common-suite", nil, text, args, Ordered)
 }
 
 func ChainsSuiteDescribe(text string, args ...interface{}) bool {
-	return Describe("[chains-suite "+text+"]", args, Ordered)
+	return suiteDescribe("chains-suite", []Service{TektonChains, BuildService}, text, args, Ordered)
 }
 
 func BuildSuiteDescribe(text string, args ...interface{}) bool {
-	return Describe("[build-service-suite "+text+"]", args)
+	return suiteDescribe("build-service-suite", []Service{BuildService, ImageController, ApplicationService}, text, args)
 }
 
 func JVMBuildSuiteDescribe(text string, args ...interface{}) bool {
-	return Describe("[jvm-build-service-suite "+text+"]", args, Ordered)
+	return suiteDescribe("jvm-build-service-suite", []Service{JVMBuildService, BuildService}, text, args, Ordered)
 }
 
 func MultiPlatformBuildSuiteDescribe(text string, args ...interface{}) bool {
-	return Describe("[multi-platform-build-service-suite "+text+"]", args, Ordered)
+	return suiteDescribe("multi-platform-build-service-suite", []Service{MultiPlatformController, BuildService}, text, args, Ordered)
 }
 
 func IntegrationServiceSuiteDescribe(text string, args ...interface{}) bool {
-	return Describe("[integration-service-suite "+text+"]", args, Ordered)
+	return suiteDescribe("integration-service-suite", []Service{IntegrationService, ApplicationService, BuildService}, text, args, Ordered)
 }
 
 func RhtapDemoSuiteDescribe(args ...interface{}) bool {
-	return Describe("[rhtap-demo-suite]", args)
+	return suiteDescribe("rhtap-demo-suite", []Service{ApplicationService, BuildService, JVMBuildService, IntegrationService, ReleaseService, GitOpsService}, "", args)
 }
 
 func SPISuiteDescribe(args ...interface{}) bool {
-	return Describe("[spi-suite]", args, Ordered)
+	return suiteDescribe("spi-suite", []Service{SPIService}, "", args, Ordered)
 }
 
 func RemoteSecretSuiteDescribe(args ...interface{}) bool {
-	return Describe("[remotesecret-suite]", args, Ordered)
+	return suiteDescribe("remotesecret-suite", []Service{RemoteSecretService, ImageController}, "", args, Ordered)
 }
 
 func EnterpriseContractSuiteDescribe(text string, args ...interface{}) bool {
-	return Describe("[enterprise-contract-suite "+text+"]", args, Ordered)
+	return suiteDescribe("enterprise-contract-suite", []Service{TektonChains}, text, args, Ordered)
 }
 
 func UpgradeSuiteDescribe(text string, args ...interface{}) bool {
-	return Describe("[upgrade-suite "+text+"]", args, Ordered)
+	return suiteDescribe("upgrade-suite", []Service{ApplicationService, BuildService, IntegrationService, ReleaseService}, text, args, Ordered)
 }
 
 func ReleasePipelinesSuiteDescribe(text string, args ...interface{}) bool {
-	return Describe("[release-pipelines-suite "+text+"]", args, Ordered)
+	return suiteDescribe("release-pipelines-suite", []Service{ReleaseService}, text, args, Ordered)
 }
 
 func ReleaseServiceSuiteDescribe(text string, args ...interface{}) bool {
-	return Describe("[release-service-suite "+text+"]", args, Ordered)
+	return suiteDescribe("release-service-suite", []Service{ReleaseService, ApplicationService, GitOpsService}, text, args, Ordered)
 }
@@ -0,0 +1,130 @@
+package framework
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
+	"k8s.io/klog/v2"
+)
+
+// ProductBugFailureCategory is the category of the failures which don't match any rule, so they're likely caused by a bug
+// in the service exercised by the suite.
+const ProductBugFailureCategory = "product-bug"
+
+// FailureRule assigns a category and a suspected owning service to the failures matching its pattern.
+type FailureRule struct {
+	// Category groups the failures with the same root cause, e.g. "quay-rate-limit"
+	Category string `json:"category"`
+	// Service is the suspected owner of the failure, the first service exercised by the suite is suspected if empty
+	Service string `json:"service,omitempty"`
+	// Pattern is a regular expression matched against the failure message and location
+	Pattern string `json:"pattern"`
+	// MatchOutput makes the pattern match the GinkgoWriter output of the spec too
+	MatchOutput bool `json:"matchOutput,omitempty"`
+
+	regexp *regexp.Regexp
+}
+
+// FailureRules is the format of the rules file given by the FAILURE_CLASSIFICATION_RULES environment variable.
+type FailureRules struct {
+	Rules []FailureRule `json:"rules"`
+}
+
+// FailureClassification is the root-cause class of a failed spec.
+type FailureClassification struct {
+	Category string
+	Service  string
+}
+
+// FailureClassifier classifies the failed specs by the first matching rule.
+type FailureClassifier struct {
+	rules []FailureRule
+}
+
+// DefaultFailureRules recognize the usual infrastructure issues the e2e tests run into.
+var DefaultFailureRules = []FailureRule{
+	{Category: "quay-rate-limit", Service: "Quay", Pattern: `(?i)toomanyrequests|quay\.io.*(429|too many requests)`, MatchOutput: true},
+	{Category: "github-rate-limit", Service: "GitHub", Pattern: `(?i)(api|secondary) rate limit exceeded`, MatchOutput: true},
+	{Category: "sandbox-proxy-unavailable", Service: "Sandbox Proxy", Pattern: `(?i)(proxy|sandbox|toolchain).*(503|service unavailable)|(503|service unavailable).*(proxy|sandbox|toolchain)`},
+	{Category: "pac-webhook-not-delivered", Service: "Pipelines as Code", Pattern: `(?i)\b(webhook|pipelinesascode|pac|pull request)\b.*(not (been )?(delivered|triggered|created)|timed out waiting for .*pipelinerun)`},
+	{Category: "cluster-connection", Service: "OpenShift", Pattern: `(?i)connection refused|connection reset by peer|tls handshake timeout|i/o timeout|no route to host|the server is currently unable to handle the request`},
+}
+
+// NewFailureClassifier compiles the patterns of the rules.
+func NewFailureClassifier(rules []FailureRule) (*FailureClassifier, error) {
+	classifier := &FailureClassifier{}
+	for _, rule := range rules {
+		if rule.Category == "" {
+			return nil, fmt.Errorf("the failure rule with pattern %q has no category", rule.Pattern)
+		}
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern of the failure rule %s: %v", rule.Category, err)
+		}
+		rule.regexp = re
+		classifier.rules = append(classifier.rules, rule)
+	}
+	return classifier, nil
+}
+
+// LoadFailureRules reads the rules from a JSON file.
+func LoadFailureRules(path string) ([]FailureRule, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rules := FailureRules{}
+	if err := json.Unmarshal(content, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse the failure rules %s: %v", path, err)
+	}
+	return rules.Rules, nil
+}
+
+// DefaultFailureClassifier returns a classifier with the rules from the file given by the FAILURE_CLASSIFICATION_RULES
+// environment variable, which take precedence over the DefaultFailureRules. An invalid rules file is reported and ignored.
+func DefaultFailureClassifier() *FailureClassifier {
+	rules := DefaultFailureRules
+	if path := os.Getenv(constants.FAILURE_CLASSIFICATION_RULES_ENV); path != "" {
+		customRules, err := LoadFailureRules(path)
+		if err != nil {
+			klog.Errorf("ignoring the failure classification rules: %v", err)
+		} else {
+			rules = append(customRules, DefaultFailureRules...)
+		}
+	}
+
+	classifier, err := NewFailureClassifier(rules)
+	if err != nil {
+		klog.Errorf("ignoring the failure classification rules: %v", err)
+		// the default rules are known to be valid
+		classifier, _ = NewFailureClassifier(DefaultFailureRules)
+	}
+	return classifier
+}
+
+// Classify returns the category and the suspected owning service of the failed spec.
+func (c *FailureClassifier) Classify(spec types.SpecReport) FailureClassification {
+	failure := strings.Join([]string{spec.FailureMessage(), spec.FailureLocation().String(), spec.FailureLocation().FullStackTrace}, "\n")
+	for _, rule := range c.rules {
+		if rule.regexp.MatchString(failure) || (rule.MatchOutput && rule.regexp.MatchString(spec.CapturedGinkgoWriterOutput)) {
+			classification := FailureClassification{Category: rule.Category, Service: rule.Service}
+			if classification.Service == "" {
+				classification.Service = suspectedService(spec)
+			}
+			return classification
+		}
+	}
+	return FailureClassification{Category: ProductBugFailureCategory, Service: suspectedService(spec)}
+}
+
+func suspectedService(spec types.SpecReport) string {
+	if services := SuiteServices(spec); len(services) > 0 {
+		return services[0].Name
+	}
+	return ""
+}
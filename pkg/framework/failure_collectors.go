@@ -0,0 +1,214 @@
+package framework
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	appstudioApi "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	releaseApi "github.com/redhat-appstudio/release-service/api/v1alpha1"
+	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// ControllerLogsCollector stores the logs of the services exercised by the suite of the failed spec,
+// limited to the entries referencing the tenant namespace written since the spec started.
+type ControllerLogsCollector struct {
+	// Services overrides the services declared by the suite
+	Services []Service
+}
+
+func (c *ControllerLogsCollector) Name() string {
+	return "controller-logs"
+}
+
+func (c *ControllerLogsCollector) Collect(ctx context.Context, fwk *Framework, report *FailureReport) error {
+	services := c.Services
+	if len(services) == 0 {
+		services = SuiteServices(report.Spec)
+	}
+
+	// only the entries related to the tenant namespace and written while the spec was running are stored
+	filter := LogFilter{Start: report.Spec.StartTime, End: time.Now(), Namespace: report.Namespace}
+
+	var errs []error
+	for _, service := range services {
+		podList, err := fwk.AsKubeAdmin.CommonController.KubeInterface().CoreV1().Pods(service.Namespace).List(ctx, metav1.ListOptions{LabelSelector: service.LabelSelector})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list pods of %s in namespace %s: %v", service.Name, service.Namespace, err))
+			continue
+		}
+
+		for i := range podList.Items {
+			for podName, log := range fwk.AsKubeAdmin.CommonController.GetPodLogs(&podList.Items[i]) {
+				if filteredLogs := FilterLogEntries(string(log), filter); filteredLogs != "" {
+					report.AddArtifact(podName, []byte(filteredLogs))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// EventsCollector stores the Events of the tenant namespace and adds them to the timeline.
+type EventsCollector struct{}
+
+func (c *EventsCollector) Name() string {
+	return "events"
+}
+
+func (c *EventsCollector) Collect(ctx context.Context, fwk *Framework, report *FailureReport) error {
+	events, err := fwk.AsKubeAdmin.CommonController.KubeInterface().CoreV1().Events(report.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list events in namespace %s: %v", report.Namespace, err)
+	}
+
+	for _, event := range events.Items {
+		entry := TimelineEntry{
+			Time:    eventTime(event),
+			Source:  event.InvolvedObject.Kind,
+			Object:  event.InvolvedObject.Name,
+			Reason:  event.Reason,
+			Message: event.Message,
+		}
+		if event.Type == corev1.EventTypeWarning {
+			entry.Type = TimelineWarning
+		}
+		if event.Count > 1 {
+			entry.Message = fmt.Sprintf("%s (x%d)", event.Message, event.Count)
+		}
+		report.AddTimelineEntry(entry)
+	}
+
+	eventsYaml, err := yaml.Marshal(events.Items)
+	if err != nil {
+		return fmt.Errorf("failed to marshal events: %v", err)
+	}
+	report.AddArtifact("events.yaml", eventsYaml)
+	return nil
+}
+
+// eventTime returns the time the Event was last seen.
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// DefaultTimelineResources are the resources added to the timeline by the DefaultFailureCollectors.
+var DefaultTimelineResources = []schema.GroupVersionResource{
+	appstudioApi.GroupVersion.WithResource("applications"),
+	appstudioApi.GroupVersion.WithResource("components"),
+	appstudioApi.GroupVersion.WithResource("snapshots"),
+	appstudioApi.GroupVersion.WithResource("environments"),
+	pipeline.SchemeGroupVersion.WithResource("pipelineruns"),
+	releaseApi.GroupVersion.WithResource("releases"),
+}
+
+// ResourceTimelineCollector stores the YAML of the given resources in the tenant namespace
+// and adds their creation, deletion and status condition changes to the timeline.
+type ResourceTimelineCollector struct {
+	Resources []schema.GroupVersionResource
+}
+
+func (c *ResourceTimelineCollector) Name() string {
+	return "resource-timeline"
+}
+
+func (c *ResourceTimelineCollector) Collect(ctx context.Context, fwk *Framework, report *FailureReport) error {
+	var errs []error
+	for _, resource := range c.Resources {
+		list, err := fwk.AsKubeAdmin.CommonController.DynamicClient().Resource(resource).Namespace(report.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			// the resource isn't installed in the cluster
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("failed to list %s in namespace %s: %v", resource.Resource, report.Namespace, err))
+			continue
+		}
+		if len(list.Items) == 0 {
+			continue
+		}
+
+		objects := []map[string]interface{}{}
+		for _, item := range list.Items {
+			objects = append(objects, item.Object)
+			for _, entry := range resourceTimeline(&item) {
+				report.AddTimelineEntry(entry)
+			}
+		}
+
+		resourcesYaml, err := yaml.Marshal(objects)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to marshal %s: %v", resource.Resource, err))
+			continue
+		}
+		report.AddArtifact(resource.Resource+".yaml", resourcesYaml)
+	}
+	return errors.Join(errs...)
+}
+
+// resourceTimeline returns the timeline entries of a given object: its creation, the last transition of each status condition and its deletion.
+// Both metav1.Condition and knative (Tekton) conditions are supported, as they share these fields.
+func resourceTimeline(u *unstructured.Unstructured) []TimelineEntry {
+	entries := []TimelineEntry{{Time: u.GetCreationTimestamp().Time, Source: u.GetKind(), Object: u.GetName(), Reason: "Created"}}
+
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _, _ := unstructured.NestedString(condition, "type")
+		status, _, _ := unstructured.NestedString(condition, "status")
+		reason, _, _ := unstructured.NestedString(condition, "reason")
+		message, _, _ := unstructured.NestedString(condition, "message")
+		lastTransitionTime, _, _ := unstructured.NestedString(condition, "lastTransitionTime")
+
+		transitionTime, err := time.Parse(time.RFC3339, lastTransitionTime)
+		if err != nil {
+			continue
+		}
+		entry := TimelineEntry{
+			Time:    transitionTime,
+			Source:  u.GetKind(),
+			Object:  u.GetName(),
+			Reason:  fmt.Sprintf("%s=%s", conditionType, status),
+			Message: message,
+		}
+		if reason != "" {
+			entry.Message = fmt.Sprintf("%s: %s", reason, message)
+		}
+		if status == string(metav1.ConditionFalse) && !isProgressingReason(reason) {
+			entry.Type = TimelineWarning
+		}
+		entries = append(entries, entry)
+	}
+
+	if deletionTimestamp := u.GetDeletionTimestamp(); deletionTimestamp != nil {
+		entries = append(entries, TimelineEntry{Time: deletionTimestamp.Time, Source: u.GetKind(), Object: u.GetName(), Reason: "Deleting"})
+	}
+	return entries
+}
+
+// isProgressingReason returns true for the reasons used by the RHTAP controllers while a false condition is still expected to change.
+func isProgressingReason(reason string) bool {
+	switch reason {
+	case "Progressing", "Running", "Pending", "Started":
+		return true
+	}
+	return false
+}
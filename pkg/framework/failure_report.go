@@ -1,21 +1,66 @@
 package framework
 
 import (
-	"regexp"
-	"strings"
+	"context"
 	"time"
 
 	"github.com/redhat-appstudio/e2e-tests/pkg/logs"
 
 	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
 )
 
-func ReportFailure(f **Framework) func() {
-	namespaces := map[string]string{
-		"Build Service":       "build-service",
-		"JVM Build Service":   "jvm-build-service",
-		"Application Service": "application-service",
-		"Image Controller":    "image-controller"}
+// failureCollectionTimeout limits the time spent collecting the debugging information of a failed spec.
+const failureCollectionTimeout = 5 * time.Minute
+
+// FailureCollector gathers debugging information about a failed spec, like logs or resources, into a FailureReport.
+type FailureCollector interface {
+	// Name identifies the collector in the error messages
+	Name() string
+	// Collect adds artifacts and timeline entries to the report
+	Collect(ctx context.Context, fwk *Framework, report *FailureReport) error
+}
+
+// FailureReport holds what the FailureCollectors gathered about a failed spec.
+// The artifacts are stored into the artifact directory of the spec together with a timeline.html
+// rendered from the timeline entries.
+type FailureReport struct {
+	// Spec is the report of the failed spec
+	Spec types.SpecReport
+	// Namespace is the tenant namespace of the spec
+	Namespace string
+	// Artifacts are the files to store, keyed by the file name
+	Artifacts map[string][]byte
+	// Timeline lists the things that happened during the spec, in no particular order
+	Timeline []TimelineEntry
+}
+
+// AddArtifact adds a file to be stored into the artifact directory of the spec.
+func (r *FailureReport) AddArtifact(name string, content []byte) {
+	r.Artifacts[name] = content
+}
+
+// AddTimelineEntry adds an entry to the timeline of the spec.
+func (r *FailureReport) AddTimelineEntry(entry TimelineEntry) {
+	r.Timeline = append(r.Timeline, entry)
+}
+
+// DefaultFailureCollectors returns the collectors used by ReportFailure: the logs of the controllers relevant for the suite,
+// the Events of the tenant namespace and the timeline of the RHTAP resources and PipelineRuns in the tenant namespace.
+func DefaultFailureCollectors() []FailureCollector {
+	return []FailureCollector{
+		&ControllerLogsCollector{},
+		&EventsCollector{},
+		&ResourceTimelineCollector{Resources: DefaultTimelineResources},
+	}
+}
+
+// ReportFailure returns a function to be used in AfterEach which collects debugging information when the spec fails.
+// The DefaultFailureCollectors are used unless some collectors are given.
+func ReportFailure(f **Framework, collectors ...FailureCollector) func() {
+	if len(collectors) == 0 {
+		collectors = DefaultFailureCollectors()
+	}
 
 	return func() {
 		if !CurrentSpecReport().Failed() {
@@ -27,59 +72,50 @@ func ReportFailure(f **Framework) func() {
 			return
 		}
 
-		if err := logs.StoreTestTiming(); err != nil {
-			GinkgoWriter.Printf("failed to store test timing: %v\n", err)
-		}
-
-		allPodLogs := make(map[string][]byte)
-		for _, namespace := range namespaces {
-			podList, err := fwk.AsKubeAdmin.CommonController.ListAllPods(namespace)
-			if err != nil {
-				GinkgoWriter.Printf("failed to list pods in namespace %s: %v\n", namespace, err)
-				return
-			}
-
-			for _, pod := range podList.Items {
-				podLogs := fwk.AsKubeAdmin.CommonController.GetPodLogs(&pod)
-
-				for podName, log := range podLogs {
-					if filteredLogs := FilterLogs(string(log), CurrentSpecReport().StartTime); filteredLogs != "" {
-						allPodLogs[podName] = []byte(filteredLogs)
-					}
-				}
-			}
-		}
+		ctx, cancel := context.WithTimeout(context.Background(), failureCollectionTimeout)
+		defer cancel()
 
-		if err := logs.StoreArtifacts(allPodLogs); err != nil {
-			GinkgoWriter.Printf("failed to store pod logs: %v\n", err)
+		report := CollectFailureReport(ctx, fwk, CurrentSpecReport(), collectors...)
+		if err := logs.StoreArtifacts(report.Artifacts); err != nil {
+			GinkgoWriter.Printf("failed to store failure report: %v\n", err)
 		}
 	}
 }
 
-func FilterLogs(logs string, start time.Time) string {
+// CollectFailureReport runs the given collectors for the spec and renders the timeline.html artifact.
+// A failing collector doesn't prevent the others from running, its error is only reported to the GinkgoWriter.
+func CollectFailureReport(ctx context.Context, fwk *Framework, spec types.SpecReport, collectors ...FailureCollector) *FailureReport {
+	report := &FailureReport{
+		Spec:      spec,
+		Namespace: fwk.UserNamespace,
+		Artifacts: map[string][]byte{},
+	}
+	report.AddTimelineEntry(TimelineEntry{Time: spec.StartTime, Source: "Spec", Reason: "Started", Message: spec.FullText()})
+	for _, step := range spec.SpecEvents.WithType(types.SpecEventByStart) {
+		report.AddTimelineEntry(TimelineEntry{Time: step.TimelineLocation.Time, Source: "Spec", Reason: "Step", Message: step.Message})
+	}
+	if !spec.Failure.IsZero() {
+		report.AddTimelineEntry(TimelineEntry{Time: spec.Failure.TimelineLocation.Time, Source: "Spec", Type: TimelineWarning, Reason: "Failed", Message: spec.Failure.Message})
+	}
 
-	//bit of a hack, the logs are in different formats and are not always valid JSON
-	//just look for RFC 3339 dates line by line, once we find one after the start time dump the
-	//rest of the lines
-	lines := strings.Split(logs, "\n")
-	ret := []string{}
-	rfc3339Pattern := `(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2}))`
-
-	re := regexp.MustCompile(rfc3339Pattern)
-	for pos, i := range lines {
-		match := re.FindStringSubmatch(i)
-
-		if match != nil {
-			dateString := match[1]
-			ts, err := time.Parse(time.RFC3339, dateString)
-			if err != nil {
-				ret = append(ret, "Invalid Time, unable to parse date: "+i)
-			} else if ts.Equal(start) || ts.After(start) {
-				ret = append(ret, lines[pos:]...)
-				break
-			}
+	for _, collector := range collectors {
+		if err := collector.Collect(ctx, fwk, report); err != nil {
+			GinkgoWriter.Printf("failure collector %s failed: %v\n", collector.Name(), err)
 		}
 	}
 
-	return strings.Join(ret, "\n")
+	timeline, err := renderTimeline(report)
+	if err != nil {
+		GinkgoWriter.Printf("failed to render the timeline: %v\n", err)
+	} else {
+		report.AddArtifact("timeline.html", timeline)
+	}
+
+	return report
+}
+
+// FilterLogs returns the log entries written since the given start time.
+// See FilterLogEntries for filtering the entries by namespace too.
+func FilterLogs(logs string, start time.Time) string {
+	return FilterLogEntries(logs, LogFilter{Start: start})
 }
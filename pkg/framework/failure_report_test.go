@@ -1,9 +1,16 @@
 package framework
 
 import (
-	"github.com/stretchr/testify/assert"
+	"context"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	appstudioApi "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const plainLogs = `
@@ -50,3 +57,139 @@ func TestJsonLogParsing(t *testing.T) {
 {"level":"info","ts":"2023-08-18T01:34:06Z","logger":"artifactbuild","caller":"artifactbuild/artifactbuild.go:530","msg":"Found community dependency, creating ArtifactBuild","namespace":"rhtap-demo-afcg-tenant","resource":"hacbs-test-project-jyxg-on-push-vxwtr","kind":"PipelineRun","gav":"io.github.stuartwdouglas.hacbs-test.shaded:shaded-jdk11:1.9","artifactbuild":"shaded.jdk11.1.9-c65abf6b","action":"ADD"}
 {"level":"info","ts":"2023-08-18T01:35:06Z","logger":"artifactbuild","caller":"artifactbuild/artifactbuild.go:530","msg":"Found community dependency, creating ArtifactBuild","namespace":"rhtap-demo-afcg-tenant","resource":"hacbs-test-project-jyxg-on-push-vxwtr","kind":"PipelineRun","gav":"io.github.stuartwdouglas.hacbs-test.simple:simple-jdk17:0.1.2","artifactbuild":"simple.jdk17.0.1.2-22fafbfd","action":"ADD"}`, filtered)
 }
+
+func TestCollectFailureReport(t *testing.T) {
+	namespace := fakeUserName + "-tenant"
+	start := time.Date(2023, 8, 18, 1, 0, 0, 0, time.UTC)
+	component := &appstudioApi.Component{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-component", Namespace: namespace, CreationTimestamp: metav1.NewTime(start.Add(time.Minute))},
+		Status: appstudioApi.ComponentStatus{
+			Conditions: []metav1.Condition{{
+				Type:               "Created",
+				Status:             metav1.ConditionFalse,
+				Reason:             "Error",
+				Message:            "devfile not found",
+				LastTransitionTime: metav1.NewTime(start.Add(2 * time.Minute)),
+			}},
+		},
+	}
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "test-component.1", Namespace: namespace},
+		InvolvedObject: corev1.ObjectReference{Kind: "Component", Name: "test-component", Namespace: namespace},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "ReconcileFailed",
+		Message:        "failed to reconcile",
+		Count:          3,
+		LastTimestamp:  metav1.NewTime(start.Add(3 * time.Minute)),
+	}
+	fwk, err := NewFakeFramework(fakeUserName, component, event)
+	assert.NoError(t, err)
+
+	spec := types.SpecReport{LeafNodeText: "creates a component", StartTime: start}
+	report := CollectFailureReport(context.Background(), fwk, spec,
+		&EventsCollector{},
+		&ResourceTimelineCollector{Resources: DefaultTimelineResources},
+	)
+
+	assert.Contains(t, report.Artifacts, "events.yaml")
+	assert.Contains(t, report.Artifacts, "components.yaml")
+	assert.NotContains(t, report.Artifacts, "applications.yaml")
+	assert.Contains(t, string(report.Artifacts["timeline.html"]), "failed to reconcile (x3)")
+
+	reasons := []string{}
+	for _, entry := range report.Timeline {
+		reasons = append(reasons, entry.Reason)
+	}
+	assert.Equal(t, []string{"Started", "Created", "Created=False", "ReconcileFailed"}, reasons)
+	assert.Equal(t, TimelineWarning, report.Timeline[2].Type)
+	assert.Equal(t, "Error: devfile not found", report.Timeline[2].Message)
+}
+
+func TestSuiteServices(t *testing.T) {
+	BuildSuiteDescribe("Build service E2E tests", func() {})
+
+	spec := types.SpecReport{ContainerHierarchyTexts: []string{"[build-service-suite Build service E2E tests]", "test"}}
+	assert.Equal(t, []Service{BuildService, ImageController, ApplicationService}, SuiteServices(spec))
+
+	spec = types.SpecReport{ContainerHierarchyTexts: []string{"[unknown-suite]"}}
+	assert.Equal(t, defaultSuiteServices, SuiteServices(spec))
+}
+
+func TestParseZapJSONLogWithEpochTimestamp(t *testing.T) {
+	entry := LogParser{}.ParseLine(`{"level":"error","ts":1692322086.5,"logger":"controllers.Component","msg":"failed to reconcile","namespace":"build-e2e-tenant","name":"test-component"}`)
+	assert.Equal(t, LogFormatJSON, entry.Format)
+	assert.Equal(t, time.Unix(1692322086, 5e8).UTC(), entry.Time)
+	assert.Equal(t, "error", entry.Level)
+	assert.Equal(t, "controllers.Component", entry.Logger)
+	assert.Equal(t, "failed to reconcile", entry.Message)
+	assert.Equal(t, "build-e2e-tenant", entry.Namespace)
+	assert.Equal(t, "test-component", entry.Name)
+}
+
+func TestParseKlogLog(t *testing.T) {
+	entry := LogParser{Year: 2023}.ParseLine(`E0818 01:18:56.213000       1 controller.go:329] "Reconciler error" err="not found" pod="build-e2e-tenant/build-pod"`)
+	assert.Equal(t, LogFormatKlog, entry.Format)
+	assert.Equal(t, time.Date(2023, 8, 18, 1, 18, 56, 213000000, time.UTC), entry.Time)
+	assert.Equal(t, "error", entry.Level)
+	assert.Empty(t, entry.Logger)
+	assert.Equal(t, "controller.go:329", entry.Location)
+	assert.Equal(t, "build-e2e-tenant", entry.Namespace)
+	assert.Equal(t, "build-pod", entry.Name)
+}
+
+func TestParsePlainLog(t *testing.T) {
+	entries := LogParser{}.Parse(plainLogs)
+	assert.Len(t, entries, 7)
+
+	entry := entries[0]
+	assert.Equal(t, LogFormatPlain, entry.Format)
+	assert.Equal(t, time.Date(2023, 8, 18, 1, 18, 56, 213000000, time.UTC), entry.Time)
+	assert.Equal(t, "info", entry.Level)
+	assert.Equal(t, "ComponentImageRepository", entry.Logger)
+	assert.Equal(t, "controllers/component_image_controller.go:249", entry.Location)
+	assert.Equal(t, "Prepared image repository build-e2e-rsql-tenant/test-app-ngqh/build-suite-test-component-image-source-ajdr for Component", entry.Message)
+	assert.Equal(t, "build-e2e-rsql-tenant", entry.Namespace)
+	assert.Equal(t, "build-suite-test-component-image-source-ajdr", entry.Name)
+}
+
+func TestFilterLogEntriesByNamespace(t *testing.T) {
+	logs := plainLogs + `
+2023-08-18T01:20:00.000Z	ERROR	controller-runtime	failed to renew the lease
+goroutine 1 [running]:
+main.main()
+2023-08-18T01:21:00.000Z	INFO	ComponentImageRepository	Reconciling	{"namespace": "build-e2e-rsql-tenant", "name": "other-component"}`
+
+	start, _ := time.Parse(time.RFC3339, "2023-08-18T01:19:00Z")
+	end, _ := time.Parse(time.RFC3339, "2023-08-18T01:20:30Z")
+	filtered := FilterLogEntries(logs, LogFilter{Start: start, End: end, Namespace: "build-e2e-bslz-tenant"})
+
+	lines := strings.Split(filtered, "\n")
+	assert.Len(t, lines, 5)
+	assert.Contains(t, lines[0], "2023-08-18T01:19:57.257Z")
+	assert.Contains(t, lines[1], "2023-08-18T01:19:57.327Z")
+	assert.Equal(t, "2023-08-18T01:20:00.000Z\tERROR\tcontroller-runtime\tfailed to renew the lease", lines[2])
+	assert.Equal(t, "main.main()", lines[4])
+}
+
+func TestFilterLogEntriesByLevelAndLogger(t *testing.T) {
+	logs := `{"level":"info","ts":1692322086.1,"logger":"controllers.Component","msg":"reconciling","namespace":"build-e2e-tenant"}
+{"level":"error","ts":1692322086.2,"logger":"controllers.Component","msg":"failed to reconcile","namespace":"build-e2e-tenant"}
+{"level":"error","ts":1692322086.3,"logger":"controllers.ComponentDetectionQuery","msg":"failed to detect","namespace":"build-e2e-tenant"}
+{"level":"error","ts":1692322086.4,"logger":"webhook","msg":"failed to validate","namespace":"build-e2e-tenant"}
+{"level":"warn","ts":1692322086.5,"logger":"controllersfoo","msg":"deprecated field","namespace":"build-e2e-tenant"}`
+
+	filtered := FilterLogEntries(logs, LogFilter{Namespace: "build-e2e-tenant", Levels: []string{"ERROR"}})
+	assert.Len(t, strings.Split(filtered, "\n"), 3)
+	assert.NotContains(t, filtered, "reconciling")
+
+	filtered = FilterLogEntries(logs, LogFilter{Namespace: "build-e2e-tenant", Loggers: []string{"controllers"}})
+	lines := strings.Split(filtered, "\n")
+	assert.Len(t, lines, 3)
+	assert.NotContains(t, filtered, "deprecated field")
+
+	filtered = FilterLogEntries(logs, LogFilter{Namespace: "build-e2e-tenant", Levels: []string{"error", "warn"}, Loggers: []string{"controllers.Component", "webhook"}})
+	lines = strings.Split(filtered, "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "failed to reconcile")
+	assert.Contains(t, lines[1], "failed to validate")
+}
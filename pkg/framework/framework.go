@@ -21,19 +21,22 @@ import (
 	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
 	"github.com/redhat-appstudio/e2e-tests/pkg/sandbox"
 	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// ControllerHub groups the controllers used to interact with RHTAP services. Fields are interfaces, so a suite
+// can replace any of them with a stub or a decorator wrapping the default implementation.
 type ControllerHub struct {
-	HasController             *has.HasController
-	CommonController          *common.SuiteController
-	TektonController          *tekton.TektonController
-	GitOpsController          *gitops.GitopsController
-	SPIController             *spi.SPIController
-	RemoteSecretController    *remotesecret.RemoteSecretController
-	ReleaseController         *release.ReleaseController
-	IntegrationController     *integration.IntegrationController
-	JvmbuildserviceController *jvmbuildservice.JvmbuildserviceController
-	ImageController           *imagecontroller.ImageController
+	HasController             has.Interface
+	CommonController          common.Interface
+	TektonController          tekton.Interface
+	GitOpsController          gitops.Interface
+	SPIController             spi.Interface
+	RemoteSecretController    remotesecret.Interface
+	ReleaseController         release.Interface
+	IntegrationController     integration.Interface
+	JvmbuildserviceController jvmbuildservice.Interface
+	ImageController           imagecontroller.Interface
 }
 
 type Framework struct {
@@ -122,6 +125,23 @@ func NewFrameworkWithTimeout(userName string, timeout time.Duration, options ...
 	}, nil
 }
 
+// NewFakeFramework returns a Framework whose controllers are backed by fake clients seeded with the given objects.
+// No sandbox user is provisioned, both AsKubeAdmin and AsKubeDeveloper share the same fake clients and
+// the user namespace follows the "<userName>-tenant" naming used by the sandbox.
+func NewFakeFramework(userName string, objects ...runtime.Object) (*Framework, error) {
+	hub, err := InitControllerHub(kubeCl.NewFakeCustomClient(objects...))
+	if err != nil {
+		return nil, fmt.Errorf("error when initializing appstudio hub controllers with fake clients: %v", err)
+	}
+
+	return &Framework{
+		AsKubeAdmin:     hub,
+		AsKubeDeveloper: hub,
+		UserNamespace:   fmt.Sprintf("%s-tenant", userName),
+		UserName:        userName,
+	}, nil
+}
+
 func InitControllerHub(cc *kubeCl.CustomClient) (*ControllerHub, error) {
 	// Initialize Common controller
 	commonCtrl, err := common.NewSuiteController(cc)
@@ -0,0 +1,88 @@
+package framework
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appstudioApi "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const fakeUserName = "fake-user"
+
+func newFakePipelineRun(name, namespace string, startTime time.Time) *tektonv1.PipelineRun {
+	return &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"appstudio.openshift.io/component":      "test-component",
+				"appstudio.openshift.io/application":    "test-application",
+				"pipelines.appstudio.openshift.io/type": "build",
+			},
+		},
+		Status: tektonv1.PipelineRunStatus{
+			PipelineRunStatusFields: tektonv1.PipelineRunStatusFields{
+				StartTime: &metav1.Time{Time: startTime},
+			},
+		},
+	}
+}
+
+func TestNewFakeFramework(t *testing.T) {
+	fwk, err := NewFakeFramework(fakeUserName)
+	assert.NoError(t, err)
+	assert.Equal(t, fakeUserName, fwk.UserName)
+	assert.Equal(t, fakeUserName+"-tenant", fwk.UserNamespace)
+	assert.Same(t, fwk.AsKubeAdmin, fwk.AsKubeDeveloper)
+}
+
+func TestFakeFrameworkGetBuildPipelineRun(t *testing.T) {
+	namespace := fakeUserName + "-tenant"
+	now := time.Now()
+	fwk, err := NewFakeFramework(fakeUserName,
+		newFakePipelineRun("older-build", namespace, now.Add(-time.Minute)),
+		newFakePipelineRun("latest-build", namespace, now),
+	)
+	assert.NoError(t, err)
+
+	pipelineRun, err := fwk.AsKubeAdmin.IntegrationController.GetBuildPipelineRun("test-component", "test-application", namespace, false, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "latest-build", pipelineRun.Name)
+}
+
+func TestFakeFrameworkDeleteAllPipelineRunsInASpecificNamespace(t *testing.T) {
+	namespace := fakeUserName + "-tenant"
+	pipelineRun := newFakePipelineRun("build", namespace, time.Now())
+	pipelineRun.Finalizers = []string{"chains.tekton.dev/pipelinerun"}
+	fwk, err := NewFakeFramework(fakeUserName, pipelineRun)
+	assert.NoError(t, err)
+
+	assert.NoError(t, fwk.AsKubeAdmin.TektonController.DeleteAllPipelineRunsInASpecificNamespace(namespace))
+
+	list := &tektonv1.PipelineRunList{}
+	assert.NoError(t, fwk.AsKubeAdmin.TektonController.KubeRest().List(context.Background(), list))
+	assert.Empty(t, list.Items)
+}
+
+func TestFakeFrameworkWaitForSnapshotToGetCreated(t *testing.T) {
+	namespace := fakeUserName + "-tenant"
+	snapshot := &appstudioApi.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-snapshot",
+			Namespace: namespace,
+			Labels: map[string]string{
+				"appstudio.openshift.io/component": "test-component",
+			},
+		},
+	}
+	fwk, err := NewFakeFramework(fakeUserName, snapshot)
+	assert.NoError(t, err)
+
+	found, err := fwk.AsKubeAdmin.IntegrationController.WaitForSnapshotToGetCreated("", "", "test-component", namespace)
+	assert.NoError(t, err)
+	assert.Equal(t, snapshot.Name, found.Name)
+}
@@ -0,0 +1,293 @@
+package framework
+
+import (
+	"fmt"
+	"html/template"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/redhat-appstudio/e2e-tests/pkg/logs"
+)
+
+// htmlReportArtifactCategories are the groups the artifacts of a spec are listed in, in order.
+var htmlReportArtifactCategories = []string{"Timeline", "PipelineRun logs", "Pod logs", "Resources", "Other"}
+
+type htmlReportArtifact struct {
+	Name string
+	Link string
+	// Note describes e.g. why the artifact wasn't stored
+	Note string
+}
+
+type htmlReportArtifactGroup struct {
+	Category  string
+	Artifacts []htmlReportArtifact
+}
+
+type htmlReportSpec struct {
+	Page           string
+	Name           string
+	State          string
+	Failed         bool
+	Duration       time.Duration
+	Labels         []string
+	Failure        string
+	Location       string
+	GinkgoWriter   string
+	StdOutErr      string
+	ArtifactGroups []htmlReportArtifactGroup
+}
+
+type htmlReportSuite struct {
+	Description string
+	StartTime   time.Time
+	Duration    time.Duration
+	Passed      int
+	Failed      int
+	Skipped     int
+	Specs       []htmlReportSpec
+}
+
+var htmlReportStyle = `<style>
+body { font-family: sans-serif; font-size: 14px; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #eee; }
+.failed { color: #b00020; font-weight: bold; }
+.passed { color: #1b5e20; }
+pre { background: #f6f8fa; padding: 8px; overflow-x: auto; white-space: pre-wrap; }
+.label { background: #e8f0fe; border-radius: 4px; padding: 0 4px; margin-right: 4px; }
+</style>`
+
+var htmlReportIndexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Description }}</title>
+` + htmlReportStyle + `
+</head>
+<body>
+<h1>{{ .Description }}</h1>
+<p>Started at {{ .StartTime.Format "2006-01-02T15:04:05Z07:00" }}, took {{ .Duration }}: {{ .Passed }} passed, <span class="failed">{{ .Failed }} failed</span>, {{ .Skipped }} skipped</p>
+<table>
+<tr><th>Status</th><th>Spec</th><th>Duration</th><th>Labels</th></tr>
+{{- range .Specs }}
+<tr><td class="{{ if .Failed }}failed{{ else }}passed{{ end }}">{{ .State }}</td><td><a href="{{ .Page }}">{{ .Name }}</a></td><td>{{ .Duration }}</td><td>{{ range .Labels }}<span class="label">{{ . }}</span>{{ end }}</td></tr>
+{{- end }}
+</table>
+</body>
+</html>
+`))
+
+var htmlReportSpecTemplate = template.Must(template.New("spec").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Name }}</title>
+` + htmlReportStyle + `
+</head>
+<body>
+<p><a href="../index.html">All specs</a></p>
+<h1>{{ .Name }}</h1>
+<p class="{{ if .Failed }}failed{{ else }}passed{{ end }}">{{ .State }} after {{ .Duration }}</p>
+<p>{{ range .Labels }}<span class="label">{{ . }}</span>{{ end }}</p>
+{{- if .Failure }}
+<h2>Failure</h2>
+<p>{{ .Location }}</p>
+<pre>{{ .Failure }}</pre>
+{{- end }}
+{{- range .ArtifactGroups }}
+<h2>{{ .Category }}</h2>
+<ul>
+{{- range .Artifacts }}
+<li>{{ if .Link }}<a href="{{ .Link }}">{{ .Name }}</a>{{ else }}{{ .Name }}{{ end }}{{ if .Note }} ({{ .Note }}){{ end }}</li>
+{{- end }}
+</ul>
+{{- end }}
+{{- if .GinkgoWriter }}
+<h2>GinkgoWriter output</h2>
+<pre>{{ .GinkgoWriter }}</pre>
+{{- end }}
+{{- if .StdOutErr }}
+<h2>Captured stdout/stderr</h2>
+<pre>{{ .StdOutErr }}</pre>
+{{- end }}
+</body>
+</html>
+`))
+
+// GenerateHTMLReport generates a static HTML site into the dst directory, with an index.html listing every spec
+// and a page for each spec with its status, labels, captured output and links to its stored artifacts.
+// The artifacts of each spec are looked up in the given directories in order, since e.g. GenerateRPPreprocReport
+// moves them from the artifact directory.
+func GenerateHTMLReport(report types.Report, dst string, artifactDirs ...string) error {
+	if err := os.MkdirAll(filepath.Join(dst, "specs"), os.ModePerm); err != nil {
+		return err
+	}
+
+	suite := htmlReportSuite{Description: report.SuiteDescription, StartTime: report.StartTime, Duration: report.RunTime.Round(time.Second)}
+	for i, spec := range report.SpecReports {
+		// the suite level nodes (e.g. BeforeSuite) are reported only when they fail
+		if spec.LeafNodeType != types.NodeTypeIt && !spec.Failed() {
+			continue
+		}
+
+		reportSpec := htmlReportSpec{
+			Page:         fmt.Sprintf("specs/%d.html", i),
+			Name:         spec.FullText(),
+			State:        spec.State.String(),
+			Failed:       spec.Failed(),
+			Duration:     spec.RunTime.Round(time.Millisecond),
+			Labels:       spec.Labels(),
+			GinkgoWriter: spec.CapturedGinkgoWriterOutput,
+			StdOutErr:    spec.CapturedStdOutErr,
+		}
+		if reportSpec.Name == "" {
+			reportSpec.Name = spec.LeafNodeType.String()
+		}
+		if spec.Failed() {
+			reportSpec.Failure = spec.FailureMessage()
+			reportSpec.Location = spec.FailureLocation().String()
+		} else if PassedAfterRetry(spec) {
+			reportSpec.State = fmt.Sprintf("flaky (passed after %d attempts)", spec.NumAttempts)
+			reportSpec.Failure = attemptFailuresLog(spec)
+		}
+		if specDir := findSpecArtifactDir(logs.ShortenStringAddHash(spec), artifactDirs); specDir != "" {
+			reportSpec.ArtifactGroups = specArtifactGroups(filepath.Join(dst, "specs"), specDir, artifactDirs)
+		}
+
+		switch {
+		case spec.Failed():
+			suite.Failed++
+		case spec.State.Is(types.SpecStatePassed):
+			suite.Passed++
+		default:
+			suite.Skipped++
+		}
+		suite.Specs = append(suite.Specs, reportSpec)
+	}
+
+	// failed specs go first
+	sort.SliceStable(suite.Specs, func(i, j int) bool {
+		return suite.Specs[i].Failed && !suite.Specs[j].Failed
+	})
+
+	for _, spec := range suite.Specs {
+		if err := writeHTMLReportPage(filepath.Join(dst, spec.Page), htmlReportSpecTemplate, spec); err != nil {
+			return err
+		}
+	}
+	return writeHTMLReportPage(filepath.Join(dst, "index.html"), htmlReportIndexTemplate, suite)
+}
+
+func writeHTMLReportPage(path string, tmpl *template.Template, data any) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := tmpl.Execute(file, data); err != nil {
+		return fmt.Errorf("failed to render %s: %v", path, err)
+	}
+	return nil
+}
+
+func findSpecArtifactDir(name string, artifactDirs []string) string {
+	for _, dir := range artifactDirs {
+		specDir := filepath.Join(dir, name)
+		if info, err := os.Stat(specDir); err == nil && info.IsDir() {
+			return specDir
+		}
+	}
+	return ""
+}
+
+// findArtifactBlob returns the path of the by-sha256 blob of a deduplicated log. The blobs stay in the artifact directory
+// when the spec directories are moved, so they are looked up in the artifact directories before the parent of the spec directory.
+func findArtifactBlob(blob, specDir string, artifactDirs []string) string {
+	for _, dir := range append(append([]string{}, artifactDirs...), filepath.Dir(specDir)) {
+		if _, err := os.Stat(filepath.Join(dir, blob)); err == nil {
+			return filepath.Join(dir, blob)
+		}
+	}
+	return filepath.Join(filepath.Dir(specDir), blob)
+}
+
+// specArtifactGroups lists the artifacts of the spec grouped by their category, with links relative to the page directory.
+// The artifacts are taken from the index.json manifest written by logs.LocalArtifactStore, if there's any.
+func specArtifactGroups(pageDir, specDir string, artifactDirs []string) []htmlReportArtifactGroup {
+	artifacts := map[string][]htmlReportArtifact{}
+	link := func(path string) string {
+		absPageDir, err := filepath.Abs(pageDir)
+		if err != nil {
+			return ""
+		}
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return ""
+		}
+		rel, err := filepath.Rel(absPageDir, absPath)
+		if err != nil {
+			return ""
+		}
+		// url.URL prefixes the path with "./" if its first segment contains a colon, so it isn't taken for a scheme
+		return (&url.URL{Path: filepath.ToSlash(rel)}).String()
+	}
+
+	if entries, err := logs.ReadArtifactIndex(specDir); err == nil {
+		for _, entry := range entries {
+			artifact := htmlReportArtifact{Name: entry.Name, Note: entry.Skipped}
+			switch {
+			case entry.File != "":
+				artifact.Link = link(filepath.Join(specDir, entry.File))
+				if entry.Compressed {
+					artifact.Note = "gzipped"
+				}
+			case entry.DuplicateOf != "":
+				artifact.Link = link(findArtifactBlob(entry.DuplicateOf, specDir, artifactDirs))
+				artifact.Note = "identical to a log stored before"
+				if entry.Compressed {
+					artifact.Note += ", gzipped"
+				}
+			}
+			category := artifactCategory(entry.Name)
+			artifacts[category] = append(artifacts[category], artifact)
+		}
+	} else if files, err := os.ReadDir(specDir); err == nil {
+		for _, file := range files {
+			if file.IsDir() {
+				continue
+			}
+			category := artifactCategory(file.Name())
+			artifacts[category] = append(artifacts[category], htmlReportArtifact{Name: file.Name(), Link: link(filepath.Join(specDir, file.Name()))})
+		}
+	}
+
+	groups := []htmlReportArtifactGroup{}
+	for _, category := range htmlReportArtifactCategories {
+		if len(artifacts[category]) > 0 {
+			groups = append(groups, htmlReportArtifactGroup{Category: category, Artifacts: artifacts[category]})
+		}
+	}
+	return groups
+}
+
+func artifactCategory(name string) string {
+	switch {
+	case name == "timeline.html" || name == logs.TimingFile:
+		return "Timeline"
+	case strings.HasPrefix(name, "pipelineRun-") && strings.HasSuffix(name, ".log"):
+		return "PipelineRun logs"
+	case strings.HasPrefix(name, "pod-"):
+		return "Pod logs"
+	case strings.HasSuffix(name, ".yaml"):
+		return "Resources"
+	}
+	return "Other"
+}
@@ -0,0 +1,115 @@
+package framework
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/redhat-appstudio/e2e-tests/pkg/logs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateHTMLReport(t *testing.T) {
+	artifactDir := t.TempDir()
+	dst := filepath.Join(t.TempDir(), "html-report")
+
+	failedSpec := types.SpecReport{
+		ContainerHierarchyTexts:    []string{"[build-service-suite Build service E2E tests]"},
+		ContainerHierarchyLabels:   [][]string{{"build", "HACBS"}},
+		LeafNodeType:               types.NodeTypeIt,
+		LeafNodeText:               "triggers a PipelineRun",
+		State:                      types.SpecStateFailed,
+		RunTime:                    time.Minute,
+		CapturedGinkgoWriterOutput: "waiting for the PipelineRun",
+		Failure:                    types.Failure{Message: "timed out waiting for the PipelineRun", Location: types.CodeLocation{FileName: "build.go", LineNumber: 42}},
+	}
+	passedSpec := types.SpecReport{
+		ContainerHierarchyTexts: []string{"[build-service-suite Build service E2E tests]"},
+		LeafNodeType:            types.NodeTypeIt,
+		LeafNodeText:            "creates a component",
+		State:                   types.SpecStatePassed,
+	}
+	suiteNode := types.SpecReport{LeafNodeType: types.NodeTypeBeforeSuite, State: types.SpecStatePassed}
+
+	store := &logs.LocalArtifactStore{Dir: artifactDir}
+	assert.NoError(t, store.Store(logs.ShortenStringAddHash(failedSpec), map[string][]byte{
+		"pipelineRun-build.log": []byte("step-build failed"),
+		"component.yaml":        []byte("kind: Component"),
+	}))
+
+	report := types.Report{SuiteDescription: "Red Hat App Studio E2E tests", SpecReports: types.SpecReports{passedSpec, suiteNode, failedSpec}}
+	assert.NoError(t, GenerateHTMLReport(report, dst, filepath.Join(artifactDir, "missing"), artifactDir))
+
+	index, err := os.ReadFile(filepath.Join(dst, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(index), "1 passed")
+	assert.Contains(t, string(index), `<a href="specs/2.html">`)
+	assert.NotContains(t, string(index), "specs/1.html")
+
+	page, err := os.ReadFile(filepath.Join(dst, "specs", "2.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(page), "timed out waiting for the PipelineRun")
+	assert.Contains(t, string(page), "waiting for the PipelineRun")
+	assert.Contains(t, string(page), "<h2>PipelineRun logs</h2>")
+	assert.Contains(t, string(page), "pipelineRun-build.log</a>")
+	assert.Contains(t, string(page), "<h2>Resources</h2>")
+}
+
+func TestGenerateHTMLReportAfterRPPreprocReport(t *testing.T) {
+	artifactDir := t.TempDir()
+	t.Setenv("ARTIFACT_DIR", artifactDir)
+	dst := filepath.Join(t.TempDir(), "html-report")
+
+	newFailedSpec := func(text string) types.SpecReport {
+		return types.SpecReport{
+			ContainerHierarchyTexts: []string{"[build-service-suite Build service E2E tests]"},
+			LeafNodeType:            types.NodeTypeIt,
+			LeafNodeText:            text,
+			State:                   types.SpecStateFailed,
+			Failure:                 types.Failure{Message: "timed out"},
+		}
+	}
+	firstSpec, secondSpec, thirdSpec := newFailedSpec("first"), newFailedSpec("second"), newFailedSpec("third")
+
+	podLog := map[string][]byte{"controller-pod.log": []byte("the same controller log")}
+	store := &logs.LocalArtifactStore{Dir: artifactDir}
+	assert.NoError(t, store.Store(logs.ShortenStringAddHash(firstSpec), podLog))
+	assert.NoError(t, store.Store(logs.ShortenStringAddHash(secondSpec), podLog))
+	// the manifest of a duplicate without a file in the spec directory, it's linked to the blob
+	index, err := logs.ReadArtifactIndex(filepath.Join(artifactDir, logs.ShortenStringAddHash(secondSpec)))
+	assert.NoError(t, err)
+	index[0].File = ""
+	thirdSpecDir := filepath.Join(artifactDir, logs.ShortenStringAddHash(thirdSpec))
+	assert.NoError(t, os.MkdirAll(thirdSpecDir, os.ModePerm))
+	content, err := json.Marshal(index)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(thirdSpecDir, logs.ArtifactIndexFile), content, 0644))
+
+	// the spec directories are moved by the RP preproc report before the HTML report is generated, like in cmd/e2e_test.go
+	report := types.Report{SuiteDescription: "Red Hat App Studio E2E tests", SpecReports: types.SpecReports{firstSpec, secondSpec, thirdSpec}}
+	GenerateRPPreprocReport(report, artifactDir)
+	assert.NoDirExists(t, filepath.Join(artifactDir, logs.ShortenStringAddHash(secondSpec)))
+	assert.NoError(t, GenerateHTMLReport(report, dst, artifactDir, filepath.Join(artifactDir, "rp_preproc", "attachments", "xunit")))
+
+	links := regexp.MustCompile(`<a href="([^"]+)">controller-pod.log</a>`)
+	for i := range report.SpecReports {
+		page, err := os.ReadFile(filepath.Join(dst, "specs", fmt.Sprintf("%d.html", i)))
+		assert.NoError(t, err)
+		match := links.FindStringSubmatch(string(page))
+		if !assert.Len(t, match, 2, "the link of the log of the spec %d", i) {
+			continue
+		}
+		href, err := url.PathUnescape(html.UnescapeString(match[1]))
+		assert.NoError(t, err)
+		content, err := os.ReadFile(filepath.Join(dst, "specs", filepath.FromSlash(href)))
+		assert.NoError(t, err, "the link of the log of the spec %d is broken", i)
+		assert.Equal(t, podLog["controller-pod.log"], content)
+	}
+}
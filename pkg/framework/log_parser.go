@@ -0,0 +1,303 @@
+package framework
+
+import (
+	"encoding/json"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogFormat is the format of a controller log line.
+type LogFormat string
+
+const (
+	// LogFormatJSON is used by zap/logr with the JSON encoder, e.g. {"level":"info","ts":1692321486.2,"logger":"controller","msg":"..."}
+	LogFormatJSON LogFormat = "json"
+	// LogFormatKlog is used by klog, e.g. I0818 01:18:06.123456       1 controller.go:228] message key="value"
+	LogFormatKlog LogFormat = "klog"
+	// LogFormatPlain is any other line, e.g. the zap console encoder output 2023-08-18T01:18:06.123Z	INFO	controller	message	{"key": "value"}
+	LogFormatPlain LogFormat = "plain"
+)
+
+// LogEntry is a single entry of a controller log. Lines without a timestamp (e.g. stack traces) are added to the previous entry.
+type LogEntry struct {
+	Format LogFormat
+	// Time is zero if the entry has no timestamp
+	Time    time.Time
+	Level   string
+	Logger  string
+	Message string
+	// Location is the source location of the entry, e.g. "controller.go:228", if the log has one
+	Location string
+	// Namespace and Name identify the object the entry is about, if the log has such keys
+	Namespace string
+	Name      string
+	// Raw holds the original lines of the entry
+	Raw string
+}
+
+var (
+	rfc3339Regexp = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`)
+	klogRegexp    = regexp.MustCompile(`^([IWEF])(\d{4} \d{2}:\d{2}:\d{2}\.\d+)\s+\d+ ([^\]]+)\] (.*)$`)
+	klogKeyRegexp = regexp.MustCompile(`(\w+)=("(?:[^"\\]|\\.)*"|\S+)`)
+	plainLevels   = map[string]string{"DEBUG": "debug", "INFO": "info", "WARN": "warn", "WARNING": "warn", "ERROR": "error", "DPANIC": "dpanic", "PANIC": "panic", "FATAL": "fatal"}
+	klogLevels    = map[string]string{"I": "info", "W": "warn", "E": "error", "F": "fatal"}
+)
+
+// LogParser parses controller logs in the zap/logr JSON, klog and plain formats.
+type LogParser struct {
+	// Year is used for the klog timestamps, which don't include it. The current year is used if not set.
+	Year int
+}
+
+// Parse splits the logs into entries.
+func (p LogParser) Parse(logs string) []LogEntry {
+	entries := []LogEntry{}
+	for _, line := range strings.Split(logs, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		entry := p.ParseLine(line)
+		if entry.Time.IsZero() && len(entries) > 0 {
+			entries[len(entries)-1].Raw += "\n" + line
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// ParseLine parses a single log line.
+func (p LogParser) ParseLine(line string) LogEntry {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "{") {
+		if entry, ok := parseJSONLine(trimmed); ok {
+			entry.Raw = line
+			return entry
+		}
+	}
+	if entry, ok := p.parseKlogLine(trimmed); ok {
+		entry.Raw = line
+		return entry
+	}
+	entry := parsePlainLine(line)
+	entry.Raw = line
+	return entry
+}
+
+func parseJSONLine(line string) (LogEntry, bool) {
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		return LogEntry{}, false
+	}
+
+	entry := LogEntry{Format: LogFormatJSON}
+	for _, key := range []string{"ts", "time", "timestamp", "@timestamp"} {
+		if ts, ok := fields[key]; ok {
+			entry.Time = parseJSONTime(ts)
+			break
+		}
+	}
+	entry.Level = strings.ToLower(firstString(fields, "level", "severity"))
+	entry.Logger = firstString(fields, "logger")
+	entry.Location = firstString(fields, "caller")
+	entry.Message = firstString(fields, "msg", "message")
+	entry.Namespace, entry.Name = objectKeys(fields)
+	return entry, true
+}
+
+// parseJSONTime parses both the epoch (seconds with a fraction) and the RFC3339 timestamps.
+func parseJSONTime(ts interface{}) time.Time {
+	switch value := ts.(type) {
+	case float64:
+		seconds, fraction := math.Modf(value)
+		return time.Unix(int64(seconds), int64(fraction*1e9)).UTC()
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return t
+		}
+		if epoch, err := strconv.ParseFloat(value, 64); err == nil {
+			return parseJSONTime(epoch)
+		}
+	}
+	return time.Time{}
+}
+
+func (p LogParser) parseKlogLine(line string) (LogEntry, bool) {
+	match := klogRegexp.FindStringSubmatch(line)
+	if match == nil {
+		return LogEntry{}, false
+	}
+	year := p.Year
+	if year == 0 {
+		year = time.Now().Year()
+	}
+	ts, err := time.Parse("2006 0102 15:04:05.999999", strconv.Itoa(year)+" "+match[2])
+	if err != nil {
+		return LogEntry{}, false
+	}
+
+	entry := LogEntry{Format: LogFormatKlog, Time: ts, Level: klogLevels[match[1]], Location: match[3], Message: match[4]}
+	keys := map[string]interface{}{}
+	for _, kv := range klogKeyRegexp.FindAllStringSubmatch(match[4], -1) {
+		value := kv[2]
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		keys[kv[1]] = value
+	}
+	entry.Namespace, entry.Name = objectKeys(keys)
+	return entry, true
+}
+
+// parsePlainLine looks for the first RFC3339 timestamp in the line. The lines of the zap console encoder
+// are split by tabs into the timestamp, level, logger, caller, message and a JSON object with the keys.
+func parsePlainLine(line string) LogEntry {
+	entry := LogEntry{Format: LogFormatPlain, Message: line}
+	if ts := rfc3339Regexp.FindString(line); ts != "" {
+		if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			entry.Time = t
+		}
+	}
+
+	fields := strings.Split(line, "\t")
+	if len(fields) < 3 || entry.Time.IsZero() {
+		return entry
+	}
+	level, ok := plainLevels[strings.ToUpper(fields[1])]
+	if !ok {
+		return entry
+	}
+	entry.Level = level
+	rest := fields[2:]
+	if len(rest) > 1 && !strings.Contains(rest[0], ".go:") {
+		entry.Logger = rest[0]
+		rest = rest[1:]
+	}
+	if len(rest) > 1 && strings.Contains(rest[0], ".go:") {
+		entry.Location = rest[0]
+		rest = rest[1:]
+	}
+	if last := rest[len(rest)-1]; len(rest) > 1 && strings.HasPrefix(last, "{") {
+		keys := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(last), &keys); err == nil {
+			entry.Namespace, entry.Name = objectKeys(keys)
+			rest = rest[:len(rest)-1]
+		}
+	}
+	entry.Message = strings.Join(rest, "\t")
+	return entry
+}
+
+// objectKeys returns the namespace and name of the object the entry is about. Besides the "namespace" and "name" keys,
+// the "request" key ("namespace/name") and the nested objects added by controller-runtime ({"Component": {"name": "...", "namespace": "..."}})
+// are recognized.
+func objectKeys(keys map[string]interface{}) (string, string) {
+	if namespace := firstString(keys, "namespace", "Namespace"); namespace != "" {
+		return namespace, firstString(keys, "name", "Name", "resource")
+	}
+	for _, key := range []string{"request", "object", "pod"} {
+		if namespace, name, found := strings.Cut(firstString(keys, key), "/"); found {
+			return namespace, name
+		}
+	}
+	for _, value := range keys {
+		if nested, ok := value.(map[string]interface{}); ok {
+			if namespace := firstString(nested, "namespace"); namespace != "" {
+				return namespace, firstString(nested, "name")
+			}
+		}
+	}
+	return "", ""
+}
+
+func firstString(fields map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := fields[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// LogFilter selects the log entries relevant for a spec.
+type LogFilter struct {
+	// Start and End limit the time window of the entries, End is ignored if zero
+	Start time.Time
+	End   time.Time
+	// Namespace limits the entries to those referencing the namespace, either by their keys or in the message.
+	// Errors not related to any namespace are kept too, as they might affect every namespace.
+	Namespace string
+	// Levels limits the entries to the given levels (e.g. "warn", "error"), all levels are kept if empty
+	Levels []string
+	// Loggers limits the entries to the given loggers and their children, e.g. "controllers" matches "controllers.Component".
+	// All loggers are kept if empty.
+	Loggers []string
+}
+
+// Matches returns true if the entry is in the time window, has one of the levels and loggers and references the namespace.
+func (f LogFilter) Matches(entry LogEntry) bool {
+	if entry.Time.IsZero() || entry.Time.Before(f.Start) || (!f.End.IsZero() && entry.Time.After(f.End)) {
+		return false
+	}
+	if !f.matchesLevel(entry.Level) || !f.matchesLogger(entry.Logger) {
+		return false
+	}
+	if f.Namespace == "" || entry.Namespace == f.Namespace {
+		return true
+	}
+	if entry.Namespace == "" && isErrorLevel(entry.Level) {
+		return true
+	}
+	return strings.Contains(entry.Raw, f.Namespace)
+}
+
+func (f LogFilter) matchesLevel(level string) bool {
+	if len(f.Levels) == 0 {
+		return true
+	}
+	for _, l := range f.Levels {
+		if strings.EqualFold(l, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f LogFilter) matchesLogger(logger string) bool {
+	if len(f.Loggers) == 0 {
+		return true
+	}
+	for _, l := range f.Loggers {
+		if logger == l || strings.HasPrefix(logger, l+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func isErrorLevel(level string) bool {
+	switch level {
+	case "error", "dpanic", "panic", "fatal":
+		return true
+	}
+	return false
+}
+
+// FilterLogEntries returns the lines of the log entries matching the filter.
+func FilterLogEntries(logs string, filter LogFilter) string {
+	parser := LogParser{}
+	if !filter.Start.IsZero() {
+		parser.Year = filter.Start.Year()
+	}
+
+	ret := []string{}
+	for _, entry := range parser.Parse(logs) {
+		if filter.Matches(entry) {
+			ret = append(ret, entry.Raw)
+		}
+	}
+	return strings.Join(ret, "\n")
+}
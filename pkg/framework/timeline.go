@@ -0,0 +1,67 @@
+package framework
+
+import (
+	"bytes"
+	"html/template"
+	"sort"
+	"time"
+)
+
+// TimelineWarning is the type of the timeline entries which point to a problem, like a Warning Event or a failed condition.
+const TimelineWarning = "Warning"
+
+// TimelineEntry is a single thing that happened during a spec, e.g. a resource got created, a condition changed or an Event was emitted.
+type TimelineEntry struct {
+	Time time.Time
+	// Source is the kind of the object the entry is about ("Spec" for the steps of the spec itself)
+	Source string
+	// Object is the namespaced name of the object
+	Object string
+	// Type is either empty or TimelineWarning
+	Type    string
+	Reason  string
+	Message string
+}
+
+var timelineTemplate = template.Must(template.New("timeline").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Spec.FullText }}</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #eee; }
+tr.spec { background: #e8f0fe; }
+tr.warning { background: #fde8e8; }
+td.message { white-space: pre-wrap; font-family: monospace; }
+</style>
+</head>
+<body>
+<h1>{{ .Spec.FullText }}</h1>
+<p>Namespace: <code>{{ .Namespace }}</code></p>
+<p>Started at {{ .Spec.StartTime.Format "2006-01-02T15:04:05Z07:00" }}, failed at {{ .Spec.Failure.Location }}</p>
+<pre>{{ .Spec.Failure.Message }}</pre>
+<table>
+<tr><th>Time</th><th>Source</th><th>Object</th><th>Reason</th><th>Message</th></tr>
+{{- range .Timeline }}
+<tr class="{{ if eq .Type "Warning" }}warning{{ else if eq .Source "Spec" }}spec{{ end }}"><td>{{ .Time.Format "15:04:05.000" }}</td><td>{{ .Source }}</td><td>{{ .Object }}</td><td>{{ .Reason }}</td><td class="message">{{ .Message }}</td></tr>
+{{- end }}
+</table>
+</body>
+</html>
+`))
+
+// renderTimeline renders the timeline of the report as an HTML page, with the entries sorted chronologically.
+func renderTimeline(report *FailureReport) ([]byte, error) {
+	sort.SliceStable(report.Timeline, func(i, j int) bool {
+		return report.Timeline[i].Time.Before(report.Timeline[j].Time)
+	})
+
+	out := &bytes.Buffer{}
+	if err := timelineTemplate.Execute(out, report); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,304 @@
+package logs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
+	. "github.com/redhat-appstudio/e2e-tests/pkg/utils"
+)
+
+const (
+	// ArtifactIndexFile is the name of the manifest listing the artifacts of a spec
+	ArtifactIndexFile = "index.json"
+
+	// ArtifactBlobsDir is the directory, relative to the artifact directory, of the deduplicated logs named by their SHA256 checksum
+	ArtifactBlobsDir = "by-sha256"
+
+	defaultSpecQuotaMB     = 200
+	defaultGzipThresholdKB = 1024
+)
+
+// ArtifactStore stores the artifacts of the specs, like pod logs and resource YAMLs.
+type ArtifactStore interface {
+	// Store stores the given artifacts, keyed by the file name, of the spec with the given directory name.
+	Store(spec string, artifacts map[string][]byte) error
+}
+
+// ArtifactIndexEntry describes an artifact in the index.json manifest of a spec.
+type ArtifactIndexEntry struct {
+	Name string `json:"name"`
+	// File is the name of the stored file, empty if the artifact was skipped
+	File string `json:"file,omitempty"`
+	// Size is the size of the artifact before compression
+	Size int64 `json:"size"`
+	// StoredSize is the size of the stored file
+	StoredSize int64  `json:"storedSize"`
+	SHA256     string `json:"sha256"`
+	Compressed bool   `json:"compressed,omitempty"`
+	// DuplicateOf is the path, relative to the artifact directory, of the by-sha256 blob of an identical log stored before
+	DuplicateOf string `json:"duplicateOf,omitempty"`
+	// Skipped explains why the artifact wasn't stored
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// LocalArtifactStore stores the artifacts of each spec into a subdirectory of Dir.
+// Log artifacts larger than CompressThreshold are gzipped. Every stored log is also kept as an immutable blob in the by-sha256 directory
+// (hard linked when possible) and the logs identical to an already stored one (e.g. the same pod logs stored by several specs)
+// are hard links of that blob, referenced by the index.json of the spec, so the reference stays valid even if the first spec
+// replaces its log.
+// Once the artifacts of a spec reach SpecQuota, the rest is skipped, starting with the largest artifacts.
+type LocalArtifactStore struct {
+	Dir string
+	// SpecQuota is the maximum size of the stored files of a spec in bytes, 0 disables the limit
+	SpecQuota int64
+	// CompressThreshold is the size in bytes above which the logs are gzipped, 0 disables the compression
+	CompressThreshold int64
+
+	mu sync.Mutex
+}
+
+var (
+	artifactStoreMu sync.Mutex
+	artifactStore   ArtifactStore
+)
+
+// NewLocalArtifactStore returns a LocalArtifactStore writing into the $ARTIFACT_DIR directory (./tmp by default),
+// with the quota and compression threshold given by the ARTIFACTS_SPEC_QUOTA_MB and ARTIFACTS_GZIP_THRESHOLD_KB environment variables.
+func NewLocalArtifactStore() *LocalArtifactStore {
+	wd, _ := os.Getwd()
+	return &LocalArtifactStore{
+		Dir:               GetEnv("ARTIFACT_DIR", fmt.Sprintf("%s/tmp", wd)),
+		SpecQuota:         envSize(constants.ARTIFACTS_SPEC_QUOTA_MB_ENV, defaultSpecQuotaMB) << 20,
+		CompressThreshold: envSize(constants.ARTIFACTS_GZIP_THRESHOLD_KB_ENV, defaultGzipThresholdKB) << 10,
+	}
+}
+
+func envSize(name string, defaultValue int64) int64 {
+	size, err := strconv.ParseInt(GetEnv(name, strconv.FormatInt(defaultValue, 10)), 10, 64)
+	if err != nil || size < 0 {
+		return defaultValue
+	}
+	return size
+}
+
+// SetArtifactStore replaces the store used by StoreArtifacts and the other Store functions of this package.
+func SetArtifactStore(store ArtifactStore) {
+	artifactStoreMu.Lock()
+	defer artifactStoreMu.Unlock()
+	artifactStore = store
+}
+
+// GetArtifactStore returns the store used by StoreArtifacts, a LocalArtifactStore unless replaced by SetArtifactStore.
+func GetArtifactStore() ArtifactStore {
+	artifactStoreMu.Lock()
+	defer artifactStoreMu.Unlock()
+	if artifactStore == nil {
+		artifactStore = NewLocalArtifactStore()
+	}
+	return artifactStore
+}
+
+func (s *LocalArtifactStore) Store(spec string, artifacts map[string][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	specDir := filepath.Join(s.Dir, spec)
+	if err := os.MkdirAll(specDir, os.ModePerm); err != nil {
+		return err
+	}
+	index, err := readArtifactIndex(specDir)
+	if err != nil {
+		return err
+	}
+
+	// store the small artifacts first, so the quota is rather exceeded by a huge log than by many resource YAMLs
+	names := make([]string, 0, len(artifacts))
+	for name := range artifacts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(artifacts[names[i]]) != len(artifacts[names[j]]) {
+			return len(artifacts[names[i]]) < len(artifacts[names[j]])
+		}
+		return names[i] < names[j]
+	})
+
+	var errs []error
+	for _, name := range names {
+		entry, err := s.store(spec, name, artifacts[name], index)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to store artifact %s: %v", name, err))
+			continue
+		}
+		index[name] = entry
+	}
+
+	if err := writeArtifactIndex(specDir, index); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (s *LocalArtifactStore) store(spec, name string, content []byte, index map[string]ArtifactIndexEntry) (ArtifactIndexEntry, error) {
+	sum := sha256.Sum256(content)
+	entry := ArtifactIndexEntry{Name: name, Size: int64(len(content)), SHA256: hex.EncodeToString(sum[:])}
+
+	// the artifact replaces the one stored under the same name before
+	if previous, ok := index[name]; ok && previous.File != "" {
+		if err := os.Remove(filepath.Join(s.Dir, spec, previous.File)); err != nil && !os.IsNotExist(err) {
+			return entry, err
+		}
+		delete(index, name)
+	}
+
+	isLog := strings.HasSuffix(name, ".log")
+	compress := s.CompressThreshold > 0 && entry.Size > s.CompressThreshold && isLog
+	blob := filepath.Join(ArtifactBlobsDir, entry.SHA256)
+	if compress {
+		blob += ".gz"
+	}
+	entry.File = name
+	if compress {
+		entry.File = name + ".gz"
+	}
+	path := filepath.Join(s.Dir, spec, entry.File)
+	if isLog {
+		if _, err := os.Stat(filepath.Join(s.Dir, blob)); err == nil {
+			entry.DuplicateOf = filepath.ToSlash(blob)
+			// the spec directory keeps a hard link of the blob taking no space, e.g. for Report Portal collecting
+			// the attachments from the spec directories, and a copy if the blob can't be linked
+			if err := os.Link(filepath.Join(s.Dir, blob), path); err == nil {
+				entry.Compressed = compress
+				return entry, nil
+			}
+		}
+	}
+
+	stored := content
+	entry.File = name
+	if compress {
+		compressed, err := gzipContent(content)
+		if err != nil {
+			return entry, err
+		}
+		stored = compressed
+		entry.File = name + ".gz"
+		entry.Compressed = true
+	}
+	entry.StoredSize = int64(len(stored))
+
+	if s.SpecQuota > 0 && storedSize(index)+entry.StoredSize > s.SpecQuota {
+		entry.File = ""
+		entry.Compressed = false
+		entry.StoredSize = 0
+		entry.Skipped = fmt.Sprintf("the artifacts of the spec exceed the quota of %d bytes", s.SpecQuota)
+		return entry, nil
+	}
+
+	if isLog && entry.DuplicateOf == "" && writeBlob(filepath.Join(s.Dir, blob), stored) {
+		// the blob is never modified or removed, so the file of the spec can be replaced without breaking the references to the blob
+		if err := os.Link(filepath.Join(s.Dir, blob), path); err == nil {
+			return entry, nil
+		}
+	}
+	if err := os.WriteFile(path, stored, 0644); err != nil {
+		return entry, err
+	}
+	return entry, nil
+}
+
+// writeBlob creates the by-sha256 blob of a log, so the identical logs stored later only reference it.
+// False is returned if the blob wasn't created, which just disables the deduplication of the log.
+func writeBlob(path string, content []byte) bool {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return false
+	}
+	// O_EXCL keeps the first stored log as the blob when the specs run in parallel processes
+	blob, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return false
+	}
+	_, err = blob.Write(content)
+	if closeErr := blob.Close(); err != nil || closeErr != nil {
+		_ = os.Remove(path)
+		return false
+	}
+	return true
+}
+
+func storedSize(index map[string]ArtifactIndexEntry) int64 {
+	var size int64
+	for _, entry := range index {
+		size += entry.StoredSize
+	}
+	return size
+}
+
+func gzipContent(content []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	writer := gzip.NewWriter(buf)
+	if _, err := writer.Write(content); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadArtifactIndex returns the entries of the index.json manifest in the given spec artifact directory.
+func ReadArtifactIndex(specDir string) ([]ArtifactIndexEntry, error) {
+	content, err := os.ReadFile(filepath.Join(specDir, ArtifactIndexFile))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []ArtifactIndexEntry{}
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", ArtifactIndexFile, err)
+	}
+	return entries, nil
+}
+
+func readArtifactIndex(specDir string) (map[string]ArtifactIndexEntry, error) {
+	index := map[string]ArtifactIndexEntry{}
+	entries, err := ReadArtifactIndex(specDir)
+	if os.IsNotExist(err) {
+		return index, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		index[entry.Name] = entry
+	}
+	return index, nil
+}
+
+func writeArtifactIndex(specDir string, index map[string]ArtifactIndexEntry) error {
+	entries := make([]ArtifactIndexEntry, 0, len(index))
+	for _, entry := range index {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	content, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(specDir, ArtifactIndexFile), content, 0644)
+}
@@ -0,0 +1,116 @@
+package logs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readIndex(t *testing.T, dir, spec string) map[string]ArtifactIndexEntry {
+	index, err := readArtifactIndex(filepath.Join(dir, spec))
+	assert.NoError(t, err)
+	return index
+}
+
+func TestLocalArtifactStoreCompressesLargeLogs(t *testing.T) {
+	store := &LocalArtifactStore{Dir: t.TempDir(), CompressThreshold: 100}
+	largeLog := []byte(strings.Repeat("log line\n", 100))
+
+	assert.NoError(t, store.Store("spec", map[string][]byte{"pod.log": largeLog, "pod.yaml": largeLog}))
+
+	index := readIndex(t, store.Dir, "spec")
+	assert.True(t, index["pod.log"].Compressed)
+	assert.Equal(t, "pod.log.gz", index["pod.log"].File)
+	assert.False(t, index["pod.yaml"].Compressed)
+
+	compressed, err := os.Open(filepath.Join(store.Dir, "spec", "pod.log.gz"))
+	assert.NoError(t, err)
+	defer compressed.Close()
+	reader, err := gzip.NewReader(compressed)
+	assert.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, largeLog, content)
+}
+
+func TestLocalArtifactStoreDeduplicatesLogs(t *testing.T) {
+	store := &LocalArtifactStore{Dir: t.TempDir()}
+	podLog := []byte("the same controller log")
+
+	assert.NoError(t, store.Store("first-spec", map[string][]byte{"pod.log": podLog}))
+	assert.NoError(t, store.Store("second-spec", map[string][]byte{"pod.log": podLog, "pod.yaml": podLog}))
+
+	index := readIndex(t, store.Dir, "second-spec")
+	assert.Equal(t, "by-sha256/"+readIndex(t, store.Dir, "first-spec")["pod.log"].SHA256, index["pod.log"].DuplicateOf)
+	// the spec directory has a hard link of the blob, e.g. for Report Portal collecting the spec directories
+	assert.Equal(t, "pod.log", index["pod.log"].File)
+	assert.Zero(t, index["pod.log"].StoredSize)
+	content, err := os.ReadFile(filepath.Join(store.Dir, "second-spec", "pod.log"))
+	assert.NoError(t, err)
+	assert.Equal(t, podLog, content)
+	blob, err := os.Stat(filepath.Join(store.Dir, index["pod.log"].DuplicateOf))
+	assert.NoError(t, err)
+	file, err := os.Stat(filepath.Join(store.Dir, "second-spec", "pod.log"))
+	assert.NoError(t, err)
+	assert.True(t, os.SameFile(blob, file))
+	// only logs are deduplicated
+	assert.Equal(t, "pod.yaml", index["pod.yaml"].File)
+}
+
+func TestLocalArtifactStoreQuota(t *testing.T) {
+	store := &LocalArtifactStore{Dir: t.TempDir(), SpecQuota: 100}
+
+	assert.NoError(t, store.Store("spec", map[string][]byte{
+		"small.yaml": bytes.Repeat([]byte("a"), 40),
+		"large.log":  bytes.Repeat([]byte("b"), 80),
+	}))
+	assert.NoError(t, store.Store("spec", map[string][]byte{"other.yaml": bytes.Repeat([]byte("c"), 50)}))
+
+	index := readIndex(t, store.Dir, "spec")
+	assert.Len(t, index, 3)
+	assert.Equal(t, "small.yaml", index["small.yaml"].File)
+	assert.Equal(t, "other.yaml", index["other.yaml"].File)
+	assert.NotEmpty(t, index["large.log"].Skipped)
+	assert.NoFileExists(t, filepath.Join(store.Dir, "spec", "large.log"))
+}
+
+func TestLocalArtifactStoreReplacesArtifact(t *testing.T) {
+	store := &LocalArtifactStore{Dir: t.TempDir(), SpecQuota: 100}
+
+	assert.NoError(t, store.Store("spec", map[string][]byte{"resource.yaml": bytes.Repeat([]byte("a"), 80)}))
+	assert.NoError(t, store.Store("spec", map[string][]byte{"resource.yaml": bytes.Repeat([]byte("b"), 90)}))
+
+	index := readIndex(t, store.Dir, "spec")
+	assert.Equal(t, int64(90), index["resource.yaml"].StoredSize)
+	content, err := os.ReadFile(filepath.Join(store.Dir, "spec", "resource.yaml"))
+	assert.NoError(t, err)
+	assert.Len(t, content, 90)
+}
+
+func TestLocalArtifactStoreKeepsDuplicatesOfReplacedLogs(t *testing.T) {
+	store := &LocalArtifactStore{Dir: t.TempDir()}
+	podLog := []byte("the same controller log")
+
+	assert.NoError(t, store.Store("first-spec", map[string][]byte{"pod.log": podLog}))
+	assert.NoError(t, store.Store("second-spec", map[string][]byte{"pod.log": podLog}))
+	// the first spec stores the log again with a different content
+	assert.NoError(t, store.Store("first-spec", map[string][]byte{"pod.log": []byte("a newer controller log")}))
+
+	content, err := os.ReadFile(filepath.Join(store.Dir, "first-spec", "pod.log"))
+	assert.NoError(t, err)
+	assert.Equal(t, "a newer controller log", string(content))
+
+	duplicate := readIndex(t, store.Dir, "second-spec")["pod.log"]
+	content, err = os.ReadFile(filepath.Join(store.Dir, duplicate.DuplicateOf))
+	assert.NoError(t, err)
+	assert.Equal(t, podLog, content)
+	content, err = os.ReadFile(filepath.Join(store.Dir, "second-spec", duplicate.File))
+	assert.NoError(t, err)
+	assert.Equal(t, podLog, content)
+}
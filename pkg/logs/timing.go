@@ -0,0 +1,94 @@
+package logs
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/redhat-appstudio/e2e-tests/pkg/utils"
+)
+
+// TimingFile is the name of the artifact with the timing of a spec
+const TimingFile = "timing.json"
+
+// StepTiming is the timing of a By() step of a spec. The durations are in nanoseconds, as in the Ginkgo JSON report.
+type StepTiming struct {
+	Text     string        `json:"text"`
+	Location string        `json:"location"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	// WaitDuration is the time spent in the WaitUntil functions called during the step
+	WaitDuration time.Duration `json:"waitDuration"`
+}
+
+// SpecTiming is the breakdown of the time spent by a spec into its By() steps and the calls of the WaitUntil functions.
+type SpecTiming struct {
+	Spec     string        `json:"spec"`
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+	Duration time.Duration `json:"duration"`
+	// WaitDuration is the total time spent in the WaitUntil functions
+	WaitDuration time.Duration `json:"waitDuration"`
+	Steps        []StepTiming  `json:"steps"`
+	Waits        []WaitTiming  `json:"waits"`
+}
+
+// GetSpecTiming computes the timing of the spec from its By() events and the report entries added by the WaitUntil functions.
+// The spec is considered running until now if it hasn't ended yet.
+func GetSpecTiming(spec types.SpecReport) SpecTiming {
+	timing := SpecTiming{Spec: spec.FullText(), Start: spec.StartTime, End: spec.EndTime, Steps: []StepTiming{}, Waits: SpecWaits(spec)}
+	if timing.End.IsZero() {
+		timing.End = time.Now()
+	}
+	timing.Duration = timing.End.Sub(timing.Start)
+
+	steps := spec.SpecEvents.WithType(types.SpecEventByStart)
+	ends := spec.SpecEvents.WithType(types.SpecEventByEnd)
+	for i, step := range steps {
+		stepTiming := StepTiming{Text: step.Message, Location: step.CodeLocation.String(), Start: step.TimelineLocation.Time}
+		// a step ends when its callback returns or, for the steps without a callback, when the next one starts
+		end := timing.End
+		if i+1 < len(steps) {
+			end = steps[i+1].TimelineLocation.Time
+		}
+		for _, byEnd := range ends {
+			if byEnd.CodeLocation == step.CodeLocation && byEnd.Message == step.Message && !byEnd.TimelineLocation.Time.Before(stepTiming.Start) {
+				end = byEnd.TimelineLocation.Time
+				break
+			}
+		}
+		stepTiming.Duration = end.Sub(stepTiming.Start)
+
+		for _, wait := range timing.Waits {
+			if !wait.Start.Before(stepTiming.Start) && wait.Start.Before(end) {
+				stepTiming.WaitDuration += wait.Duration
+			}
+		}
+		timing.Steps = append(timing.Steps, stepTiming)
+	}
+
+	for _, wait := range timing.Waits {
+		timing.WaitDuration += wait.Duration
+	}
+	return timing
+}
+
+// SpecWaits returns the calls of the WaitUntil functions made by the spec.
+func SpecWaits(spec types.SpecReport) []WaitTiming {
+	waits := []WaitTiming{}
+	for _, entry := range spec.ReportEntries {
+		if entry.Name != WaitReportEntryName {
+			continue
+		}
+		// the raw value is available only in the process which ran the spec, the others decode it from JSON
+		if wait, ok := entry.Value.GetRawValue().(WaitTiming); ok {
+			waits = append(waits, wait)
+			continue
+		}
+		wait := WaitTiming{}
+		if err := json.Unmarshal([]byte(entry.Value.AsJSON), &wait); err == nil {
+			waits = append(waits, wait)
+		}
+	}
+	return waits
+}
@@ -0,0 +1,52 @@
+package logs
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSpecTiming(t *testing.T) {
+	start := time.Date(2023, 8, 18, 1, 0, 0, 0, time.UTC)
+	at := func(minutes int) types.TimelineLocation {
+		return types.TimelineLocation{Time: start.Add(time.Duration(minutes) * time.Minute)}
+	}
+	buildLocation := types.CodeLocation{FileName: "rhtap_demo.go", LineNumber: 10}
+	releaseLocation := types.CodeLocation{FileName: "rhtap_demo.go", LineNumber: 20}
+
+	buildWait := utils.WaitTiming{Caller: "has.(*HasController).WaitForComponentPipelineToBeFinished", Start: start.Add(time.Minute), Duration: 20 * time.Minute}
+	releaseWait := utils.WaitTiming{Caller: "release.(*ReleaseController).WaitForReleaseToBeFinished", Start: start.Add(31 * time.Minute), Duration: 5 * time.Minute}
+	releaseWaitJSON, err := json.Marshal(releaseWait)
+	assert.NoError(t, err)
+
+	spec := types.SpecReport{
+		ContainerHierarchyTexts: []string{"[rhtap-demo-suite]"},
+		LeafNodeText:            "builds and releases the application",
+		StartTime:               start,
+		EndTime:                 start.Add(40 * time.Minute),
+		SpecEvents: types.SpecEvents{
+			{SpecEventType: types.SpecEventByStart, Message: "building the component", CodeLocation: buildLocation, TimelineLocation: at(0)},
+			{SpecEventType: types.SpecEventByEnd, Message: "building the component", CodeLocation: buildLocation, TimelineLocation: at(25)},
+			{SpecEventType: types.SpecEventByStart, Message: "releasing the application", CodeLocation: releaseLocation, TimelineLocation: at(30)},
+		},
+		ReportEntries: types.ReportEntries{
+			{Name: utils.WaitReportEntryName, Value: types.WrapEntryValue(buildWait)},
+			// the entries reported by the other parallel processes are decoded from JSON
+			{Name: utils.WaitReportEntryName, Value: types.ReportEntryValue{AsJSON: string(releaseWaitJSON)}},
+			{Name: "unrelated", Value: types.WrapEntryValue("value")},
+		},
+	}
+
+	timing := GetSpecTiming(spec)
+	assert.Equal(t, 40*time.Minute, timing.Duration)
+	assert.Equal(t, 25*time.Minute, timing.WaitDuration)
+	assert.Equal(t, []utils.WaitTiming{buildWait, releaseWait}, timing.Waits)
+	assert.Equal(t, []StepTiming{
+		{Text: "building the component", Location: buildLocation.String(), Start: start, Duration: 25 * time.Minute, WaitDuration: 20 * time.Minute},
+		{Text: "releasing the application", Location: releaseLocation.String(), Start: start.Add(30 * time.Minute), Duration: 10 * time.Minute, WaitDuration: 5 * time.Minute},
+	}, timing.Steps)
+}
@@ -1,29 +1,15 @@
 package logs
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
-	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/redhat-appstudio/e2e-tests/pkg/utils"
 	"sigs.k8s.io/yaml"
 )
 
-// createArtifactDirectory creates directory for storing artifacts of current spec.
-func createArtifactDirectory() (string, error) {
-	wd, _ := os.Getwd()
-	artifactDir := GetEnv("ARTIFACT_DIR", fmt.Sprintf("%s/tmp", wd))
-	classname := ShortenStringAddHash(CurrentSpecReport())
-	testLogsDir := fmt.Sprintf("%s/%s", artifactDir, classname)
-
-	if err := os.MkdirAll(testLogsDir, os.ModePerm); err != nil {
-		return "", err
-	}
-
-	return testLogsDir, nil
-}
-
 // StoreResourceYaml stores yaml of given resource.
 func StoreResourceYaml(resource any, name string) error {
 	resourceYaml, err := yaml.Marshal(resource)
@@ -38,32 +24,29 @@ func StoreResourceYaml(resource any, name string) error {
 	return StoreArtifacts(resources)
 }
 
-// StoreArtifacts stores given artifacts under artifact directory.
+// StoreArtifacts stores given artifacts of the current spec using the ArtifactStore returned by GetArtifactStore.
 func StoreArtifacts(artifacts map[string][]byte) error {
-	artifactsDirectory, err := createArtifactDirectory()
-	if err != nil {
-		return err
-	}
+	return GetArtifactStore().Store(ShortenStringAddHash(CurrentSpecReport()), artifacts)
+}
 
-	for artifact_name, artifact_value := range artifacts {
-		filePath := fmt.Sprintf("%s/%s", artifactsDirectory, artifact_name)
-		if err := os.WriteFile(filePath, []byte(artifact_value), 0644); err != nil {
-			return err
-		}
+// StoreWaitTimeoutError stores the last observed object and its events carried by a given WaitTimeoutError.
+// Other errors are ignored.
+func StoreWaitTimeoutError(err error) error {
+	timeoutErr := &WaitTimeoutError{}
+	if !errors.As(err, &timeoutErr) {
+		return nil
 	}
 
-	return nil
+	return StoreArtifacts(timeoutErr.Artifacts())
 }
 
+// StoreTestTiming stores the timing.json with the duration of the current spec, its By() steps and the time spent in the WaitUntil functions.
 func StoreTestTiming() error {
-	artifactsDirectory, err := createArtifactDirectory()
+	timing, err := json.MarshalIndent(GetSpecTiming(CurrentSpecReport()), "", "  ")
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to marshal test timing: %v", err)
 	}
-
-	testTime := "Test started at: " + CurrentSpecReport().StartTime.String() + "\nTest ended at: " + time.Now().String()
-	filePath := fmt.Sprintf("%s/test-timing", artifactsDirectory)
-	if err := os.WriteFile(filePath, []byte(testTime), 0644); err != nil {
+	if err := StoreArtifacts(map[string][]byte{TimingFile: timing}); err != nil {
 		return fmt.Errorf("failed to store test timing: %v", err)
 	}
 
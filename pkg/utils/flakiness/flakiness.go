@@ -0,0 +1,441 @@
+package flakiness
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/redhat-appstudio/e2e-tests/pkg/framework"
+)
+
+const (
+	// DefaultThreshold is the flip rate above which a spec is considered flaky
+	DefaultThreshold = 0.3
+	// DefaultMinRuns is the number of runs (not counting the skipped ones) needed before a spec can be quarantined
+	DefaultMinRuns = 5
+
+	junitTimestampLayout = "2006-01-02T15:04:05"
+	// the spec names longer than this are shortened by logs.ShortenStringAddHash to this length followed by " sha: <sha1 of the rest>"
+	shortenedNameLength = 100
+)
+
+// Status is the result of a spec in a single test run.
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+	// StatusPassedAfterRetry specs failed at first, but passed when retried
+	StatusPassedAfterRetry Status = "passed-after-retry"
+)
+
+// Category tells how a spec behaved across the test runs.
+type Category string
+
+const (
+	// CategoryStable specs passed in the latest run and don't flip between passing and failing often
+	CategoryStable Category = "stable"
+	// CategoryFlaky specs flip between passing and failing
+	CategoryFlaky Category = "flaky"
+	// CategoryNewFailure specs failed in the latest run for the first time
+	CategoryNewFailure Category = "new-failure"
+	// CategoryFailing specs failed in the latest runs consistently
+	CategoryFailing Category = "failing"
+)
+
+// Run holds the results of the specs of a single test run, read from a JUnit file generated by framework.GenerateCustomJUnitReport.
+type Run struct {
+	File  string
+	Time  time.Time
+	Specs []SpecResult
+}
+
+// SpecResult is the result of a spec in a single test run.
+type SpecResult struct {
+	// Classname is the suite of the spec and Name its text, as generated by logs.GetClassnameFromReport and logs.ShortenStringAddHash
+	Classname string
+	Name      string
+	Labels    []string
+	Status    Status
+}
+
+// SpecHistory summarizes the results of a spec across the test runs.
+type SpecHistory struct {
+	Classname string   `json:"classname"`
+	Name      string   `json:"name"`
+	Labels    []string `json:"labels,omitempty"`
+	// Statuses lists the results of the spec, oldest first
+	Statuses []Status `json:"statuses"`
+	// Passed counts the runs in which the spec passed, including those in which it passed after being retried
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+	Retried int `json:"retried"`
+	// Score is the flakiness score: the ratio of the consecutive runs (skipped runs are ignored) in which the spec flipped between passing and failing.
+	// A spec which passed after being retried counts as failing and then passing in that run.
+	Score float64 `json:"score"`
+	// FailureRate is the ratio of the runs, not counting the skipped ones, in which the spec failed
+	FailureRate float64  `json:"failureRate"`
+	Category    Category `json:"category"`
+}
+
+// Config configures the flakiness analysis.
+type Config struct {
+	// Threshold is the score from which a spec is considered flaky
+	Threshold float64
+	// MinRuns is the number of runs, not counting the skipped ones, needed before a spec is considered flaky
+	MinRuns int
+}
+
+// Report is the result of the flakiness analysis.
+type Report struct {
+	Runs   int           `json:"runs"`
+	Config Config        `json:"config"`
+	Specs  []SpecHistory `json:"specs"`
+}
+
+// QuarantinedSpec is a flaky spec which should be excluded from the test runs.
+type QuarantinedSpec struct {
+	Classname string   `json:"classname"`
+	Name      string   `json:"name"`
+	Labels    []string `json:"labels"`
+	Score     float64  `json:"score"`
+	// Skip is the regular expression matching the full text of the spec, as expected by the --skip flag of ginkgo
+	Skip string `json:"skip"`
+}
+
+// Quarantine lists the specs which should be excluded from the test runs.
+type Quarantine struct {
+	Specs []QuarantinedSpec `json:"specs"`
+	// Refused lists the flaky specs which can't be excluded without excluding other specs of the history too
+	Refused []QuarantinedSpec `json:"refused,omitempty"`
+}
+
+// ReadRuns reads the JUnit files in the directory (recursively), sorted by the start time of the test run.
+// The start time is taken from the timestamp of the first test suite, the modification time of the file is used if it's missing.
+func ReadRuns(dir string) ([]Run, error) {
+	runs := []Run{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".xml" {
+			return nil
+		}
+		run, err := ReadRun(path)
+		if err != nil {
+			return err
+		}
+		runs = append(runs, run)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].Time.Before(runs[j].Time)
+	})
+	return runs, nil
+}
+
+// ReadRun reads a single JUnit file.
+func ReadRun(path string) (Run, error) {
+	run := Run{File: path}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return run, err
+	}
+
+	report := framework.CustomJUnitTestSuites{}
+	if err := xml.Unmarshal(content, &report); err != nil {
+		return run, fmt.Errorf("failed to parse JUnit file %s: %v", path, err)
+	}
+
+	for _, suite := range report.TestSuites {
+		if t, err := time.Parse(junitTimestampLayout, suite.Timestamp); err == nil && run.Time.IsZero() {
+			run.Time = t
+		}
+		for _, testCase := range suite.TestCases {
+			run.Specs = append(run.Specs, SpecResult{
+				Classname: testCase.Classname,
+				Name:      testCase.Name,
+				Labels:    testCaseLabels(testCase),
+				Status:    testCaseStatus(testCase),
+			})
+		}
+	}
+
+	if run.Time.IsZero() {
+		info, err := os.Stat(path)
+		if err != nil {
+			return run, err
+		}
+		run.Time = info.ModTime()
+	}
+	return run, nil
+}
+
+func testCaseStatus(testCase framework.CustomJUnitTestCase) Status {
+	switch {
+	case testCase.Failure != nil || testCase.Error != nil:
+		return StatusFailed
+	case testCase.Skipped != nil:
+		return StatusSkipped
+	case len(testCase.FlakyFailures) > 0:
+		return StatusPassedAfterRetry
+	}
+	return StatusPassed
+}
+
+func testCaseLabels(testCase framework.CustomJUnitTestCase) []string {
+	if testCase.Properties == nil {
+		return nil
+	}
+	for _, property := range testCase.Properties.Properties {
+		if property.Name != "Labels" {
+			continue
+		}
+		labels := []string{}
+		for _, label := range strings.Split(strings.Trim(property.Value, "[]"), ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				labels = append(labels, label)
+			}
+		}
+		return labels
+	}
+	return nil
+}
+
+// Analyze computes the history of every spec across the runs, which have to be sorted from the oldest one.
+// The specs are sorted by their score, the flakiest first.
+func Analyze(runs []Run, config Config) *Report {
+	if config.Threshold <= 0 {
+		config.Threshold = DefaultThreshold
+	}
+	if config.MinRuns <= 0 {
+		config.MinRuns = DefaultMinRuns
+	}
+
+	type specKey struct{ classname, name string }
+	histories := map[specKey]*SpecHistory{}
+	keys := []specKey{}
+	for _, run := range runs {
+		for _, spec := range run.Specs {
+			key := specKey{spec.Classname, spec.Name}
+			history, ok := histories[key]
+			if !ok {
+				history = &SpecHistory{Classname: spec.Classname, Name: spec.Name}
+				histories[key] = history
+				keys = append(keys, key)
+			}
+			// the latest labels win, as the labels of the spec might have changed
+			if len(spec.Labels) > 0 {
+				history.Labels = spec.Labels
+			}
+			history.Statuses = append(history.Statuses, spec.Status)
+		}
+	}
+
+	report := &Report{Runs: len(runs), Config: config, Specs: make([]SpecHistory, 0, len(keys))}
+	for _, key := range keys {
+		history := histories[key]
+		history.analyze(config)
+		report.Specs = append(report.Specs, *history)
+	}
+	sort.SliceStable(report.Specs, func(i, j int) bool {
+		return report.Specs[i].Score > report.Specs[j].Score
+	})
+	return report
+}
+
+func (h *SpecHistory) analyze(config Config) {
+	executed := []Status{}
+	for _, status := range h.Statuses {
+		switch status {
+		case StatusPassed:
+			h.Passed++
+		case StatusFailed:
+			h.Failed++
+		case StatusSkipped:
+			h.Skipped++
+			continue
+		case StatusPassedAfterRetry:
+			h.Passed++
+			h.Retried++
+			executed = append(executed, StatusFailed, StatusPassed)
+			continue
+		}
+		executed = append(executed, status)
+	}
+
+	if len(executed) == 0 {
+		h.Category = CategoryStable
+		return
+	}
+	runs := h.Passed + h.Failed
+	flips := 0
+	for i := 1; i < len(executed); i++ {
+		if executed[i] != executed[i-1] {
+			flips++
+		}
+	}
+	if len(executed) > 1 {
+		h.Score = float64(flips) / float64(len(executed)-1)
+	}
+	h.FailureRate = float64(h.Failed) / float64(runs)
+
+	lastFailed := executed[len(executed)-1] == StatusFailed
+	switch {
+	case h.Failed == 0 && h.Retried == 0:
+		h.Category = CategoryStable
+	case h.Passed == 0:
+		h.Category = CategoryFailing
+	case lastFailed && h.Failed == 1 && h.Retried == 0:
+		h.Category = CategoryNewFailure
+	case h.Score >= config.Threshold && runs >= config.MinRuns:
+		h.Category = CategoryFlaky
+	case lastFailed:
+		h.Category = CategoryFailing
+	default:
+		h.Category = CategoryStable
+	}
+}
+
+// Quarantine returns the flaky specs. Each spec is excluded by its full text, the specs whose text can't be told apart
+// from the text of a spec which isn't flaky (e.g. their JUnit names were shortened to the same prefix) are refused.
+func (r *Report) Quarantine() Quarantine {
+	quarantine := Quarantine{Specs: []QuarantinedSpec{}}
+	for _, spec := range r.Specs {
+		if spec.Category != CategoryFlaky {
+			continue
+		}
+		quarantined := QuarantinedSpec{Classname: spec.Classname, Name: spec.Name, Labels: spec.Labels, Score: spec.Score, Skip: skipPattern(spec.Classname, spec.Name)}
+		if r.matchesOtherSpecs(quarantined) {
+			quarantine.Refused = append(quarantine.Refused, quarantined)
+			continue
+		}
+		quarantine.Specs = append(quarantine.Specs, quarantined)
+	}
+	return quarantine
+}
+
+// matchesOtherSpecs returns true if the skip pattern of the quarantined spec matches any spec of the history which isn't flaky.
+func (r *Report) matchesOtherSpecs(quarantined QuarantinedSpec) bool {
+	skip := regexp.MustCompile(quarantined.Skip)
+	for _, spec := range r.Specs {
+		if spec.Category == CategoryFlaky {
+			continue
+		}
+		// the suite description isn't in the JUnit report, only the space separating it from the text matters
+		if text, _ := specText(spec.Classname, spec.Name); skip.MatchString(" " + text) {
+			return true
+		}
+	}
+	return false
+}
+
+// specText returns the full text of the spec, as matched by the --skip flag of ginkgo, from its JUnit classname and name.
+// The name is the full text without the suite name, see logs.ShortenStringAddHash. If the name was shortened,
+// only the beginning of the text is known and false is returned.
+func specText(classname, name string) (string, bool) {
+	complete := true
+	if len(name) > shortenedNameLength && strings.HasPrefix(name[shortenedNameLength:], " sha: ") {
+		name = name[:shortenedNameLength]
+		complete = false
+	}
+	// "[ Build service E2E tests] test" -> "[build-service-suite Build service E2E tests] test"
+	if strings.HasPrefix(name, "[") {
+		name = "[" + classname + name[1:]
+	}
+	return name, complete
+}
+
+// skipPattern returns the regular expression matching the full text of the spec, or its beginning if the name was shortened.
+// Ginkgo matches the --skip flag against "<suite description> <spec text>", so the text is anchored at the space before it.
+func skipPattern(classname, name string) string {
+	text, complete := specText(classname, name)
+	if complete {
+		return " " + regexp.QuoteMeta(text) + "$"
+	}
+	return " " + regexp.QuoteMeta(text)
+}
+
+// WriteText writes a human readable summary of the report, listing the specs which aren't stable.
+func (r *Report) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "Flakiness of the specs in the last %d runs (flaky from the score %.2f and %d runs)\n\n", r.Runs, r.Config.Threshold, r.Config.MinRuns)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "CATEGORY\tSCORE\tPASSED\tFAILED\tSKIPPED\tRETRIED\tSUITE\tSPEC")
+	for _, spec := range r.Specs {
+		if spec.Category == CategoryStable {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%.2f\t%d\t%d\t%d\t%d\t%s\t%s\n", spec.Category, spec.Score, spec.Passed, spec.Failed, spec.Skipped, spec.Retried, spec.Classname, strings.TrimSpace(spec.Name))
+	}
+	return tw.Flush()
+}
+
+// WriteFiles writes the report into flakiness-report.json and flakiness-report.txt and the quarantine list into quarantine.json in the directory.
+func (r *Report) WriteFiles(dir string) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	if err := writeJSON(filepath.Join(dir, "flakiness-report.json"), r); err != nil {
+		return err
+	}
+	if err := writeJSON(filepath.Join(dir, "quarantine.json"), r.Quarantine()); err != nil {
+		return err
+	}
+
+	file, err := os.Create(filepath.Join(dir, "flakiness-report.txt"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return r.WriteText(file)
+}
+
+func writeJSON(path string, data any) error {
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// ReadQuarantine reads the quarantine list written by Report.WriteFiles.
+func ReadQuarantine(path string) (Quarantine, error) {
+	quarantine := Quarantine{}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return quarantine, err
+	}
+	if err := json.Unmarshal(content, &quarantine); err != nil {
+		return quarantine, fmt.Errorf("failed to parse quarantine list %s: %v", path, err)
+	}
+	return quarantine, nil
+}
+
+// SkipFlags returns the --skip flags of ginkgo excluding the quarantined specs.
+func (q Quarantine) SkipFlags() []string {
+	flags := []string{}
+	for _, spec := range q.Specs {
+		skip := spec.Skip
+		if skip == "" {
+			// an empty pattern would skip every spec
+			skip = skipPattern(spec.Classname, spec.Name)
+		}
+		flags = append(flags, "--skip="+skip)
+	}
+	return flags
+}
@@ -0,0 +1,124 @@
+package flakiness
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/redhat-appstudio/e2e-tests/pkg/framework"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeJUnitReport(t *testing.T, dir string, start time.Time, states map[string]types.SpecState) {
+	report := types.Report{SuiteDescription: "Red Hat App Studio E2E tests", StartTime: start}
+	for text, state := range states {
+		report.SpecReports = append(report.SpecReports, types.SpecReport{
+			ContainerHierarchyTexts:  []string{"[build-service-suite Build service E2E tests]"},
+			ContainerHierarchyLabels: [][]string{{"build", "HACBS"}},
+			LeafNodeType:             types.NodeTypeIt,
+			LeafNodeText:             text,
+			LeafNodeLabels:           []string{text},
+			State:                    state,
+		})
+	}
+	dst := filepath.Join(dir, fmt.Sprintf("%d", start.Unix()), "xunit.xml")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(dst), os.ModePerm))
+	assert.NoError(t, framework.GenerateCustomJUnitReport(report, dst))
+}
+
+func TestAnalyze(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2023, 8, 18, 0, 0, 0, 0, time.UTC)
+	statesPerRun := []map[string]types.SpecState{
+		{"stable": types.SpecStatePassed, "flaky": types.SpecStatePassed, "new": types.SpecStatePassed, "broken": types.SpecStateFailed},
+		{"stable": types.SpecStatePassed, "flaky": types.SpecStateFailed, "new": types.SpecStatePassed, "broken": types.SpecStateFailed},
+		{"stable": types.SpecStateSkipped, "flaky": types.SpecStatePassed, "new": types.SpecStatePassed, "broken": types.SpecStateFailed},
+		{"stable": types.SpecStatePassed, "flaky": types.SpecStateFailed, "new": types.SpecStatePassed, "broken": types.SpecStateFailed},
+		{"stable": types.SpecStatePassed, "flaky": types.SpecStatePassed, "new": types.SpecStateFailed, "broken": types.SpecStateFailed},
+	}
+	// the runs are written in reverse order to check they're sorted by their timestamp
+	for i := len(statesPerRun) - 1; i >= 0; i-- {
+		writeJUnitReport(t, dir, start.Add(time.Duration(i)*time.Hour), statesPerRun[i])
+	}
+
+	runs, err := ReadRuns(dir)
+	assert.NoError(t, err)
+	assert.Len(t, runs, 5)
+
+	report := Analyze(runs, Config{})
+	assert.Equal(t, 5, report.Runs)
+	categories := map[string]SpecHistory{}
+	for _, spec := range report.Specs {
+		assert.Equal(t, "build-service-suite", spec.Classname)
+		categories[spec.Labels[2]] = spec
+	}
+
+	assert.Equal(t, CategoryStable, categories["stable"].Category)
+	assert.Equal(t, 1, categories["stable"].Skipped)
+	assert.Equal(t, CategoryFlaky, categories["flaky"].Category)
+	assert.Equal(t, []Status{StatusPassed, StatusFailed, StatusPassed, StatusFailed, StatusPassed}, categories["flaky"].Statuses)
+	assert.Equal(t, 1.0, categories["flaky"].Score)
+	assert.Equal(t, CategoryNewFailure, categories["new"].Category)
+	assert.Equal(t, 0.25, categories["new"].Score)
+	assert.Equal(t, CategoryFailing, categories["broken"].Category)
+	assert.Equal(t, 1.0, categories["broken"].FailureRate)
+	assert.Equal(t, CategoryFlaky, report.Specs[0].Category)
+
+	quarantine := report.Quarantine()
+	assert.Len(t, quarantine.Specs, 1)
+	assert.Equal(t, []string{"build", "HACBS", "flaky"}, quarantine.Specs[0].Labels)
+	assert.Equal(t, ` \[build-service-suite Build service E2E tests\] flaky$`, quarantine.Specs[0].Skip)
+
+	assert.NoError(t, report.WriteFiles(dir))
+	read, err := ReadQuarantine(filepath.Join(dir, "quarantine.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, quarantine, read)
+}
+
+func TestQuarantineSkipsSpecsByText(t *testing.T) {
+	longText := strings.Repeat("x", 120)
+	report := &Report{Specs: []SpecHistory{
+		{Classname: "build-service-suite", Name: "[ Build service E2E tests] (flaky) spec", Labels: []string{"build", "slow"}, Category: CategoryFlaky, Score: 0.5},
+		{Classname: "build-service-suite", Name: "[ Build service E2E tests] stable spec", Labels: []string{"build", "slow"}, Category: CategoryStable},
+		{Classname: "spi-suite", Name: shortened("[ SPI tests] " + longText + "a"), Category: CategoryFlaky, Score: 0.4},
+		{Classname: "spi-suite", Name: shortened("[ SPI tests] " + longText + "b"), Category: CategoryStable},
+	}}
+
+	quarantine := report.Quarantine()
+	// the stable spec with the same labels isn't excluded
+	assert.Len(t, quarantine.Specs, 1)
+	assert.Equal(t, ` \[build-service-suite Build service E2E tests\] \(flaky\) spec$`, quarantine.Specs[0].Skip)
+	// the shortened name of the flaky spec matches the stable one too
+	assert.Len(t, quarantine.Refused, 1)
+	assert.Equal(t, "spi-suite", quarantine.Refused[0].Classname)
+
+	flags := quarantine.SkipFlags()
+	assert.Equal(t, []string{"--skip=" + quarantine.Specs[0].Skip}, flags)
+	// ginkgo matches the skip flags joined by "|" against the suite description and the spec text
+	skip := regexp.MustCompile(strings.Join(quarantine.SkipFlags(), "|")[len("--skip="):])
+	assert.True(t, skip.MatchString("Red Hat App Studio E2E tests [build-service-suite Build service E2E tests] (flaky) spec"))
+	assert.False(t, skip.MatchString("Red Hat App Studio E2E tests [build-service-suite Build service E2E tests] stable spec"))
+	assert.False(t, skip.MatchString("Red Hat App Studio E2E tests [build-service-suite Build service E2E tests] (flaky) spec 2"))
+
+	assert.Empty(t, Quarantine{}.SkipFlags())
+}
+
+func shortened(name string) string {
+	return name[:shortenedNameLength] + " sha: " + strings.Repeat("0", 40)
+}
+
+func TestAnalyzePassedAfterRetry(t *testing.T) {
+	history := SpecHistory{Statuses: []Status{StatusPassed, StatusPassedAfterRetry, StatusPassed, StatusPassedAfterRetry, StatusPassed}}
+	history.analyze(Config{Threshold: DefaultThreshold, MinRuns: DefaultMinRuns})
+
+	assert.Equal(t, 5, history.Passed)
+	assert.Equal(t, 2, history.Retried)
+	assert.Equal(t, 0.0, history.FailureRate)
+	assert.Equal(t, 4.0/6.0, history.Score)
+	assert.Equal(t, CategoryFlaky, history.Category)
+}
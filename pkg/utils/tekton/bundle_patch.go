@@ -0,0 +1,272 @@
+package tekton
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	remoteimg "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/redhat-appstudio/e2e-tests/pkg/constants"
+	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog"
+	"sigs.k8s.io/yaml"
+)
+
+// The types of the BundlePatch
+const (
+	// ReplaceTaskBundle makes the pipeline task reference the task from the Bundle, with the TaskName if it's set
+	ReplaceTaskBundle = "replace-task-bundle"
+	// ChangeStepImage changes the Image of the Step of the task referenced by the pipeline task and pushes the task into a new bundle
+	ChangeStepImage = "change-step-image"
+	// AddParam adds the Param with the Value to the pipeline task, or to the pipeline params with the Value as the default if Task is empty
+	AddParam = "add-param"
+	// RenamePipeline changes the name of the pipeline to the Name
+	RenamePipeline = "rename-pipeline"
+)
+
+// BundlePatch is a declarative change of a pipeline or of a task referenced by the pipeline.
+type BundlePatch struct {
+	// Type is one of ReplaceTaskBundle, ChangeStepImage, AddParam and RenamePipeline
+	Type string `json:"type"`
+	// Task is the name of the pipeline task or of the task it references, e.g. "build-container" or "buildah", only the
+	// first matching pipeline task is patched
+	Task string `json:"task,omitempty"`
+	// Bundle is the reference of the task bundle
+	Bundle string `json:"bundle,omitempty"`
+	// TaskName is the name of the task in the Bundle
+	TaskName string `json:"taskName,omitempty"`
+	Step     string `json:"step,omitempty"`
+	Image    string `json:"image,omitempty"`
+	Param    string `json:"param,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Name     string `json:"name,omitempty"`
+}
+
+// PipelineBundleOverride is a pipeline bundle built by patching one of the default build pipelines.
+type PipelineBundleOverride struct {
+	// Selector is the name of the build pipeline selector of the base pipeline, e.g. "Docker build"
+	Selector string `json:"selector"`
+	// Pipeline is the name of the pipeline in the base bundle, e.g. "docker-build"
+	Pipeline string        `json:"pipeline"`
+	Patches  []BundlePatch `json:"patches"`
+	// Env is the env var the reference of the patched bundle is exported to, e.g. CUSTOM_BUILDAH_REMOTE_PIPELINE_BUILD_BUNDLE
+	Env string `json:"env"`
+}
+
+// BundlePatcher builds pipeline bundles by applying patches to existing ones and pushes them.
+type BundlePatcher struct {
+	// Repository is the image repository the bundles are pushed to
+	Repository string
+	// Tag makes the tags of the pushed bundles unique: pipeline-bundle-<tag> and task-bundle-<task>-<tag>
+	Tag          string
+	RemoteOption remoteimg.Option
+
+	extract func(bundleRef, kind, name string) (runtime.Object, error)
+	push    func(yamlContent []byte, ref name.Reference, remoteOption remoteimg.Option) error
+}
+
+// NewBundlePatcher creates a patcher pushing the bundles into the repository with the given tag suffix.
+func NewBundlePatcher(repository, tag string, remoteOption remoteimg.Option) *BundlePatcher {
+	return &BundlePatcher{
+		Repository:   repository,
+		Tag:          tag,
+		RemoteOption: remoteOption,
+		extract:      ExtractTektonObjectFromBundle,
+		push:         BuildAndPushTektonBundle,
+	}
+}
+
+// LoadPipelineBundleOverrides reads a YAML list of the pipeline bundle overrides.
+func LoadPipelineBundleOverrides(path string) ([]PipelineBundleOverride, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	overrides := []PipelineBundleOverride{}
+	if err := yaml.Unmarshal(content, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse the pipeline bundle overrides %s: %v", path, err)
+	}
+	return overrides, nil
+}
+
+// PipelineRef returns the reference the patched pipeline is pushed to.
+func (p *BundlePatcher) PipelineRef() (name.Reference, error) {
+	return name.ParseReference(fmt.Sprintf("%s:pipeline-bundle-%s", p.Repository, p.Tag))
+}
+
+func (p *BundlePatcher) taskRef(taskName string) (name.Reference, error) {
+	return name.ParseReference(fmt.Sprintf("%s:task-bundle-%s-%s", p.Repository, taskName, p.Tag))
+}
+
+// Override patches the default build pipeline of the override, pushes it and sets the env var of the override
+// to the reference of the pushed bundle.
+func (p *BundlePatcher) Override(override PipelineBundleOverride) (string, error) {
+	baseBundleRef, err := GetDefaultPipelineBundleRef(constants.BuildPipelineSelectorYamlURL, override.Selector)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the pipeline bundle ref: %+v", err)
+	}
+	ref, err := p.Patch(baseBundleRef, override.Pipeline, override.Patches)
+	if err != nil {
+		return "", err
+	}
+	if override.Env != "" {
+		os.Setenv(override.Env, ref.String())
+	}
+	return ref.String(), nil
+}
+
+// Patch applies the patches to the pipeline from the base bundle and pushes the patched pipeline, returning its reference.
+func (p *BundlePatcher) Patch(baseBundleRef, pipelineName string, patches []BundlePatch) (name.Reference, error) {
+	obj, err := p.extract(baseBundleRef, "pipeline", pipelineName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract the Tekton Pipeline from bundle: %+v", err)
+	}
+	pipelineObj, ok := obj.(*pipeline.Pipeline)
+	if !ok {
+		return nil, fmt.Errorf("the object %s in the bundle %s is not a v1 Pipeline", pipelineName, baseBundleRef)
+	}
+
+	for _, patch := range patches {
+		if err := p.apply(pipelineObj, patch); err != nil {
+			return nil, fmt.Errorf("failed to apply the %s patch of the pipeline %s: %v", patch.Type, pipelineName, err)
+		}
+	}
+
+	pipelineYaml, err := yaml.Marshal(pipelineObj)
+	if err != nil {
+		return nil, fmt.Errorf("error when marshalling a new pipeline to YAML: %v", err)
+	}
+	ref, err := p.PipelineRef()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.push(pipelineYaml, ref, p.RemoteOption); err != nil {
+		return nil, fmt.Errorf("error when building/pushing a tekton pipeline bundle: %v", err)
+	}
+	return ref, nil
+}
+
+func (p *BundlePatcher) apply(pipelineObj *pipeline.Pipeline, patch BundlePatch) error {
+	switch patch.Type {
+	case RenamePipeline:
+		if patch.Name == "" {
+			return fmt.Errorf("the name is not set")
+		}
+		pipelineObj.Name = patch.Name
+		return nil
+	case AddParam:
+		if patch.Param == "" {
+			return fmt.Errorf("the param is not set")
+		}
+		if patch.Task == "" {
+			pipelineObj.Spec.Params = append(pipelineObj.Spec.Params, pipeline.ParamSpec{Name: patch.Param, Default: pipeline.NewStructuredValues(patch.Value)})
+			return nil
+		}
+	case ReplaceTaskBundle:
+		if patch.Bundle == "" {
+			return fmt.Errorf("the bundle is not set")
+		}
+	case ChangeStepImage:
+		if patch.Step == "" || patch.Image == "" {
+			return fmt.Errorf("the step or the image is not set")
+		}
+	default:
+		return fmt.Errorf("unknown patch type %q", patch.Type)
+	}
+
+	task := matchingPipelineTask(pipelineObj, patch.Task)
+	if task == nil {
+		return fmt.Errorf("no task %s found in the pipeline %s", patch.Task, pipelineObj.Name)
+	}
+	switch patch.Type {
+	case AddParam:
+		task.Params = append(task.Params, pipeline.Param{Name: patch.Param, Value: *pipeline.NewStructuredValues(patch.Value)})
+	case ReplaceTaskBundle:
+		setTaskRefParam(task, "bundle", patch.Bundle)
+		if patch.TaskName != "" {
+			setTaskRefParam(task, "name", patch.TaskName)
+		}
+		klog.Infof("pipeline task %s of the pipeline %s references the task %s from %s", task.Name, pipelineObj.Name, taskRefParam(task, "name"), patch.Bundle)
+	case ChangeStepImage:
+		return p.changeStepImage(task, patch)
+	}
+	return nil
+}
+
+// changeStepImage pushes the task referenced by the pipeline task with the image of the step changed into a new bundle
+// and makes the pipeline task reference it.
+func (p *BundlePatcher) changeStepImage(task *pipeline.PipelineTask, patch BundlePatch) error {
+	taskName, bundleRef := taskRefParam(task, "name"), taskRefParam(task, "bundle")
+	klog.Infof("found current task ref %s", bundleRef)
+	obj, err := p.extract(bundleRef, "task", taskName)
+	if err != nil {
+		return fmt.Errorf("failed to extract the Tekton Task from bundle: %+v", err)
+	}
+	taskObj, ok := obj.(*pipeline.Task)
+	if !ok {
+		return fmt.Errorf("the object %s in the bundle %s is not a v1 Task", taskName, bundleRef)
+	}
+
+	changed := false
+	for i := range taskObj.Spec.Steps {
+		if taskObj.Spec.Steps[i].Name == patch.Step {
+			taskObj.Spec.Steps[i].Image = patch.Image
+			changed = true
+		}
+	}
+	if !changed {
+		return fmt.Errorf("no step %s found in the task %s", patch.Step, taskName)
+	}
+
+	taskYaml, err := yaml.Marshal(taskObj)
+	if err != nil {
+		return fmt.Errorf("error when marshalling a new task to YAML: %v", err)
+	}
+	ref, err := p.taskRef(taskName)
+	if err != nil {
+		return err
+	}
+	if err := p.push(taskYaml, ref, p.RemoteOption); err != nil {
+		return fmt.Errorf("error when building/pushing a tekton task bundle: %v", err)
+	}
+	setTaskRefParam(task, "bundle", ref.String())
+	return nil
+}
+
+// matchingPipelineTask returns the first pipeline task with the given name or referencing the task with the given name,
+// only the first one is patched (e.g. the build task of a pipeline which runs the same task twice), nil if there's none
+func matchingPipelineTask(pipelineObj *pipeline.Pipeline, name string) *pipeline.PipelineTask {
+	for i := range pipelineObj.Spec.Tasks {
+		task := &pipelineObj.Spec.Tasks[i]
+		if task.Name == name || taskRefParam(task, "name") == name {
+			return task
+		}
+	}
+	return nil
+}
+
+func taskRefParam(task *pipeline.PipelineTask, param string) string {
+	if task.TaskRef == nil {
+		return ""
+	}
+	for _, p := range task.TaskRef.Params {
+		if p.Name == param {
+			return p.Value.StringVal
+		}
+	}
+	return ""
+}
+
+func setTaskRefParam(task *pipeline.PipelineTask, param, value string) {
+	if task.TaskRef == nil {
+		task.TaskRef = &pipeline.TaskRef{ResolverRef: pipeline.ResolverRef{Resolver: "bundles"}}
+	}
+	for i := range task.TaskRef.Params {
+		if task.TaskRef.Params[i].Name == param {
+			task.TaskRef.Params[i].Value = *pipeline.NewStructuredValues(value)
+			return
+		}
+	}
+	task.TaskRef.Params = append(task.TaskRef.Params, pipeline.Param{Name: param, Value: *pipeline.NewStructuredValues(value)})
+}
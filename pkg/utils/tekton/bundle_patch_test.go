@@ -0,0 +1,133 @@
+package tekton
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	remoteimg "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+func bundleTask(pipelineTaskName, taskName, bundle string) pipeline.PipelineTask {
+	return pipeline.PipelineTask{
+		Name: pipelineTaskName,
+		TaskRef: &pipeline.TaskRef{ResolverRef: pipeline.ResolverRef{Resolver: "bundles", Params: pipeline.Params{
+			{Name: "name", Value: *pipeline.NewStructuredValues(taskName)},
+			{Name: "bundle", Value: *pipeline.NewStructuredValues(bundle)},
+			{Name: "kind", Value: *pipeline.NewStructuredValues("task")},
+		}}},
+	}
+}
+
+func newFakeBundlePatcher(objects map[string]runtime.Object, pushed map[string][]byte) *BundlePatcher {
+	patcher := NewBundlePatcher("quay.io/redhat-appstudio-qe/test-images", "1692321486-abcd", nil)
+	patcher.extract = func(bundleRef, kind, name string) (runtime.Object, error) {
+		if obj, ok := objects[bundleRef+"/"+name]; ok {
+			return obj.DeepCopyObject(), nil
+		}
+		return nil, fmt.Errorf("%s %s not found in %s", kind, name, bundleRef)
+	}
+	patcher.push = func(yamlContent []byte, ref name.Reference, _ remoteimg.Option) error {
+		pushed[ref.String()] = yamlContent
+		return nil
+	}
+	return patcher
+}
+
+func TestBundlePatcherMultiPlatformPipeline(t *testing.T) {
+	base := "quay.io/redhat-appstudio-tekton-catalog/pipeline-docker-build:devel"
+	dockerBuild := &pipeline.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "docker-build"},
+		Spec: pipeline.PipelineSpec{Tasks: []pipeline.PipelineTask{
+			bundleTask("init", "init", "quay.io/redhat-appstudio-tekton-catalog/task-init:0.1"),
+			bundleTask("build-container", "buildah", "quay.io/redhat-appstudio-tekton-catalog/task-buildah:0.1"),
+			bundleTask("build-source-image", "buildah", "quay.io/redhat-appstudio-tekton-catalog/task-buildah:0.1"),
+		}},
+	}
+	pushed := map[string][]byte{}
+	patcher := newFakeBundlePatcher(map[string]runtime.Object{base + "/docker-build": dockerBuild}, pushed)
+
+	ref, err := patcher.Patch(base, "docker-build", []BundlePatch{
+		{Type: ReplaceTaskBundle, Task: "buildah", Bundle: "quay.io/redhat-appstudio-tekton-catalog/task-buildah-remote:0.1", TaskName: "buildah-remote"},
+		{Type: AddParam, Task: "buildah-remote", Param: "PLATFORM", Value: "$(params.PLATFORM)"},
+		{Type: AddParam, Param: "PLATFORM", Value: "linux/arm64"},
+		{Type: RenamePipeline, Name: "buildah-remote-pipeline"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "quay.io/redhat-appstudio-qe/test-images:pipeline-bundle-1692321486-abcd", ref.String())
+
+	patched := &pipeline.Pipeline{}
+	assert.NoError(t, yaml.Unmarshal(pushed[ref.String()], patched))
+	assert.Equal(t, "buildah-remote-pipeline", patched.Name)
+	assert.Equal(t, "linux/arm64", patched.Spec.Params[0].Default.StringVal)
+	buildTask := &patched.Spec.Tasks[1]
+	assert.Equal(t, "buildah-remote", taskRefParam(buildTask, "name"))
+	assert.Equal(t, "quay.io/redhat-appstudio-tekton-catalog/task-buildah-remote:0.1", taskRefParam(buildTask, "bundle"))
+	assert.Equal(t, pipeline.Params{{Name: "PLATFORM", Value: *pipeline.NewStructuredValues("$(params.PLATFORM)")}}, buildTask.Params)
+	// the other tasks are kept as they are, only the first task matching a patch is patched
+	assert.Equal(t, dockerBuild.Spec.Tasks[0], patched.Spec.Tasks[0])
+	assert.Equal(t, dockerBuild.Spec.Tasks[2], patched.Spec.Tasks[2])
+}
+
+func TestBundlePatcherChangeStepImage(t *testing.T) {
+	base := "quay.io/redhat-appstudio-tekton-catalog/pipeline-java-builder:devel"
+	s2iBundle := "quay.io/redhat-appstudio-tekton-catalog/task-s2i-java:0.1"
+	javaBuilder := &pipeline.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "java-builder"},
+		Spec: pipeline.PipelineSpec{Tasks: []pipeline.PipelineTask{
+			bundleTask("build-container", "s2i-java", s2iBundle),
+			bundleTask("build-container-copy", "s2i-java", s2iBundle),
+		}},
+	}
+	s2iJava := &pipeline.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "s2i-java"},
+		Spec: pipeline.TaskSpec{Steps: []pipeline.Step{
+			{Name: "build", Image: "registry.access.redhat.com/ubi9/buildah"},
+			{Name: "analyse-dependencies-java-sbom", Image: "quay.io/redhat-appstudio/hacbs-jvm-build-request-processor:old"},
+		}},
+	}
+	pushed := map[string][]byte{}
+	patcher := newFakeBundlePatcher(map[string]runtime.Object{base + "/java-builder": javaBuilder, s2iBundle + "/s2i-java": s2iJava}, pushed)
+
+	ref, err := patcher.Patch(base, "java-builder", []BundlePatch{
+		{Type: ChangeStepImage, Task: "s2i-java", Step: "analyse-dependencies-java-sbom", Image: "quay.io/redhat-appstudio/hacbs-jvm-build-request-processor:pr-123"},
+	})
+	assert.NoError(t, err)
+
+	taskRef := "quay.io/redhat-appstudio-qe/test-images:task-bundle-s2i-java-1692321486-abcd"
+	patchedTask := &pipeline.Task{}
+	assert.NoError(t, yaml.Unmarshal(pushed[taskRef], patchedTask))
+	assert.Equal(t, "registry.access.redhat.com/ubi9/buildah", patchedTask.Spec.Steps[0].Image)
+	assert.Equal(t, "quay.io/redhat-appstudio/hacbs-jvm-build-request-processor:pr-123", patchedTask.Spec.Steps[1].Image)
+
+	patched := &pipeline.Pipeline{}
+	assert.NoError(t, yaml.Unmarshal(pushed[ref.String()], patched))
+	assert.Equal(t, taskRef, taskRefParam(&patched.Spec.Tasks[0], "bundle"))
+	assert.Equal(t, s2iBundle, taskRefParam(&patched.Spec.Tasks[1], "bundle"))
+}
+
+func TestBundlePatcherInvalidPatches(t *testing.T) {
+	base := "quay.io/redhat-appstudio-tekton-catalog/pipeline-docker-build:devel"
+	dockerBuild := &pipeline.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "docker-build"},
+		Spec:       pipeline.PipelineSpec{Tasks: []pipeline.PipelineTask{bundleTask("build-container", "buildah", "quay.io/redhat-appstudio-tekton-catalog/task-buildah:0.1")}},
+	}
+	for _, patch := range []BundlePatch{
+		{Type: "remove-task", Task: "buildah"},
+		{Type: ReplaceTaskBundle, Task: "kaniko", Bundle: "quay.io/redhat-appstudio-tekton-catalog/task-kaniko:0.1"},
+		{Type: ReplaceTaskBundle, Task: "buildah"},
+		{Type: ChangeStepImage, Task: "buildah", Step: "unknown-step", Image: "quay.io/image:tag"},
+		{Type: RenamePipeline},
+	} {
+		pushed := map[string][]byte{}
+		patcher := newFakeBundlePatcher(map[string]runtime.Object{base + "/docker-build": dockerBuild}, pushed)
+		_, err := patcher.Patch(base, "docker-build", []BundlePatch{patch})
+		assert.Error(t, err, "patch %+v", patch)
+		assert.Empty(t, pushed, "nothing should be pushed when a patch fails")
+	}
+}
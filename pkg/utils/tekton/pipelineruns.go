@@ -46,6 +46,14 @@ type FailedPipelineRunDetails struct {
 	FailedContainerName string
 }
 
+// PipelineRunState returns the reason of the Succeeded condition of a given PipelineRun, or "Pending" if it isn't set yet.
+func PipelineRunState(pr *pipeline.PipelineRun) string {
+	if reason := pr.GetStatusCondition().GetCondition(apis.ConditionSucceeded).GetReason(); reason != "" {
+		return reason
+	}
+	return "Pending"
+}
+
 // This is a demo pipeline to create test image and task signing
 func (b BuildahDemo) Generate() (*pipeline.PipelineRun, error) {
 	return &pipeline.PipelineRun{
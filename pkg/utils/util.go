@@ -125,13 +125,47 @@ func GetGeneratedNamespace(name string) string {
 }
 
 func WaitUntilWithInterval(cond wait.ConditionFunc, interval time.Duration, timeout time.Duration) error {
-	return wait.PollUntilContextTimeout(context.Background(), interval, timeout, true, func(ctx context.Context) (bool, error) { return cond() })
+	return WaitUntilWithIntervalAndContext(context.Background(), cond, interval, timeout)
 }
 
 func WaitUntil(cond wait.ConditionFunc, timeout time.Duration) error {
 	return WaitUntilWithInterval(cond, time.Second, timeout)
 }
 
+// WaitUntilWithIntervalAndContext polls the given condition until it is met, the timeout expires or the context is cancelled.
+// Passing a Ginkgo SpecContext makes the wait stop as soon as the spec is interrupted or times out.
+// If the condition isn't met in time, a *WaitTimeoutError is returned. Use WaitUntilTargetWithContext
+// to tell the error what was awaited and what was observed.
+// The time spent waiting is recorded as a report entry of the current spec, see WaitTiming.
+func WaitUntilWithIntervalAndContext(ctx context.Context, cond wait.ConditionFunc, interval time.Duration, timeout time.Duration) error {
+	return WaitUntilTargetWithContext(ctx, WaitTarget{Description: "the condition"}, cond, interval, timeout)
+}
+
+// WaitUntilTargetWithContext is like WaitUntilWithIntervalAndContext but the *WaitTimeoutError returned
+// when the condition isn't met in time describes the given target and the object it observed last.
+func WaitUntilTargetWithContext(ctx context.Context, target WaitTarget, cond wait.ConditionFunc, interval time.Duration, timeout time.Duration) error {
+	start := time.Now()
+	polls := 0
+	err := wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		polls++
+		return cond()
+	})
+	if err != nil && wait.Interrupted(err) {
+		timeoutErr := &WaitTimeoutError{Target: target.Description, Elapsed: time.Since(start), Polls: polls, Err: err}
+		if target.LastObserved != nil {
+			timeoutErr.LastObserved = target.LastObserved()
+		}
+		err = timeoutErr
+	}
+	RecordWait(start, polls, err)
+	return err
+}
+
+// WaitUntilWithContext is like WaitUntil but it stops as soon as the given context is cancelled.
+func WaitUntilWithContext(ctx context.Context, cond wait.ConditionFunc, timeout time.Duration) error {
+	return WaitUntilWithIntervalAndContext(ctx, cond, time.Second, timeout)
+}
+
 func ExecuteCommandInASpecificDirectory(command string, args []string, directory string) error {
 	cmd := exec.Command(command, args...) // nolint:gosec
 	cmd.Dir = directory
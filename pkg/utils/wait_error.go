@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// WaitTimeoutError is returned by the wait helpers when the awaited condition isn't met in time.
+// Besides the number of polls it carries the last observed object with its conditions and related Events
+// (when the helper knows which object it waits for), so the failure message explains why the wait timed out.
+type WaitTimeoutError struct {
+	// Target describes what was awaited, e.g. "pipelineruns my-tenant/my-pipelinerun"
+	Target string
+	// Elapsed is the time spent waiting
+	Elapsed time.Duration
+	// Polls is the number of times the condition was checked
+	Polls int
+	// LastObserved is the last object the condition was checked for, nil if none was observed
+	LastObserved *unstructured.Unstructured
+	// Transitions lists the observed state changes of the awaited objects
+	Transitions []string
+	// Events are the Kubernetes Events related to the last observed object
+	Events []corev1.Event
+	// Err is the error which stopped the wait (context.DeadlineExceeded or context.Canceled)
+	Err error
+}
+
+// WaitTarget describes what is awaited by WaitUntilTargetWithContext.
+type WaitTarget struct {
+	// Description of the awaited state, e.g. "components my-tenant/my-component to be deleted"
+	Description string
+	// LastObserved returns the last object the condition was checked for, or nil if there is none. It is called only when the wait times out.
+	LastObserved func() *unstructured.Unstructured
+}
+
+// ObservedObject converts an object seen by a wait condition so it can be returned by WaitTarget.LastObserved.
+// The kind is set explicitly since the typed objects returned by the clients usually don't have it.
+// Nil is returned if the object isn't a non-nil pointer or can't be converted.
+func ObservedObject(kind string, obj interface{}) *unstructured.Unstructured {
+	if v := reflect.ValueOf(obj); v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetKind(kind)
+	return u
+}
+
+func (e *WaitTimeoutError) Error() string {
+	b := &strings.Builder{}
+	if errors.Is(e.Err, context.Canceled) {
+		fmt.Fprintf(b, "waiting for %s was cancelled after %s", e.Target, e.Elapsed.Round(time.Second))
+	} else {
+		fmt.Fprintf(b, "timed out after %s waiting for %s", e.Elapsed.Round(time.Second), e.Target)
+	}
+	fmt.Fprintf(b, " (condition checked %d times)", e.Polls)
+
+	if e.LastObserved == nil {
+		if e.Polls == 0 {
+			b.WriteString("; no matching object was observed")
+		}
+		return b.String()
+	}
+
+	fmt.Fprintf(b, "\nlast observed %s %s/%s (resourceVersion %s)", e.LastObserved.GetKind(), e.LastObserved.GetNamespace(), e.LastObserved.GetName(), e.LastObserved.GetResourceVersion())
+	if conditions := e.conditions(); len(conditions) > 0 {
+		b.WriteString("\nconditions:")
+		for _, c := range conditions {
+			fmt.Fprintf(b, "\n  %s", c)
+		}
+	}
+	if len(e.Transitions) > 0 {
+		b.WriteString("\nstate transitions:")
+		for _, t := range e.Transitions {
+			fmt.Fprintf(b, "\n  %s", t)
+		}
+	}
+	if len(e.Events) > 0 {
+		b.WriteString("\nevents:")
+		for _, event := range e.Events {
+			fmt.Fprintf(b, "\n  %s %s %s (x%d): %s", event.LastTimestamp.Format(time.RFC3339), event.Type, event.Reason, event.Count, event.Message)
+		}
+	}
+	return b.String()
+}
+
+func (e *WaitTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Artifacts returns the last observed object and its Events as YAML files, which can be stored with logs.StoreArtifacts.
+func (e *WaitTimeoutError) Artifacts() map[string][]byte {
+	artifacts := map[string][]byte{}
+	if e.LastObserved == nil {
+		return artifacts
+	}
+	prefix := fmt.Sprintf("wait-timeout-%s-%s", strings.ToLower(e.LastObserved.GetKind()), e.LastObserved.GetName())
+	if objectYaml, err := yaml.Marshal(e.LastObserved.Object); err == nil {
+		artifacts[prefix+".yaml"] = objectYaml
+	}
+	if len(e.Events) > 0 {
+		if eventsYaml, err := yaml.Marshal(e.Events); err == nil {
+			artifacts[prefix+"-events.yaml"] = eventsYaml
+		}
+	}
+	return artifacts
+}
+
+// conditions returns the status conditions of the last observed object formatted as "Type=Status (Reason): Message".
+// Both metav1.Condition and knative (Tekton) conditions are supported, as they share these fields.
+func (e *WaitTimeoutError) conditions() []string {
+	conditions, _, _ := unstructured.NestedSlice(e.LastObserved.Object, "status", "conditions")
+	formatted := []string{}
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _, _ := unstructured.NestedString(condition, "type")
+		status, _, _ := unstructured.NestedString(condition, "status")
+		reason, _, _ := unstructured.NestedString(condition, "reason")
+		message, _, _ := unstructured.NestedString(condition, "message")
+		formatted = append(formatted, fmt.Sprintf("%s=%s (%s): %s", conditionType, status, reason, message))
+	}
+	return formatted
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// WaitReportEntryName is the name of the Ginkgo report entries recording the time spent in the WaitUntil functions and watcher.Watcher.Until.
+const WaitReportEntryName = "WaitUntil"
+
+// WaitTiming records a single call of the WaitUntil functions or watcher.Watcher.Until.
+type WaitTiming struct {
+	// Caller is the function which waited, e.g. "has.(*HasController).WaitForComponentPipelineToBeFinished"
+	Caller string `json:"caller"`
+	// Location is the file and line of the call
+	Location string        `json:"location"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	Polls    int           `json:"polls"`
+	// Error is the reason why the wait failed, empty if the condition was met
+	Error string `json:"error,omitempty"`
+}
+
+func (t WaitTiming) String() string {
+	return fmt.Sprintf("%s waited %s (%d polls)", t.Caller, t.Duration.Round(time.Millisecond), t.Polls)
+}
+
+// RecordWait adds a report entry with the timing of the wait to the current spec. Nothing is recorded outside of a running spec,
+// e.g. when the WaitUntil functions are used by the mage targets.
+func RecordWait(start time.Time, polls int, err error) {
+	if CurrentSpecReport().LeafNodeType == types.NodeTypeInvalid {
+		return
+	}
+
+	timing := WaitTiming{Start: start, Duration: time.Since(start), Polls: polls}
+	timing.Caller, timing.Location = waitCaller()
+	if err != nil {
+		timing.Error = err.Error()
+	}
+	AddReportEntry(WaitReportEntryName, timing, ReportEntryVisibilityNever)
+}
+
+// waitCaller returns the first function on the stack outside of the WaitUntil functions and the Watcher methods.
+func waitCaller() (string, string) {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		name := frame.Function
+		if !strings.HasPrefix(name, "github.com/redhat-appstudio/e2e-tests/pkg/utils.WaitUntil") && !strings.HasPrefix(name, "github.com/redhat-appstudio/e2e-tests/pkg/utils/watcher.(*Watcher[") {
+			// "github.com/redhat-appstudio/e2e-tests/pkg/clients/has.(*HasController).Wait..." -> "has.(*HasController).Wait..."
+			return name[strings.LastIndex(name, "/")+1:], fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return "", ""
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+// DefaultPollInterval is used when the watch can't be established and the Watcher falls back to listing the objects.
+const DefaultPollInterval = 5 * time.Second
+
+// Transition is a change of the state of an observed object, as returned by Watcher.State.
+type Transition struct {
+	Time time.Time
+	Name string
+	From string
+	To   string
+}
+
+func (t Transition) String() string {
+	return fmt.Sprintf("%s %s: %q -> %q", t.Time.Format(time.RFC3339), t.Name, t.From, t.To)
+}
+
+// ConditionsState describes the given conditions as a state, so it can be used by the State functions
+// of the resources reporting their status via metav1.Condition.
+func ConditionsState(conditions []metav1.Condition) string {
+	states := []string{}
+	for _, condition := range conditions {
+		states = append(states, fmt.Sprintf("%s=%s (%s)", condition.Type, condition.Status, condition.Reason))
+	}
+	return strings.Join(states, ", ")
+}
+
+// Watcher waits until an object of a given resource meets a condition.
+// Instead of fetching the object every few seconds it lists the matching objects once and then watches them,
+// resuming the watch from the last seen resourceVersion whenever the server closes it.
+// If the watch fails (e.g. the resourceVersion is too old or the proxy doesn't support watches)
+// the objects are listed again and, if watching isn't possible at all, every PollInterval until the condition is met.
+type Watcher[T any] struct {
+	Client    dynamic.Interface
+	Resource  schema.GroupVersionResource
+	Namespace string
+	// Name limits the watcher to the object with the given name
+	Name string
+	// LabelSelector limits the watcher to the objects matching the selector
+	LabelSelector labels.Selector
+	// PollInterval is the interval between lists when watching isn't possible
+	PollInterval time.Duration
+	// State returns a short description of the object state (e.g. a condition reason).
+	// If set, every change of the state is reported to the GinkgoWriter and recorded as a Transition.
+	State func(obj *T) string
+
+	mu          sync.Mutex
+	states      map[string]string
+	transitions []Transition
+	// pollOnly is set once watching isn't possible, it's read by the concurrent waits of the watcher without the mutex
+	pollOnly     atomic.Bool
+	polls        int
+	lastObserved *unstructured.Unstructured
+}
+
+// New returns a Watcher of the given resource in the given namespace.
+func New[T any](client dynamic.Interface, resource schema.GroupVersionResource, namespace string) *Watcher[T] {
+	return &Watcher[T]{
+		Client:       client,
+		Resource:     resource,
+		Namespace:    namespace,
+		PollInterval: DefaultPollInterval,
+	}
+}
+
+// Until blocks until the condition returns true for one of the watched objects and returns that object.
+// It returns an error if the condition returns one, or a *utils.WaitTimeoutError describing the last observed object
+// if the timeout expires or the context is cancelled first.
+// The time spent waiting is recorded as a report entry of the current spec, see utils.WaitTiming.
+func (w *Watcher[T]) Until(ctx context.Context, timeout time.Duration, condition func(obj *T) (bool, error)) (obj *T, err error) {
+	start := time.Now()
+	w.mu.Lock()
+	startPolls := w.polls
+	w.mu.Unlock()
+	defer func() {
+		w.mu.Lock()
+		polls := w.polls - startPolls
+		w.mu.Unlock()
+		utils.RecordWait(start, polls, err)
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	relist := true
+	resourceVersion := ""
+	for {
+		if relist {
+			list, err := w.resource().List(ctx, w.listOptions())
+			if err != nil {
+				GinkgoWriter.Printf("failed to list %s in %s namespace: %v\n", w.Resource.Resource, w.Namespace, err)
+			} else {
+				for i := range list.Items {
+					if obj, err := w.observe(&list.Items[i], condition); obj != nil || err != nil {
+						return obj, err
+					}
+				}
+				resourceVersion = list.GetResourceVersion()
+				relist = false
+			}
+		}
+
+		if !relist && !w.pollOnly.Load() {
+			obj, resume, err := w.watch(ctx, &resourceVersion, condition)
+			if obj != nil || err != nil {
+				return obj, err
+			}
+			if resume && ctx.Err() == nil {
+				// the server closed the watch, resume it from the last seen resourceVersion
+				continue
+			}
+		}
+		relist = true
+
+		select {
+		case <-ctx.Done():
+			return nil, w.timeoutError(time.Since(start), ctx.Err())
+		case <-time.After(w.PollInterval):
+		}
+	}
+}
+
+// Transitions returns the state transitions observed so far.
+func (w *Watcher[T]) Transitions() []Transition {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]Transition{}, w.transitions...)
+}
+
+// watch processes the watch events until the condition is met, the watch fails or the context is done.
+// The resourceVersion is updated with every event and true is returned if the server closed the watch, so it can be resumed.
+func (w *Watcher[T]) watch(ctx context.Context, resourceVersion *string, condition func(obj *T) (bool, error)) (*T, bool, error) {
+	opts := w.listOptions()
+	opts.ResourceVersion = *resourceVersion
+	opts.AllowWatchBookmarks = true
+	watcher, err := w.resource().Watch(ctx, opts)
+	if err != nil {
+		if apierrors.IsMethodNotSupported(err) || apierrors.IsForbidden(err) || apierrors.IsNotFound(err) {
+			w.pollOnly.Store(true)
+		}
+		GinkgoWriter.Printf("failed to watch %s in %s namespace, falling back to polling: %v\n", w.Resource.Resource, w.Namespace, err)
+		return nil, false, nil
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, false, nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil, true, nil
+			}
+			if event.Type == watch.Error {
+				GinkgoWriter.Printf("watch of %s in %s namespace failed, listing them again: %v\n", w.Resource.Resource, w.Namespace, apierrors.FromObject(event.Object))
+				return nil, false, nil
+			}
+			u, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			*resourceVersion = u.GetResourceVersion()
+			switch event.Type {
+			case watch.Deleted:
+				if w.matches(u) {
+					w.record(u.GetName(), "Deleted")
+				}
+			case watch.Added, watch.Modified:
+				if obj, err := w.observe(u, condition); obj != nil || err != nil {
+					return obj, false, err
+				}
+			}
+		}
+	}
+}
+
+// observe converts the object, records its state and evaluates the condition for it.
+// The object is returned only if it meets the condition.
+func (w *Watcher[T]) observe(u *unstructured.Unstructured, condition func(obj *T) (bool, error)) (*T, error) {
+	if !w.matches(u) {
+		return nil, nil
+	}
+	w.mu.Lock()
+	w.polls++
+	w.lastObserved = u
+	w.mu.Unlock()
+
+	obj := new(T)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), obj); err != nil {
+		return nil, fmt.Errorf("failed to convert %s %s/%s: %+v", w.Resource.Resource, u.GetNamespace(), u.GetName(), err)
+	}
+	if w.State != nil {
+		w.record(u.GetName(), w.State(obj))
+	}
+	if done, err := condition(obj); !done || err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// matches filters the objects on the client side too, since not every client honours the selectors of a watch.
+func (w *Watcher[T]) matches(u *unstructured.Unstructured) bool {
+	if w.Name != "" && u.GetName() != w.Name {
+		return false
+	}
+	return w.LabelSelector == nil || w.LabelSelector.Matches(labels.Set(u.GetLabels()))
+}
+
+func (w *Watcher[T]) record(name, state string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.states == nil {
+		w.states = map[string]string{}
+	}
+	previous, seen := w.states[name]
+	if seen && previous == state {
+		return
+	}
+	w.states[name] = state
+	transition := Transition{Time: time.Now(), Name: fmt.Sprintf("%s %s/%s", w.Resource.Resource, w.Namespace, name), From: previous, To: state}
+	w.transitions = append(w.transitions, transition)
+	GinkgoWriter.Println(transition.String())
+}
+
+// timeoutError describes the state of the wait when it was interrupted.
+func (w *Watcher[T]) timeoutError(elapsed time.Duration, cause error) *utils.WaitTimeoutError {
+	target := fmt.Sprintf("%s in %s namespace", w.Resource.Resource, w.Namespace)
+	if w.Name != "" {
+		target = fmt.Sprintf("%s %s/%s", w.Resource.Resource, w.Namespace, w.Name)
+	} else if w.LabelSelector != nil {
+		target = fmt.Sprintf("%s with labels %q in %s namespace", w.Resource.Resource, w.LabelSelector.String(), w.Namespace)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	timeoutErr := &utils.WaitTimeoutError{Target: target, Elapsed: elapsed, Polls: w.polls, LastObserved: w.lastObserved, Err: cause}
+	for _, t := range w.transitions {
+		timeoutErr.Transitions = append(timeoutErr.Transitions, t.String())
+	}
+	if w.lastObserved != nil {
+		timeoutErr.Events = w.events(w.lastObserved)
+	}
+	return timeoutErr
+}
+
+// events returns the Events related to a given object, sorted from the oldest one.
+func (w *Watcher[T]) events(u *unstructured.Unstructured) []corev1.Event {
+	// the context of the wait is already done at this point
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	list, err := w.Client.Resource(corev1.SchemeGroupVersion.WithResource("events")).Namespace(u.GetNamespace()).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.Set{"involvedObject.name": u.GetName(), "involvedObject.kind": u.GetKind()}.String(),
+	})
+	if err != nil {
+		GinkgoWriter.Printf("failed to list events of %s %s/%s: %v\n", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+		return nil
+	}
+
+	events := []corev1.Event{}
+	for _, item := range list.Items {
+		event := corev1.Event{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.UnstructuredContent(), &event); err != nil {
+			continue
+		}
+		if event.InvolvedObject.Name == u.GetName() && event.InvolvedObject.Kind == u.GetKind() {
+			events = append(events, event)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].LastTimestamp.Before(&events[j].LastTimestamp)
+	})
+	return events
+}
+
+func (w *Watcher[T]) resource() dynamic.ResourceInterface {
+	return w.Client.Resource(w.Resource).Namespace(w.Namespace)
+}
+
+func (w *Watcher[T]) listOptions() metav1.ListOptions {
+	opts := metav1.ListOptions{}
+	if w.Name != "" {
+		opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", w.Name).String()
+	}
+	if w.LabelSelector != nil {
+		opts.LabelSelector = w.LabelSelector.String()
+	}
+	return opts
+}
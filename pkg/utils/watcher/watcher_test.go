@@ -0,0 +1,157 @@
+package watcher
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/redhat-appstudio/e2e-tests/pkg/logs"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var configMapsResource = corev1.SchemeGroupVersion.WithResource("configmaps")
+
+func newConfigMap(name, phase string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+		Data:       map[string]string{"phase": phase},
+	}
+}
+
+func newConfigMapWatcher(objects ...runtime.Object) *Watcher[corev1.ConfigMap] {
+	w := New[corev1.ConfigMap](dynamicfake.NewSimpleDynamicClient(scheme.Scheme, objects...), configMapsResource, "test-ns")
+	w.Name = "test-cm"
+	w.PollInterval = 10 * time.Millisecond
+	w.State = func(cm *corev1.ConfigMap) string { return cm.Data["phase"] }
+	return w
+}
+
+func phaseIs(phase string) func(cm *corev1.ConfigMap) (bool, error) {
+	return func(cm *corev1.ConfigMap) (bool, error) {
+		return cm.Data["phase"] == phase, nil
+	}
+}
+
+func TestUntilConditionMetByList(t *testing.T) {
+	w := newConfigMapWatcher(newConfigMap("test-cm", "Done"), newConfigMap("other-cm", "Running"))
+
+	cm, err := w.Until(context.Background(), time.Second, phaseIs("Done"))
+	assert.NoError(t, err)
+	assert.Equal(t, "test-cm", cm.Name)
+	assert.Len(t, w.Transitions(), 1)
+}
+
+func TestUntilConditionMetByWatchEvent(t *testing.T) {
+	w := newConfigMapWatcher(newConfigMap("test-cm", "Running"))
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(newConfigMap("test-cm", "Done"))
+		assert.NoError(t, err)
+		_, err = w.Client.Resource(configMapsResource).Namespace("test-ns").Update(context.Background(), &unstructured.Unstructured{Object: u}, metav1.UpdateOptions{})
+		assert.NoError(t, err)
+	}()
+
+	cm, err := w.Until(context.Background(), 5*time.Second, phaseIs("Done"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Done", cm.Data["phase"])
+
+	transitions := w.Transitions()
+	assert.Len(t, transitions, 2)
+	assert.Equal(t, "Running", transitions[1].From)
+	assert.Equal(t, "Done", transitions[1].To)
+}
+
+func TestUntilTimeout(t *testing.T) {
+	event := &corev1.Event{
+		TypeMeta:       metav1.TypeMeta{Kind: "Event", APIVersion: "v1"},
+		ObjectMeta:     metav1.ObjectMeta{Name: "test-cm.1", Namespace: "test-ns"},
+		InvolvedObject: corev1.ObjectReference{Kind: "ConfigMap", Name: "test-cm", Namespace: "test-ns"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "Stuck",
+		Message:        "the phase is not changing",
+	}
+	w := newConfigMapWatcher(newConfigMap("test-cm", "Running"), event)
+
+	_, err := w.Until(context.Background(), 100*time.Millisecond, phaseIs("Done"))
+	assert.True(t, wait.Interrupted(err))
+
+	timeoutErr := &utils.WaitTimeoutError{}
+	assert.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, "configmaps test-ns/test-cm", timeoutErr.Target)
+	assert.GreaterOrEqual(t, timeoutErr.Polls, 1)
+	assert.Equal(t, "test-cm", timeoutErr.LastObserved.GetName())
+	assert.Len(t, timeoutErr.Events, 1)
+	assert.Contains(t, err.Error(), "Warning Stuck (x0): the phase is not changing")
+	assert.Contains(t, timeoutErr.Artifacts(), "wait-timeout-configmap-test-cm.yaml")
+	assert.Contains(t, timeoutErr.Artifacts(), "wait-timeout-configmap-test-cm-events.yaml")
+}
+
+func TestUntilNoObjectObserved(t *testing.T) {
+	w := newConfigMapWatcher()
+
+	_, err := w.Until(context.Background(), 100*time.Millisecond, phaseIs("Done"))
+	assert.ErrorContains(t, err, "no matching object was observed")
+}
+
+func TestUntilFallsBackToPollingInConcurrentWaits(t *testing.T) {
+	w := newConfigMapWatcher(newConfigMap("test-cm", "Running"))
+	w.Client.(*dynamicfake.FakeDynamicClient).PrependWatchReactor("*", func(k8stesting.Action) (bool, watch.Interface, error) {
+		return true, nil, apierrors.NewMethodNotSupported(configMapsResource.GroupResource(), "watch")
+	})
+
+	// the waits share the watcher, run with -race to check its state
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := w.Until(context.Background(), 100*time.Millisecond, phaseIs("Done"))
+			assert.True(t, wait.Interrupted(err))
+		}()
+	}
+	wg.Wait()
+	assert.True(t, w.pollOnly.Load())
+}
+
+// the waits are recorded only in a running spec, so TestUntilRecordsWaitTiming runs this one
+var recordedWaits []utils.WaitTiming
+
+var _ = ginkgo.Describe("Watcher", func() {
+	ginkgo.It("records the time spent waiting", func() {
+		w := newConfigMapWatcher(newConfigMap("test-cm", "Done"))
+		if _, err := w.Until(context.Background(), time.Second, phaseIs("Done")); err != nil {
+			ginkgo.Fail(err.Error())
+		}
+	})
+
+	ginkgo.ReportAfterEach(func(report ginkgo.SpecReport) {
+		recordedWaits = logs.SpecWaits(report)
+	})
+})
+
+func TestUntilRecordsWaitTiming(t *testing.T) {
+	ginkgo.RunSpecs(t, "Watcher")
+
+	assert.Len(t, recordedWaits, 1)
+	timing := recordedWaits[0]
+	assert.Equal(t, 1, timing.Polls)
+	assert.Empty(t, timing.Error)
+	// the caller is the spec, not the watcher
+	assert.Contains(t, timing.Caller, "watcher.init")
+	assert.Contains(t, timing.Location, "watcher_test.go")
+}
@@ -92,7 +92,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 			pacBranchName = constants.PaCPullRequestBranchPrefix + componentName
 			componentBaseBranchName = fmt.Sprintf("base-%s", util.GenerateRandomString(6))
 
-			err = f.AsKubeAdmin.CommonController.Github.CreateRef(helloWorldComponentGitSourceRepoName, helloWorldComponentDefaultBranch, helloWorldComponentRevision, componentBaseBranchName)
+			err = f.AsKubeAdmin.CommonController.GithubClient().CreateRef(helloWorldComponentGitSourceRepoName, helloWorldComponentDefaultBranch, helloWorldComponentRevision, componentBaseBranchName)
 			Expect(err).ShouldNot(HaveOccurred())
 
 			defaultBranchTestComponentName = fmt.Sprintf("test-custom-default-branch-%s", util.GenerateRandomString(6))
@@ -105,15 +105,15 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 			}
 
 			// Delete new branches created by PaC and a testing branch used as a component's base branch
-			err = f.AsKubeAdmin.CommonController.Github.DeleteRef(helloWorldComponentGitSourceRepoName, pacBranchName)
+			err = f.AsKubeAdmin.CommonController.GithubClient().DeleteRef(helloWorldComponentGitSourceRepoName, pacBranchName)
 			if err != nil {
 				Expect(err.Error()).To(ContainSubstring("Reference does not exist"))
 			}
-			err = f.AsKubeAdmin.CommonController.Github.DeleteRef(helloWorldComponentGitSourceRepoName, componentBaseBranchName)
+			err = f.AsKubeAdmin.CommonController.GithubClient().DeleteRef(helloWorldComponentGitSourceRepoName, componentBaseBranchName)
 			if err != nil {
 				Expect(err.Error()).To(ContainSubstring("Reference does not exist"))
 			}
-			err = f.AsKubeAdmin.CommonController.Github.DeleteRef(helloWorldComponentGitSourceRepoName, constants.PaCPullRequestBranchPrefix+defaultBranchTestComponentName)
+			err = f.AsKubeAdmin.CommonController.GithubClient().DeleteRef(helloWorldComponentGitSourceRepoName, constants.PaCPullRequestBranchPrefix+defaultBranchTestComponentName)
 			if err != nil {
 				Expect(err.Error()).To(ContainSubstring("Reference does not exist"))
 			}
@@ -126,7 +126,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 			interval = time.Second * 10
 
 			Eventually(func() *github.CheckRun {
-				checkRuns, err := f.AsKubeAdmin.CommonController.Github.ListCheckRuns(helloWorldComponentGitSourceRepoName, prHeadSha)
+				checkRuns, err := f.AsKubeAdmin.CommonController.GithubClient().ListCheckRuns(helloWorldComponentGitSourceRepoName, prHeadSha)
 				Expect(err).ShouldNot(HaveOccurred())
 				for _, cr := range checkRuns {
 					if strings.Contains(cr.GetDetailsURL(), osConsoleHost) {
@@ -138,7 +138,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 			}, timeout, interval).ShouldNot(BeNil(), fmt.Sprintf("timed out when waiting for the PaC Check run with `Details URL` field containing %s to appear in the Component repo %s in PR #%d", osConsoleHost, helloWorldComponentGitSourceRepoName, prNumber))
 
 			Eventually(func() string {
-				checkRun, err = f.AsKubeAdmin.CommonController.Github.GetCheckRun(helloWorldComponentGitSourceRepoName, checkRun.GetID())
+				checkRun, err = f.AsKubeAdmin.CommonController.GithubClient().GetCheckRun(helloWorldComponentGitSourceRepoName, checkRun.GetID())
 				Expect(err).ShouldNot(HaveOccurred())
 				return checkRun.GetStatus()
 			}, timeout, interval).Should(Equal("completed"), fmt.Sprintf("timed out when waiting for the PaC Check suite status to be 'completed' in the Component repo %s in PR #%d", helloWorldComponentGitSourceRepoName, prNumber))
@@ -167,7 +167,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 				timeout = time.Second * 300
 				interval = time.Second * 1
 				Eventually(func() bool {
-					prs, err := f.AsKubeAdmin.CommonController.Github.ListPullRequests(helloWorldComponentGitSourceRepoName)
+					prs, err := f.AsKubeAdmin.CommonController.GithubClient().ListPullRequests(helloWorldComponentGitSourceRepoName)
 					Expect(err).ShouldNot(HaveOccurred())
 
 					for _, pr := range prs {
@@ -282,7 +282,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 				interval = time.Second * 1
 				branchName := constants.PaCPullRequestBranchPrefix + defaultBranchTestComponentName
 				Eventually(func() bool {
-					exists, err := f.AsKubeAdmin.CommonController.Github.ExistsRef(helloWorldComponentGitSourceRepoName, constants.PaCPullRequestBranchPrefix+defaultBranchTestComponentName)
+					exists, err := f.AsKubeAdmin.CommonController.GithubClient().ExistsRef(helloWorldComponentGitSourceRepoName, constants.PaCPullRequestBranchPrefix+defaultBranchTestComponentName)
 					Expect(err).ShouldNot(HaveOccurred())
 					return exists
 				}, timeout, interval).Should(BeFalse(), fmt.Sprintf("timed out when waiting for the branch %s to be deleted from %s repository", branchName, helloWorldComponentGitSourceRepoName))
@@ -343,7 +343,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 				interval = time.Second * 1
 
 				Eventually(func() bool {
-					prs, err := f.AsKubeAdmin.CommonController.Github.ListPullRequests(helloWorldComponentGitSourceRepoName)
+					prs, err := f.AsKubeAdmin.CommonController.GithubClient().ListPullRequests(helloWorldComponentGitSourceRepoName)
 					Expect(err).ShouldNot(HaveOccurred())
 
 					for _, pr := range prs {
@@ -424,7 +424,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 
 			BeforeAll(func() {
 				fileToCreatePath := fmt.Sprintf(".tekton/%s-readme.md", componentName)
-				createdFile, err := f.AsKubeAdmin.CommonController.Github.CreateFile(helloWorldComponentGitSourceRepoName, fileToCreatePath, fmt.Sprintf("test PaC branch %s update", pacBranchName), pacBranchName)
+				createdFile, err := f.AsKubeAdmin.CommonController.GithubClient().CreateFile(helloWorldComponentGitSourceRepoName, fileToCreatePath, fmt.Sprintf("test PaC branch %s update", pacBranchName), pacBranchName)
 				Expect(err).NotTo(HaveOccurred())
 
 				createdFileSHA = createdFile.GetSHA()
@@ -451,7 +451,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 				interval = time.Second * 1
 
 				Eventually(func() bool {
-					prs, err := f.AsKubeAdmin.CommonController.Github.ListPullRequests(helloWorldComponentGitSourceRepoName)
+					prs, err := f.AsKubeAdmin.CommonController.GithubClient().ListPullRequests(helloWorldComponentGitSourceRepoName)
 					Expect(err).ShouldNot(HaveOccurred())
 
 					for _, pr := range prs {
@@ -481,7 +481,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 
 			BeforeAll(func() {
 				Eventually(func() error {
-					mergeResult, err = f.AsKubeAdmin.CommonController.Github.MergePullRequest(helloWorldComponentGitSourceRepoName, prNumber)
+					mergeResult, err = f.AsKubeAdmin.CommonController.GithubClient().MergePullRequest(helloWorldComponentGitSourceRepoName, prNumber)
 					return err
 				}, time.Minute).Should(BeNil(), fmt.Sprintf("error when merging PaC pull request #%d in repo %s", prNumber, helloWorldComponentGitSourceRepoName))
 
@@ -608,7 +608,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 				timeout = time.Second * 10
 				interval = time.Second * 2
 				Consistently(func() error {
-					prs, err := f.AsKubeAdmin.CommonController.Github.ListPullRequests(helloWorldComponentGitSourceRepoName)
+					prs, err := f.AsKubeAdmin.CommonController.GithubClient().ListPullRequests(helloWorldComponentGitSourceRepoName)
 					Expect(err).ShouldNot(HaveOccurred())
 
 					for _, pr := range prs {
@@ -653,7 +653,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 			)
 
 			multiComponentBaseBranchName = fmt.Sprintf("multi-component-base-%s", util.GenerateRandomString(6))
-			err = f.AsKubeAdmin.CommonController.Github.CreateRef(multiComponentGitSourceRepoName, multiComponentDefaultBranch, multiComponentGitRevision, multiComponentBaseBranchName)
+			err = f.AsKubeAdmin.CommonController.GithubClient().CreateRef(multiComponentGitSourceRepoName, multiComponentDefaultBranch, multiComponentGitRevision, multiComponentBaseBranchName)
 			Expect(err).ShouldNot(HaveOccurred())
 
 			//Branch for creating pull request
@@ -669,18 +669,18 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 
 			// Delete new branches created by PaC and a testing branch used as a component's base branch
 			for _, pacBranchName := range pacBranchNames {
-				err = f.AsKubeAdmin.CommonController.Github.DeleteRef(multiComponentGitSourceRepoName, pacBranchName)
+				err = f.AsKubeAdmin.CommonController.GithubClient().DeleteRef(multiComponentGitSourceRepoName, pacBranchName)
 				if err != nil {
 					Expect(err.Error()).To(ContainSubstring("Reference does not exist"))
 				}
 			}
 			// Delete the created base branch
-			err = f.AsKubeAdmin.CommonController.Github.DeleteRef(multiComponentGitSourceRepoName, multiComponentBaseBranchName)
+			err = f.AsKubeAdmin.CommonController.GithubClient().DeleteRef(multiComponentGitSourceRepoName, multiComponentBaseBranchName)
 			if err != nil {
 				Expect(err.Error()).To(ContainSubstring("Reference does not exist"))
 			}
 			// Delete the created pr branch
-			err = f.AsKubeAdmin.CommonController.Github.DeleteRef(multiComponentGitSourceRepoName, multiComponentPRBranchName)
+			err = f.AsKubeAdmin.CommonController.GithubClient().DeleteRef(multiComponentGitSourceRepoName, multiComponentPRBranchName)
 			if err != nil {
 				Expect(err.Error()).To(ContainSubstring("Reference does not exist"))
 			}
@@ -733,7 +733,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 					interval := time.Second * 1
 
 					Eventually(func() bool {
-						prs, err := f.AsKubeAdmin.CommonController.Github.ListPullRequests(multiComponentGitSourceRepoName)
+						prs, err := f.AsKubeAdmin.CommonController.GithubClient().ListPullRequests(multiComponentGitSourceRepoName)
 						Expect(err).ShouldNot(HaveOccurred())
 
 						for _, pr := range prs {
@@ -753,7 +753,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 
 				It("merging the PR should be successful", func() {
 					Eventually(func() error {
-						mergeResult, err = f.AsKubeAdmin.CommonController.Github.MergePullRequest(multiComponentGitSourceRepoName, prNumber)
+						mergeResult, err = f.AsKubeAdmin.CommonController.GithubClient().MergePullRequest(multiComponentGitSourceRepoName, prNumber)
 						return err
 					}, time.Minute).Should(BeNil(), fmt.Sprintf("error when merging PaC pull request #%d in repo %s", prNumber, multiComponentGitSourceRepoName))
 
@@ -781,12 +781,12 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 				//Delete all the pipelineruns in the namespace before sending PR
 				Expect(f.AsKubeAdmin.TektonController.DeleteAllPipelineRunsInASpecificNamespace(testNamespace)).To(Succeed())
 				//Create the ref, add the file and create the PR
-				err = f.AsKubeAdmin.CommonController.Github.CreateRef(multiComponentGitSourceRepoName, multiComponentDefaultBranch, mergeResultSha, multiComponentPRBranchName)
+				err = f.AsKubeAdmin.CommonController.GithubClient().CreateRef(multiComponentGitSourceRepoName, multiComponentDefaultBranch, mergeResultSha, multiComponentPRBranchName)
 				Expect(err).ShouldNot(HaveOccurred())
 				fileToCreatePath := fmt.Sprintf("%s/sample-file.txt", multiComponentContextDirs[0])
-				createdFileSha, err := f.AsKubeAdmin.CommonController.Github.CreateFile(multiComponentGitSourceRepoName, fileToCreatePath, fmt.Sprintf("sample test file inside %s", multiComponentContextDirs[0]), multiComponentPRBranchName)
+				createdFileSha, err := f.AsKubeAdmin.CommonController.GithubClient().CreateFile(multiComponentGitSourceRepoName, fileToCreatePath, fmt.Sprintf("sample test file inside %s", multiComponentContextDirs[0]), multiComponentPRBranchName)
 				Expect(err).ShouldNot(HaveOccurred(), fmt.Sprintf("error while creating file: %s", fileToCreatePath))
-				pr, err := f.AsKubeAdmin.CommonController.Github.CreatePullRequest(multiComponentGitSourceRepoName, "sample pr title", "sample pr body", multiComponentPRBranchName, multiComponentBaseBranchName)
+				pr, err := f.AsKubeAdmin.CommonController.GithubClient().CreatePullRequest(multiComponentGitSourceRepoName, "sample pr title", "sample pr body", multiComponentPRBranchName, multiComponentBaseBranchName)
 				Expect(err).ShouldNot(HaveOccurred())
 				GinkgoWriter.Printf("PR #%d got created with sha %s\n", pr.GetNumber(), createdFileSha.GetSHA())
 			})
@@ -1447,7 +1447,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 
 			for _, i := range components {
 				println("creating branch " + i.componentBranch)
-				err = f.AsKubeAdmin.CommonController.Github.CreateRef(i.repoName, i.baseBranch, i.baseRevision, i.componentBranch)
+				err = f.AsKubeAdmin.CommonController.GithubClient().CreateRef(i.repoName, i.baseBranch, i.baseRevision, i.componentBranch)
 				Expect(err).ShouldNot(HaveOccurred())
 			}
 			// Also setup a release namespace so we can test nudging of distribution repository images
@@ -1493,11 +1493,11 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 			// Delete new branches created by renovate and a testing branch used as a component's base branch
 			for _, c := range components {
 				println("deleting branch " + c.componentBranch)
-				err = f.AsKubeAdmin.CommonController.Github.DeleteRef(c.repoName, c.componentBranch)
+				err = f.AsKubeAdmin.CommonController.GithubClient().DeleteRef(c.repoName, c.componentBranch)
 				if err != nil {
 					Expect(err.Error()).To(ContainSubstring("Reference does not exist"))
 				}
-				err = f.AsKubeAdmin.CommonController.Github.DeleteRef(c.repoName, c.pacBranchName)
+				err = f.AsKubeAdmin.CommonController.GithubClient().DeleteRef(c.repoName, c.pacBranchName)
 				if err != nil {
 					Expect(err.Error()).To(ContainSubstring("Reference does not exist"))
 				}
@@ -1568,18 +1568,18 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 				annotations := component.GetAnnotations()
 				imageRepoName, err := build.GetQuayImageName(annotations)
 				Expect(err).ShouldNot(HaveOccurred())
-				err = f.AsKubeAdmin.CommonController.Github.CreateRef(ChildComponentDef.repoName, ChildComponentDef.baseBranch, ChildComponentDef.baseRevision, ChildComponentDef.pacBranchName)
+				err = f.AsKubeAdmin.CommonController.GithubClient().CreateRef(ChildComponentDef.repoName, ChildComponentDef.baseBranch, ChildComponentDef.baseRevision, ChildComponentDef.pacBranchName)
 				Expect(err).ShouldNot(HaveOccurred())
 				parentImageNameWithNoDigest = "quay.io/" + gihubOrg + "/" + imageRepoName
-				_, err = f.AsKubeAdmin.CommonController.Github.CreateFile(ChildComponentDef.repoName, "Dockerfile.tmp", "FROM "+parentImageNameWithNoDigest+"@"+parentFirstDigest+"\nRUN echo hello\n", ChildComponentDef.pacBranchName)
+				_, err = f.AsKubeAdmin.CommonController.GithubClient().CreateFile(ChildComponentDef.repoName, "Dockerfile.tmp", "FROM "+parentImageNameWithNoDigest+"@"+parentFirstDigest+"\nRUN echo hello\n", ChildComponentDef.pacBranchName)
 				Expect(err).ShouldNot(HaveOccurred())
 
-				_, err = f.AsKubeAdmin.CommonController.Github.CreateFile(ChildComponentDef.repoName, "manifest.yaml", "image: "+distributionRepository+"@"+parentFirstDigest, ChildComponentDef.pacBranchName)
+				_, err = f.AsKubeAdmin.CommonController.GithubClient().CreateFile(ChildComponentDef.repoName, "manifest.yaml", "image: "+distributionRepository+"@"+parentFirstDigest, ChildComponentDef.pacBranchName)
 				Expect(err).ShouldNot(HaveOccurred())
 
-				_, err = f.AsKubeAdmin.CommonController.Github.CreatePullRequest(ChildComponentDef.repoName, "update to build repo image", "update to build repo image", ChildComponentDef.pacBranchName, ChildComponentDef.componentBranch)
+				_, err = f.AsKubeAdmin.CommonController.GithubClient().CreatePullRequest(ChildComponentDef.repoName, "update to build repo image", "update to build repo image", ChildComponentDef.pacBranchName, ChildComponentDef.componentBranch)
 				Expect(err).ShouldNot(HaveOccurred())
-				prs, err := f.AsKubeAdmin.CommonController.Github.ListPullRequests(ChildComponentDef.repoName)
+				prs, err := f.AsKubeAdmin.CommonController.GithubClient().ListPullRequests(ChildComponentDef.repoName)
 				Expect(err).ShouldNot(HaveOccurred())
 
 				prno := -1
@@ -1589,7 +1589,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 					}
 				}
 				Expect(prno).ShouldNot(Equal(-1))
-				_, err = f.AsKubeAdmin.CommonController.Github.MergePullRequest(ChildComponentDef.repoName, prno)
+				_, err = f.AsKubeAdmin.CommonController.GithubClient().MergePullRequest(ChildComponentDef.repoName, prno)
 				Expect(err).ShouldNot(HaveOccurred())
 
 			})
@@ -1599,7 +1599,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 				interval := time.Second * 1
 
 				Eventually(func() bool {
-					prs, err := f.AsKubeAdmin.CommonController.Github.ListPullRequests(ParentComponentDef.repoName)
+					prs, err := f.AsKubeAdmin.CommonController.GithubClient().ListPullRequests(ParentComponentDef.repoName)
 					Expect(err).ShouldNot(HaveOccurred())
 
 					for _, pr := range prs {
@@ -1613,7 +1613,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 			})
 			It(fmt.Sprintf("Merging the PaC PR should be successful for parent component %s", ParentComponentDef.componentName), func() {
 				Eventually(func() error {
-					mergeResult, err = f.AsKubeAdmin.CommonController.Github.MergePullRequest(ParentComponentDef.repoName, prNumber)
+					mergeResult, err = f.AsKubeAdmin.CommonController.GithubClient().MergePullRequest(ParentComponentDef.repoName, prNumber)
 					return err
 				}, time.Minute).Should(BeNil(), fmt.Sprintf("error when merging PaC pull request #%d in repo %s", prNumber, ParentComponentDef.repoName))
 
@@ -1654,7 +1654,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 				interval := time.Second * 1
 
 				Eventually(func() bool {
-					prs, err := f.AsKubeAdmin.CommonController.Github.ListPullRequests(componentDependenciesChildRepoName)
+					prs, err := f.AsKubeAdmin.CommonController.GithubClient().ListPullRequests(componentDependenciesChildRepoName)
 					Expect(err).ShouldNot(HaveOccurred())
 
 					for _, pr := range prs {
@@ -1668,7 +1668,7 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 			})
 			It(fmt.Sprintf("merging the PR should be successful for child component %s", ChildComponentDef.componentName), func() {
 				Eventually(func() error {
-					mergeResult, err = f.AsKubeAdmin.CommonController.Github.MergePullRequest(componentDependenciesChildRepoName, prNumber)
+					mergeResult, err = f.AsKubeAdmin.CommonController.GithubClient().MergePullRequest(componentDependenciesChildRepoName, prNumber)
 					return err
 				}, time.Minute).Should(BeNil(), fmt.Sprintf("error when merging nudge pull request #%d in repo %s", prNumber, componentDependenciesChildRepoName))
 
@@ -1686,13 +1686,13 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build", "
 				annotations := component.GetAnnotations()
 				imageRepoName, err := build.GetQuayImageName(annotations)
 				Expect(err).ShouldNot(HaveOccurred())
-				contents, err := f.AsKubeAdmin.CommonController.Github.GetFile(ChildComponentDef.repoName, "Dockerfile.tmp", ChildComponentDef.componentBranch)
+				contents, err := f.AsKubeAdmin.CommonController.GithubClient().GetFile(ChildComponentDef.repoName, "Dockerfile.tmp", ChildComponentDef.componentBranch)
 				Expect(err).ShouldNot(HaveOccurred())
 				content, err := contents.GetContent()
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(content).Should(Equal("FROM quay.io/" + gihubOrg + "/" + imageRepoName + "@" + parentPostPacMergeDigest + "\nRUN echo hello\n"))
 
-				contents, err = f.AsKubeAdmin.CommonController.Github.GetFile(ChildComponentDef.repoName, "manifest.yaml", ChildComponentDef.componentBranch)
+				contents, err = f.AsKubeAdmin.CommonController.GithubClient().GetFile(ChildComponentDef.repoName, "manifest.yaml", ChildComponentDef.componentBranch)
 				Expect(err).ShouldNot(HaveOccurred())
 				content, err = contents.GetContent()
 				Expect(err).ShouldNot(HaveOccurred())
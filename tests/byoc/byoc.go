@@ -99,7 +99,7 @@ var _ = framework.ByocSuiteDescribe(Label("byoc"), Ordered, func() {
 				if suite.Byoc.ClusterType == appservice.ConfigurationClusterType_Kubernetes {
 					Expect(sh.Run("which", "vcluster")).To(Succeed(), "please install vcluster locally in order to run kubernetes suite")
 
-					vc = vcluster.NewVclusterController(fmt.Sprintf("%s/tmp", rootPath), fw.AsKubeAdmin.CommonController.CustomClient)
+					vc = vcluster.NewVclusterController(fmt.Sprintf("%s/tmp", rootPath), fw.AsKubeAdmin.CommonController)
 
 					byocKubeconfig, err = vc.InitializeVCluster(fw.UserNamespace, fw.UserNamespace, kubeIngressDomain)
 					Expect(err).NotTo(HaveOccurred())
@@ -191,7 +191,7 @@ var _ = framework.ByocSuiteDescribe(Label("byoc"), Ordered, func() {
 					// application info should be stored even after deleting the application in application variable
 					gitOpsRepository := gitops.ObtainGitOpsRepositoryName(application.Status.Devfile)
 
-					return fw.AsKubeAdmin.CommonController.Github.CheckIfRepositoryExist(gitOpsRepository)
+					return fw.AsKubeAdmin.CommonController.GithubClient().CheckIfRepositoryExist(gitOpsRepository)
 				}, 1*time.Minute, 1*time.Second).Should(BeTrue(), fmt.Sprintf("timed out waiting for HAS controller to create gitops repository for the %s application in %s namespace", applicationName, fw.UserNamespace))
 			})
 
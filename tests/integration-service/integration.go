@@ -64,8 +64,8 @@ var _ = framework.IntegrationServiceSuiteDescribe("Integration Service E2E tests
 		})
 
 		When("a new Component is created", func() {
-			It("triggers a build PipelineRun", Label("integration-service"), func() {
-				pipelineRun, err = f.AsKubeDeveloper.IntegrationController.GetBuildPipelineRun(componentName, applicationName, testNamespace, false, "")
+			It("triggers a build PipelineRun", Label("integration-service"), func(ctx SpecContext) {
+				pipelineRun, err = f.AsKubeDeveloper.IntegrationController.GetBuildPipelineRunWithContext(ctx, componentName, applicationName, testNamespace, false, "")
 				Expect(err).ShouldNot(HaveOccurred())
 			})
 
@@ -80,25 +80,25 @@ var _ = framework.IntegrationServiceSuiteDescribe("Integration Service E2E tests
 				}, 1*time.Minute, 1*time.Second).Should(Succeed(), "timeout when waiting for finalizer to be added")
 			})
 
-			It("waits for build PipelineRun to succeed", Label("integration-service"), func() {
-				Expect(f.AsKubeDeveloper.HasController.WaitForComponentPipelineToBeFinished(originalComponent, "",
+			It("waits for build PipelineRun to succeed", Label("integration-service"), func(ctx SpecContext) {
+				Expect(f.AsKubeDeveloper.HasController.WaitForComponentPipelineToBeFinishedWithContext(ctx, originalComponent, "",
 					f.AsKubeAdmin.TektonController, &has.RetryOptions{Retries: 2, Always: true})).To(Succeed())
 				Expect(pipelineRun.Annotations[snapshotAnnotation]).To(Equal(""))
 			})
 		})
 
 		When("the build pipelineRun run succeeded", func() {
-			It("checks if the BuildPipelineRun have the annotation of chains signed", func() {
-				Expect(f.AsKubeDeveloper.IntegrationController.WaitForBuildPipelineRunToGetAnnotated(testNamespace, applicationName, componentName, chainsSignedAnnotation)).To(Succeed())
+			It("checks if the BuildPipelineRun have the annotation of chains signed", func(ctx SpecContext) {
+				Expect(f.AsKubeDeveloper.IntegrationController.WaitForBuildPipelineRunToGetAnnotatedWithContext(ctx, testNamespace, applicationName, componentName, chainsSignedAnnotation)).To(Succeed())
 			})
 
-			It("checks if the Snapshot is created", func() {
-				snapshot, err = f.AsKubeDeveloper.IntegrationController.WaitForSnapshotToGetCreated("", "", componentName, testNamespace)
+			It("checks if the Snapshot is created", func(ctx SpecContext) {
+				snapshot, err = f.AsKubeDeveloper.IntegrationController.WaitForSnapshotToGetCreatedWithContext(ctx, "", "", componentName, testNamespace)
 				Expect(err).ShouldNot(HaveOccurred())
 			})
 
-			It("checks if the Build PipelineRun got annotated with Snapshot name", func() {
-				Expect(f.AsKubeDeveloper.IntegrationController.WaitForBuildPipelineRunToGetAnnotated(testNamespace, applicationName, componentName, snapshotAnnotation)).To(Succeed())
+			It("checks if the Build PipelineRun got annotated with Snapshot name", func(ctx SpecContext) {
+				Expect(f.AsKubeDeveloper.IntegrationController.WaitForBuildPipelineRunToGetAnnotatedWithContext(ctx, testNamespace, applicationName, componentName, snapshotAnnotation)).To(Succeed())
 			})
 
 			It("verifies that the finalizer has been removed from the build pipelinerun", func() {
@@ -114,8 +114,8 @@ var _ = framework.IntegrationServiceSuiteDescribe("Integration Service E2E tests
 				}, timeout, interval).Should(Succeed(), "timeout when waiting for finalizer to be removed")
 			})
 
-			It("checks if all of the integrationPipelineRuns passed", Label("slow"), func() {
-				Expect(f.AsKubeDeveloper.IntegrationController.WaitForAllIntegrationPipelinesToBeFinished(testNamespace, applicationName, snapshot)).To(Succeed())
+			It("checks if all of the integrationPipelineRuns passed", Label("slow"), func(ctx SpecContext) {
+				Expect(f.AsKubeDeveloper.IntegrationController.WaitForAllIntegrationPipelinesToBeFinishedWithContext(ctx, testNamespace, applicationName, snapshot)).To(Succeed())
 			})
 
 			It("checks if the passed status of integration test is reported in the Snapshot", func() {
@@ -135,8 +135,8 @@ var _ = framework.IntegrationServiceSuiteDescribe("Integration Service E2E tests
 				}, timeout, interval).Should(Succeed())
 			})
 
-			It("checks if the finalizer was removed from all of the related Integration pipelineRuns", func() {
-				Expect(f.AsKubeDeveloper.IntegrationController.WaitForFinalizerToGetRemovedFromAllIntegrationPipelineRuns(testNamespace, applicationName, snapshot)).To(Succeed())
+			It("checks if the finalizer was removed from all of the related Integration pipelineRuns", func(ctx SpecContext) {
+				Expect(f.AsKubeDeveloper.IntegrationController.WaitForFinalizerToGetRemovedFromAllIntegrationPipelineRunsWithContext(ctx, testNamespace, applicationName, snapshot)).To(Succeed())
 			})
 		})
 
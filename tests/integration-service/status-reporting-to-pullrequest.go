@@ -67,7 +67,7 @@ var _ = framework.IntegrationServiceSuiteDescribe("Status Reporting of Integrati
 			pacBranchName = constants.PaCPullRequestBranchPrefix + componentName
 			componentBaseBranchName = fmt.Sprintf("base-%s", util.GenerateRandomString(6))
 
-			err = f.AsKubeAdmin.CommonController.Github.CreateRef(componentRepoNameForStatusReporting, componentDefaultBranch, componentRevision, componentBaseBranchName)
+			err = f.AsKubeAdmin.CommonController.GithubClient().CreateRef(componentRepoNameForStatusReporting, componentDefaultBranch, componentRevision, componentBaseBranchName)
 			Expect(err).ShouldNot(HaveOccurred())
 		})
 
@@ -77,11 +77,11 @@ var _ = framework.IntegrationServiceSuiteDescribe("Status Reporting of Integrati
 			}
 
 			// Delete new branches created by PaC and a testing branch used as a component's base branch
-			err = f.AsKubeAdmin.CommonController.Github.DeleteRef(componentRepoNameForStatusReporting, pacBranchName)
+			err = f.AsKubeAdmin.CommonController.GithubClient().DeleteRef(componentRepoNameForStatusReporting, pacBranchName)
 			if err != nil {
 				Expect(err.Error()).To(ContainSubstring(referenceDoesntExist))
 			}
-			err = f.AsKubeAdmin.CommonController.Github.DeleteRef(componentRepoNameForStatusReporting, componentBaseBranchName)
+			err = f.AsKubeAdmin.CommonController.GithubClient().DeleteRef(componentRepoNameForStatusReporting, componentBaseBranchName)
 			if err != nil {
 				Expect(err.Error()).To(ContainSubstring(referenceDoesntExist))
 			}
@@ -132,7 +132,7 @@ var _ = framework.IntegrationServiceSuiteDescribe("Status Reporting of Integrati
 				interval = time.Second * 1
 
 				Eventually(func() bool {
-					prs, err := f.AsKubeAdmin.CommonController.Github.ListPullRequests(componentRepoNameForStatusReporting)
+					prs, err := f.AsKubeAdmin.CommonController.GithubClient().ListPullRequests(componentRepoNameForStatusReporting)
 					Expect(err).ShouldNot(HaveOccurred())
 
 					for _, pr := range prs {
@@ -221,7 +221,7 @@ func validateCheckRun(f framework.Framework, checkRunName, conclusion, repoName,
 	interval = time.Second * 2
 
 	Eventually(func() *github.CheckRun {
-		checkRuns, err := f.AsKubeAdmin.CommonController.Github.ListCheckRuns(repoName, prHeadSha)
+		checkRuns, err := f.AsKubeAdmin.CommonController.GithubClient().ListCheckRuns(repoName, prHeadSha)
 		Expect(err).ShouldNot(HaveOccurred())
 		for _, cr := range checkRuns {
 			if strings.Contains(cr.GetName(), checkRunName) {
@@ -233,7 +233,7 @@ func validateCheckRun(f framework.Framework, checkRunName, conclusion, repoName,
 	}, timeout, interval).ShouldNot(BeNil(), fmt.Sprintf("timed out when waiting for the PaC CheckRun, with `Name` field containing the substring %s, to appear in the PR #%d of the Component repo %s", checkRunName, prNumber, repoName))
 
 	Eventually(func() string {
-		checkRun, err = f.AsKubeAdmin.CommonController.Github.GetCheckRun(repoName, checkRun.GetID())
+		checkRun, err = f.AsKubeAdmin.CommonController.GithubClient().GetCheckRun(repoName, checkRun.GetID())
 		Expect(err).ShouldNot(HaveOccurred())
 		return checkRun.GetStatus()
 	}, timeout, interval).Should(Equal(checkrunStatusCompleted), fmt.Sprintf("timed out when waiting for the PaC Check suite status to be 'completed' in the Component repo %s in PR #%d", repoName, prNumber))
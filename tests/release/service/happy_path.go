@@ -149,36 +149,18 @@ var _ = framework.ReleaseServiceSuiteDescribe("Release service happy path", Labe
 		})
 
 		It("verifies that a Release CR should have been created in the dev namespace", func() {
-			Eventually(func() error {
-				releaseCR, err = fw.AsKubeAdmin.ReleaseController.GetFirstReleaseInNamespace(devNamespace)
-				return err
-			}, releaseConst.ReleaseCreationTimeout, releaseConst.DefaultInterval).Should(Succeed())
+			releaseCR, err = fw.AsKubeAdmin.ReleaseController.WaitForReleaseToGetCreated("", "", devNamespace, releaseConst.ReleaseCreationTimeout)
+			Expect(err).NotTo(HaveOccurred())
 		})
 
 		It("verifies that Release PipelineRun is triggered", func() {
-			Eventually(func() error {
-				pr, err := fw.AsKubeAdmin.ReleaseController.GetPipelineRunInNamespace(managedNamespace, releaseCR.GetName(), releaseCR.GetNamespace())
-				if err != nil {
-					GinkgoWriter.Printf("release pipelineRun for release '%s' in namespace '%s' not created yet: %+v\n", releaseCR.GetName(), releaseCR.GetNamespace(), err)
-					return err
-				}
-				if !pr.HasStarted() {
-					return fmt.Errorf("pipelinerun %s/%s hasn't started yet", pr.GetNamespace(), pr.GetName())
-				}
-				return nil
-			}, releaseConst.ReleasePipelineRunCreationTimeout, releaseConst.DefaultInterval).Should(Succeed(), fmt.Sprintf("timed out waiting for a pipelinerun to start for a release %s/%s", releaseCR.GetName(), releaseCR.GetNamespace()))
+			_, err := fw.AsKubeAdmin.ReleaseController.WaitForReleasePipelineToGetStarted(managedNamespace, releaseCR.GetName(), releaseCR.GetNamespace(), releaseConst.ReleasePipelineRunCreationTimeout)
+			Expect(err).NotTo(HaveOccurred(), fmt.Sprintf("timed out waiting for a pipelinerun to start for a release %s/%s", releaseCR.GetName(), releaseCR.GetNamespace()))
 		})
 
 		It("verifies that Release PipelineRun should eventually succeed", func() {
-			Eventually(func() error {
-				pr, err := fw.AsKubeAdmin.ReleaseController.GetPipelineRunInNamespace(managedNamespace, releaseCR.GetName(), releaseCR.GetNamespace())
-				Expect(err).ShouldNot(HaveOccurred())
-				if !pr.IsDone() {
-					return fmt.Errorf("release pipelinerun %s/%s did not finish yet", pr.GetNamespace(), pr.GetName())
-				}
-				Expect(tekton.HasPipelineRunSucceeded(pr)).To(BeTrue(), fmt.Sprintf("release pipelinerun %s/%s did not succeed", pr.GetNamespace(), pr.GetName()))
-				return nil
-			}, releaseConst.ReleasePipelineRunCompletionTimeout, releaseConst.DefaultInterval).Should(Succeed())
+			_, err := fw.AsKubeAdmin.ReleaseController.WaitForReleasePipelineToBeFinished(managedNamespace, releaseCR.GetName(), releaseCR.GetNamespace(), releaseConst.ReleasePipelineRunCompletionTimeout)
+			Expect(err).NotTo(HaveOccurred(), fmt.Sprintf("release pipelinerun for a release %s/%s did not succeed", releaseCR.GetName(), releaseCR.GetNamespace()))
 		})
 
 		It("verifies that Enterprise Contract Task has succeeded in the Release PipelineRun", func() {
@@ -194,16 +176,8 @@ var _ = framework.ReleaseServiceSuiteDescribe("Release service happy path", Labe
 		})
 
 		It("verifies that a Release is marked as succeeded.", func() {
-			Eventually(func() error {
-				releaseCR, err = fw.AsKubeAdmin.ReleaseController.GetFirstReleaseInNamespace(devNamespace)
-				if err != nil {
-					return err
-				}
-				if !releaseCR.IsReleased() {
-					return fmt.Errorf("release %s/%s is not marked as finished yet", releaseCR.GetNamespace(), releaseCR.GetName())
-				}
-				return nil
-			}, releaseConst.ReleaseCreationTimeout, releaseConst.DefaultInterval).Should(Succeed())
+			releaseCR, err = fw.AsKubeAdmin.ReleaseController.WaitForReleaseToGetFinished("", "", devNamespace, releaseConst.ReleaseCreationTimeout)
+			Expect(err).NotTo(HaveOccurred())
 		})
 	})
 })
@@ -317,7 +317,7 @@ var _ = framework.RemoteSecretSuiteDescribe(Label("remote-secret", "rs-environme
 				// application info should be stored even after deleting the application in application variable
 				gitOpsRepository := gitops.ObtainGitOpsRepositoryName(application.Status.Devfile)
 
-				return fw.AsKubeAdmin.CommonController.Github.CheckIfRepositoryExist(gitOpsRepository)
+				return fw.AsKubeAdmin.CommonController.GithubClient().CheckIfRepositoryExist(gitOpsRepository)
 			}, 1*time.Minute, 1*time.Second).Should(BeTrue(), fmt.Sprintf("timed out waiting for HAS controller to create gitops repository for the %s application in %s namespace", applicationName, fw.UserNamespace))
 		})
 
@@ -86,7 +86,7 @@ var _ = framework.RemoteSecretSuiteDescribe(Label("remote-secret", "image-reposi
 			Eventually(func() bool {
 				gitOpsRepository := gitops.ObtainGitOpsRepositoryName(application.Status.Devfile)
 
-				return fw.AsKubeDeveloper.CommonController.Github.CheckIfRepositoryExist(gitOpsRepository)
+				return fw.AsKubeDeveloper.CommonController.GithubClient().CheckIfRepositoryExist(gitOpsRepository)
 			}, 1*time.Minute, 1*time.Second).Should(BeTrue(), fmt.Sprintf("timed out waiting for HAS controller to create gitops repository for the %s application in %s namespace", applicationName, fw.UserNamespace))
 		})
 
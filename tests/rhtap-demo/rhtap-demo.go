@@ -70,7 +70,6 @@ const (
 	defaultPollingInterval    = time.Second * 2
 	jvmRebuildPollingInterval = time.Second * 10
 	snapshotPollingInterval   = time.Second * 1
-	releasePollingInterval    = time.Second * 1
 
 	stageTimeout = time.Minute * 5
 )
@@ -204,7 +203,7 @@ var _ = framework.RhtapDemoSuiteDescribe(Label("rhtap-demo"), Label("verify-stag
 						Eventually(func() bool {
 							gitOpsRepository := gitops.ObtainGitOpsRepositoryName(application.Status.Devfile)
 
-							return fw.AsKubeDeveloper.CommonController.Github.CheckIfRepositoryExist(gitOpsRepository)
+							return fw.AsKubeDeveloper.CommonController.GithubClient().CheckIfRepositoryExist(gitOpsRepository)
 						}, 1*time.Minute, 1*time.Second).Should(BeTrue(), fmt.Sprintf("timed out waiting for HAS controller to create gitops repository for the %s application in %s namespace", appTest.ApplicationName, fw.UserNamespace))
 					}
 				})
@@ -247,7 +246,7 @@ var _ = framework.RhtapDemoSuiteDescribe(Label("rhtap-demo"), Label("verify-stag
 						if componentSpec.AdvancedBuildSpec != nil {
 							componentNewBaseBranch = fmt.Sprintf("base-%s", util.GenerateRandomString(6))
 							gitRevision = componentNewBaseBranch
-							Expect(fw.AsKubeAdmin.CommonController.Github.CreateRef(componentRepositoryName, componentSpec.GitSourceDefaultBranchName, componentSpec.GitSourceRevision, componentNewBaseBranch)).To(Succeed())
+							Expect(fw.AsKubeAdmin.CommonController.GithubClient().CreateRef(componentRepositoryName, componentSpec.GitSourceDefaultBranchName, componentSpec.GitSourceRevision, componentNewBaseBranch)).To(Succeed())
 						}
 						cdq, err = fw.AsKubeDeveloper.HasController.CreateComponentDetectionQuery(componentSpec.Name, namespace, componentSpec.GitSourceUrl, gitRevision, componentSpec.GitSourceContext, secret, false)
 						Expect(err).NotTo(HaveOccurred())
@@ -428,8 +427,8 @@ var _ = framework.RhtapDemoSuiteDescribe(Label("rhtap-demo"), Label("verify-stag
 								}
 
 								// Delete new branch created by PaC and a testing branch used as a component's base branch
-								Expect(fw.AsKubeAdmin.CommonController.Github.DeleteRef(componentRepositoryName, pacBranchName)).To(Succeed())
-								Expect(fw.AsKubeAdmin.CommonController.Github.DeleteRef(componentRepositoryName, componentNewBaseBranch)).To(Succeed())
+								Expect(fw.AsKubeAdmin.CommonController.GithubClient().DeleteRef(componentRepositoryName, pacBranchName)).To(Succeed())
+								Expect(fw.AsKubeAdmin.CommonController.GithubClient().DeleteRef(componentRepositoryName, componentNewBaseBranch)).To(Succeed())
 							})
 							When("Component is switched to Advanced Build mode", func() {
 
@@ -449,7 +448,7 @@ var _ = framework.RhtapDemoSuiteDescribe(Label("rhtap-demo"), Label("verify-stag
 
 									var prSHA string
 									Eventually(func() error {
-										prs, err := fw.AsKubeAdmin.CommonController.Github.ListPullRequests(componentRepositoryName)
+										prs, err := fw.AsKubeAdmin.CommonController.GithubClient().ListPullRequests(componentRepositoryName)
 										Expect(err).ShouldNot(HaveOccurred())
 										for _, pr := range prs {
 											if pr.Head.GetRef() == pacBranchName {
@@ -503,7 +502,7 @@ var _ = framework.RhtapDemoSuiteDescribe(Label("rhtap-demo"), Label("verify-stag
 								})
 								It("should eventually lead to triggering another PipelineRun after merging the PaC init branch ", func() {
 									Eventually(func() error {
-										mergeResult, err = fw.AsKubeAdmin.CommonController.Github.MergePullRequest(componentRepositoryName, prNumber)
+										mergeResult, err = fw.AsKubeAdmin.CommonController.GithubClient().MergePullRequest(componentRepositoryName, prNumber)
 										return err
 									}, mergePRTimeout).Should(BeNil(), fmt.Sprintf("error when merging PaC pull request: %+v\n", err))
 
@@ -605,10 +604,8 @@ var _ = framework.RhtapDemoSuiteDescribe(Label("rhtap-demo"), Label("verify-stag
 								})
 
 								It("should trigger creation of Release CR", func() {
-									Eventually(func() error {
-										release, err = fw.AsKubeAdmin.ReleaseController.GetRelease("", snapshot.Name, fw.UserNamespace)
-										return err
-									}, releaseTimeout, releasePollingInterval).Should(Succeed(), fmt.Sprintf("timed out when trying to check if the release exists for snapshot %s/%s", fw.UserNamespace, snapshot.GetName()))
+									release, err = fw.AsKubeAdmin.ReleaseController.WaitForReleaseToGetCreated("", snapshot.Name, fw.UserNamespace, releaseTimeout)
+									Expect(err).NotTo(HaveOccurred(), fmt.Sprintf("timed out when trying to check if the release exists for snapshot %s/%s", fw.UserNamespace, snapshot.GetName()))
 								})
 							})
 
@@ -644,14 +641,8 @@ var _ = framework.RhtapDemoSuiteDescribe(Label("rhtap-demo"), Label("verify-stag
 							})
 							When("Release PipelineRun is completed", func() {
 								It("should lead to Release CR being marked as succeeded", func() {
-									Eventually(func() error {
-										release, err = fw.AsKubeAdmin.ReleaseController.GetRelease(release.Name, "", fw.UserNamespace)
-										Expect(err).ShouldNot(HaveOccurred())
-										if !release.IsReleased() {
-											return fmt.Errorf("release CR %s/%s is not marked as finished yet", release.GetNamespace(), release.GetName())
-										}
-										return nil
-									}, customResourceUpdateTimeout, defaultPollingInterval).Should(Succeed(), fmt.Sprintf("failed to see release %q in namespace %q get marked as released", release.Name, fw.UserNamespace))
+									release, err = fw.AsKubeAdmin.ReleaseController.WaitForReleaseToGetFinished(release.Name, "", fw.UserNamespace, customResourceUpdateTimeout)
+									Expect(err).NotTo(HaveOccurred(), fmt.Sprintf("failed to see release %q in namespace %q get marked as released", release.Name, fw.UserNamespace))
 								})
 							})
 
@@ -688,14 +679,14 @@ var _ = framework.RhtapDemoSuiteDescribe(Label("rhtap-demo"), Label("verify-stag
 								})
 								AfterAll(func() {
 									// Delete the new branch created by sending purge PR while moving to simple build
-									err = fw.AsKubeAdmin.CommonController.Github.DeleteRef(componentRepositoryName, pacPurgeBranchName)
+									err = fw.AsKubeAdmin.CommonController.GithubClient().DeleteRef(componentRepositoryName, pacPurgeBranchName)
 									if err != nil {
 										Expect(err.Error()).To(ContainSubstring("Reference does not exist"))
 									}
 								})
 								It("creates a pull request for removing PAC configuration", func() {
 									Eventually(func() error {
-										prs, err := fw.AsKubeAdmin.CommonController.Github.ListPullRequests(componentRepositoryName)
+										prs, err := fw.AsKubeAdmin.CommonController.GithubClient().ListPullRequests(componentRepositoryName)
 										Expect(err).ShouldNot(HaveOccurred())
 										for _, pr := range prs {
 											if pr.Head.GetRef() == pacPurgeBranchName {
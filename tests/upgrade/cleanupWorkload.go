@@ -31,4 +31,33 @@ var _ = framework.UpgradeSuiteDescribe("Create users and check their state", Lab
 		Expect(err).NotTo(HaveOccurred())
 	})
 
+	It("Delete SPIAccessTokenBinding", func() {
+		Expect(fw.AsKubeAdmin.SPIController.DeleteAllBindingTokensInASpecificNamespace(fw.UserNamespace)).To(Succeed())
+	})
+
+	It("Delete ReleasePlan and ReleasePlanAdmission", func() {
+		Expect(fw.AsKubeAdmin.ReleaseController.DeleteReleasePlan(utils.ReleasePlanName, fw.UserNamespace, false)).To(Succeed())
+		Expect(fw.AsKubeAdmin.ReleaseController.DeleteReleasePlanAdmission(utils.ReleasePlanAdmissionName, utils.ManagedNamespace, false)).To(Succeed())
+		Expect(fw.AsKubeAdmin.CommonController.DeleteNamespace(utils.ManagedNamespace)).To(Succeed())
+	})
+
+	It("Delete IntegrationTestScenario and Snapshot", func() {
+		scenarios, err := fw.AsKubeAdmin.IntegrationController.GetIntegrationTestScenarios(utils.ApplicationName, fw.UserNamespace)
+		Expect(err).NotTo(HaveOccurred())
+		for _, scenario := range *scenarios {
+			scenario := scenario
+			Expect(fw.AsKubeAdmin.IntegrationController.DeleteIntegrationTestScenario(&scenario, fw.UserNamespace)).To(Succeed())
+		}
+
+		snapshot, err := fw.AsKubeAdmin.IntegrationController.GetSnapshot(utils.SnapshotName, "", "", fw.UserNamespace)
+		if err == nil {
+			Expect(fw.AsKubeAdmin.IntegrationController.DeleteSnapshot(snapshot, fw.UserNamespace)).To(Succeed())
+		}
+	})
+
+	It("Delete Application with Component", func() {
+		Expect(fw.AsKubeAdmin.HasController.DeleteComponent(utils.ComponentName, fw.UserNamespace, false)).To(Succeed())
+		Expect(fw.AsKubeAdmin.HasController.DeleteApplication(utils.ApplicationName, fw.UserNamespace, false)).To(Succeed())
+	})
+
 })
@@ -0,0 +1,96 @@
+package create
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/gomega"
+	appstudioApi "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	"github.com/redhat-appstudio/e2e-tests/pkg/clients/has"
+	"github.com/redhat-appstudio/e2e-tests/pkg/framework"
+	frameworkUtils "github.com/redhat-appstudio/e2e-tests/pkg/utils"
+	releasecommon "github.com/redhat-appstudio/e2e-tests/tests/release"
+	utils "github.com/redhat-appstudio/e2e-tests/tests/upgrade/utils"
+	tektonutils "github.com/redhat-appstudio/release-service/tekton/utils"
+	"github.com/redhat-appstudio/service-provider-integration-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func CreateApplicationWithComponent(fw *framework.Framework) {
+	app, err := fw.AsKubeAdmin.HasController.CreateApplication(utils.ApplicationName, fw.UserNamespace)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(frameworkUtils.WaitUntil(fw.AsKubeAdmin.HasController.ApplicationGitopsRepoExists(app.Status.Devfile), 30*time.Second)).To(
+		Succeed(), fmt.Sprintf("timed out waiting for gitops content to be created for app %s in namespace %s", app.Name, app.Namespace),
+	)
+
+	cdq, err := fw.AsKubeAdmin.HasController.CreateComponentDetectionQuery(utils.ComponentName, fw.UserNamespace, utils.ComponentRepoURL, "", "", "", false)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(cdq.Status.ComponentDetected).To(HaveLen(1), "Expected length of the detected Components was not 1")
+
+	for _, compDetected := range cdq.Status.ComponentDetected {
+		// the component has a fixed name, so it can be found by the verify and cleanup specs after the upgrade
+		compDetected.ComponentStub.ComponentName = utils.ComponentName
+		component, err := fw.AsKubeAdmin.HasController.CreateComponent(compDetected.ComponentStub, fw.UserNamespace, "", "", utils.ApplicationName, true, map[string]string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.Name).To(Equal(utils.ComponentName))
+	}
+}
+
+func WaitForBuildPipelineRun(fw *framework.Framework) {
+	component, err := fw.AsKubeAdmin.HasController.GetComponent(utils.ComponentName, fw.UserNamespace)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(fw.AsKubeAdmin.HasController.WaitForComponentPipelineToBeFinished(component, "",
+		fw.AsKubeAdmin.TektonController, &has.RetryOptions{Retries: 2, Always: true})).To(Succeed())
+}
+
+func CreateSnapshot(fw *framework.Framework) {
+	component, err := fw.AsKubeAdmin.HasController.GetComponent(utils.ComponentName, fw.UserNamespace)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(component.Spec.ContainerImage).NotTo(BeEmpty(), "the image built for the component %s isn't set", component.Name)
+
+	snapshotComponents := []appstudioApi.SnapshotComponent{{Name: utils.ComponentName, ContainerImage: component.Spec.ContainerImage}}
+	_, err = fw.AsKubeAdmin.IntegrationController.CreateSnapshotWithComponents(utils.SnapshotName, utils.ComponentName, utils.ApplicationName, fw.UserNamespace, snapshotComponents)
+	Expect(err).NotTo(HaveOccurred())
+}
+
+func CreateIntegrationTestScenario(fw *framework.Framework) {
+	_, err := fw.AsKubeAdmin.IntegrationController.CreateIntegrationTestScenario(utils.ApplicationName, fw.UserNamespace, utils.IntegrationTestScenarioBundle, utils.IntegrationTestScenarioPipeline)
+	Expect(err).NotTo(HaveOccurred())
+}
+
+func CreateReleasePlanAndAdmission(fw *framework.Framework) {
+	_, err := fw.AsKubeAdmin.CommonController.CreateTestNamespace(utils.ManagedNamespace)
+	Expect(err).NotTo(HaveOccurred(), "Error when creating managedNamespace: %v", err)
+
+	_, err = fw.AsKubeAdmin.ReleaseController.CreateReleasePlan(utils.ReleasePlanName, fw.UserNamespace, utils.ApplicationName, utils.ManagedNamespace, "false")
+	Expect(err).NotTo(HaveOccurred())
+
+	_, err = fw.AsKubeAdmin.ReleaseController.CreateReleasePlanAdmission(utils.ReleasePlanAdmissionName, utils.ManagedNamespace, "", fw.UserNamespace, releasecommon.ReleaseStrategyPolicyDefault, releasecommon.ReleasePipelineServiceAccountDefault, []string{utils.ApplicationName}, false, &tektonutils.PipelineRef{
+		Resolver: "git",
+		Params: []tektonutils.Param{
+			{Name: "url", Value: releasecommon.RelSvcCatalogURL},
+			{Name: "revision", Value: releasecommon.RelSvcCatalogRevision},
+			{Name: "pathInRepo", Value: "pipelines/e2e/e2e.yaml"},
+		},
+	}, nil)
+	Expect(err).NotTo(HaveOccurred())
+
+	// the workload is verified after the upgrade against the state it was in before
+	Eventually(func() bool {
+		releasePlan, err := fw.AsKubeAdmin.ReleaseController.GetReleasePlan(utils.ReleasePlanName, fw.UserNamespace)
+		Expect(err).NotTo(HaveOccurred())
+		return releasePlan.IsMatched()
+	}, releasecommon.ReleasePlanStatusUpdateTimeout, releasecommon.DefaultInterval).Should(BeTrue(), "time out when waiting for the ReleasePlan %s to be matched", utils.ReleasePlanName)
+}
+
+func CreateSPIAccessTokenBinding(fw *framework.Framework) {
+	_, err := fw.AsKubeAdmin.SPIController.CreateSPIAccessTokenBinding(utils.SPIAccessTokenBindingName, fw.UserNamespace, utils.SPIAccessTokenBindingRepoURL, utils.SPIAccessTokenBindingSecretName, corev1.SecretTypeBasicAuth)
+	Expect(err).NotTo(HaveOccurred())
+
+	// no token is uploaded, so the binding waits for it across the upgrade
+	Eventually(func() v1beta1.SPIAccessTokenBindingPhase {
+		binding, err := fw.AsKubeAdmin.SPIController.GetSPIAccessTokenBinding(utils.SPIAccessTokenBindingName, fw.UserNamespace)
+		Expect(err).NotTo(HaveOccurred())
+		return binding.Status.Phase
+	}, 1*time.Minute, 5*time.Second).Should(Equal(v1beta1.SPIAccessTokenBindingPhaseAwaitingTokenData), "SPIAccessTokenBinding %s is not in %s phase", utils.SPIAccessTokenBindingName, v1beta1.SPIAccessTokenBindingPhaseAwaitingTokenData)
+}
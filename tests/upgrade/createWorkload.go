@@ -29,4 +29,28 @@ var _ = framework.UpgradeSuiteDescribe("Create users and check their state", Lab
 		create.CreateAppStudioBannedUser(fw)
 	})
 
+	It("creates Application with Component", func() {
+		create.CreateApplicationWithComponent(fw)
+	})
+
+	It("waits for the build PipelineRun of the Component to finish", func() {
+		create.WaitForBuildPipelineRun(fw)
+	})
+
+	It("creates Snapshot", func() {
+		create.CreateSnapshot(fw)
+	})
+
+	It("creates IntegrationTestScenario", func() {
+		create.CreateIntegrationTestScenario(fw)
+	})
+
+	It("creates ReleasePlan and ReleasePlanAdmission", func() {
+		create.CreateReleasePlanAndAdmission(fw)
+	})
+
+	It("creates SPIAccessTokenBinding", func() {
+		create.CreateSPIAccessTokenBinding(fw)
+	})
+
 })
@@ -9,4 +9,18 @@ const (
 	ProvisionedAppStudioSpace = "mig-appst-space"
 
 	UpgradeNamespace = "upgrade-namespace"
+
+	// Application workload kept across the upgrade
+	ApplicationName                 = "mig-app"
+	ComponentName                   = "mig-comp"
+	ComponentRepoURL                = "https://github.com/redhat-appstudio-qe/hacbs-test-project"
+	SnapshotName                    = "mig-snapshot"
+	IntegrationTestScenarioBundle   = "quay.io/redhat-appstudio/example-tekton-bundle:integration-pipeline-pass"
+	IntegrationTestScenarioPipeline = "integration-pipeline-pass"
+	ManagedNamespace                = "mig-managed"
+	ReleasePlanName                 = "mig-releaseplan"
+	ReleasePlanAdmissionName        = "mig-releaseplanadmission"
+	SPIAccessTokenBindingName       = "mig-spi-binding"
+	SPIAccessTokenBindingRepoURL    = "https://github.com/redhat-appstudio-qe/private-quarkus-devfile-sample"
+	SPIAccessTokenBindingSecretName = "mig-spi-secret"
 )
@@ -0,0 +1,75 @@
+package verify
+
+import (
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/redhat-appstudio/e2e-tests/pkg/framework"
+	"github.com/redhat-appstudio/e2e-tests/pkg/utils/tekton"
+	releasecommon "github.com/redhat-appstudio/e2e-tests/tests/release"
+	utils "github.com/redhat-appstudio/e2e-tests/tests/upgrade/utils"
+	releaseApi "github.com/redhat-appstudio/release-service/api/v1alpha1"
+	"github.com/redhat-appstudio/service-provider-integration-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+func VerifyApplicationWithComponent(fw *framework.Framework) {
+	app, err := fw.AsKubeAdmin.HasController.GetApplication(utils.ApplicationName, fw.UserNamespace)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(app.Status.Devfile).NotTo(BeEmpty(), "the devfile of the application %s is lost", app.Name)
+
+	component, err := fw.AsKubeAdmin.HasController.GetComponent(utils.ComponentName, fw.UserNamespace)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(component.Spec.Application).To(Equal(utils.ApplicationName))
+	Expect(component.Spec.ContainerImage).NotTo(BeEmpty(), "the image of the component %s is lost", component.Name)
+	Expect(meta.IsStatusConditionTrue(component.Status.Conditions, "Created")).To(BeTrue(), "the component %s isn't created: %v", component.Name, component.Status.Conditions)
+}
+
+func VerifyBuildPipelineRun(fw *framework.Framework) {
+	pipelineRun, err := fw.AsKubeAdmin.HasController.GetComponentPipelineRun(utils.ComponentName, utils.ApplicationName, fw.UserNamespace, "")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(tekton.HasPipelineRunSucceeded(pipelineRun)).To(BeTrue(), "the build PipelineRun %s isn't succeeded anymore: %v", pipelineRun.Name, pipelineRun.Status.Conditions)
+}
+
+func VerifySnapshot(fw *framework.Framework) {
+	component, err := fw.AsKubeAdmin.HasController.GetComponent(utils.ComponentName, fw.UserNamespace)
+	Expect(err).NotTo(HaveOccurred())
+
+	snapshot, err := fw.AsKubeAdmin.IntegrationController.GetSnapshot(utils.SnapshotName, "", "", fw.UserNamespace)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(snapshot.Spec.Application).To(Equal(utils.ApplicationName))
+	Expect(snapshot.Spec.Components).To(HaveLen(1))
+	Expect(snapshot.Spec.Components[0].Name).To(Equal(utils.ComponentName))
+	Expect(snapshot.Spec.Components[0].ContainerImage).To(Equal(component.Spec.ContainerImage))
+}
+
+func VerifyIntegrationTestScenario(fw *framework.Framework) {
+	scenarios, err := fw.AsKubeAdmin.IntegrationController.GetIntegrationTestScenarios(utils.ApplicationName, fw.UserNamespace)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(*scenarios).To(HaveLen(1), "expected a single IntegrationTestScenario of the application %s", utils.ApplicationName)
+	Expect((*scenarios)[0].Spec.Application).To(Equal(utils.ApplicationName))
+}
+
+func VerifyReleasePlanAndAdmission(fw *framework.Framework) {
+	// the controller can take some time to reconcile the objects after the upgrade
+	Eventually(func() bool {
+		releasePlan, err := fw.AsKubeAdmin.ReleaseController.GetReleasePlan(utils.ReleasePlanName, fw.UserNamespace)
+		Expect(err).NotTo(HaveOccurred())
+		return releasePlan.IsMatched() && releasePlan.Status.ReleasePlanAdmission.Name == utils.ManagedNamespace+"/"+utils.ReleasePlanAdmissionName
+	}, releasecommon.ReleasePlanStatusUpdateTimeout, releasecommon.DefaultInterval).Should(BeTrue(), "the ReleasePlan %s isn't matched with the ReleasePlanAdmission %s", utils.ReleasePlanName, utils.ReleasePlanAdmissionName)
+
+	releasePlanAdmission, err := fw.AsKubeAdmin.ReleaseController.GetReleasePlanAdmission(utils.ReleasePlanAdmissionName, utils.ManagedNamespace)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(releasePlanAdmission.Spec.Applications).To(ContainElement(utils.ApplicationName))
+	Expect(releasePlanAdmission.Spec.Origin).To(Equal(fw.UserNamespace))
+	Expect(meta.IsStatusConditionTrue(releasePlanAdmission.Status.Conditions, releaseApi.MatchedConditionType.String())).To(BeTrue(), "the ReleasePlanAdmission %s isn't matched", releasePlanAdmission.Name)
+	Expect(releasePlanAdmission.Status.ReleasePlans).To(ContainElement(releaseApi.MatchedReleasePlan{Name: fw.UserNamespace + "/" + utils.ReleasePlanName, Active: true}))
+}
+
+func VerifySPIAccessTokenBinding(fw *framework.Framework) {
+	Eventually(func() v1beta1.SPIAccessTokenBindingPhase {
+		binding, err := fw.AsKubeAdmin.SPIController.GetSPIAccessTokenBinding(utils.SPIAccessTokenBindingName, fw.UserNamespace)
+		Expect(err).NotTo(HaveOccurred())
+		return binding.Status.Phase
+	}, 1*time.Minute, 5*time.Second).Should(Equal(v1beta1.SPIAccessTokenBindingPhaseAwaitingTokenData), "SPIAccessTokenBinding %s is not in %s phase", utils.SPIAccessTokenBindingName, v1beta1.SPIAccessTokenBindingPhaseAwaitingTokenData)
+}
@@ -29,4 +29,28 @@ var _ = framework.UpgradeSuiteDescribe("Create users and check their state", Lab
 		verify.VerifyAppStudioBannedUser(fw)
 	})
 
+	It("Verify Application with Component", func() {
+		verify.VerifyApplicationWithComponent(fw)
+	})
+
+	It("Verify build PipelineRun", func() {
+		verify.VerifyBuildPipelineRun(fw)
+	})
+
+	It("Verify Snapshot", func() {
+		verify.VerifySnapshot(fw)
+	})
+
+	It("Verify IntegrationTestScenario", func() {
+		verify.VerifyIntegrationTestScenario(fw)
+	})
+
+	It("Verify ReleasePlan and ReleasePlanAdmission", func() {
+		verify.VerifyReleasePlanAndAdmission(fw)
+	})
+
+	It("Verify SPIAccessTokenBinding", func() {
+		verify.VerifySPIAccessTokenBinding(fw)
+	})
+
 })